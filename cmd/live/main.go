@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"github.com/cossim/coss-server/internal/live/interfaces/grpc"
 	"github.com/cossim/coss-server/internal/live/interfaces/http"
 	"github.com/cossim/coss-server/internal/live/service"
 	ctrl "github.com/cossim/coss-server/pkg/alias"
@@ -47,11 +48,16 @@ func init() {
 }
 
 func main() {
+	grpcService := &grpc.LiveServiceServer{}
 	mgr, err := ctrl.NewManager(config.GetConfigOrDie(), ctrl.Options{
 		//Http: ctrl.HTTPServer{
 		//	HTTPService:        &http.Handler{},
 		//	HealthCheckAddress: httpProbeAddr,
 		//},
+		Grpc: ctrl.GRPCServer{
+			GRPCService:         grpcService,
+			HealthzCheckAddress: grpcProbeAddr,
+		},
 		Config: ctrl.Config{
 			LoadFromConfigCenter: remoteConfig,
 			RemoteConfigAddr:     remoteConfigAddr,
@@ -8,12 +8,14 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 )
 
 // ServerInterface represents all server handlers.
@@ -21,6 +23,9 @@ type ServerInterface interface {
 	// 发送全体通知
 	// (POST /api/v1/admin/notification/send_all)
 	SendAllNotification(c *gin.Context)
+	// 断开用户连接
+	// (POST /api/v1/admin/user/{id}/disconnect)
+	DisconnectUser(c *gin.Context, id string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -45,6 +50,30 @@ func (siw *ServerInterfaceWrapper) SendAllNotification(c *gin.Context) {
 	siw.Handler.SendAllNotification(c)
 }
 
+// DisconnectUser operation middleware
+func (siw *ServerInterfaceWrapper) DisconnectUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DisconnectUser(c, id)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -73,20 +102,26 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	}
 
 	router.POST(options.BaseURL+"/api/v1/admin/notification/send_all", wrapper.SendAllNotification)
+	router.POST(options.BaseURL+"/api/v1/admin/user/:id/disconnect", wrapper.DisconnectUser)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7yTwWvUQBTG/5Xw9BiatOolt/XWi5TqTYpMk7fZKcnMdN6kuJRAV6gUsVSpvXipBwvi",
-	"oa0ggoL/jUm7/4XMZLtm3awn6e0xu3y/L9/33i7EMldSoDAE0S5QPMCcuXEdSUlBaGelpUJtOLpfYpm4",
-	"1wQp1lwZLgVEUB0fVj/eXX0YgQ/4nOUqQ4hWwtAHM1QIEXBhMEUNpQ8JM2yRQn1yWR+egw+iyDK2aVWM",
-	"LnAqIze3MDZWJad0oci3g3p00XYC1dHb8d6oPnhTvTqFqRoZzUUKZdmh/xhF0suyR9LwPo+ZBazjdoFk",
-	"ujIRBoWZ9zPee391ela93K/Ov8/4eTLg5HHymCdagE5nGrcLrjGB6OkUtDFn2P6Ti76c99BbW/X6UnuE",
-	"IuEinQGSJXLjLLW/1OutrYIPO6ipEVleCpdCG4tUKJjiEME99+SDYmbgUgiY4sHOcsCSnIugjQks+xnL",
-	"MhedpI6kmoKq/U+/fh43qYGDaSewmkDU1Qg08SCZhzIZ/lUFUyq7MbBFlnKz4na6q7EPEdwJ/txAMDmA",
-	"4B/du6BnrU8cQLsqu7Suu+aMXD4rYfjfHE7vs8NPs+XNKdjK7t8Stzp6UZ9cjj+/vr4YVR+/XH89s/QH",
-	"t0Z3CzThlj5QkedMDxftlmEp2ZPq2W2FjbIsy98BAAD//7OO1HMQBQAA",
+	"H4sIAAAAAAAC/+xVQW8bRRT+K6sHx1XXbcNlpR6CcvEFVQVOKKqmu8/OVLsz25lxRBRZshGhLrguqGlB",
+	"AZEKJcgg4QYJFWMM/TPeXfuUv4BmJph1ds0JKg7c1jPj933zve97sw8BjxPOkCkJ/j7IYAdjYj63qAw4",
+	"YxiodyWKW3ivhVLpjUTwBIWiaI6Fgu6iuE1D8wNlIGiiKGfgw3z0e3pyv751Pu3PxpP8u0n2+Yvs6Q/p",
+	"tJM96GRfPbD74AK+T+IkQvABXFB7if6SSlDWhLYLAonkrFzelkoHx+mXz5zrN9Kzbv5t19m4MR9/Mxt/",
+	"kk9enk/7i1+/mI9OZuPJcrEIt7FEo0xhEwW028slfucuBkoTuIUy4Uxi+fIBD7FMLH38MJ0c5s+6Raxr",
+	"tVoZzYWQKLKuQvbkLHs4AhdYK4rIHV1FiRZWMIxlc22RF72s+3xF5PTRZ4tON+t9mn58XBa8SoG3kYWb",
+	"UfQWV7RBA6IB1hoi4EwhU2U+i85RfnyafnSQjn5Z4fPODpUOlQ5xWAGgkpnAey0qMAT/vSXQdomwPklZ",
+	"g5c5bN6sOw0uHIkspKy5Aig1IlWGUvGmzubNOriwi0LaIlev1K7UtCw8QUYSCj5cN0suJETtGBU8klBv",
+	"96pHwpgyrwjjaezbJIqMdFxWKGUblB4MZ789tqqBAROmQD0Ev6ojYOVBqd7k4d6lVpAkif4kcPciTjbs",
+	"+ut1gQ3w4TXvr2ng2V3p/U3vjdCr1C8YQLFV2rSmdzZGRp9rtdo/xnCZzwo+1uU2CrplG68IN330Qfbk",
+	"bPF9f/68m578OP/pVKO/8crQjYEucNsuyFYcE7G3zluKNKWOlHErbOt/rBq4JVF4+zRse+HyWVjvXzuZ",
+	"88Nh1vu5OOqz3tN55yDr309HR3YlP/owGwwXne785dfZ4PR82s8Ph7PJYDmu7V/sbLcFS1FYfahMCAWJ",
+	"UaHQd7rMzRapb4EeEeCbxIILjMRmMocl57qFflweSNv/TuSqn97/0/YfTZu1u3VwZeaKebDHqjLXbv8x",
+	"ANisiBWOCQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -3,6 +3,15 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.16.2 DO NOT EDIT.
 package v1
 
+// DisconnectUserRequest defines model for DisconnectUserRequest.
+type DisconnectUserRequest struct {
+	// DriverId 设备ID，为空时断开所有设备
+	DriverId *string `json:"driver_id,omitempty"`
+
+	// Reason 断开原因 3=封禁 4=踢下线，默认为踢下线
+	Reason *int `json:"reason,omitempty"`
+}
+
 // Response defines model for Response.
 type Response struct {
 	// Code 响应码
@@ -23,3 +32,6 @@ type SendAllNotificationRequest struct {
 
 // SendAllNotificationJSONRequestBody defines body for SendAllNotification for application/json ContentType.
 type SendAllNotificationJSONRequestBody = SendAllNotificationRequest
+
+// DisconnectUserJSONRequestBody defines body for DisconnectUser for application/json ContentType.
+type DisconnectUserJSONRequestBody = DisconnectUserRequest
@@ -35,6 +35,8 @@ type AdminService interface {
 	CreateAdmin(ctx context.Context, admin *entity.Admin) (interface{}, error)
 	SendAllNotification(ctx context.Context, content string) (interface{}, error)
 	GetAdminByUserID(ctx context.Context, userId string) (*entity.Admin, error)
+	// DisconnectUser 断开用户的推送连接，driverId为空时断开所有设备
+	DisconnectUser(ctx context.Context, userId string, driverId string, reason pushgrpcv1.DisconnectReason) error
 }
 
 func (s *ServiceImpl) CreateAdmin(ctx context.Context, admin *entity.Admin) (interface{}, error) {
@@ -292,3 +294,28 @@ func (s *ServiceImpl) GetAdminByUserID(ctx context.Context, userId string) (*ent
 	}
 	return find[0], nil
 }
+
+func (s *ServiceImpl) DisconnectUser(ctx context.Context, userId string, driverId string, reason pushgrpcv1.DisconnectReason) error {
+	if reason != pushgrpcv1.DisconnectReason_DisconnectBanned {
+		reason = pushgrpcv1.DisconnectReason_DisconnectKicked
+	}
+
+	var err error
+	if driverId == "" {
+		_, err = s.pushService.DisconnectUser(ctx, &pushgrpcv1.DisconnectUserRequest{
+			UserId: userId,
+			Reason: reason,
+		})
+	} else {
+		_, err = s.pushService.DisconnectDevice(ctx, &pushgrpcv1.DisconnectDeviceRequest{
+			UserId:   userId,
+			DriverId: driverId,
+			Reason:   reason,
+		})
+	}
+	if err != nil {
+		s.logger.Error("断开用户连接失败", zap.String("user_id", userId), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	v1 "github.com/cossim/coss-server/internal/admin/api/http/v1"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/http/response"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -32,3 +33,38 @@ func (h *Handler) SendAllNotification(c *gin.Context) {
 
 	response.SetSuccess(c, "发送成功", nil)
 }
+
+// DisconnectUser
+// @Summary 断开用户连接
+// @Description 断开用户所有设备或者指定设备的推送连接，用于踢下线或者封禁用户
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @param id path string true "用户ID"
+// @param request body v1.DisconnectUserRequest{} true "request"
+// @Success		200 {object} v1.Response{}
+// @Router /admin/user/{id}/disconnect [post]
+func (h *Handler) DisconnectUser(c *gin.Context, id string) {
+	req := new(v1.DisconnectUserRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	var driverId string
+	if req.DriverId != nil {
+		driverId = *req.DriverId
+	}
+	var reason pushgrpcv1.DisconnectReason
+	if req.Reason != nil {
+		reason = pushgrpcv1.DisconnectReason(*req.Reason)
+	}
+
+	if err := h.svc.DisconnectUser(c, id, driverId, reason); err != nil {
+		response.SetFail(c, "断开连接失败", err)
+		return
+	}
+
+	response.SetSuccess(c, "断开连接成功", nil)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cossim/coss-server/internal/live/domain/repository"
 	"github.com/cossim/coss-server/pkg/code"
 	"github.com/redis/go-redis/v9"
+	"strconv"
 	"time"
 )
 
@@ -16,8 +17,13 @@ const (
 	liveUserPrefix  = "live.User."
 	liveGroupPrefix = "live.Group."
 	liveRoomPrefix  = "live.Room."
+	// liveRingingKey 等待接听的房间，分数为振铃超时时间
+	liveRingingKey = "live.Ringing"
 )
 
+// roomModifyRetries 修改房间时和其他修改冲突的最大重试次数
+const roomModifyRetries = 10
+
 var (
 	timeout              = 60 * time.Second
 	ErrCacheContentEmpty = errors.New("cache content cannot be empty")
@@ -69,6 +75,10 @@ func (r *RedisLiveRepository) setPersist(ctx context.Context, key string) error
 		return err
 	}
 
+	// 已经永久有效时不需要处理，-2 表示键不存在
+	if remaining == -1 {
+		return nil
+	}
 	if remaining < 0 {
 		return code.LiveErrCallNotFound
 	}
@@ -299,8 +309,55 @@ func (r *RedisLiveRepository) UpdateRoom(ctx context.Context, room *entity.Room)
 	return r.client.Set(ctx, roomKey, data, ttl).Err()
 }
 
-func (r *RedisLiveRepository) DeleteRoom(ctx context.Context, roomID string) error {
-	return r.client.Del(ctx, liveRoomPrefix+roomID).Err()
+func (r *RedisLiveRepository) ModifyRoom(ctx context.Context, roomID string, fn func(room *entity.Room) error) (*entity.Room, error) {
+	roomKey := liveRoomPrefix + roomID
+
+	var room *entity.Room
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, roomKey).Bytes()
+		if err != nil {
+			if err == redis.Nil {
+				return code.LiveErrCallNotFound.Reason(err)
+			}
+			return err
+		}
+		room = &entity.Room{}
+		if err := room.Unmarshal(data); err != nil {
+			return err
+		}
+		if err := fn(room); err != nil {
+			return err
+		}
+		if data, err = room.Marshal(); err != nil {
+			return err
+		}
+		// 房间在读取后被修改时事务不会执行
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, roomKey, data, redis.KeepTTL)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < roomModifyRetries; i++ {
+		err := r.client.Watch(ctx, txf, roomKey)
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return room, nil
+	}
+	return nil, redis.TxFailedErr
+}
+
+func (r *RedisLiveRepository) DeleteRoom(ctx context.Context, roomID string) (bool, error) {
+	n, err := r.client.Del(ctx, liveRoomPrefix+roomID).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
 }
 
 func (r *RedisLiveRepository) ListRooms(ctx context.Context, roomIDs []string) ([]*entity.Room, error) {
@@ -406,3 +463,44 @@ func (r *RedisLiveRepository) ListRoomsByOwner(ctx context.Context, owner string
 
 	return rooms, nil
 }
+
+func (r *RedisLiveRepository) AddRingingRoom(ctx context.Context, roomID string, deadline int64) error {
+	if roomID == "" {
+		return ErrCacheKeyEmpty
+	}
+	return r.client.ZAdd(ctx, liveRingingKey, redis.Z{Score: float64(deadline), Member: roomID}).Err()
+}
+
+func (r *RedisLiveRepository) ListRingingRooms(ctx context.Context, before int64, limit int64) ([]string, error) {
+	return r.client.ZRangeByScore(ctx, liveRingingKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before, 10),
+		Count: limit,
+	}).Result()
+}
+
+// claimRingingScript 房间仍在振铃列表中且已经超时才更新分数，检查和更新需要原子完成
+var claimRingingScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if score and tonumber(score) <= tonumber(ARGV[2]) then
+	redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+	return 1
+end
+return 0
+`)
+
+func (r *RedisLiveRepository) ClaimRingingRoom(ctx context.Context, roomID string, now, leaseUntil int64) (bool, error) {
+	n, err := claimRingingScript.Run(ctx, r.client, []string{liveRingingKey}, roomID, now, leaseUntil).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *RedisLiveRepository) RemoveRingingRoom(ctx context.Context, roomID string) (bool, error) {
+	n, err := r.client.ZRem(ctx, liveRingingKey, roomID).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,163 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/pkg/code"
+)
+
+func newTestRedisLiveRepository(t *testing.T) (*RedisLiveRepository, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	repo, err := NewRedisLiveRepository(mr.Addr(), "", 0)
+	if err != nil {
+		t.Fatalf("NewRedisLiveRepository: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.client.Close() })
+	return repo, mr
+}
+
+func TestRedisModifyRoomConcurrent(t *testing.T) {
+	repo, mr := newTestRedisLiveRepository(t)
+	ctx := context.Background()
+
+	const n = 8
+	room := &entity.Room{ID: "r1", Participants: make(map[string]*entity.ActiveParticipant)}
+	for i := 0; i < n; i++ {
+		room.Participants[fmt.Sprintf("u%d", i)] = &entity.ActiveParticipant{}
+	}
+	if err := repo.CreateRoom(ctx, room); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	// 并发修改不同的参与者，所有修改都要保留
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(uid string) {
+			defer wg.Done()
+			if _, err := repo.ModifyRoom(ctx, "r1", func(room *entity.Room) error {
+				room.Participants[uid].Connected = true
+				room.NumParticipants++
+				return nil
+			}); err != nil {
+				t.Errorf("ModifyRoom %s: %v", uid, err)
+			}
+		}(fmt.Sprintf("u%d", i))
+	}
+	wg.Wait()
+
+	got, err := repo.GetRoom(ctx, "r1")
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if got.NumParticipants != n {
+		t.Fatalf("expected %d participants, got %d", n, got.NumParticipants)
+	}
+	for uid, p := range got.Participants {
+		if !p.Connected {
+			t.Fatalf("participant %s update lost", uid)
+		}
+	}
+	// 修改不会改变房间的过期时间
+	if ttl := mr.TTL(liveRoomPrefix + "r1"); ttl != timeout {
+		t.Fatalf("expected ttl %v, got %v", timeout, ttl)
+	}
+
+	if _, err := repo.ModifyRoom(ctx, "missing", func(room *entity.Room) error { return nil }); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("expected LiveErrCallNotFound, got %v", err)
+	}
+}
+
+func TestRedisClaimRingingRoom(t *testing.T) {
+	repo, _ := newTestRedisLiveRepository(t)
+	ctx := context.Background()
+
+	if err := repo.AddRingingRoom(ctx, "r1", 100); err != nil {
+		t.Fatalf("AddRingingRoom: %v", err)
+	}
+	// 还没有超时
+	if ok, err := repo.ClaimRingingRoom(ctx, "r1", 50, 150); err != nil || ok {
+		t.Fatalf("expected claim before deadline to fail, got %v, %v", ok, err)
+	}
+	if ok, err := repo.ClaimRingingRoom(ctx, "r1", 100, 200); err != nil || !ok {
+		t.Fatalf("ClaimRingingRoom: %v, %v", ok, err)
+	}
+	// 租约期间不能被重复领取
+	if ok, err := repo.ClaimRingingRoom(ctx, "r1", 150, 250); err != nil || ok {
+		t.Fatalf("expected claimed room to be skipped, got %v, %v", ok, err)
+	}
+	if rooms, err := repo.ListRingingRooms(ctx, 199, 10); err != nil || len(rooms) != 0 {
+		t.Fatalf("expected no rooms before lease expiry, got %v, %v", rooms, err)
+	}
+	// 租约到期后可以重新领取
+	if ok, err := repo.ClaimRingingRoom(ctx, "r1", 200, 300); err != nil || !ok {
+		t.Fatalf("expected room to be claimed after lease expiry, got %v, %v", ok, err)
+	}
+
+	if ok, err := repo.RemoveRingingRoom(ctx, "r1"); err != nil || !ok {
+		t.Fatalf("RemoveRingingRoom: %v, %v", ok, err)
+	}
+	if ok, err := repo.ClaimRingingRoom(ctx, "r1", 1000, 1100); err != nil || ok {
+		t.Fatalf("expected removed room not to be claimed, got %v, %v", ok, err)
+	}
+}
+
+func TestRedisDeleteRoom(t *testing.T) {
+	repo, _ := newTestRedisLiveRepository(t)
+	ctx := context.Background()
+	if err := repo.CreateRoom(ctx, &entity.Room{ID: "r1"}); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	// 同时结束通话时只有一个请求删除成功
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	deleted := 0
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.DeleteRoom(ctx, "r1")
+			if err != nil {
+				t.Errorf("DeleteRoom: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				deleted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if deleted != 1 {
+		t.Fatalf("expected exactly one delete, got %d", deleted)
+	}
+}
+
+func TestRedisSetRoomPersist(t *testing.T) {
+	repo, mr := newTestRedisLiveRepository(t)
+	ctx := context.Background()
+	if err := repo.CreateRoom(ctx, &entity.Room{ID: "r1"}); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	// 多人加入群聊通话时会重复设置
+	for i := 0; i < 2; i++ {
+		if err := repo.SetRoomPersist(ctx, "r1"); err != nil {
+			t.Fatalf("SetRoomPersist: %v", err)
+		}
+	}
+	if ttl := mr.TTL(liveRoomPrefix + "r1"); ttl != 0 {
+		t.Fatalf("expected no ttl, got %v", ttl)
+	}
+	if err := repo.SetRoomPersist(ctx, "missing"); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("expected LiveErrCallNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/live/domain/media"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/storage/minio"
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	lksdk "github.com/livekit/server-sdk-go"
+	"time"
+)
+
+var _ media.MediaProvider = &LiveKitMediaProvider{}
+
+func NewLiveKitMediaProvider(url, apiKey, apiSecret string, opts ...LiveKitOption) *LiveKitMediaProvider {
+	p := &LiveKitMediaProvider{
+		apiKey:       apiKey,
+		apiSecret:    apiSecret,
+		roomService:  lksdk.NewRoomServiceClient(url, apiKey, apiSecret),
+		egressClient: lksdk.NewEgressClient(url, apiKey, apiSecret),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+type LiveKitOption func(*LiveKitMediaProvider)
+
+// WithRecordingUpload 录制文件由 LiveKit egress 直接上传到对象存储的录制桶
+func WithRecordingUpload(endpoint, accessKey, secretKey string) LiveKitOption {
+	return func(p *LiveKitMediaProvider) {
+		p.upload = &livekit.S3Upload{
+			AccessKey:      accessKey,
+			Secret:         secretKey,
+			Endpoint:       endpoint,
+			Bucket:         minio.RecordBucket,
+			ForcePathStyle: true,
+		}
+	}
+}
+
+type LiveKitMediaProvider struct {
+	apiKey       string
+	apiSecret    string
+	roomService  *lksdk.RoomServiceClient
+	egressClient *lksdk.EgressClient
+	upload       *livekit.S3Upload
+}
+
+func (p *LiveKitMediaProvider) CreateRoom(ctx context.Context, req *media.CreateRoomRequest) (*media.Room, error) {
+	room, err := p.roomService.CreateRoom(ctx, &livekit.CreateRoomRequest{
+		Name:            req.Name,
+		EmptyTimeout:    uint32(req.EmptyTimeout.Seconds()),
+		MaxParticipants: req.MaxParticipants,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return livekitRoomToMedia(room), nil
+}
+
+func (p *LiveKitMediaProvider) GetRoom(ctx context.Context, room string) (*media.Room, error) {
+	rooms, err := p.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{Names: []string{room}})
+	if err != nil {
+		return nil, err
+	}
+	if len(rooms.Rooms) == 0 {
+		return nil, code.LiveErrCallNotFound
+	}
+	return livekitRoomToMedia(rooms.Rooms[0]), nil
+}
+
+func (p *LiveKitMediaProvider) DeleteRoom(ctx context.Context, room string) error {
+	_, err := p.roomService.DeleteRoom(ctx, &livekit.DeleteRoomRequest{Room: room})
+	return err
+}
+
+func (p *LiveKitMediaProvider) IssueToken(ctx context.Context, req *media.TokenRequest) (string, error) {
+	grant := &auth.VideoGrant{
+		RoomJoin: true,
+		Room:     req.Room,
+	}
+	if req.Admin {
+		grant.RoomCreate = true
+		grant.RoomList = true
+		grant.RoomAdmin = true
+	}
+	at := auth.NewAccessToken(p.apiKey, p.apiSecret)
+	at.AddGrant(grant).SetName(req.Name).SetIdentity(req.Identity).SetValidFor(req.ValidFor)
+	return at.ToJWT()
+}
+
+func (p *LiveKitMediaProvider) ListParticipants(ctx context.Context, room string) ([]*media.Participant, error) {
+	res, err := p.roomService.ListParticipants(ctx, &livekit.ListParticipantsRequest{Room: room})
+	if err != nil {
+		return nil, err
+	}
+	participants := make([]*media.Participant, 0, len(res.Participants))
+	for _, v := range res.Participants {
+		participants = append(participants, &media.Participant{
+			ID:          v.Sid,
+			Identity:    v.Identity,
+			Name:        v.Name,
+			IsPublisher: v.IsPublisher,
+			State:       media.ParticipantState(v.State),
+			JoinedAt:    v.JoinedAt,
+		})
+	}
+	return participants, nil
+}
+
+func (p *LiveKitMediaProvider) RemoveParticipant(ctx context.Context, room, identity string) error {
+	_, err := p.roomService.RemoveParticipant(ctx, &livekit.RoomParticipantIdentity{
+		Room:     room,
+		Identity: identity,
+	})
+	return err
+}
+
+func (p *LiveKitMediaProvider) StartRecording(ctx context.Context, req *media.RecordingRequest) (*media.Recording, error) {
+	if p.upload == nil {
+		return nil, errors.New("recording upload not configured")
+	}
+	info, err := p.egressClient.StartRoomCompositeEgress(ctx, &livekit.RoomCompositeEgressRequest{
+		RoomName:  req.Room,
+		AudioOnly: req.AudioOnly,
+		FileOutputs: []*livekit.EncodedFileOutput{{
+			FileType: livekit.EncodedFileType_MP4,
+			Filepath: req.Filepath,
+			Output:   &livekit.EncodedFileOutput_S3{S3: p.upload},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return EgressToRecording(info), nil
+}
+
+func (p *LiveKitMediaProvider) StopRecording(ctx context.Context, id string) (*media.Recording, error) {
+	info, err := p.egressClient.StopEgress(ctx, &livekit.StopEgressRequest{EgressId: id})
+	if err != nil {
+		return nil, err
+	}
+	return EgressToRecording(info), nil
+}
+
+// EgressToRecording 将 LiveKit 的 egress 信息转换为录制信息，webhook 回调时也使用该方法
+func EgressToRecording(info *livekit.EgressInfo) *media.Recording {
+	r := &media.Recording{
+		ID:        info.EgressId,
+		Room:      info.RoomName,
+		StartedAt: info.StartedAt / int64(time.Second),
+		EndedAt:   info.EndedAt / int64(time.Second),
+		Error:     info.Error,
+	}
+
+	switch info.Status {
+	case livekit.EgressStatus_EGRESS_STARTING:
+		r.Status = media.RecordingStarting
+	case livekit.EgressStatus_EGRESS_ACTIVE:
+		r.Status = media.RecordingActive
+	case livekit.EgressStatus_EGRESS_ENDING:
+		r.Status = media.RecordingEnding
+	case livekit.EgressStatus_EGRESS_COMPLETE:
+		r.Status = media.RecordingComplete
+	default:
+		// 中止或者达到限制时没有可用的录制文件
+		r.Status = media.RecordingFailed
+	}
+
+	for _, f := range info.GetFileResults() {
+		r.Filepath = minio.RecordBucket + "/" + f.Filename
+		r.Size = f.Size
+		r.Duration = f.Duration / int64(time.Second)
+	}
+	return r
+}
+
+func livekitRoomToMedia(room *livekit.Room) *media.Room {
+	return &media.Room{
+		Name:            room.Name,
+		NumParticipants: room.NumParticipants,
+		NumPublishers:   room.NumPublishers,
+		MaxParticipants: room.MaxParticipants,
+		CreationTime:    room.CreationTime,
+	}
+}
@@ -0,0 +1,204 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"github.com/cossim/coss-server/internal/live/domain/media"
+	"github.com/cossim/coss-server/pkg/code"
+	"sync"
+	"time"
+)
+
+var _ media.MediaProvider = &MemoryMediaProvider{}
+
+// MemoryMediaProvider 内存实现的媒体服务，不连接任何媒体服务器，用于测试
+type MemoryMediaProvider struct {
+	mu         sync.Mutex
+	rooms      map[string]*memoryRoom
+	tokens     []*media.TokenRequest
+	recordings map[string]*media.Recording
+}
+
+type memoryRoom struct {
+	room         media.Room
+	participants map[string]*media.Participant
+}
+
+func NewMemoryMediaProvider() *MemoryMediaProvider {
+	return &MemoryMediaProvider{
+		rooms:      make(map[string]*memoryRoom),
+		recordings: make(map[string]*media.Recording),
+	}
+}
+
+func (p *MemoryMediaProvider) CreateRoom(ctx context.Context, req *media.CreateRoomRequest) (*media.Room, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r, ok := p.rooms[req.Name]; ok {
+		room := r.room
+		return &room, nil
+	}
+	r := &memoryRoom{
+		room: media.Room{
+			Name:            req.Name,
+			MaxParticipants: req.MaxParticipants,
+			CreationTime:    time.Now().Unix(),
+		},
+		participants: make(map[string]*media.Participant),
+	}
+	p.rooms[req.Name] = r
+	room := r.room
+	return &room, nil
+}
+
+func (p *MemoryMediaProvider) GetRoom(ctx context.Context, room string) (*media.Room, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.rooms[room]
+	if !ok {
+		return nil, code.LiveErrCallNotFound
+	}
+	res := r.room
+	return &res, nil
+}
+
+func (p *MemoryMediaProvider) DeleteRoom(ctx context.Context, room string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.rooms, room)
+	return nil
+}
+
+func (p *MemoryMediaProvider) IssueToken(ctx context.Context, req *media.TokenRequest) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.rooms[req.Room]; !ok {
+		return "", code.LiveErrCallNotFound
+	}
+	tr := *req
+	p.tokens = append(p.tokens, &tr)
+	return fmt.Sprintf("%s:%s:%d", req.Room, req.Identity, len(p.tokens)), nil
+}
+
+func (p *MemoryMediaProvider) ListParticipants(ctx context.Context, room string) ([]*media.Participant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.rooms[room]
+	if !ok {
+		return nil, code.LiveErrCallNotFound
+	}
+	participants := make([]*media.Participant, 0, len(r.participants))
+	for _, v := range r.participants {
+		participant := *v
+		participants = append(participants, &participant)
+	}
+	return participants, nil
+}
+
+func (p *MemoryMediaProvider) RemoveParticipant(ctx context.Context, room, identity string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.rooms[room]
+	if !ok {
+		return code.LiveErrCallNotFound
+	}
+	if _, ok := r.participants[identity]; !ok {
+		return code.LiveErrUserNotInCall
+	}
+	delete(r.participants, identity)
+	r.room.NumParticipants = uint32(len(r.participants))
+	r.room.NumPublishers = r.room.NumParticipants
+	return nil
+}
+
+func (p *MemoryMediaProvider) StartRecording(ctx context.Context, req *media.RecordingRequest) (*media.Recording, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.rooms[req.Room]; !ok {
+		return nil, code.LiveErrCallNotFound
+	}
+	r := &media.Recording{
+		ID:        fmt.Sprintf("EG_%d", len(p.recordings)+1),
+		Room:      req.Room,
+		Status:    media.RecordingActive,
+		Filepath:  "record/" + req.Filepath,
+		StartedAt: time.Now().Unix(),
+	}
+	p.recordings[r.ID] = r
+	res := *r
+	return &res, nil
+}
+
+func (p *MemoryMediaProvider) StopRecording(ctx context.Context, id string) (*media.Recording, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.recordings[id]
+	if !ok || r.Status.IsFinished() {
+		return nil, code.LiveErrNotRecording
+	}
+	r.Status = media.RecordingEnding
+	res := *r
+	return &res, nil
+}
+
+// Connect 模拟客户端使用凭证连接到房间
+func (p *MemoryMediaProvider) Connect(room, identity string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.rooms[room]
+	if !ok {
+		return code.LiveErrCallNotFound
+	}
+	if r.room.MaxParticipants != 0 && uint32(len(r.participants)) >= r.room.MaxParticipants {
+		return code.LiveErrMaxParticipantsExceeded
+	}
+	r.participants[identity] = &media.Participant{
+		ID:          identity,
+		Identity:    identity,
+		IsPublisher: true,
+		State:       media.ParticipantActive,
+		JoinedAt:    time.Now().Unix(),
+	}
+	r.room.NumParticipants = uint32(len(r.participants))
+	r.room.NumPublishers = r.room.NumParticipants
+	return nil
+}
+
+// HasRoom 判断房间是否存在
+func (p *MemoryMediaProvider) HasRoom(room string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.rooms[room]
+	return ok
+}
+
+// Recording 返回录制信息
+func (p *MemoryMediaProvider) Recording(id string) (*media.Recording, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.recordings[id]
+	if !ok {
+		return nil, false
+	}
+	res := *r
+	return &res, true
+}
+
+// Recordings 返回所有录制
+func (p *MemoryMediaProvider) Recordings() []*media.Recording {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := make([]*media.Recording, 0, len(p.recordings))
+	for _, r := range p.recordings {
+		rec := *r
+		res = append(res, &rec)
+	}
+	return res
+}
+
+// Tokens 返回已签发凭证的请求
+func (p *MemoryMediaProvider) Tokens() []*media.TokenRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*media.TokenRequest(nil), p.tokens...)
+}
@@ -0,0 +1,739 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.1
+// source: api/grpc/v1/live.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 通话结果，与通话消息的子类型保持一致
+type CallOutcome int32
+
+const (
+	CallOutcome_Normal    CallOutcome = 0 // 正常通话
+	CallOutcome_Cancelled CallOutcome = 1 // 取消通话
+	CallOutcome_Rejected  CallOutcome = 2 // 拒绝通话
+	CallOutcome_Missed    CallOutcome = 3 // 未接通话
+)
+
+// Enum value maps for CallOutcome.
+var (
+	CallOutcome_name = map[int32]string{
+		0: "Normal",
+		1: "Cancelled",
+		2: "Rejected",
+		3: "Missed",
+	}
+	CallOutcome_value = map[string]int32{
+		"Normal":    0,
+		"Cancelled": 1,
+		"Rejected":  2,
+		"Missed":    3,
+	}
+)
+
+func (x CallOutcome) Enum() *CallOutcome {
+	p := new(CallOutcome)
+	*p = x
+	return p
+}
+
+func (x CallOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CallOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_v1_live_proto_enumTypes[0].Descriptor()
+}
+
+func (CallOutcome) Type() protoreflect.EnumType {
+	return &file_api_grpc_v1_live_proto_enumTypes[0]
+}
+
+func (x CallOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CallOutcome.Descriptor instead.
+func (CallOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_v1_live_proto_rawDescGZIP(), []int{0}
+}
+
+type CallParticipant struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"user_id"
+	UserId string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"user_id"`
+	// @inject_tag: json:"joined_at"
+	// 加入时间，未加入时为0
+	JoinedAt int64 `protobuf:"varint,2,opt,name=JoinedAt,proto3" json:"joined_at"`
+	// @inject_tag: json:"left_at"
+	// 退出时间，未加入时为0
+	LeftAt int64 `protobuf:"varint,3,opt,name=LeftAt,proto3" json:"left_at"`
+}
+
+func (x *CallParticipant) Reset() {
+	*x = CallParticipant{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_live_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallParticipant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallParticipant) ProtoMessage() {}
+
+func (x *CallParticipant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_live_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallParticipant.ProtoReflect.Descriptor instead.
+func (*CallParticipant) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_live_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CallParticipant) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CallParticipant) GetJoinedAt() int64 {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return 0
+}
+
+func (x *CallParticipant) GetLeftAt() int64 {
+	if x != nil {
+		return x.LeftAt
+	}
+	return 0
+}
+
+type CallRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"id"
+	Id uint32 `protobuf:"varint,1,opt,name=Id,proto3" json:"id"`
+	// @inject_tag: json:"room"
+	Room string `protobuf:"bytes,2,opt,name=Room,proto3" json:"room"`
+	// @inject_tag: json:"type"
+	// 通话类型 user=私聊 group=群聊
+	Type string `protobuf:"bytes,3,opt,name=Type,proto3" json:"type"`
+	// @inject_tag: json:"creator"
+	Creator string `protobuf:"bytes,4,opt,name=Creator,proto3" json:"creator"`
+	// @inject_tag: json:"group_id"
+	GroupId uint32 `protobuf:"varint,5,opt,name=GroupId,proto3" json:"group_id"`
+	// @inject_tag: json:"video_enabled"
+	VideoEnabled bool `protobuf:"varint,6,opt,name=VideoEnabled,proto3" json:"video_enabled"`
+	// @inject_tag: json:"audio_enabled"
+	AudioEnabled bool `protobuf:"varint,7,opt,name=AudioEnabled,proto3" json:"audio_enabled"`
+	// @inject_tag: json:"outcome"
+	Outcome CallOutcome `protobuf:"varint,8,opt,name=Outcome,proto3,enum=live_v1.CallOutcome" json:"outcome"`
+	// @inject_tag: json:"participants"
+	Participants []*CallParticipant `protobuf:"bytes,9,rep,name=Participants,proto3" json:"participants"`
+	// @inject_tag: json:"start_at"
+	StartAt int64 `protobuf:"varint,10,opt,name=StartAt,proto3" json:"start_at"`
+	// @inject_tag: json:"connect_at"
+	// 接通时间，未接通时为0
+	ConnectAt int64 `protobuf:"varint,11,opt,name=ConnectAt,proto3" json:"connect_at"`
+	// @inject_tag: json:"end_at"
+	EndAt int64 `protobuf:"varint,12,opt,name=EndAt,proto3" json:"end_at"`
+	// @inject_tag: json:"duration"
+	// 通话时长，单位秒
+	Duration int64 `protobuf:"varint,13,opt,name=Duration,proto3" json:"duration"`
+}
+
+func (x *CallRecord) Reset() {
+	*x = CallRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_live_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallRecord) ProtoMessage() {}
+
+func (x *CallRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_live_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallRecord.ProtoReflect.Descriptor instead.
+func (*CallRecord) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_live_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CallRecord) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CallRecord) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *CallRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CallRecord) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *CallRecord) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *CallRecord) GetVideoEnabled() bool {
+	if x != nil {
+		return x.VideoEnabled
+	}
+	return false
+}
+
+func (x *CallRecord) GetAudioEnabled() bool {
+	if x != nil {
+		return x.AudioEnabled
+	}
+	return false
+}
+
+func (x *CallRecord) GetOutcome() CallOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return CallOutcome_Normal
+}
+
+func (x *CallRecord) GetParticipants() []*CallParticipant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *CallRecord) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *CallRecord) GetConnectAt() int64 {
+	if x != nil {
+		return x.ConnectAt
+	}
+	return 0
+}
+
+func (x *CallRecord) GetEndAt() int64 {
+	if x != nil {
+		return x.EndAt
+	}
+	return 0
+}
+
+func (x *CallRecord) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type GetCallRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"id"
+	Id uint32 `protobuf:"varint,1,opt,name=Id,proto3" json:"id"`
+}
+
+func (x *GetCallRecordRequest) Reset() {
+	*x = GetCallRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_live_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCallRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallRecordRequest) ProtoMessage() {}
+
+func (x *GetCallRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_live_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetCallRecordRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_live_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCallRecordRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetCallRecordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"user_id"
+	// 参与者，为空时不过滤
+	UserId string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"user_id"`
+	// @inject_tag: json:"type"
+	// 通话类型，为空时不过滤
+	Type string `protobuf:"bytes,2,opt,name=Type,proto3" json:"type"`
+	// @inject_tag: json:"group_id"
+	GroupId uint32 `protobuf:"varint,3,opt,name=GroupId,proto3" json:"group_id"`
+	// @inject_tag: json:"filter_outcome"
+	// 是否按通话结果过滤
+	FilterOutcome bool `protobuf:"varint,4,opt,name=FilterOutcome,proto3" json:"filter_outcome"`
+	// @inject_tag: json:"outcome"
+	Outcome CallOutcome `protobuf:"varint,5,opt,name=Outcome,proto3,enum=live_v1.CallOutcome" json:"outcome"`
+	// @inject_tag: json:"start_at"
+	StartAt int64 `protobuf:"varint,6,opt,name=StartAt,proto3" json:"start_at"`
+	// @inject_tag: json:"end_at"
+	EndAt int64 `protobuf:"varint,7,opt,name=EndAt,proto3" json:"end_at"`
+	// @inject_tag: json:"page_num"
+	PageNum int32 `protobuf:"varint,8,opt,name=PageNum,proto3" json:"page_num"`
+	// @inject_tag: json:"page_size"
+	PageSize int32 `protobuf:"varint,9,opt,name=PageSize,proto3" json:"page_size"`
+}
+
+func (x *GetCallRecordsRequest) Reset() {
+	*x = GetCallRecordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_live_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCallRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallRecordsRequest) ProtoMessage() {}
+
+func (x *GetCallRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_live_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallRecordsRequest.ProtoReflect.Descriptor instead.
+func (*GetCallRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_live_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCallRecordsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetCallRecordsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetCallRecordsRequest) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *GetCallRecordsRequest) GetFilterOutcome() bool {
+	if x != nil {
+		return x.FilterOutcome
+	}
+	return false
+}
+
+func (x *GetCallRecordsRequest) GetOutcome() CallOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return CallOutcome_Normal
+}
+
+func (x *GetCallRecordsRequest) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *GetCallRecordsRequest) GetEndAt() int64 {
+	if x != nil {
+		return x.EndAt
+	}
+	return 0
+}
+
+func (x *GetCallRecordsRequest) GetPageNum() int32 {
+	if x != nil {
+		return x.PageNum
+	}
+	return 0
+}
+
+func (x *GetCallRecordsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetCallRecordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"list"
+	List []*CallRecord `protobuf:"bytes,1,rep,name=List,proto3" json:"list"`
+	// @inject_tag: json:"total"
+	Total int64 `protobuf:"varint,2,opt,name=Total,proto3" json:"total"`
+}
+
+func (x *GetCallRecordsResponse) Reset() {
+	*x = GetCallRecordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_live_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCallRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallRecordsResponse) ProtoMessage() {}
+
+func (x *GetCallRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_live_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallRecordsResponse.ProtoReflect.Descriptor instead.
+func (*GetCallRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_live_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCallRecordsResponse) GetList() []*CallRecord {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *GetCallRecordsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_api_grpc_v1_live_proto protoreflect.FileDescriptor
+
+var file_api_grpc_v1_live_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x69,
+	0x76, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x76,
+	0x31, 0x22, 0x5d, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x6c, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x66, 0x74,
+	0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4c, 0x65, 0x66, 0x74, 0x41, 0x74,
+	0x22, 0x98, 0x03, 0x0a, 0x0a, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52,
+	0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x56,
+	0x69, 0x64, 0x65, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x22, 0x0a, 0x0c, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x6c, 0x6c, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61,
+	0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x76, 0x65,
+	0x5f, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x52, 0x0c, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x6e, 0x64,
+	0x41, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x45, 0x6e, 0x64, 0x41, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x26, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x02, 0x49, 0x64, 0x22, 0x99, 0x02, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x76,
+	0x65, 0x5f, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x52, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x6e, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x45, 0x6e, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x67,
+	0x65, 0x4e, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x61, 0x67, 0x65,
+	0x4e, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22,
+	0x57, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x4c, 0x69, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x76,
+	0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x2a, 0x42, 0x0a, 0x0b, 0x43, 0x61, 0x6c, 0x6c,
+	0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x6f, 0x72, 0x6d, 0x61,
+	0x6c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64,
+	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0x02,
+	0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x10, 0x03, 0x32, 0xa5, 0x01, 0x0a,
+	0x0b, 0x4c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1d, 0x2e,
+	0x6c, 0x69, 0x76, 0x65, 0x5f, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c,
+	0x69, 0x76, 0x65, 0x5f, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x69, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x2d, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6c,
+	0x69, 0x76, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_grpc_v1_live_proto_rawDescOnce sync.Once
+	file_api_grpc_v1_live_proto_rawDescData = file_api_grpc_v1_live_proto_rawDesc
+)
+
+func file_api_grpc_v1_live_proto_rawDescGZIP() []byte {
+	file_api_grpc_v1_live_proto_rawDescOnce.Do(func() {
+		file_api_grpc_v1_live_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_grpc_v1_live_proto_rawDescData)
+	})
+	return file_api_grpc_v1_live_proto_rawDescData
+}
+
+var file_api_grpc_v1_live_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_grpc_v1_live_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_grpc_v1_live_proto_goTypes = []interface{}{
+	(CallOutcome)(0),               // 0: live_v1.CallOutcome
+	(*CallParticipant)(nil),        // 1: live_v1.CallParticipant
+	(*CallRecord)(nil),             // 2: live_v1.CallRecord
+	(*GetCallRecordRequest)(nil),   // 3: live_v1.GetCallRecordRequest
+	(*GetCallRecordsRequest)(nil),  // 4: live_v1.GetCallRecordsRequest
+	(*GetCallRecordsResponse)(nil), // 5: live_v1.GetCallRecordsResponse
+}
+var file_api_grpc_v1_live_proto_depIdxs = []int32{
+	0, // 0: live_v1.CallRecord.Outcome:type_name -> live_v1.CallOutcome
+	1, // 1: live_v1.CallRecord.Participants:type_name -> live_v1.CallParticipant
+	0, // 2: live_v1.GetCallRecordsRequest.Outcome:type_name -> live_v1.CallOutcome
+	2, // 3: live_v1.GetCallRecordsResponse.List:type_name -> live_v1.CallRecord
+	3, // 4: live_v1.LiveService.GetCallRecord:input_type -> live_v1.GetCallRecordRequest
+	4, // 5: live_v1.LiveService.GetCallRecords:input_type -> live_v1.GetCallRecordsRequest
+	2, // 6: live_v1.LiveService.GetCallRecord:output_type -> live_v1.CallRecord
+	5, // 7: live_v1.LiveService.GetCallRecords:output_type -> live_v1.GetCallRecordsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_grpc_v1_live_proto_init() }
+func file_api_grpc_v1_live_proto_init() {
+	if File_api_grpc_v1_live_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_grpc_v1_live_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallParticipant); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_live_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_live_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCallRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_live_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCallRecordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_live_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCallRecordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_grpc_v1_live_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_grpc_v1_live_proto_goTypes,
+		DependencyIndexes: file_api_grpc_v1_live_proto_depIdxs,
+		EnumInfos:         file_api_grpc_v1_live_proto_enumTypes,
+		MessageInfos:      file_api_grpc_v1_live_proto_msgTypes,
+	}.Build()
+	File_api_grpc_v1_live_proto = out.File
+	file_api_grpc_v1_live_proto_rawDesc = nil
+	file_api_grpc_v1_live_proto_goTypes = nil
+	file_api_grpc_v1_live_proto_depIdxs = nil
+}
@@ -0,0 +1,148 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: api/grpc/v1/live.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LiveService_GetCallRecord_FullMethodName  = "/live_v1.LiveService/GetCallRecord"
+	LiveService_GetCallRecords_FullMethodName = "/live_v1.LiveService/GetCallRecords"
+)
+
+// LiveServiceClient is the client API for LiveService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LiveServiceClient interface {
+	// 获取通话记录
+	GetCallRecord(ctx context.Context, in *GetCallRecordRequest, opts ...grpc.CallOption) (*CallRecord, error)
+	// 按条件分页获取通话记录
+	GetCallRecords(ctx context.Context, in *GetCallRecordsRequest, opts ...grpc.CallOption) (*GetCallRecordsResponse, error)
+}
+
+type liveServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLiveServiceClient(cc grpc.ClientConnInterface) LiveServiceClient {
+	return &liveServiceClient{cc}
+}
+
+func (c *liveServiceClient) GetCallRecord(ctx context.Context, in *GetCallRecordRequest, opts ...grpc.CallOption) (*CallRecord, error) {
+	out := new(CallRecord)
+	err := c.cc.Invoke(ctx, LiveService_GetCallRecord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveServiceClient) GetCallRecords(ctx context.Context, in *GetCallRecordsRequest, opts ...grpc.CallOption) (*GetCallRecordsResponse, error) {
+	out := new(GetCallRecordsResponse)
+	err := c.cc.Invoke(ctx, LiveService_GetCallRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LiveServiceServer is the server API for LiveService service.
+// All implementations should embed UnimplementedLiveServiceServer
+// for forward compatibility
+type LiveServiceServer interface {
+	// 获取通话记录
+	GetCallRecord(context.Context, *GetCallRecordRequest) (*CallRecord, error)
+	// 按条件分页获取通话记录
+	GetCallRecords(context.Context, *GetCallRecordsRequest) (*GetCallRecordsResponse, error)
+}
+
+// UnimplementedLiveServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedLiveServiceServer struct {
+}
+
+func (UnimplementedLiveServiceServer) GetCallRecord(context.Context, *GetCallRecordRequest) (*CallRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCallRecord not implemented")
+}
+func (UnimplementedLiveServiceServer) GetCallRecords(context.Context, *GetCallRecordsRequest) (*GetCallRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCallRecords not implemented")
+}
+
+// UnsafeLiveServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LiveServiceServer will
+// result in compilation errors.
+type UnsafeLiveServiceServer interface {
+	mustEmbedUnimplementedLiveServiceServer()
+}
+
+func RegisterLiveServiceServer(s grpc.ServiceRegistrar, srv LiveServiceServer) {
+	s.RegisterService(&LiveService_ServiceDesc, srv)
+}
+
+func _LiveService_GetCallRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCallRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveServiceServer).GetCallRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LiveService_GetCallRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveServiceServer).GetCallRecord(ctx, req.(*GetCallRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LiveService_GetCallRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCallRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveServiceServer).GetCallRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LiveService_GetCallRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveServiceServer).GetCallRecords(ctx, req.(*GetCallRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LiveService_ServiceDesc is the grpc.ServiceDesc for LiveService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LiveService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "live_v1.LiveService",
+	HandlerType: (*LiveServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCallRecord",
+			Handler:    _LiveService_GetCallRecord_Handler,
+		},
+		{
+			MethodName: "GetCallRecords",
+			Handler:    _LiveService_GetCallRecords_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/grpc/v1/live.proto",
+}
@@ -1,6 +1,6 @@
 // Package v1 provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
+// Code generated by github.com/deepmap/oapi-codegen version v1.16.2 DO NOT EDIT.
 package v1
 
 import (
@@ -26,6 +26,9 @@ type ServerInterface interface {
 	// 获取群聊当前通话房间信息
 	// (GET /api/v1/live/group/{groupId})
 	GetGroupRoom(c *gin.Context, groupId uint32)
+	// 获取通话记录
+	// (GET /api/v1/live/history)
+	GetCallHistory(c *gin.Context, params GetCallHistoryParams)
 	// 获取用户当前通话房间信息
 	// (GET /api/v1/live/user)
 	GetUserRoom(c *gin.Context)
@@ -38,6 +41,9 @@ type ServerInterface interface {
 	// 加入通话
 	// (POST /api/v1/live/{id}/join)
 	JoinRoom(c *gin.Context, id string)
+	// 通话录制
+	// (POST /api/v1/live/{id}/recording)
+	RecordRoom(c *gin.Context, id string)
 	// 拒绝通话
 	// (POST /api/v1/live/{id}/reject)
 	RejectRoom(c *gin.Context, id string)
@@ -75,7 +81,7 @@ func (siw *ServerInterfaceWrapper) GetGroupRoom(c *gin.Context) {
 	// ------------- Path parameter "groupId" -------------
 	var groupId uint32
 
-	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameter("simple", false, "groupId", c.Param("groupId"), &groupId)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter groupId: %w", err), http.StatusBadRequest)
 		return
@@ -93,6 +99,96 @@ func (siw *ServerInterfaceWrapper) GetGroupRoom(c *gin.Context) {
 	siw.Handler.GetGroupRoom(c, groupId)
 }
 
+// GetCallHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetCallHistory(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCallHistoryParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "group_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_id", c.Request.URL.Query(), &params.GroupId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "outcome" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outcome", c.Request.URL.Query(), &params.Outcome)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter outcome: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "start_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_at", c.Request.URL.Query(), &params.StartAt)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_at: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "end_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_at", c.Request.URL.Query(), &params.EndAt)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_at: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page_num" -------------
+
+	if paramValue := c.Query("page_num"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_num is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_num", c.Request.URL.Query(), &params.PageNum)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_num: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page_size" -------------
+
+	if paramValue := c.Query("page_size"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_size is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_size", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCallHistory(c, params)
+}
+
 // GetUserRoom operation middleware
 func (siw *ServerInterfaceWrapper) GetUserRoom(c *gin.Context) {
 
@@ -116,7 +212,7 @@ func (siw *ServerInterfaceWrapper) DeleteRoom(c *gin.Context) {
 	// ------------- Path parameter "id" -------------
 	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
@@ -142,7 +238,7 @@ func (siw *ServerInterfaceWrapper) GetRoom(c *gin.Context) {
 	// ------------- Path parameter "id" -------------
 	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
@@ -168,7 +264,7 @@ func (siw *ServerInterfaceWrapper) JoinRoom(c *gin.Context) {
 	// ------------- Path parameter "id" -------------
 	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
@@ -186,6 +282,32 @@ func (siw *ServerInterfaceWrapper) JoinRoom(c *gin.Context) {
 	siw.Handler.JoinRoom(c, id)
 }
 
+// RecordRoom operation middleware
+func (siw *ServerInterfaceWrapper) RecordRoom(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RecordRoom(c, id)
+}
+
 // RejectRoom operation middleware
 func (siw *ServerInterfaceWrapper) RejectRoom(c *gin.Context) {
 
@@ -194,7 +316,7 @@ func (siw *ServerInterfaceWrapper) RejectRoom(c *gin.Context) {
 	// ------------- Path parameter "id" -------------
 	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
@@ -241,40 +363,53 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.POST(options.BaseURL+"/api/v1/live", wrapper.CreateRoom)
 	router.GET(options.BaseURL+"/api/v1/live/group/:groupId", wrapper.GetGroupRoom)
+	router.GET(options.BaseURL+"/api/v1/live/history", wrapper.GetCallHistory)
 	router.GET(options.BaseURL+"/api/v1/live/user", wrapper.GetUserRoom)
 	router.DELETE(options.BaseURL+"/api/v1/live/:id", wrapper.DeleteRoom)
 	router.GET(options.BaseURL+"/api/v1/live/:id", wrapper.GetRoom)
 	router.POST(options.BaseURL+"/api/v1/live/:id/join", wrapper.JoinRoom)
+	router.POST(options.BaseURL+"/api/v1/live/:id/recording", wrapper.RecordRoom)
 	router.POST(options.BaseURL+"/api/v1/live/:id/reject", wrapper.RejectRoom)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RY324Txxd+ldX8fhettMEBSlX5rgWBzA1VaMUFsqzx7nE8sLuzzMwGImulgEBNg1Kq",
-	"UpqSkhaktMpNFahQIpEGXiZrO1d9hWpm1s7au/4TZ93Sq8Q7O2fP+b7vnDlnGsiirk898ARHxQbiVh1c",
-	"rP49zwALmKPUnYNbAXAhH/qM+sAEAfXKPKOBXyG2/N8GbjHiC0I9VEStt5utvfulC8hENcpcLFARBcQT",
-	"Z88gE4lFH1AREU/APDBkojsz83RGPp3hN4k/Q5UV7Mz4VL7DUFGwAEITkVraB22sgcALXFS8rn1C5TAM",
-	"TeSCW5Xb+70rXTBozRB1MAIOzLhdp8Zt4jgGAwvIAqgVCzsOMhER4Ca+g7hgxJtH0ji+U9KLZ86d6waF",
-	"GcOL44YkX6Ou/IQvFlGxhh0uw6Sxnw30fwY1VET/KxyxVIgpKkhmrug3QxOJOqgdDG4FhIHdhULSUw7N",
-	"Lk69SBwurbe3N1qv9qKfHyKzC6KEBZkdLE0Ed7DrOzK+eKUXjXEZDM0e/5SRLkvlrlVavQGWkFElNch9",
-	"6nFIC4BR6qYDay6/O1x7rQQ4ka8DqBHEBRqIQUC2dx4013aaazuHa68/iFafHOyv/vXnOv8wmQbEExPn",
-	"wACvAuakPboN1TlhNZ+tRisvoqdb0bOX0cZSrnCEGYxdpsQbWjOOL+7hnxkkC0Fv6ozoRSVaeR49+DWW",
-	"/fp9/Va+GnmP2PgcM0Es4mNPlLwaTaNEbPAEkUb6Hf5SVkaPWDc97ELOCBFe8YOqQ3g9qzpfq4OoA1NV",
-	"2D8KwCBcF2ZZE2iiCFUpdQB7J/TpBiUe2BWckdtSbIbM/L40/vijvBNZYZ06bE5mkwssMiq/zB7jqsAi",
-	"4H1hfZJvVFm6TKZtejEu6L1KtQOGO5VjuiQkNKdSpNMBDKtX/YkW5toQdI64nGXBRCz36eLZ6Tzy9H6B",
-	"2EArskerMLAosytx1Z24McmU4ZXuYdUrRhzYhFbAw1UH7CEVjBr6HUNtyLtkWdQGK/318+pxvhW7xrAL",
-	"FZZZSS7KNUOtTbfNYcCpE3QY6StnR2vmFJR2DKrVhnypTsnzhMY4WAEjYvGqrF5a0VXADNingagf/brY",
-	"4fLytS+QqWdDFZJaPQqxLoSP1MBF4iZDEKGmBYtyTlzDkUMV9gky0QIwrpE7fWr21CxS4w54crGIzqpH",
-	"sgKLuvKqgH1SWDhdkAZUFlKecT5Hyz9Fe290T4eUPX1UlGyZDd0RAunpA7j4jNqq47GoJ0BXeez7DrHU",
-	"tsINrhWmi/uo0p+ekxUUvS62t3ebr+4d7D+OHt1rPnmJkoNQPNyy+ExUgZ+ZnZ2Kg/Gxm+FhEsTm8rfR",
-	"yi8oKRVUvN4rkuvlsGwiHrguZotpEgSe53LGU8yVpaUklwU1WRYa6k/JDtVlAmRQ2/5mN3r0Q+vtZvvu",
-	"SrT/OPp6teOhHO8O3r1o3t1OUX4JxCVpOCbdx7JACWBcBTHwooLI31J6qNOIodi/FF1mAvqR9xuhBGpq",
-	"7KogsxQ3ADmN2SQMj8nFCObVBcIIur/fai7vHoduOax0U/zfATrl84mBHoXCCKAbROeVDQ5kHdva/uHS",
-	"UvTVm+RXUvheUAbGSab2b3e1vdb6/aTLg9KLDM+sviu3QWnUf/EjAdduxAEds449P3y6OaiOmcN0O55W",
-	"318g/4E0ySc1Jk2Hghzyh/QR6m4o2v0j+v3H6NlWH/YpLjt3UGOQqS1Pkcz825r+i7wxmpppNjGpC78M",
-	"f7Sgkjd8x0z95M7xBMVA9eMDJdV8+F1rb6PPcK+M5pSJMYWk7f0Hq8JI2nRkk9DWszNNWxj+HQAA//8o",
-	"JvoR5RoAAA==",
+	"H4sIAAAAAAAC/9Rab28TyRn/KqtpX7TS5uwkvaqylBftobvm3lyVa3UvUGRt1pN4YHdnmZ0FXGTJQVCc",
+	"QHCAhkBqjuMKB6KCQI8mEUmOL+NZ26/4CtXMrO2Nd9f/sg7wKt5/zzzzPL/n9/yZXAI6Nm1sQYs6IHMJ",
+	"OHoempr4+YVmGH9GDsWkMAcdG1sO5Ldtgm1IKILiJQM5lP9FFJrixq8JXAQZ8KtUR27KF5riEuegjkkO",
+	"FFVACzYEGaARohWACi5OLOEJfm/COYvsCWxThC3NmLAxsigkIEOJC/lr2OSL2bQAMoua4UAuClPN4Ksv",
+	"YmJqFGQAsujvfwfai3ARS5Aca5mOynjhDNQp3wPf0V80QpGObM2iYfucwciCuawmHuWgoxMkVgQZwFZ/",
+	"YFefeJs7zc037w9ueNXn7Tu1vbdpoI51Nyow4CKNVKxZKrFrbz+cYq4DSRbluGK+XIcSZC2NxXs+HkOO",
+	"07FlQT3aQN7NJ83SVtBA7TsnYiCdQI1iEgGpyq3G/3Ybpauzp4CaoPFUkHOJJhcJo2Wrsf2AG2Pj3W/Y",
+	"2kbtcO39wZbz27FbAVrRcVXfv+M9eNjxzvZ/6k9vy0uv/PPY1Voi2LV9+LZXcpFFp6eSXupEFsF2y++9",
+	"qH0OY/Mb+Sb/xqU6NmEcWLiHvq8q6Rnvxb/Z3p4yOcMqd72dsjI1412/Xd9/oEzPyKBKejN2h6udodJW",
+	"kOSTzV0EYzNZqlOBQzUSTV2SIT5YcEhhMah4vc++v65w9p+pP11uLK8qIpZm6r88biyvJkpokdmAsyrk",
+	"QJ6D51zoRGTzYGx3kc4vj+v7V2ZPBS14zHjkAb4Y1qFlQmi5JsicljqB+SLfkwnNBRiRF2ZPKXhRoXko",
+	"rKtcyGPlAjIMhUAdovNQPNE1wwBqJyKOGpsL1y7OyodTn3+eaAiMQjE0D8UXBJ5zEYG5tim4e+YHghpQ",
+	"20bkZgFqy5YqgBc10zb4/vwnI0GPuySonxDS9tJ8HwzGldwtwuiqScrvmptvEs/7FJkQuzTOkI2dq97m",
+	"juSPHsk/8QqRGGGNLsCFOap71TW2+ojdf8aqr9iD0thZ42uMrJ6cMTy4ey8TBwuKz0IrrsvwYb91Rb6V",
+	"LEY+Im8E0vSstYjDVkI5aFFEC2GF/8aZ0UL6WUszYcIWQk7WdhcM5OSj2Pm7PKR5SAQLBwoUBTniVqva",
+	"b6u0gLEBNeuYOvXoTDnYFB75Y68HhK2TL35oBPPz6FG+pRp1na5t/WH8cwLZZSJrKZYmND26vWKHG6y8",
+	"491Zqx1WAwlLVHiAbxbbgUwyZH5SgebmEM5iy4iICO/eNlv/iVWeSx2aD39u/njr/cENdlBiT6/7im3u",
+	"eNUVb6M8Mjy7k6RviPneVoxNjq1XIqs0qXPiSdKRqIpZrr6645WWlfSMvKztveANz+5/2XLVe/GjMiV+",
+	"v7zhldeV6Rn2+HXjzZOxps9ofHYMGn7oFxxHLR2cCIyXJOyjA7aBerbuRPBp9Wwn04Qlqf15lIM4y3uI",
+	"rAzBrF8VjFw4R8Lwm3Yx1cWegsagpS0YMNcjw2JFvqOID5JOqTrOQT28+hfidrKUs0g0E2ZJZKb7kj9T",
+	"xLPxluEEOthwo/PWXOeZOgakDeFq8UGyrg7B85jCHKi7BNHCt5y9JKIXoEYg+aNL852rL1u+/Pq7vwJV",
+	"ntWILYmnnS3mKbWBGAggvwimiIpuVseOg0zF4E2/ZiOggvOQONJyk5+lP0uL6Z0NLf4wA6bFLc7ANC+0",
+	"Smk2Sp2fTHEB/NrGTtSQqfwvtv9W9hxAyJOpYjbHo6Hd4gKZ+KFD/4RzBX/oTqFkec22DaSLz1JnHIkw",
+	"Se59x3WhOY4wxVEVG9u73uvLtcM7rHLZ23gFgjWIXxoRPyeKjU+l02NRUC4RpWHQiF55na0+BEGogMzp",
+	"oyA5PV+cV4HjmqZGCmEnUG3J4eWV8Nw8lxT0ZUpMPlKXxJ/ZXFEMu2CEaxs3d1nlrpzIscM7bGWtpSEf",
+	"P9TePfKWt0Mu/wrSr7hg3+m2xgmKQuKITcQO0hC/5tADrUYB+PqF3KUGTN93/lYszo/Ru2KTUYiLsZy0",
+	"2SgeHtAXfTyfl0e8fTwu5df/+cwr77LK5drezca7a/WtK/4Y6OUrdrjB58k3VoITZla6zd5WovAQOFzu",
+	"h4iukZ1AxTkXkkIHFv5greOguLle11CzqPaDX9dC7QnjkIBTEzgIidKnddASVMfULiKTb39aBSay5O/0",
+	"IEoFXVfbu968vx55RBClSLt6jTRMTBnbV4XVYVTwzwOHViBKlq0twSzHUC+iaRt3ckjBDvo77C255cLJ",
+	"dFrtvc44qSzqP0BimS3IBKOzWVBKf+4S4d0nVUnKGiJV8UFguzz5MEkipPOxk0Q/K/Qx9CUka4IcNGBU",
+	"yyHly38cCa4Ssu8pIWCQQqDx07KU184ygSOOiNIA9a4Kupk/Jm66D1W4waUa/oaGrMF+aN5/HFeDqb1w",
+	"OxhWP15DnkCYJBMao4ZDig/Qe/RA4tyFj/Ze3GPVZ122D/mydb4zgDOl5DE6M/mWrPuQbICGbJwNWOgw",
+	"LUIfCajg6dmQoR/8cjBAtWfXPVAlRu9e+a4cHvuDebEIH8xXnnvVFR8WKyWvutIoXfXKfotQf/movv4P",
+	"duseq2zX9p/IIwX+lRzk371W29+Rc2i2fpNVbjVLy6z8qnYQyeNyFD8oYuUc/FNCbOjA5iObIYSPQqIw",
+	"LFw8CjVKR0m/DYxeMQmLha5saLrCohtUXMSAoJLyPsGc1pd05M5GIZ0jX4bdViz+fwAfwrRF7y0AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
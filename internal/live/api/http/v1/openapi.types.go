@@ -1,6 +1,6 @@
 // Package v1 provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
+// Code generated by github.com/deepmap/oapi-codegen version v1.16.2 DO NOT EDIT.
 package v1
 
 const (
@@ -9,10 +9,67 @@ const (
 
 // Defines values for CreateRoomRequestType.
 const (
-	Group CreateRoomRequestType = "group"
-	User  CreateRoomRequestType = "user"
+	CreateRoomRequestTypeGroup CreateRoomRequestType = "group"
+	CreateRoomRequestTypeUser  CreateRoomRequestType = "user"
 )
 
+// Defines values for RecordingRequestAction.
+const (
+	Start RecordingRequestAction = "start"
+	Stop  RecordingRequestAction = "stop"
+)
+
+// Defines values for GetCallHistoryParamsType.
+const (
+	GetCallHistoryParamsTypeGroup GetCallHistoryParamsType = "group"
+	GetCallHistoryParamsTypeUser  GetCallHistoryParamsType = "user"
+)
+
+// CallHistoryResponse defines model for CallHistoryResponse.
+type CallHistoryResponse struct {
+	List  []CallRecord `json:"list"`
+	Total int64        `json:"total"`
+}
+
+// CallParticipant defines model for CallParticipant.
+type CallParticipant struct {
+	// JoinedAt 加入时间，未加入时为0
+	JoinedAt int64 `json:"joined_at"`
+
+	// LeftAt 退出时间，未加入时为0
+	LeftAt int64  `json:"left_at"`
+	UserId string `json:"user_id"`
+}
+
+// CallRecord defines model for CallRecord.
+type CallRecord struct {
+	// ConnectAt 接通时间，未接通时为0
+	ConnectAt int64 `json:"connect_at"`
+
+	// Creator 发起者ID
+	Creator string `json:"creator"`
+
+	// Duration 通话时长(单位：s)
+	Duration int64 `json:"duration"`
+
+	// EndAt 结束时间，毫秒时间戳
+	EndAt   int64      `json:"end_at"`
+	GroupId uint32     `json:"group_id"`
+	Id      uint32     `json:"id"`
+	Option  RoomOption `json:"option"`
+
+	// Outcome 通话结果 0=正常 1=取消 2=拒绝 3=未接
+	Outcome      int               `json:"outcome"`
+	Participants []CallParticipant `json:"participants"`
+	Room         string            `json:"room"`
+
+	// StartAt 发起时间，毫秒时间戳
+	StartAt int64 `json:"start_at"`
+
+	// Type 通话类型 user=私聊 group=群聊
+	Type string `json:"type"`
+}
+
 // CreateRoomRequest defines model for CreateRoomRequest.
 type CreateRoomRequest struct {
 	// GroupId 群组ID
@@ -75,6 +132,27 @@ type ParticipantInfo struct {
 	State int8 `json:"state"`
 }
 
+// RecordingRequest defines model for RecordingRequest.
+type RecordingRequest struct {
+	// Action 录制操作
+	Action RecordingRequestAction `json:"action"`
+
+	// AudioOnly 是否只录制音频，开始录制时有效
+	AudioOnly bool `json:"audio_only,omitempty"`
+}
+
+// RecordingRequestAction 录制操作
+type RecordingRequestAction string
+
+// RecordingResponse defines model for RecordingResponse.
+type RecordingResponse struct {
+	// RecordingId 录制ID
+	RecordingId string `json:"recording_id"`
+
+	// Status 录制状态 0=录制中 1=已停止 2=已完成 3=失败
+	Status int `json:"status"`
+}
+
 // Response defines model for Response.
 type Response = map[string]interface{}
 
@@ -106,8 +184,34 @@ type RoomOption struct {
 	VideoEnabled bool `json:"video_enabled"`
 }
 
+// GetCallHistoryParams defines parameters for GetCallHistory.
+type GetCallHistoryParams struct {
+	// Type 通话类型
+	Type *GetCallHistoryParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// GroupId 群组ID
+	GroupId *uint32 `form:"group_id,omitempty" json:"group_id,omitempty"`
+
+	// Outcome 通话结果 0=正常 1=取消 2=拒绝 3=未接
+	Outcome *int `form:"outcome,omitempty" json:"outcome,omitempty"`
+
+	// StartAt 发起时间下限，毫秒时间戳
+	StartAt *int64 `form:"start_at,omitempty" json:"start_at,omitempty"`
+
+	// EndAt 发起时间上限，毫秒时间戳
+	EndAt    *int64 `form:"end_at,omitempty" json:"end_at,omitempty"`
+	PageNum  int    `form:"page_num" json:"page_num"`
+	PageSize int    `form:"page_size" json:"page_size"`
+}
+
+// GetCallHistoryParamsType defines parameters for GetCallHistory.
+type GetCallHistoryParamsType string
+
 // CreateRoomJSONRequestBody defines body for CreateRoom for application/json ContentType.
 type CreateRoomJSONRequestBody = CreateRoomRequest
 
 // JoinRoomJSONRequestBody defines body for JoinRoom for application/json ContentType.
 type JoinRoomJSONRequestBody = JoinRoomRequest
+
+// RecordRoomJSONRequestBody defines body for RecordRoom for application/json ContentType.
+type RecordRoomJSONRequestBody = RecordingRequest
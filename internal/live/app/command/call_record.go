@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+)
+
+// saveCallRecord 保存通话记录，失败时只记录日志，不影响结束通话的流程
+func (h *LiveHandler) saveCallRecord(ctx context.Context, room *entity.Room, outcome entity.CallOutcome) {
+	if h.callRecordRepo == nil {
+		return
+	}
+
+	record := entity.NewCallRecord(room, outcome, pkgtime.Now())
+	if err := h.callRecordRepo.Create(ctx, record); err != nil {
+		h.logger.Error("保存通话记录失败", zap.Error(err), zap.String("room", room.ID))
+		return
+	}
+
+	h.logger.Info("保存通话记录", zap.String("room", room.ID), zap.Uint("outcome", uint(outcome)), zap.Int64("duration", record.Duration))
+}
+
+// answeredOutcome 根据是否有人接听判断通话结果
+func answeredOutcome(room *entity.Room) entity.CallOutcome {
+	if room.Answered() {
+		return entity.CallOutcomeNormal
+	}
+	return entity.CallOutcomeCancelled
+}
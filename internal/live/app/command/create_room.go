@@ -5,18 +5,18 @@ import (
 	"fmt"
 	groupgrpcv1 "github.com/cossim/coss-server/internal/group/api/grpc/v1"
 	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/media"
 	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/code"
 	"github.com/cossim/coss-server/pkg/utils"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
 	any2 "github.com/golang/protobuf/ptypes/any"
 	"github.com/google/uuid"
-	"github.com/livekit/protocol/livekit"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"strings"
-	"time"
 )
 
 type CreateRoom struct {
@@ -118,9 +118,6 @@ func (h *LiveHandler) CreateRoom(ctx context.Context, cmd *CreateRoom) (*CreateR
 		zap.String("creator", cmd.Creator),
 	)
 
-	// 通话超时处理
-	h.handleLiveTimeout(roomName, int(h.liveTimeout.Seconds()), cmd.DriverID)
-
 	return &CreateRoomResponse{
 		Url:     h.webRtcUrl,
 		Room:    roomName,
@@ -129,9 +126,9 @@ func (h *LiveHandler) CreateRoom(ctx context.Context, cmd *CreateRoom) (*CreateR
 }
 
 func (h *LiveHandler) createRoomAndRecord(ctx context.Context, roomName string, roomType entity.RoomType, creator string, groupID uint32, maxParticipants uint32, participants []string, option entity.RoomOption) error {
-	_, err := h.roomService.CreateRoom(ctx, &livekit.CreateRoomRequest{
+	_, err := h.media.CreateRoom(ctx, &media.CreateRoomRequest{
 		Name:            roomName,
-		EmptyTimeout:    uint32(h.liveTimeout.Seconds()),
+		EmptyTimeout:    h.liveTimeout,
 		MaxParticipants: maxParticipants,
 	})
 	if err != nil {
@@ -155,6 +152,7 @@ func (h *LiveHandler) createRoomAndRecord(ctx context.Context, roomName string,
 			FrameRate:    option.FrameRate,
 			Codec:        option.Codec,
 		},
+		CreatedAt: pkgtime.Now(),
 	}
 
 	//if err := h.liveRepo.CreateRoom(ctx, roomEntity); err != nil {
@@ -162,38 +160,12 @@ func (h *LiveHandler) createRoomAndRecord(ctx context.Context, roomName string,
 	//	return err
 	//}
 
-	return h.liveRepo.CreateRoom(ctx, roomEntity)
-}
-
-func (h *LiveHandler) handleLiveTimeout(roomID string, timeoutSeconds int, driverID string) {
-	time.AfterFunc(time.Duration(timeoutSeconds)*time.Second, func() {
-		ctx := context.Background()
-
-		livekitRoom, err := h.getLivekitRoom(ctx, roomID)
-		if err != nil {
-			h.logger.Error("Failed to get livekit room", zap.Error(err))
-			return
-		}
-
-		room, err := h.liveRepo.GetRoom(ctx, roomID)
-		if err != nil {
-			h.logger.Error("Failed to get room", zap.Error(err))
-			return
-		}
-
-		if room.NumParticipants >= 2 {
-			return
-		}
-
-		h.logger.Info("推送通话超时事件", zap.Duration("timeout", time.Duration(timeoutSeconds)*time.Second), zap.Any("room", room))
-		if err := h.handleMissed(ctx, livekitRoom, room, room.Creator, driverID); err != nil {
-			h.logger.Error("Failed to handle missed", zap.Error(err))
-		}
+	if err := h.liveRepo.CreateRoom(ctx, roomEntity); err != nil {
+		return err
+	}
 
-		if err := h.cleanUserRoom(ctx, room); err != nil {
-			h.logger.Error("Failed to clean user room", zap.Error(err))
-		}
-	})
+	// 超时未接听由后台任务处理
+	return h.startRinging(ctx, roomEntity)
 }
 
 func (h *LiveHandler) isUserInLive(ctx context.Context, userID string) error {
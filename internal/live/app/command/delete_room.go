@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/media"
 	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
 	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
@@ -13,7 +14,6 @@ import (
 	"github.com/cossim/coss-server/pkg/utils"
 	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
 	any2 "github.com/golang/protobuf/ptypes/any"
-	"github.com/livekit/protocol/livekit"
 	"go.uber.org/zap"
 	"strconv"
 	"time"
@@ -43,10 +43,11 @@ func (h *LiveHandler) DeleteRoom(ctx context.Context, cmd *DeleteRoom) error {
 	}
 }
 
-func (h *LiveHandler) cleanUserRoom(ctx context.Context, room *entity.Room) error {
-	// 删除房间和用户连接信息
-	if err := h.deleteRoom(ctx, room.ID); err != nil {
-		h.logger.Error("删除 Redis 房间错误", zap.Error(err))
+// cleanUserRoom 删除房间和用户连接信息，返回是否由本次调用结束通话
+func (h *LiveHandler) cleanUserRoom(ctx context.Context, room *entity.Room) (bool, error) {
+	deleted, err := h.deleteRoom(ctx, room.ID)
+	if err != nil {
+		return false, err
 	}
 
 	for userID := range room.Participants {
@@ -55,7 +56,7 @@ func (h *LiveHandler) cleanUserRoom(ctx context.Context, room *entity.Room) erro
 		}
 	}
 
-	return nil
+	return deleted, nil
 }
 
 func (h *LiveHandler) handleDeleteUserRoom(ctx context.Context, cmd *DeleteRoom, room *entity.Room) error {
@@ -65,16 +66,20 @@ func (h *LiveHandler) handleDeleteUserRoom(ctx context.Context, cmd *DeleteRoom,
 		return code.LiveErrUserNotInCall
 	}
 
-	liveRoom, err := h.getLivekitRoom(ctx, room.ID)
+	liveRoom, err := h.getMediaRoom(ctx, room.ID)
 	if err != nil {
-		h.logger.Error("获取 LiveKit 房间错误", zap.Error(err))
+		h.logger.Error("获取媒体房间错误", zap.Error(err))
 		return err
 	}
 
-	// 无论什么情况都先删除房间信息
-	if err := h.cleanUserRoom(ctx, room); err != nil {
+	// 无论什么情况都先删除房间信息，通话已经被其他请求结束时不再重复处理
+	deleted, err := h.cleanUserRoom(ctx, room)
+	if err != nil {
 		return err
 	}
+	if !deleted {
+		return nil
+	}
 
 	// 存储当前用户的参与者信息
 	thisActiveParticipant := participant
@@ -115,34 +120,50 @@ func (h *LiveHandler) deleteGroupRoom(ctx context.Context, cmd *DeleteRoom, room
 		return err
 	}
 
-	ap, ok := room.Participants[cmd.UserID]
-	if !ok {
-		return code.LiveErrUserNotInCall
-	}
+	var ap *entity.ActiveParticipant
+	var last bool
+	room, err := h.liveRepo.ModifyRoom(ctx, room.ID, func(room *entity.Room) error {
+		var ok bool
+		ap, ok = room.Participants[cmd.UserID]
+		if !ok {
+			return code.LiveErrUserNotInCall
+		}
 
-	// 如果是最后一个退出的用户，则删除整个房间
-	if room.NumParticipants-1 == 0 {
-		if err := h.liveRepo.DeleteGroupLive(ctx, strconv.Itoa(int(room.GroupID))); err != nil {
-			h.logger.Error("delete group live error", zap.Error(err))
-			return err
+		// 如果是最后一个退出的用户，则删除整个房间
+		last = room.NumParticipants <= 1
+		if last {
+			return nil
 		}
-		return h.deleteRoom(ctx, room.ID)
-	}
 
-	_, err := h.roomService.RemoveParticipant(context.Background(), &livekit.RoomParticipantIdentity{
-		Room:     room.ID,
-		Identity: cmd.UserID,
+		room.NumParticipants--
+		ap.LeftAt = pkgtime.Now()
+		if room.LeftParticipants == nil {
+			room.LeftParticipants = make(map[string]*entity.ActiveParticipant)
+		}
+		room.LeftParticipants[cmd.UserID] = ap
+		delete(room.Participants, cmd.UserID)
+		return nil
 	})
 	if err != nil {
-		h.logger.Error("remove participant error", zap.Error(err))
+		h.logger.Error("update room error", zap.Error(err))
 		return err
 	}
 
-	room.NumParticipants--
-	delete(room.Participants, cmd.UserID)
-	if err := h.liveRepo.UpdateRoom(ctx, room); err != nil {
-		h.logger.Error("update room error", zap.Error(err))
-		return err
+	if last {
+		// 多个成员同时退出时只由删除房间的请求保存通话记录
+		deleted, err := h.deleteRoom(ctx, room.ID)
+		if err != nil || !deleted {
+			return err
+		}
+		if err := h.liveRepo.DeleteGroupLive(ctx, strconv.Itoa(int(room.GroupID))); err != nil {
+			h.logger.Error("delete group live error", zap.Error(err))
+		}
+		h.saveCallRecord(ctx, room, answeredOutcome(room))
+		return nil
+	}
+
+	if err := h.media.RemoveParticipant(context.Background(), room.ID, cmd.UserID); err != nil {
+		h.logger.Error("remove participant error", zap.Error(err))
 	}
 
 	h.logger.Info("退出群聊通话", zap.String("uid", cmd.UserID), zap.Any("room", room))
@@ -157,7 +178,8 @@ func formatDuration(duration time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", minutes, seconds)
 }
 
-func (h *LiveHandler) deleteEntireGroupRoom(ctx context.Context, room *entity.Room) error {
+// deleteEntireGroupRoom 结束群聊通话，返回是否由本次调用删除房间
+func (h *LiveHandler) deleteEntireGroupRoom(ctx context.Context, room *entity.Room) (bool, error) {
 	for userID := range room.Participants {
 		if err := h.liveRepo.DeleteUsersLive(ctx, userID); err != nil {
 			h.logger.Error("delete user live error", zap.Error(err))
@@ -166,15 +188,7 @@ func (h *LiveHandler) deleteEntireGroupRoom(ctx context.Context, room *entity.Ro
 	if err := h.liveRepo.DeleteGroupLive(ctx, strconv.Itoa(int(room.GroupID))); err != nil {
 		h.logger.Error("delete group live error", zap.Error(err))
 	}
-	if err := h.liveRepo.DeleteRoom(ctx, room.ID); err != nil {
-		h.logger.Error("delete redis room error", zap.Error(err))
-	}
-	if _, err := h.roomService.DeleteRoom(ctx, &livekit.DeleteRoomRequest{
-		Room: room.ID,
-	}); err != nil {
-		return err
-	}
-	return nil
+	return h.deleteRoom(ctx, room.ID)
 }
 
 func (h *LiveHandler) getMessageType(room *entity.Room) uint {
@@ -256,12 +270,12 @@ func (h *LiveHandler) pushEventToParticipants(ctx context.Context, event pushgrp
 
 // getRoomDuration 获取房间通话时长
 func (h *LiveHandler) getRoomDuration(ctx context.Context, room string) time.Duration {
-	livekitRoom, err := h.getLivekitRoom(ctx, room)
+	mediaRoom, err := h.getMediaRoom(ctx, room)
 	if err != nil {
 		h.logger.Error("获取房间信息失败", zap.Error(err))
 		return 0
 	}
-	creationTime := time.Unix(livekitRoom.CreationTime, 0)
+	creationTime := time.Unix(mediaRoom.CreationTime, 0)
 	duration := time.Since(creationTime)
 	return duration
 }
@@ -272,18 +286,17 @@ func calculationDuration(startAt int64) time.Duration {
 	return duration
 }
 
-func (h *LiveHandler) getLivekitRoom(ctx context.Context, room string) (*livekit.Room, error) {
-	rooms, err := h.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{Names: []string{room}})
+func (h *LiveHandler) getMediaRoom(ctx context.Context, room string) (*media.Room, error) {
+	mediaRoom, err := h.media.GetRoom(ctx, room)
 	if err != nil {
+		if code.IsCode(err, code.LiveErrCallNotFound) {
+			return nil, err
+		}
 		h.logger.Error("获取房间信息失败", zap.Error(err))
 		return nil, code.LiveErrGetCallInfoFailed
 	}
 
-	if len(rooms.Rooms) == 0 {
-		return nil, code.LiveErrCallNotFound
-	}
-
-	return rooms.Rooms[0], nil
+	return mediaRoom, nil
 }
 
 func (h *LiveHandler) notifyGroupCallEnd(ctx context.Context, room *entity.Room, senderID string, connected bool) {
@@ -321,18 +334,22 @@ func (h *LiveHandler) sendPushMessage(ctx context.Context, senderID, recipientID
 	}
 }
 
-func (h *LiveHandler) deleteRoom(ctx context.Context, roomID string) error {
-	if err := h.liveRepo.DeleteRoom(ctx, roomID); err != nil {
+// deleteRoom 删除房间，返回是否由本次调用删除，房间已经被其他请求删除时不再处理媒体房间
+func (h *LiveHandler) deleteRoom(ctx context.Context, roomID string) (bool, error) {
+	deleted, err := h.liveRepo.DeleteRoom(ctx, roomID)
+	if err != nil {
 		h.logger.Error("delete redis room error", zap.Error(err))
-		return err
+		return false, err
+	}
+	if !deleted {
+		return false, nil
 	}
 
-	if _, err := h.roomService.DeleteRoom(ctx, &livekit.DeleteRoomRequest{
-		Room: roomID,
-	}); err != nil {
-		return err
+	// 媒体房间可能已经关闭，只记录日志
+	if err := h.media.DeleteRoom(ctx, roomID); err != nil {
+		h.logger.Error("delete media room error", zap.Error(err), zap.String("room", roomID))
 	}
-	return nil
+	return true, nil
 }
 
 // buildPushMessageBytes 创建推送消息并转换为字节切片
@@ -351,31 +368,33 @@ func buildPushMessageBytes(recipientID string, event pushgrpcv1.WSEventType, dat
 	return toBytes, nil
 }
 
-func (h *LiveHandler) handleMissed(ctx context.Context, liveRoom *livekit.Room, room *entity.Room, userID, driverID string) error {
+func (h *LiveHandler) handleMissed(ctx context.Context, liveRoom *media.Room, room *entity.Room, userID, driverID string) error {
 	content := "无应答"
 	subType := int32(msggrpcv1.CallSubType_Missed)
 	return h.handleUserMessage(ctx, room, userID, driverID, content, subType)
 }
 
-func (h *LiveHandler) handleCancelled(ctx context.Context, liveRoom *livekit.Room, room *entity.Room, userID, driverID string) error {
+func (h *LiveHandler) handleCancelled(ctx context.Context, liveRoom *media.Room, room *entity.Room, userID, driverID string) error {
 	content := "取消"
 	subType := int32(msggrpcv1.CallSubType_Cancelled)
 	return h.handleUserMessage(ctx, room, userID, driverID, content, subType)
 }
 
-func (h *LiveHandler) handleRejected(ctx context.Context, liveRoom *livekit.Room, room *entity.Room, userID, driverID string) error {
+func (h *LiveHandler) handleRejected(ctx context.Context, liveRoom *media.Room, room *entity.Room, userID, driverID string) error {
 	content := "拒绝"
 	subType := int32(msggrpcv1.CallSubType_Rejected)
 	return h.handleUserMessage(ctx, room, userID, driverID, content, subType)
 }
 
-func (h *LiveHandler) handleAnyDisconnected(ctx context.Context, liveRoom *livekit.Room, room *entity.Room, userID, driverID string) error {
+func (h *LiveHandler) handleAnyDisconnected(ctx context.Context, liveRoom *media.Room, room *entity.Room, userID, driverID string) error {
 	content := fmt.Sprintf("通话时长：%s", formatDuration(calculationDuration(liveRoom.CreationTime)))
 	subType := int32(msggrpcv1.CallSubType_Normal)
 	return h.handleUserMessage(ctx, room, userID, driverID, content, subType)
 }
 
 func (h *LiveHandler) handleUserMessage(ctx context.Context, room *entity.Room, userID, driverID string, content string, subType int32) error {
+	h.saveCallRecord(ctx, room, entity.CallOutcome(subType))
+
 	var senderID string
 	var recipientID string
 
@@ -426,7 +445,7 @@ func (h *LiveHandler) handleUserMessage(ctx context.Context, room *entity.Room,
 
 	// 推送通话事件
 	eventType := pushgrpcv1.WSEventType_UserCallRejectEvent
-	if subType == int32(msggrpcv1.CallSubType_Normal) {
+	if subType == int32(msggrpcv1.CallSubType_Normal) || subType == int32(msggrpcv1.CallSubType_Missed) {
 		eventType = pushgrpcv1.WSEventType_UserCallEndEvent
 	}
 	h.pushEventToParticipants(ctx, eventType, message, room)
@@ -3,16 +3,15 @@ package command
 import (
 	"context"
 	"errors"
-	"fmt"
 	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/media"
 	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/code"
 	"github.com/cossim/coss-server/pkg/utils"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
 	any2 "github.com/golang/protobuf/ptypes/any"
-	"github.com/livekit/protocol/auth"
-	"github.com/livekit/protocol/livekit"
 	"go.uber.org/zap"
 	"strconv"
 )
@@ -103,61 +102,44 @@ func (h *LiveHandler) JoinRoom(ctx context.Context, cmd *JoinRoom) (*JoinRoomRes
 }
 
 func (h *LiveHandler) joinUserRoom(ctx context.Context, roomID, userID, driverID string) (*entity.Room, error) {
-	room, err := h.liveRepo.GetRoom(ctx, roomID)
+	mediaRoom, err := h.media.GetRoom(ctx, roomID)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, ok := room.Participants[userID]; !ok {
-		return nil, code.Forbidden
-	}
-
-	if room.Participants[userID].Connected {
-		return nil, code.LiveErrAlreadyInCall
-	}
-
-	if room.NumParticipants+1 > room.MaxParticipants {
-		return nil, code.LiveErrMaxParticipantsExceeded
-	}
+	// 双方同时加入或者与挂断、超时处理同时发生时，基于最新的房间状态判断和修改
+	room, err := h.liveRepo.ModifyRoom(ctx, roomID, func(room *entity.Room) error {
+		ap, ok := room.Participants[userID]
+		if !ok {
+			return code.Forbidden
+		}
+		if ap.Connected {
+			return code.LiveErrAlreadyInCall
+		}
+		if room.NumParticipants+1 > room.MaxParticipants || mediaRoom.NumPublishers+1 > room.MaxParticipants {
+			return code.LiveErrMaxParticipantsExceeded
+		}
 
-	rooms, err := h.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{
-		Names: []string{roomID},
+		room.NumParticipants++
+		ap.DriverID = driverID
+		ap.Connected = true
+		ap.Status = entity.ParticipantInfo_JOINED
+		ap.JoinedAt = pkgtime.Now()
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(rooms.Rooms) == 0 {
-		return nil, code.LiveErrCallNotFound
-	}
-
-	if rooms.Rooms[0].NumPublishers+1 > room.MaxParticipants {
-		return nil, code.LiveErrMaxParticipantsExceeded
-	}
-
-	room.NumParticipants++
-	room.Participants[userID].DriverID = driverID
-	room.Participants[userID].Connected = true
-	room.Participants[userID].Status = entity.ParticipantInfo_JOINED
-
 	// 人数等于 MaxParticipants(2) 代表双方都加入通话了，更新过期时间为永久直至挂断才删除通话
 	if room.NumParticipants == room.MaxParticipants {
-		for i, _ := range room.Participants {
+		for i := range room.Participants {
 			if err := h.liveRepo.SetUserLivePersist(ctx, i); err != nil {
 				h.logger.Error("更新用户过期时间失败", zap.Error(err))
 				return nil, err
 			}
 		}
-		//if err := h.liveRepo.SetRoomPersist(ctx, room.ID); err != nil {
-		//	h.logger.Error("更新房间过期时间失败", zap.Error(err))
-		//	return nil, err
-		//}
-		if err := h.liveRepo.UpdateRoomWithExpiration(ctx, room, PermanentExpiration); err != nil {
-			h.logger.Error("更新房间过期时间失败", zap.Error(err))
-			return nil, err
-		}
-	} else {
-		if err := h.liveRepo.UpdateRoomWithExpiration(ctx, room, PreviousExpiration); err != nil {
+		if err := h.liveRepo.SetRoomPersist(ctx, room.ID); err != nil {
 			h.logger.Error("更新房间过期时间失败", zap.Error(err))
 			return nil, err
 		}
@@ -204,20 +186,6 @@ func (h *LiveHandler) joinGroupRoom(ctx context.Context, roomID string, groupID
 		return nil, err
 	}
 
-	room, err := h.liveRepo.GetRoom(ctx, roomID)
-	if err != nil {
-		return nil, err
-	}
-	p, ok := room.Participants[userID]
-	if ok {
-		if p.Connected || p.Status == entity.ParticipantInfo_JOINED {
-			return nil, code.LiveErrAlreadyInCall
-		}
-	}
-	if room.NumParticipants+1 > room.MaxParticipants {
-		return nil, code.LiveErrMaxParticipantsExceeded
-	}
-
 	rooms, err := h.liveRepo.GetUserRooms(ctx, userID)
 	if err != nil {
 		h.logger.Error("获取用户房间失败", zap.Error(err))
@@ -226,38 +194,40 @@ func (h *LiveHandler) joinGroupRoom(ctx context.Context, roomID string, groupID
 		}
 	}
 	for _, v := range rooms {
-		p, ok = v.Participants[userID]
+		p, ok := v.Participants[userID]
 		if ok && p.Connected {
 			return nil, code.LiveErrAlreadyInCall
 		}
 	}
 
-	livekitRooms, err := h.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{
-		Names: []string{roomID},
-	})
+	mediaRoom, err := h.media.GetRoom(ctx, roomID)
 	if err != nil {
 		return nil, err
 	}
-	if len(livekitRooms.Rooms) == 0 {
-		return nil, code.LiveErrCallNotFound
-	}
-	if livekitRooms.Rooms[0].NumPublishers+1 > room.MaxParticipants {
-		return nil, code.LiveErrMaxParticipantsExceeded
-	}
 
-	fmt.Println("room => ", room)
+	// 多人同时加入时基于最新的房间状态判断人数和修改
+	room, err := h.liveRepo.ModifyRoom(ctx, roomID, func(room *entity.Room) error {
+		p, ok := room.Participants[userID]
+		if ok && (p.Connected || p.Status == entity.ParticipantInfo_JOINED) {
+			return code.LiveErrAlreadyInCall
+		}
+		if room.NumParticipants+1 > room.MaxParticipants || mediaRoom.NumPublishers+1 > room.MaxParticipants {
+			return code.LiveErrMaxParticipantsExceeded
+		}
 
-	_, ok = room.Participants[userID]
-	if ok {
-		room.Participants[userID].Connected = true
-		room.Participants[userID].Status = entity.ParticipantInfo_JOINED
-	} else {
-		room.Participants[userID] = &entity.ActiveParticipant{
-			Connected: true,
-			Status:    entity.ParticipantInfo_JOINED,
+		if !ok {
+			p = &entity.ActiveParticipant{}
+			room.Participants[userID] = p
 		}
+		p.Connected = true
+		p.Status = entity.ParticipantInfo_JOINED
+		p.JoinedAt = pkgtime.Now()
+		room.NumParticipants++
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	room.NumParticipants++
 
 	// 用户加入了群聊通话，更新过期时间为永久直至挂断才删除通话
 	if room.NumParticipants == 1 {
@@ -266,7 +236,7 @@ func (h *LiveHandler) joinGroupRoom(ctx context.Context, roomID string, groupID
 		}
 	}
 
-	if err := h.liveRepo.UpdateRoomWithExpiration(ctx, room, PermanentExpiration); err != nil {
+	if err := h.liveRepo.SetRoomPersist(ctx, room.ID); err != nil {
 		h.logger.Error("更新房间过期时间失败", zap.Error(err))
 		return nil, err
 	}
@@ -317,36 +287,25 @@ func (h *LiveHandler) joinGroupRoom(ctx context.Context, roomID string, groupID
 }
 
 func (h *LiveHandler) GetUserJoinToken(ctx context.Context, room, userName, userID string) (string, error) {
-	at := auth.NewAccessToken(h.liveApiKey, h.liveApiSecret)
-	grant := &auth.VideoGrant{
-		RoomJoin: true,
-		Room:     room,
-	}
-	at.AddGrant(grant).SetName(userName).SetIdentity(userID).SetValidFor(h.liveTimeout)
-	jwt, err := at.ToJWT()
-	if err != nil {
-		h.logger.Error("Failed to generate JWT", zap.Error(err))
-		return "", err
-	}
-
-	return jwt, nil
+	return h.issueJoinToken(ctx, room, userName, userID, false)
 }
 
 func (h *LiveHandler) GetAdminJoinToken(ctx context.Context, room, userName, userID string) (string, error) {
-	at := auth.NewAccessToken(h.liveApiKey, h.liveApiSecret)
-	grant := &auth.VideoGrant{
-		RoomCreate: true,
-		RoomList:   true,
-		RoomAdmin:  true,
-		RoomJoin:   true,
-		Room:       room,
-	}
-	at.AddGrant(grant).SetName(userName).SetIdentity(userID).SetValidFor(h.liveTimeout)
-	jwt, err := at.ToJWT()
+	return h.issueJoinToken(ctx, room, userName, userID, true)
+}
+
+func (h *LiveHandler) issueJoinToken(ctx context.Context, room, userName, userID string, admin bool) (string, error) {
+	token, err := h.media.IssueToken(ctx, &media.TokenRequest{
+		Room:     room,
+		Identity: userID,
+		Name:     userName,
+		Admin:    admin,
+		ValidFor: h.liveTimeout,
+	})
 	if err != nil {
-		h.logger.Error("Failed to generate JWT", zap.Error(err))
+		h.logger.Error("Failed to issue join token", zap.Error(err))
 		return "", err
 	}
 
-	return jwt, nil
+	return token, nil
 }
@@ -2,14 +2,15 @@ package command
 
 import (
 	groupgrpcv1 "github.com/cossim/coss-server/internal/group/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/live/domain/media"
 	"github.com/cossim/coss-server/internal/live/domain/repository"
 	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
 	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	storagegrpcv1 "github.com/cossim/coss-server/internal/storage/api/grpc/v1"
 	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/config"
 	"github.com/cossim/coss-server/pkg/decorator"
-	lksdk "github.com/livekit/server-sdk-go"
 	"go.uber.org/zap"
 	"time"
 )
@@ -26,12 +27,14 @@ type CreateRoomHandler decorator.CommandHandler[*CreateRoom, *CreateRoomResponse
 type LiveHandler struct {
 	logger   *zap.Logger
 	liveRepo repository.Repository
+	// callRecordRepo 通话结束后保存通话记录
+	callRecordRepo repository.CallRecordRepository
+	// callRecordingRepo 保存通话录制，录制结束后根据回调生成录制文件和消息
+	callRecordingRepo repository.CallRecordingRepository
 
-	webRtcUrl     string
-	liveApiKey    string
-	liveApiSecret string
-	liveTimeout   time.Duration
-	roomService   *lksdk.RoomServiceClient
+	webRtcUrl   string
+	liveTimeout time.Duration
+	media       media.MediaProvider
 
 	msgService           msggrpcv1.MsgServiceClient
 	userService          usergrpcv1.UserServiceClient
@@ -39,6 +42,10 @@ type LiveHandler struct {
 	groupService         groupgrpcv1.GroupServiceClient
 	relationGroupService relationgrpcv1.GroupRelationServiceClient
 	relationUserService  relationgrpcv1.UserRelationServiceClient
+
+	// 录制结束后保存文件并发送到会话
+	relationDialogService relationgrpcv1.DialogServiceClient
+	storageService        storagegrpcv1.StorageServiceClient
 }
 
 type LiveHandlerOption func(*LiveHandler)
@@ -49,6 +56,18 @@ func WithRepo(repo repository.Repository) LiveHandlerOption {
 	}
 }
 
+func WithCallRecordRepo(repo repository.CallRecordRepository) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.callRecordRepo = repo
+	}
+}
+
+func WithCallRecordingRepo(repo repository.CallRecordingRepository) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.callRecordingRepo = repo
+	}
+}
+
 func WithLogger(logger *zap.Logger) LiveHandlerOption {
 	return func(h *LiveHandler) {
 		h.logger = logger
@@ -58,10 +77,13 @@ func WithLogger(logger *zap.Logger) LiveHandlerOption {
 func WithLiveKit(c config.LivekitConfig) LiveHandlerOption {
 	return func(h *LiveHandler) {
 		h.webRtcUrl = c.Url
-		h.liveApiKey = c.ApiKey
-		h.liveApiSecret = c.ApiSecret
 		h.liveTimeout = c.Timeout
-		h.roomService = lksdk.NewRoomServiceClient(c.Url, c.ApiKey, c.ApiSecret)
+	}
+}
+
+func WithMediaProvider(provider media.MediaProvider) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.media = provider
 	}
 }
 
@@ -101,6 +123,18 @@ func WithRelationUserService(service relationgrpcv1.UserRelationServiceClient) L
 	}
 }
 
+func WithRelationDialogService(service relationgrpcv1.DialogServiceClient) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.relationDialogService = service
+	}
+}
+
+func WithStorageService(service storagegrpcv1.StorageServiceClient) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.storageService = service
+	}
+}
+
 func NewLiveHandler(options ...LiveHandlerOption) *LiveHandler {
 	h := &LiveHandler{}
 	for _, option := range options {
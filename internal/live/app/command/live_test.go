@@ -0,0 +1,1318 @@
+package command_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	groupgrpcv1 "github.com/cossim/coss-server/internal/group/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/live/adapters"
+	"github.com/cossim/coss-server/internal/live/app/command"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/media"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	storagegrpcv1 "github.com/cossim/coss-server/internal/storage/api/grpc/v1"
+	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/config"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// fakeLiveRepo 内存实现的房间存储，房间序列化后保存，和 redis 一样每次读取得到新的副本
+type fakeLiveRepo struct {
+	mu        sync.Mutex
+	rooms     map[string][]byte
+	userRooms map[string]string
+	groupRoom map[string]string
+	ringing   map[string]int64
+	// getRoomErr 不为空时读取房间返回该错误，模拟存储故障
+	getRoomErr error
+}
+
+func newFakeLiveRepo() *fakeLiveRepo {
+	return &fakeLiveRepo{
+		rooms:     make(map[string][]byte),
+		userRooms: make(map[string]string),
+		groupRoom: make(map[string]string),
+		ringing:   make(map[string]int64),
+	}
+}
+
+func (f *fakeLiveRepo) getRoom(roomID string) (*entity.Room, error) {
+	if f.getRoomErr != nil {
+		return nil, f.getRoomErr
+	}
+	data, ok := f.rooms[roomID]
+	if !ok {
+		return nil, code.LiveErrCallNotFound
+	}
+	room := &entity.Room{}
+	if err := room.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (f *fakeLiveRepo) CreateRoom(ctx context.Context, room *entity.Room) error {
+	return f.UpdateRoom(ctx, room)
+}
+
+func (f *fakeLiveRepo) GetRoom(ctx context.Context, roomID string) (*entity.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.getRoom(roomID)
+}
+
+func (f *fakeLiveRepo) UpdateRoom(ctx context.Context, room *entity.Room) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, err := room.Marshal()
+	if err != nil {
+		return err
+	}
+	f.rooms[room.ID] = data
+	return nil
+}
+
+func (f *fakeLiveRepo) ModifyRoom(ctx context.Context, roomID string, fn func(room *entity.Room) error) (*entity.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	room, err := f.getRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(room); err != nil {
+		return nil, err
+	}
+	data, err := room.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	f.rooms[room.ID] = data
+	return room, nil
+}
+
+func (f *fakeLiveRepo) UpdateRoomWithExpiration(ctx context.Context, room *entity.Room, expiration time.Duration) error {
+	return f.UpdateRoom(ctx, room)
+}
+
+func (f *fakeLiveRepo) SetRoomPersist(ctx context.Context, roomID string) error {
+	return nil
+}
+
+func (f *fakeLiveRepo) DeleteRoom(ctx context.Context, roomID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.rooms[roomID]
+	delete(f.rooms, roomID)
+	return ok, nil
+}
+
+func (f *fakeLiveRepo) ListRooms(ctx context.Context, roomIDs []string) ([]*entity.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var rooms []*entity.Room
+	for _, id := range roomIDs {
+		if room, err := f.getRoom(id); err == nil {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func (f *fakeLiveRepo) GetParticipant(ctx context.Context, roomID string, participantID string) (*entity.ParticipantInfo, error) {
+	return nil, code.LiveErrUserNotInCall
+}
+
+func (f *fakeLiveRepo) ListRoomsByCreator(ctx context.Context, creator string) ([]*entity.Room, error) {
+	return nil, nil
+}
+
+func (f *fakeLiveRepo) ListRoomsByOwner(ctx context.Context, owner string) ([]*entity.Room, error) {
+	return nil, nil
+}
+
+func (f *fakeLiveRepo) GetUserRooms(ctx context.Context, userID string) ([]*entity.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	roomID, ok := f.userRooms[userID]
+	if !ok {
+		return nil, code.LiveErrCallNotFound
+	}
+	room, err := f.getRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+	return []*entity.Room{room}, nil
+}
+
+func (f *fakeLiveRepo) CreateUsersLive(ctx context.Context, roomID string, userIDs ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range userIDs {
+		f.userRooms[id] = roomID
+	}
+	return nil
+}
+
+func (f *fakeLiveRepo) DeleteUsersLive(ctx context.Context, userIDs ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range userIDs {
+		delete(f.userRooms, id)
+	}
+	return nil
+}
+
+func (f *fakeLiveRepo) UpdateUserLiveExpiration(ctx context.Context, userID string, expiration time.Duration) error {
+	return nil
+}
+
+func (f *fakeLiveRepo) SetUserLivePersist(ctx context.Context, userID string) error {
+	return nil
+}
+
+func (f *fakeLiveRepo) CreateGroupLive(ctx context.Context, roomID string, groupID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.groupRoom[groupID] = roomID
+	return nil
+}
+
+func (f *fakeLiveRepo) DeleteGroupLive(ctx context.Context, groupID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.groupRoom, groupID)
+	return nil
+}
+
+func (f *fakeLiveRepo) GetGroupRoom(ctx context.Context, groupID string) (*entity.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	roomID, ok := f.groupRoom[groupID]
+	if !ok {
+		return nil, code.LiveErrCallNotFound
+	}
+	return f.getRoom(roomID)
+}
+
+func (f *fakeLiveRepo) UpdateGroupLiveExpiration(ctx context.Context, groupID string, expiration time.Duration) error {
+	return nil
+}
+
+func (f *fakeLiveRepo) SetGroupLivePersist(ctx context.Context, userID string) error {
+	return nil
+}
+
+func (f *fakeLiveRepo) AddRingingRoom(ctx context.Context, roomID string, deadline int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ringing[roomID] = deadline
+	return nil
+}
+
+func (f *fakeLiveRepo) ListRingingRooms(ctx context.Context, before int64, limit int64) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var rooms []string
+	for id, deadline := range f.ringing {
+		if deadline <= before && int64(len(rooms)) < limit {
+			rooms = append(rooms, id)
+		}
+	}
+	return rooms, nil
+}
+
+func (f *fakeLiveRepo) ClaimRingingRoom(ctx context.Context, roomID string, now, leaseUntil int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	deadline, ok := f.ringing[roomID]
+	if !ok || deadline > now {
+		return false, nil
+	}
+	f.ringing[roomID] = leaseUntil
+	return true, nil
+}
+
+func (f *fakeLiveRepo) RemoveRingingRoom(ctx context.Context, roomID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.ringing[roomID]; !ok {
+		return false, nil
+	}
+	delete(f.ringing, roomID)
+	return true, nil
+}
+
+// 以下 grpc 客户端只实现通话流程用到的方法，所有用户互为好友并且都在群里
+
+type fakeUserRelationClient struct {
+	relationgrpcv1.UserRelationServiceClient
+}
+
+func (f *fakeUserRelationClient) GetUserRelation(ctx context.Context, in *relationgrpcv1.GetUserRelationRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetUserRelationResponse, error) {
+	return &relationgrpcv1.GetUserRelationResponse{
+		UserId:   in.UserId,
+		FriendId: in.FriendId,
+		DialogId: 1,
+		Status:   relationgrpcv1.RelationStatus_RELATION_NORMAL,
+	}, nil
+}
+
+func (f *fakeUserRelationClient) GetRelationsWithUsers(ctx context.Context, in *relationgrpcv1.GetUserRelationByUserIdsRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetUserRelationByUserIdsResponse, error) {
+	resp := &relationgrpcv1.GetUserRelationByUserIdsResponse{}
+	for _, id := range in.FriendIds {
+		resp.Users = append(resp.Users, &relationgrpcv1.GetUserRelationResponse{
+			UserId:   in.UserId,
+			FriendId: id,
+			DialogId: 1,
+			Status:   relationgrpcv1.RelationStatus_RELATION_NORMAL,
+		})
+	}
+	return resp, nil
+}
+
+// fakeGroupRelationClient 未设置身份的用户为普通成员
+type fakeGroupRelationClient struct {
+	relationgrpcv1.GroupRelationServiceClient
+
+	identities map[string]relationgrpcv1.GroupIdentity
+}
+
+func (f *fakeGroupRelationClient) GetGroupRelation(ctx context.Context, in *relationgrpcv1.GetGroupRelationRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetGroupRelationResponse, error) {
+	return &relationgrpcv1.GetGroupRelationResponse{GroupId: in.GroupId, UserId: in.UserId, Identity: f.identities[in.UserId]}, nil
+}
+
+func (f *fakeGroupRelationClient) GetBatchGroupRelation(ctx context.Context, in *relationgrpcv1.GetBatchGroupRelationRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetBatchGroupRelationResponse, error) {
+	resp := &relationgrpcv1.GetBatchGroupRelationResponse{}
+	for _, id := range in.UserIds {
+		resp.GroupRelationResponses = append(resp.GroupRelationResponses, &relationgrpcv1.GetGroupRelationResponse{GroupId: in.GroupId, UserId: id})
+	}
+	return resp, nil
+}
+
+type fakeUserClient struct {
+	usergrpcv1.UserServiceClient
+}
+
+func (f *fakeUserClient) UserInfo(ctx context.Context, in *usergrpcv1.UserInfoRequest, opts ...grpc.CallOption) (*usergrpcv1.UserInfoResponse, error) {
+	return &usergrpcv1.UserInfoResponse{
+		UserId:   in.UserId,
+		NickName: in.UserId,
+		Status:   usergrpcv1.UserStatus_USER_STATUS_NORMAL,
+	}, nil
+}
+
+func (f *fakeUserClient) GetBatchUserInfo(ctx context.Context, in *usergrpcv1.GetBatchUserInfoRequest, opts ...grpc.CallOption) (*usergrpcv1.GetBatchUserInfoResponse, error) {
+	resp := &usergrpcv1.GetBatchUserInfoResponse{}
+	for _, id := range in.UserIds {
+		resp.Users = append(resp.Users, &usergrpcv1.UserInfoResponse{UserId: id, NickName: id})
+	}
+	return resp, nil
+}
+
+type fakeDialogClient struct {
+	relationgrpcv1.DialogServiceClient
+}
+
+func (f *fakeDialogClient) GetDialogByGroupId(ctx context.Context, in *relationgrpcv1.GetDialogByGroupIdRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetDialogByGroupIdResponse, error) {
+	return &relationgrpcv1.GetDialogByGroupIdResponse{DialogId: 100 + in.GroupId, GroupId: in.GroupId}, nil
+}
+
+// fakeStorageClient 记录登记的文件
+type fakeStorageClient struct {
+	storagegrpcv1.StorageServiceClient
+
+	mu    sync.Mutex
+	files []*storagegrpcv1.UploadRequest
+}
+
+func (f *fakeStorageClient) Upload(ctx context.Context, in *storagegrpcv1.UploadRequest, opts ...grpc.CallOption) (*storagegrpcv1.UploadResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.files = append(f.files, in)
+	return &storagegrpcv1.UploadResponse{FileID: path.Base(in.Path)}, nil
+}
+
+type fakeGroupClient struct {
+	groupgrpcv1.GroupServiceClient
+}
+
+func (f *fakeGroupClient) GetGroupInfoByGid(ctx context.Context, in *groupgrpcv1.GetGroupInfoRequest, opts ...grpc.CallOption) (*groupgrpcv1.Group, error) {
+	return &groupgrpcv1.Group{Id: in.Gid, Status: groupgrpcv1.GroupStatus_GROUP_STATUS_NORMAL}, nil
+}
+
+// fakePushClient 记录推送给每个用户的事件
+type fakePushClient struct {
+	pushgrpcv1.PushServiceClient
+
+	mu     sync.Mutex
+	events map[string][]pushgrpcv1.WSEventType
+}
+
+func (f *fakePushClient) Push(ctx context.Context, in *pushgrpcv1.PushRequest, opts ...grpc.CallOption) (*pushgrpcv1.PushResponse, error) {
+	msg := &pushgrpcv1.WsMsg{}
+	if err := json.Unmarshal(in.Data, msg); err == nil {
+		f.mu.Lock()
+		f.events[msg.Uid] = append(f.events[msg.Uid], msg.Event)
+		f.mu.Unlock()
+	}
+	return &pushgrpcv1.PushResponse{}, nil
+}
+
+func (f *fakePushClient) received(uid string, event pushgrpcv1.WSEventType) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, e := range f.events[uid] {
+		if e == event {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeMsgClient 记录通话结束后发送的通话消息
+type fakeMsgClient struct {
+	msggrpcv1.MsgServiceClient
+
+	mu       sync.Mutex
+	sent     []*msggrpcv1.SendUserMsgRequest
+	messages map[string]*msggrpcv1.SendMessageRequest
+}
+
+func (f *fakeMsgClient) SendUserMessage(ctx context.Context, in *msggrpcv1.SendUserMsgRequest, opts ...grpc.CallOption) (*msggrpcv1.SendUserMsgResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, in)
+	return &msggrpcv1.SendUserMsgResponse{MsgId: uint32(len(f.sent))}, nil
+}
+
+// SendMessage 和消息服务一样按客户端消息id去重
+func (f *fakeMsgClient) SendMessage(ctx context.Context, in *msggrpcv1.SendMessageRequest, opts ...grpc.CallOption) (*msggrpcv1.SendMessageResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.messages == nil {
+		f.messages = make(map[string]*msggrpcv1.SendMessageRequest)
+	}
+	_, dup := f.messages[in.ClientMsgId]
+	f.messages[in.ClientMsgId] = in
+	return &msggrpcv1.SendMessageResponse{ClientMsgId: in.ClientMsgId, DialogId: in.DialogId, Duplicate: dup}, nil
+}
+
+// fakeCallRecordRepo 内存实现的通话记录存储，记录每次保存，用于检查是否重复保存
+type fakeCallRecordRepo struct {
+	mu      sync.Mutex
+	records []*entity.CallRecord
+}
+
+func (f *fakeCallRecordRepo) Create(ctx context.Context, record *entity.CallRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	record.ID = uint(len(f.records) + 1)
+	f.records = append(f.records, record)
+	return nil
+}
+
+func (f *fakeCallRecordRepo) Get(ctx context.Context, id uint) (*entity.CallRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id == 0 || int(id) > len(f.records) {
+		return nil, code.NotFound
+	}
+	return f.records[id-1], nil
+}
+
+func (f *fakeCallRecordRepo) Find(ctx context.Context, query *repository.CallRecordQuery) ([]*entity.CallRecord, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.records, int64(len(f.records)), nil
+}
+
+// outcomes 返回保存的通话结果
+func (f *fakeCallRecordRepo) outcomes() []entity.CallOutcome {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var outcomes []entity.CallOutcome
+	for _, r := range f.records {
+		outcomes = append(outcomes, r.Outcome)
+	}
+	return outcomes
+}
+
+// fakeCallRecordingRepo 内存实现的通话录制存储
+type fakeCallRecordingRepo struct {
+	mu         sync.Mutex
+	recordings map[string]entity.CallRecording
+}
+
+func (f *fakeCallRecordingRepo) Create(ctx context.Context, recording *entity.CallRecording) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.recordings == nil {
+		f.recordings = make(map[string]entity.CallRecording)
+	}
+	recording.ID = uint(len(f.recordings) + 1)
+	f.recordings[recording.RecordingID] = *recording
+	return nil
+}
+
+func (f *fakeCallRecordingRepo) GetByRecordingID(ctx context.Context, recordingID string) (*entity.CallRecording, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, ok := f.recordings[recordingID]
+	if !ok {
+		return nil, code.NotFound
+	}
+	return &r, nil
+}
+
+func (f *fakeCallRecordingRepo) Update(ctx context.Context, recording *entity.CallRecording) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recordings[recording.RecordingID] = *recording
+	return nil
+}
+
+type testEnv struct {
+	handler    *command.LiveHandler
+	repo       *fakeLiveRepo
+	media      *adapters.MemoryMediaProvider
+	msg        *fakeMsgClient
+	groups     *fakeGroupRelationClient
+	storage    *fakeStorageClient
+	recordings *fakeCallRecordingRepo
+	records    *fakeCallRecordRepo
+	push       *fakePushClient
+}
+
+func newTestEnv() *testEnv {
+	env := &testEnv{
+		repo:       newFakeLiveRepo(),
+		media:      adapters.NewMemoryMediaProvider(),
+		msg:        &fakeMsgClient{},
+		groups:     &fakeGroupRelationClient{identities: make(map[string]relationgrpcv1.GroupIdentity)},
+		storage:    &fakeStorageClient{},
+		recordings: &fakeCallRecordingRepo{},
+		records:    &fakeCallRecordRepo{},
+		push:       &fakePushClient{events: make(map[string][]pushgrpcv1.WSEventType)},
+	}
+	env.handler = command.NewLiveHandler(
+		command.WithRepo(env.repo),
+		command.WithLogger(zap.NewNop()),
+		// 超时时间足够长，测试过程中不会触发未接听处理
+		command.WithLiveKit(config.LivekitConfig{Url: "wss://media.test", Timeout: time.Hour}),
+		command.WithMediaProvider(env.media),
+		command.WithMsgService(env.msg),
+		command.WithUserService(&fakeUserClient{}),
+		command.WithPushService(env.push),
+		command.WithRelationGroupService(env.groups),
+		command.WithRelationUserService(&fakeUserRelationClient{}),
+		command.WithGroupService(&fakeGroupClient{}),
+		command.WithRelationDialogService(&fakeDialogClient{}),
+		command.WithStorageService(env.storage),
+		command.WithCallRecordingRepo(env.recordings),
+		command.WithCallRecordRepo(env.records),
+	)
+	return env
+}
+
+func (env *testEnv) createUserRoom(t *testing.T) string {
+	t.Helper()
+	resp, err := env.handler.CreateRoom(context.Background(), &command.CreateRoom{
+		DriverID:     "d1",
+		Creator:      "alice",
+		Type:         string(entity.UserRoomType),
+		Participants: []string{"bob"},
+		Option:       command.RoomOption{AudioEnabled: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	return resp.Room
+}
+
+func (env *testEnv) join(t *testing.T, room, userID string) *command.JoinRoomResponse {
+	t.Helper()
+	resp, err := env.handler.JoinRoom(context.Background(), &command.JoinRoom{Room: room, UserID: userID, DriverID: "d-" + userID})
+	if err != nil {
+		t.Fatalf("JoinRoom %s: %v", userID, err)
+	}
+	// 客户端拿到凭证后连接媒体服务
+	if err := env.media.Connect(room, userID); err != nil {
+		t.Fatalf("Connect %s: %v", userID, err)
+	}
+	return resp
+}
+
+func TestCreateUserRoom(t *testing.T) {
+	env := newTestEnv()
+	room := env.createUserRoom(t)
+
+	if !env.media.HasRoom(room) {
+		t.Fatalf("media room %s not created", room)
+	}
+	r, err := env.repo.GetRoom(context.Background(), room)
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if r.Creator != "alice" || len(r.Participants) != 2 || r.MaxParticipants != entity.MaxParticipantsUser {
+		t.Fatalf("unexpected room: %+v", r)
+	}
+
+	// 通话中不能再发起通话
+	_, err = env.handler.CreateRoom(context.Background(), &command.CreateRoom{
+		Creator:      "alice",
+		Type:         string(entity.UserRoomType),
+		Participants: []string{"carol"},
+	})
+	if !code.IsCode(err, code.LiveErrAlreadyInCall) {
+		t.Fatalf("expected LiveErrAlreadyInCall, got %v", err)
+	}
+}
+
+func TestJoinUserRoom(t *testing.T) {
+	env := newTestEnv()
+	room := env.createUserRoom(t)
+
+	resp := env.join(t, room, "bob")
+	if resp.Token == "" || resp.Url != "wss://media.test" || resp.Room != room {
+		t.Fatalf("unexpected join response: %+v", resp)
+	}
+	env.join(t, room, "alice")
+
+	tokens := env.media.Tokens()
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(tokens))
+	}
+	if tokens[0].Identity != "bob" || tokens[0].Admin {
+		t.Fatalf("callee should get a normal token: %+v", tokens[0])
+	}
+	if tokens[1].Identity != "alice" || !tokens[1].Admin {
+		t.Fatalf("creator should get an admin token: %+v", tokens[1])
+	}
+
+	r, err := env.repo.GetRoom(context.Background(), room)
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if r.NumParticipants != 2 || !r.Participants["bob"].Connected || r.Participants["bob"].JoinedAt == 0 {
+		t.Fatalf("unexpected room after join: %+v", r)
+	}
+
+	// 重复加入
+	_, err = env.handler.JoinRoom(context.Background(), &command.JoinRoom{Room: room, UserID: "bob", DriverID: "d-bob"})
+	if !code.IsCode(err, code.LiveErrAlreadyInCall) {
+		t.Fatalf("expected LiveErrAlreadyInCall, got %v", err)
+	}
+}
+
+func TestJoinRoomWithoutMediaRoom(t *testing.T) {
+	env := newTestEnv()
+	room := env.createUserRoom(t)
+
+	// 媒体服务的房间已经关闭
+	if err := env.media.DeleteRoom(context.Background(), room); err != nil {
+		t.Fatal(err)
+	}
+	_, err := env.handler.JoinRoom(context.Background(), &command.JoinRoom{Room: room, UserID: "bob", DriverID: "d-bob"})
+	if !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("expected LiveErrCallNotFound, got %v", err)
+	}
+}
+
+func TestRejectUserRoom(t *testing.T) {
+	env := newTestEnv()
+	room := env.createUserRoom(t)
+
+	_, err := env.handler.RejectLive(context.Background(), &command.RejectLive{Room: room, UserID: "alice", DriverID: "d1"})
+	if !code.IsCode(err, code.LiveErrRejectCallFailed) {
+		t.Fatalf("creator should not reject own call, got %v", err)
+	}
+
+	if _, err := env.handler.RejectLive(context.Background(), &command.RejectLive{Room: room, UserID: "bob", DriverID: "d2"}); err != nil {
+		t.Fatalf("RejectLive: %v", err)
+	}
+	if env.media.HasRoom(room) {
+		t.Fatal("media room should be deleted after reject")
+	}
+	if _, err := env.repo.GetRoom(context.Background(), room); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("room should be deleted after reject, got %v", err)
+	}
+	if rooms, _ := env.repo.GetUserRooms(context.Background(), "alice"); len(rooms) != 0 {
+		t.Fatal("creator should not be in call after reject")
+	}
+}
+
+func TestDeleteUserRoomCancelled(t *testing.T) {
+	env := newTestEnv()
+	room := env.createUserRoom(t)
+
+	// 对方接听前发起者挂断
+	if err := env.handler.DeleteRoom(context.Background(), &command.DeleteRoom{Room: room, UserID: "alice", DriverID: "d1"}); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if env.media.HasRoom(room) {
+		t.Fatal("media room should be deleted")
+	}
+	if len(env.msg.sent) != 1 || env.msg.sent[0].SubType != int32(msggrpcv1.CallSubType_Cancelled) {
+		t.Fatalf("expected one cancelled call message, got %+v", env.msg.sent)
+	}
+}
+
+func TestDeleteUserRoomAfterConnected(t *testing.T) {
+	env := newTestEnv()
+	room := env.createUserRoom(t)
+	env.join(t, room, "bob")
+	env.join(t, room, "alice")
+
+	if err := env.handler.DeleteRoom(context.Background(), &command.DeleteRoom{Room: room, UserID: "bob", DriverID: "d2"}); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if env.media.HasRoom(room) {
+		t.Fatal("media room should be deleted")
+	}
+	if len(env.msg.sent) != 1 || env.msg.sent[0].SubType != int32(msggrpcv1.CallSubType_Normal) {
+		t.Fatalf("expected one normal call message, got %+v", env.msg.sent)
+	}
+}
+
+func TestCallRecordOutcomes(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		end  func(t *testing.T, env *testEnv, room string)
+		want entity.CallOutcome
+	}{
+		{
+			name: "answered",
+			end: func(t *testing.T, env *testEnv, room string) {
+				env.join(t, room, "alice")
+				env.join(t, room, "bob")
+				if err := env.handler.DeleteRoom(ctx, &command.DeleteRoom{Room: room, UserID: "bob", DriverID: "d-bob"}); err != nil {
+					t.Fatalf("DeleteRoom: %v", err)
+				}
+			},
+			want: entity.CallOutcomeNormal,
+		},
+		{
+			name: "missed",
+			end: func(t *testing.T, env *testEnv, room string) {
+				env.join(t, room, "alice")
+				env.handler.ExpireRingingRooms(ctx, pkgtime.Now()+time.Hour.Milliseconds())
+			},
+			want: entity.CallOutcomeMissed,
+		},
+		{
+			name: "rejected",
+			end: func(t *testing.T, env *testEnv, room string) {
+				if _, err := env.handler.RejectLive(ctx, &command.RejectLive{Room: room, UserID: "bob", DriverID: "d-bob"}); err != nil {
+					t.Fatalf("RejectLive: %v", err)
+				}
+			},
+			want: entity.CallOutcomeRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			room := env.createUserRoom(t)
+			tt.end(t, env, room)
+
+			outcomes := env.records.outcomes()
+			if len(outcomes) != 1 || outcomes[0] != tt.want {
+				t.Fatalf("expected one %d record, got %v", tt.want, outcomes)
+			}
+			if r := env.records.records[0]; r.Room != room || len(r.Participants) != 2 {
+				t.Fatalf("unexpected record %+v", r)
+			}
+		})
+	}
+}
+
+func TestCallRecordDuplicateEnd(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "alice")
+	env.join(t, room, "bob")
+
+	// 挂断和 LiveKit 的结束回调同时到达，回调也可能重复
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		if err := env.handler.DeleteRoom(ctx, &command.DeleteRoom{Room: room, UserID: "alice", DriverID: "d-alice"}); err != nil && !code.IsCode(err, code.LiveErrCallNotFound) {
+			t.Errorf("DeleteRoom: %v", err)
+		}
+	}()
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			if err := env.handler.ReconcileRoom(ctx, &command.ReconcileRoom{Event: command.WebhookRoomFinished, Room: room}); err != nil {
+				t.Errorf("ReconcileRoom: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if outcomes := env.records.outcomes(); len(outcomes) != 1 || outcomes[0] != entity.CallOutcomeNormal {
+		t.Fatalf("expected one answered record, got %v", outcomes)
+	}
+}
+
+func TestGroupRoomLeave(t *testing.T) {
+	env := newTestEnv()
+	resp, err := env.handler.CreateRoom(context.Background(), &command.CreateRoom{
+		DriverID:     "d1",
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      1,
+		Participants: []string{"bob", "carol"},
+		Option:       command.RoomOption{VideoEnabled: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	room := resp.Room
+
+	env.join(t, room, "alice")
+	env.join(t, room, "bob")
+
+	// 未加入的成员拒绝会结束整个群聊通话，这里只验证已加入的成员退出
+	if err := env.handler.DeleteRoom(context.Background(), &command.DeleteRoom{Room: room, UserID: "bob", DriverID: "d-bob"}); err != nil {
+		t.Fatalf("DeleteRoom bob: %v", err)
+	}
+	participants, err := env.media.ListParticipants(context.Background(), room)
+	if err != nil {
+		t.Fatalf("ListParticipants: %v", err)
+	}
+	if len(participants) != 1 || participants[0].Identity != "alice" {
+		t.Fatalf("bob should be removed from media room, got %+v", participants)
+	}
+
+	// 最后一个用户退出后删除房间
+	if err := env.handler.DeleteRoom(context.Background(), &command.DeleteRoom{Room: room, UserID: "alice", DriverID: "d-alice"}); err != nil {
+		t.Fatalf("DeleteRoom alice: %v", err)
+	}
+	if env.media.HasRoom(room) {
+		t.Fatal("media room should be deleted after last participant left")
+	}
+	if _, err := env.repo.GetGroupRoom(context.Background(), "1"); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("group live should be deleted, got %v", err)
+	}
+}
+
+func TestConcurrentJoinUserRoom(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+
+	// 双方同时加入，两次修改都要保留
+	var wg sync.WaitGroup
+	for _, uid := range []string{"alice", "bob"} {
+		wg.Add(1)
+		go func(uid string) {
+			defer wg.Done()
+			if _, err := env.handler.JoinRoom(ctx, &command.JoinRoom{Room: room, UserID: uid, DriverID: "d-" + uid}); err != nil {
+				t.Errorf("JoinRoom %s: %v", uid, err)
+			}
+		}(uid)
+	}
+	wg.Wait()
+
+	r, err := env.repo.GetRoom(ctx, room)
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if r.NumParticipants != 2 || !r.Participants["alice"].Connected || !r.Participants["bob"].Connected {
+		t.Fatalf("unexpected room state %+v", r)
+	}
+	if _, err := env.handler.JoinRoom(ctx, &command.JoinRoom{Room: room, UserID: "bob", DriverID: "d-bob"}); !code.IsCode(err, code.LiveErrAlreadyInCall) {
+		t.Fatalf("expected LiveErrAlreadyInCall, got %v", err)
+	}
+}
+
+func TestConcurrentGroupRoomLeave(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	resp, err := env.handler.CreateRoom(ctx, &command.CreateRoom{
+		DriverID:     "d1",
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      2,
+		Participants: []string{"bob"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	room := resp.Room
+	env.join(t, room, "alice")
+	env.join(t, room, "bob")
+
+	// 最后两个成员同时退出，后退出的请求结束通话
+	var wg sync.WaitGroup
+	for _, uid := range []string{"alice", "bob"} {
+		wg.Add(1)
+		go func(uid string) {
+			defer wg.Done()
+			if err := env.handler.DeleteRoom(ctx, &command.DeleteRoom{Room: room, UserID: uid, DriverID: "d-" + uid}); err != nil {
+				t.Errorf("DeleteRoom %s: %v", uid, err)
+			}
+		}(uid)
+	}
+	wg.Wait()
+
+	if _, err := env.repo.GetRoom(ctx, room); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("room should be deleted, got %v", err)
+	}
+	if env.media.HasRoom(room) {
+		t.Fatal("media room should be deleted")
+	}
+	if _, err := env.repo.GetGroupRoom(ctx, "2"); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("group live should be deleted, got %v", err)
+	}
+}
+
+func TestRejectGroupRoom(t *testing.T) {
+	env := newTestEnv()
+	resp, err := env.handler.CreateRoom(context.Background(), &command.CreateRoom{
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      2,
+		Participants: []string{"bob"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	if _, err := env.handler.RejectLive(context.Background(), &command.RejectLive{Room: resp.Room, UserID: "bob"}); err != nil {
+		t.Fatalf("RejectLive: %v", err)
+	}
+	if env.media.HasRoom(resp.Room) {
+		t.Fatal("media room should be deleted after reject")
+	}
+}
+
+func TestUserRoomRecording(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "bob")
+	env.join(t, room, "alice")
+
+	// 私聊只有通话所有者可以录制
+	_, err := env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "bob"})
+	if !code.IsCode(err, code.LiveErrRecordingPermission) {
+		t.Fatalf("expected LiveErrRecordingPermission, got %v", err)
+	}
+
+	started, err := env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "alice"})
+	if err != nil {
+		t.Fatalf("StartRecording: %v", err)
+	}
+	if _, err := env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "alice"}); !code.IsCode(err, code.LiveErrAlreadyRecording) {
+		t.Fatalf("expected LiveErrAlreadyRecording, got %v", err)
+	}
+	// 通知另一方开始录制
+	if env.push.received("bob", pushgrpcv1.WSEventType_CallRecordingEvent) != 1 || env.push.received("alice", pushgrpcv1.WSEventType_CallRecordingEvent) != 0 {
+		t.Fatalf("recording event should be pushed to peer only: %+v", env.push.events)
+	}
+
+	stopped, err := env.handler.StopRecording(ctx, &command.StopRecording{Room: room, UserID: "alice"})
+	if err != nil {
+		t.Fatalf("StopRecording: %v", err)
+	}
+	if stopped.RecordingID != started.RecordingID || stopped.Status != entity.RecordingStopping {
+		t.Fatalf("unexpected stop response: %+v", stopped)
+	}
+	if _, err := env.handler.StopRecording(ctx, &command.StopRecording{Room: room, UserID: "alice"}); !code.IsCode(err, code.LiveErrNotRecording) {
+		t.Fatalf("expected LiveErrNotRecording, got %v", err)
+	}
+	if env.push.received("bob", pushgrpcv1.WSEventType_CallRecordingEvent) != 2 {
+		t.Fatalf("stop recording event should be pushed to peer")
+	}
+
+	rec, ok := env.media.Recording(started.RecordingID)
+	if !ok {
+		t.Fatalf("media recording %s not found", started.RecordingID)
+	}
+	rec.Status = media.RecordingComplete
+	rec.Size = 1024
+	rec.Duration = 30
+	// 重复回调只处理一次
+	for i := 0; i < 2; i++ {
+		if err := env.handler.FinishRecording(ctx, &command.FinishRecording{Recording: rec}); err != nil {
+			t.Fatalf("FinishRecording: %v", err)
+		}
+	}
+
+	if len(env.storage.files) != 1 {
+		t.Fatalf("expected 1 storage file, got %d", len(env.storage.files))
+	}
+	file := env.storage.files[0]
+	if file.UserID != "alice" || file.Access != storagegrpcv1.FileAccess_OwnerOnly || file.Path != rec.Filepath {
+		t.Fatalf("unexpected storage file: %+v", file)
+	}
+	// 录制消息发送给另一方，需要授权另一方访问文件
+	if len(file.Grantees) != 1 || file.Grantees[0] != "bob" {
+		t.Fatalf("peer should be granted access, got %v", file.Grantees)
+	}
+
+	msg, ok := env.msg.messages["recording:"+started.RecordingID]
+	if !ok || len(env.msg.messages) != 1 {
+		t.Fatalf("expected one recording message, got %+v", env.msg.messages)
+	}
+	if msg.SenderId != "alice" || msg.ReceiverId != "bob" || msg.DialogId != 1 || msg.Type != int32(msggrpcv1.MessageType_File) {
+		t.Fatalf("unexpected recording message: %+v", msg)
+	}
+
+	recording, err := env.recordings.GetByRecordingID(ctx, started.RecordingID)
+	if err != nil {
+		t.Fatalf("GetByRecordingID: %v", err)
+	}
+	if recording.Status != entity.RecordingComplete || recording.FileID != path.Base(rec.Filepath) || recording.Duration != 30 {
+		t.Fatalf("unexpected recording: %+v", recording)
+	}
+}
+
+func TestConcurrentStartRecording(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "bob")
+	env.join(t, room, "alice")
+
+	const n = 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	started := 0
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "alice"})
+			if err == nil {
+				mu.Lock()
+				started++
+				mu.Unlock()
+				return
+			}
+			if !code.IsCode(err, code.LiveErrAlreadyRecording) {
+				t.Errorf("expected LiveErrAlreadyRecording, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if started != 1 || len(env.media.Recordings()) != 1 {
+		t.Fatalf("only one recording should start, started %d, media recordings %d", started, len(env.media.Recordings()))
+	}
+}
+
+func TestGroupRoomRecording(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	env.groups.identities["bob"] = relationgrpcv1.GroupIdentity_IDENTITY_ADMIN
+	resp, err := env.handler.CreateRoom(ctx, &command.CreateRoom{
+		DriverID:     "d1",
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      3,
+		Participants: []string{"bob", "carol"},
+		Option:       command.RoomOption{VideoEnabled: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	room := resp.Room
+	env.join(t, room, "alice")
+
+	// 普通成员不能录制
+	_, err = env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "carol"})
+	if !code.IsCode(err, code.LiveErrRecordingPermission) {
+		t.Fatalf("expected LiveErrRecordingPermission, got %v", err)
+	}
+
+	// 录制失败后可以重新开始录制
+	failed, err := env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "bob"})
+	if err != nil {
+		t.Fatalf("StartRecording: %v", err)
+	}
+	if err := env.handler.FinishRecording(ctx, &command.FinishRecording{Recording: &media.Recording{ID: failed.RecordingID, Status: media.RecordingFailed}}); err != nil {
+		t.Fatalf("FinishRecording: %v", err)
+	}
+	if len(env.storage.files) != 0 || len(env.msg.messages) != 0 {
+		t.Fatal("failed recording should not create file or message")
+	}
+
+	started, err := env.handler.StartRecording(ctx, &command.StartRecording{Room: room, UserID: "bob"})
+	if err != nil {
+		t.Fatalf("StartRecording after failure: %v", err)
+	}
+	rec, _ := env.media.Recording(started.RecordingID)
+	rec.Status = media.RecordingComplete
+	if err := env.handler.FinishRecording(ctx, &command.FinishRecording{Recording: rec}); err != nil {
+		t.Fatalf("FinishRecording: %v", err)
+	}
+
+	if len(env.storage.files) != 1 {
+		t.Fatalf("expected 1 storage file, got %d", len(env.storage.files))
+	}
+	file := env.storage.files[0]
+	if file.UserID != "alice" || file.Access != storagegrpcv1.FileAccess_GroupMembers || file.GroupID != 3 {
+		t.Fatalf("unexpected storage file: %+v", file)
+	}
+	msg := env.msg.messages["recording:"+started.RecordingID]
+	if msg == nil || msg.GroupId != 3 || msg.DialogId != 103 {
+		t.Fatalf("unexpected recording message: %+v", msg)
+	}
+}
+
+func TestRingingTimeoutUserRoom(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "alice")
+
+	// 还没有到振铃超时时间
+	if n := env.handler.ExpireRingingRooms(ctx, pkgtime.Now()); n != 0 {
+		t.Fatalf("expected no expired rooms, got %d", n)
+	}
+
+	deadline := pkgtime.Now() + time.Hour.Milliseconds()
+	if n := env.handler.ExpireRingingRooms(ctx, deadline); n != 1 {
+		t.Fatalf("expected 1 expired room, got %d", n)
+	}
+	if _, err := env.repo.GetRoom(ctx, room); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("room should be deleted, got %v", err)
+	}
+	if env.media.HasRoom(room) {
+		t.Fatal("media room should be deleted")
+	}
+	for _, uid := range []string{"alice", "bob"} {
+		if _, err := env.repo.GetUserRooms(ctx, uid); !code.IsCode(err, code.LiveErrCallNotFound) {
+			t.Fatalf("user live of %s should be released, got %v", uid, err)
+		}
+	}
+
+	if len(env.msg.sent) != 1 || env.msg.sent[0].SubType != int32(msggrpcv1.CallSubType_Missed) {
+		t.Fatalf("expected one missed call message, got %+v", env.msg.sent)
+	}
+
+	// 重复扫描不会重复处理
+	if n := env.handler.ExpireRingingRooms(ctx, deadline); n != 0 {
+		t.Fatalf("expected no expired rooms, got %d", n)
+	}
+}
+
+func TestRingingTimeoutRetry(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "alice")
+
+	deadline := pkgtime.Now() + time.Hour.Milliseconds()
+	env.repo.getRoomErr = errors.New("storage unavailable")
+	if n := env.handler.ExpireRingingRooms(ctx, deadline); n != 0 {
+		t.Fatalf("expected failed room not to be counted, got %d", n)
+	}
+	env.repo.getRoomErr = nil
+
+	// 租约期间其他实例不会重复处理
+	if n := env.handler.ExpireRingingRooms(ctx, deadline); n != 0 {
+		t.Fatalf("expected claimed room to be skipped, got %d", n)
+	}
+	// 租约到期后重新处理
+	if n := env.handler.ExpireRingingRooms(ctx, deadline+time.Minute.Milliseconds()); n != 1 {
+		t.Fatalf("expected failed room to be retried, got %d", n)
+	}
+	if _, err := env.repo.GetRoom(ctx, room); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("room should be deleted, got %v", err)
+	}
+	if len(env.msg.sent) != 1 {
+		t.Fatalf("expected one missed call message, got %+v", env.msg.sent)
+	}
+}
+
+func TestRingingTimeoutResumeMarkedRoom(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "alice")
+
+	// 之前的实例已经标记未接听，但在结束通话前退出
+	if _, err := env.repo.ModifyRoom(ctx, room, func(r *entity.Room) error {
+		r.Participants["bob"].Status = entity.ParticipantInfo_MISSED
+		return nil
+	}); err != nil {
+		t.Fatalf("ModifyRoom: %v", err)
+	}
+
+	deadline := pkgtime.Now() + time.Hour.Milliseconds()
+	if n := env.handler.ExpireRingingRooms(ctx, deadline); n != 1 {
+		t.Fatalf("expected 1 expired room, got %d", n)
+	}
+	if _, err := env.repo.GetRoom(ctx, room); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("room should be deleted, got %v", err)
+	}
+
+	// 重新加入振铃列表模拟租约到期后的重试，房间已经结束不会再次发送
+	if err := env.repo.AddRingingRoom(ctx, room, 0); err != nil {
+		t.Fatalf("AddRingingRoom: %v", err)
+	}
+	env.handler.ExpireRingingRooms(ctx, deadline+time.Minute.Milliseconds())
+	if len(env.msg.sent) != 1 || env.msg.sent[0].SubType != int32(msggrpcv1.CallSubType_Missed) {
+		t.Fatalf("expected one missed call message, got %+v", env.msg.sent)
+	}
+	if n := env.push.received("alice", pushgrpcv1.WSEventType_UserCallEndEvent); n != 1 {
+		t.Fatalf("expected one call end event, got %d", n)
+	}
+}
+
+func TestRingingTimeoutAnsweredUserRoom(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	room := env.createUserRoom(t)
+	env.join(t, room, "bob")
+
+	env.handler.ExpireRingingRooms(ctx, pkgtime.Now()+time.Hour.Milliseconds())
+	if _, err := env.repo.GetRoom(ctx, room); err != nil {
+		t.Fatalf("answered room should be kept, got %v", err)
+	}
+	if len(env.msg.sent) != 0 {
+		t.Fatalf("answered call should not send message, got %+v", env.msg.sent)
+	}
+}
+
+func TestRingingTimeoutGroupRoom(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	resp, err := env.handler.CreateRoom(ctx, &command.CreateRoom{
+		DriverID:     "d1",
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      4,
+		Participants: []string{"bob", "carol"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	room := resp.Room
+	env.join(t, room, "alice")
+	env.join(t, room, "bob")
+
+	// 已经有人接听，只将未接听的成员移出通话
+	if n := env.handler.ExpireRingingRooms(ctx, pkgtime.Now()+time.Hour.Milliseconds()); n != 1 {
+		t.Fatalf("expected 1 expired room, got %d", n)
+	}
+	r, err := env.repo.GetRoom(ctx, room)
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if _, ok := r.Participants["carol"]; ok {
+		t.Fatal("carol should be removed from participants")
+	}
+	if ap := r.LeftParticipants["carol"]; ap == nil || ap.Status != entity.ParticipantInfo_MISSED {
+		t.Fatalf("carol should be marked missed, got %+v", ap)
+	}
+	if _, err := env.repo.GetUserRooms(ctx, "carol"); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("user live of carol should be released, got %v", err)
+	}
+	if _, err := env.repo.GetUserRooms(ctx, "bob"); err != nil {
+		t.Fatalf("user live of bob should be kept, got %v", err)
+	}
+}
+
+func TestRingingTimeoutUnansweredGroupRoom(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	resp, err := env.handler.CreateRoom(ctx, &command.CreateRoom{
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      5,
+		Participants: []string{"bob"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	env.handler.ExpireRingingRooms(ctx, pkgtime.Now()+time.Hour.Milliseconds())
+	if env.media.HasRoom(resp.Room) {
+		t.Fatal("media room should be deleted")
+	}
+	if _, err := env.repo.GetGroupRoom(ctx, "5"); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("group live should be released, got %v", err)
+	}
+	if _, err := env.repo.GetUserRooms(ctx, "alice"); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("user live of alice should be released, got %v", err)
+	}
+}
+
+func TestWebhookConcurrentParticipants(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	resp, err := env.handler.CreateRoom(ctx, &command.CreateRoom{
+		DriverID:     "d1",
+		Creator:      "alice",
+		Type:         string(entity.GroupRoomType),
+		GroupID:      3,
+		Participants: []string{"bob", "carol", "dave"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	room := resp.Room
+	users := []string{"alice", "bob", "carol", "dave"}
+
+	reconcile := func(event string, uids ...string) {
+		var wg sync.WaitGroup
+		for _, uid := range uids {
+			wg.Add(1)
+			go func(uid string) {
+				defer wg.Done()
+				if err := env.handler.ReconcileRoom(ctx, &command.ReconcileRoom{Event: event, Room: room, Identity: uid}); err != nil {
+					t.Errorf("ReconcileRoom %s %s: %v", event, uid, err)
+				}
+			}(uid)
+		}
+		wg.Wait()
+	}
+
+	// 并发到达的回调都要生效，不能互相覆盖
+	reconcile(command.WebhookParticipantJoined, users...)
+	r, err := env.repo.GetRoom(ctx, room)
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if r.NumParticipants != uint32(len(users)) {
+		t.Fatalf("expected %d participants, got %d", len(users), r.NumParticipants)
+	}
+
+	reconcile(command.WebhookParticipantLeft, "bob", "carol", "dave")
+	r, err = env.repo.GetRoom(ctx, room)
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if r.NumParticipants != 1 || !r.Participants["alice"].Connected || r.Participants["bob"].Connected {
+		t.Fatalf("unexpected room state %+v", r)
+	}
+
+	// 最后一个人离开后结束通话，重复的回调不会再次结束
+	reconcile(command.WebhookParticipantLeft, "alice", "alice")
+	if _, err := env.repo.GetRoom(ctx, room); !code.IsCode(err, code.LiveErrCallNotFound) {
+		t.Fatalf("room should be deleted, got %v", err)
+	}
+	if n := env.push.received("bob", pushgrpcv1.WSEventType_GroupCallEndEvent); n != 1 {
+		t.Fatalf("expected one call end event, got %d", n)
+	}
+}
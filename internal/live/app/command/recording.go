@@ -0,0 +1,367 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/media"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	storagegrpcv1 "github.com/cossim/coss-server/internal/storage/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"path"
+)
+
+const (
+	// recordingFileURL 录制消息指向的文件信息接口，获取时由存储服务校验访问权限
+	recordingFileURL = "/api/v1/storage/files/%s"
+	// recordingPending 录制正在启动，还没有拿到录制id
+	recordingPending = "pending"
+)
+
+type StartRecording struct {
+	Room      string
+	UserID    string
+	AudioOnly bool
+}
+
+type StopRecording struct {
+	Room   string
+	UserID string
+}
+
+type RecordingResponse struct {
+	RecordingID string
+	Status      entity.RecordingStatus
+}
+
+// StartRecording 开始录制通话，只有通话所有者或群聊管理员可以操作
+func (h *LiveHandler) StartRecording(ctx context.Context, cmd *StartRecording) (*RecordingResponse, error) {
+	h.logger.Debug("received start recording request", zap.Any("cmd", cmd))
+
+	room, err := h.liveRepo.GetRoom(ctx, cmd.Room)
+	if err != nil {
+		h.logger.Error("failed to get room", zap.Error(err))
+		return nil, err
+	}
+	if err := h.checkRecordingPermission(ctx, room, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	// 先占用房间的录制状态，同时发起的录制只有一个可以启动
+	if err := h.swapRoomRecording(ctx, room.ID, "", recordingPending); err != nil {
+		return nil, err
+	}
+
+	rec, err := h.media.StartRecording(ctx, &media.RecordingRequest{
+		Room:      room.ID,
+		Filepath:  uuid.New().String() + ".mp4",
+		AudioOnly: cmd.AudioOnly,
+	})
+	if err != nil {
+		h.logger.Error("start recording error", zap.Error(err), zap.String("room", room.ID))
+		h.releaseRoomRecording(ctx, room.ID, recordingPending)
+		return nil, code.LiveErrStartRecordingFailed
+	}
+
+	recording := &entity.CallRecording{
+		RecordingID: rec.ID,
+		Room:        room.ID,
+		Type:        room.Type,
+		Owner:       room.Owner,
+		GroupID:     room.GroupID,
+		StartedBy:   cmd.UserID,
+		Status:      entity.RecordingActive,
+		StartedAt:   pkgtime.Now(),
+	}
+	if room.Type == entity.UserRoomType {
+		recording.Peer = peerOf(room, room.Owner)
+	}
+	if err := h.callRecordingRepo.Create(ctx, recording); err != nil {
+		h.logger.Error("create call recording error", zap.Error(err))
+		// 没有录制记录无法处理录制结果，停止本次录制
+		h.stopMediaRecording(ctx, rec.ID)
+		h.releaseRoomRecording(ctx, room.ID, recordingPending)
+		return nil, code.LiveErrStartRecordingFailed
+	}
+
+	if err := h.swapRoomRecording(ctx, room.ID, recordingPending, rec.ID); err != nil {
+		h.logger.Error("update room recording error", zap.Error(err))
+		h.stopMediaRecording(ctx, rec.ID)
+		return nil, code.LiveErrStartRecordingFailed
+	}
+
+	h.logger.Info("开始录制通话", zap.String("room", room.ID), zap.String("recording", rec.ID), zap.String("uid", cmd.UserID))
+	h.pushRecordingEvent(ctx, room, cmd.UserID, recording)
+
+	return &RecordingResponse{
+		RecordingID: rec.ID,
+		Status:      recording.Status,
+	}, nil
+}
+
+// StopRecording 停止录制通话，录制文件上传完成后由回调保存并发送到会话
+func (h *LiveHandler) StopRecording(ctx context.Context, cmd *StopRecording) (*RecordingResponse, error) {
+	h.logger.Debug("received stop recording request", zap.Any("cmd", cmd))
+
+	room, err := h.liveRepo.GetRoom(ctx, cmd.Room)
+	if err != nil {
+		h.logger.Error("failed to get room", zap.Error(err))
+		return nil, err
+	}
+	if err := h.checkRecordingPermission(ctx, room, cmd.UserID); err != nil {
+		return nil, err
+	}
+	// 正在启动的录制还没有录制id，不能停止
+	if room.RecordingID == "" || room.RecordingID == recordingPending {
+		return nil, code.LiveErrNotRecording
+	}
+
+	// 先清除房间的录制状态，同时发起的停止只有一个会处理
+	recordingID := room.RecordingID
+	if err := h.swapRoomRecording(ctx, room.ID, recordingID, ""); err != nil {
+		return nil, err
+	}
+	if _, err := h.media.StopRecording(ctx, recordingID); err != nil {
+		h.logger.Error("stop recording error", zap.Error(err), zap.String("recording", recordingID))
+		if err := h.swapRoomRecording(ctx, room.ID, "", recordingID); err != nil {
+			h.logger.Error("restore room recording error", zap.Error(err), zap.String("recording", recordingID))
+		}
+		return nil, code.LiveErrStopRecordingFailed
+	}
+
+	status := entity.RecordingStopping
+	recording, err := h.callRecordingRepo.GetByRecordingID(ctx, recordingID)
+	if err != nil {
+		h.logger.Error("get call recording error", zap.Error(err), zap.String("recording", recordingID))
+		return nil, code.LiveErrStopRecordingFailed
+	}
+	// 录制结束的回调可能先于停止接口返回
+	if recording.IsFinished() {
+		status = recording.Status
+	} else {
+		recording.Status = status
+		if err := h.callRecordingRepo.Update(ctx, recording); err != nil {
+			h.logger.Error("update call recording error", zap.Error(err))
+		}
+	}
+
+	h.logger.Info("停止录制通话", zap.String("room", room.ID), zap.String("recording", recordingID), zap.String("uid", cmd.UserID))
+	h.pushRecordingEvent(ctx, room, cmd.UserID, recording)
+
+	return &RecordingResponse{
+		RecordingID: recordingID,
+		Status:      status,
+	}, nil
+}
+
+// FinishRecording 处理录制结束的回调，保存录制文件并发送到会话，重复回调不会重复处理
+type FinishRecording struct {
+	Recording *media.Recording
+}
+
+func (h *LiveHandler) FinishRecording(ctx context.Context, cmd *FinishRecording) error {
+	rec := cmd.Recording
+	recording, err := h.callRecordingRepo.GetByRecordingID(ctx, rec.ID)
+	if err != nil {
+		// 不是通过接口发起的录制
+		if code.Cause(err).Code() == code.NotFound.Code() {
+			return nil
+		}
+		return err
+	}
+	if recording.IsFinished() {
+		return nil
+	}
+
+	recording.EndedAt = pkgtime.Now()
+	if rec.Status != media.RecordingComplete || rec.Filepath == "" {
+		h.logger.Warn("通话录制失败", zap.String("recording", rec.ID), zap.String("error", rec.Error))
+		recording.Status = entity.RecordingFailed
+		if err := h.callRecordingRepo.Update(ctx, recording); err != nil {
+			h.logger.Error("update call recording error", zap.Error(err))
+			return err
+		}
+		h.clearRoomRecording(ctx, recording)
+		return nil
+	}
+
+	recording.Size = rec.Size
+	recording.Duration = rec.Duration
+
+	// 文件记录已经保存过时只重试发送消息
+	if recording.FileID == "" {
+		fileID, err := h.saveRecordingFile(ctx, recording, rec)
+		if err != nil {
+			h.logger.Error("保存录制文件失败", zap.Error(err), zap.String("recording", rec.ID))
+			return err
+		}
+		recording.FileID = fileID
+		if err := h.callRecordingRepo.Update(ctx, recording); err != nil {
+			h.logger.Error("update call recording error", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := h.sendRecordingMessage(ctx, recording); err != nil {
+		h.logger.Error("发送录制消息失败", zap.Error(err), zap.String("recording", rec.ID))
+		return err
+	}
+
+	recording.Status = entity.RecordingComplete
+	if err := h.callRecordingRepo.Update(ctx, recording); err != nil {
+		h.logger.Error("update call recording error", zap.Error(err))
+		return err
+	}
+	h.clearRoomRecording(ctx, recording)
+
+	h.logger.Info("通话录制完成", zap.String("room", recording.Room), zap.String("recording", rec.ID), zap.String("file", recording.FileID))
+	return nil
+}
+
+// checkRecordingPermission 只有通话所有者或群聊的管理员、群主可以录制
+func (h *LiveHandler) checkRecordingPermission(ctx context.Context, room *entity.Room, userID string) error {
+	if room.Owner == userID {
+		return nil
+	}
+	if room.Type != entity.GroupRoomType {
+		return code.LiveErrRecordingPermission
+	}
+
+	rel, err := h.relationGroupService.GetGroupRelation(ctx, &relationgrpcv1.GetGroupRelationRequest{
+		GroupId: room.GroupID,
+		UserId:  userID,
+	})
+	if err != nil {
+		return err
+	}
+	if rel.Identity != relationgrpcv1.GroupIdentity_IDENTITY_ADMIN && rel.Identity != relationgrpcv1.GroupIdentity_IDENTITY_OWNER {
+		return code.LiveErrRecordingPermission
+	}
+	return nil
+}
+
+// saveRecordingFile 在存储服务登记录制文件，私聊只有通话双方可以访问，群聊所有成员可以访问
+func (h *LiveHandler) saveRecordingFile(ctx context.Context, recording *entity.CallRecording, rec *media.Recording) (string, error) {
+	access := storagegrpcv1.FileAccess_OwnerOnly
+	var grantees []string
+	if recording.Type == entity.GroupRoomType {
+		access = storagegrpcv1.FileAccess_GroupMembers
+	} else if recording.Peer != "" {
+		grantees = []string{recording.Peer}
+	}
+
+	resp, err := h.storageService.Upload(ctx, &storagegrpcv1.UploadRequest{
+		UserID:   recording.Owner,
+		FileName: path.Base(rec.Filepath),
+		Path:     rec.Filepath,
+		Provider: "MinIO",
+		Type:     storagegrpcv1.FileType_Video,
+		Size:     uint64(rec.Size),
+		GroupID:  recording.GroupID,
+		Access:   access,
+		Grantees: grantees,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.FileID, nil
+}
+
+// sendRecordingMessage 将录制文件发送到通话所在的会话，使用录制id去重
+func (h *LiveHandler) sendRecordingMessage(ctx context.Context, recording *entity.CallRecording) error {
+	req := &msggrpcv1.SendMessageRequest{
+		ClientMsgId: "recording:" + recording.RecordingID,
+		SenderId:    recording.Owner,
+		Content:     fmt.Sprintf(recordingFileURL, recording.FileID),
+		Type:        int32(msggrpcv1.MessageType_File),
+	}
+
+	switch recording.Type {
+	case entity.GroupRoomType:
+		dialog, err := h.relationDialogService.GetDialogByGroupId(ctx, &relationgrpcv1.GetDialogByGroupIdRequest{GroupId: recording.GroupID})
+		if err != nil {
+			return err
+		}
+		req.DialogId = dialog.DialogId
+		req.GroupId = recording.GroupID
+	default:
+		rel, err := h.relationUserService.GetUserRelation(ctx, &relationgrpcv1.GetUserRelationRequest{
+			UserId:   recording.Owner,
+			FriendId: recording.Peer,
+		})
+		if err != nil {
+			return err
+		}
+		req.DialogId = rel.DialogId
+		req.ReceiverId = recording.Peer
+	}
+
+	_, err := h.msgService.SendMessage(ctx, req)
+	return err
+}
+
+// clearRoomRecording 录制异常结束时清除通话中的录制状态，允许重新开始录制
+func (h *LiveHandler) clearRoomRecording(ctx context.Context, recording *entity.CallRecording) {
+	if err := h.swapRoomRecording(ctx, recording.Room, recording.RecordingID, ""); err != nil {
+		h.logger.Debug("clear room recording", zap.String("recording", recording.RecordingID), zap.Error(err))
+	}
+}
+
+// swapRoomRecording 房间当前的录制id为 from 时替换为 to，否则返回正在录制或没有录制的错误
+func (h *LiveHandler) swapRoomRecording(ctx context.Context, roomID, from, to string) error {
+	_, err := h.liveRepo.ModifyRoom(ctx, roomID, func(room *entity.Room) error {
+		if room.RecordingID != from {
+			if room.RecordingID != "" {
+				return code.LiveErrAlreadyRecording
+			}
+			return code.LiveErrNotRecording
+		}
+		room.RecordingID = to
+		return nil
+	})
+	return err
+}
+
+// releaseRoomRecording 录制启动失败时释放占用的录制状态
+func (h *LiveHandler) releaseRoomRecording(ctx context.Context, roomID, recordingID string) {
+	if err := h.swapRoomRecording(ctx, roomID, recordingID, ""); err != nil {
+		h.logger.Error("release room recording error", zap.Error(err), zap.String("room", roomID))
+	}
+}
+
+func (h *LiveHandler) stopMediaRecording(ctx context.Context, recordingID string) {
+	if _, err := h.media.StopRecording(ctx, recordingID); err != nil {
+		h.logger.Error("stop recording error", zap.Error(err), zap.String("recording", recordingID))
+	}
+}
+
+// pushRecordingEvent 通知通话中的其他参与者录制状态变化
+func (h *LiveHandler) pushRecordingEvent(ctx context.Context, room *entity.Room, operator string, recording *entity.CallRecording) {
+	data := map[string]interface{}{
+		"room":         room.ID,
+		"recording_id": recording.RecordingID,
+		"status":       recording.Status,
+		"operator":     operator,
+	}
+	for participant := range room.Participants {
+		if participant == operator {
+			continue
+		}
+		h.sendPushMessage(ctx, operator, participant, pushgrpcv1.WSEventType_CallRecordingEvent, data)
+	}
+}
+
+// peerOf 返回私聊通话中的另一个参与者
+func peerOf(room *entity.Room, userID string) string {
+	for k := range room.Participants {
+		if k != userID {
+			return k
+		}
+	}
+	return ""
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/cossim/coss-server/pkg/utils"
 	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
 	any2 "github.com/golang/protobuf/ptypes/any"
-	"github.com/livekit/protocol/livekit"
 	"go.uber.org/zap"
 	"strconv"
 )
@@ -160,9 +159,14 @@ func (h *LiveHandler) rejectUser(ctx context.Context, roomID string, userID stri
 		h.logger.Error("发送消息失败", zap.Error(err))
 	}
 
-	if err := h.deleteUserLive(ctx, room); err != nil {
+	deleted, err := h.deleteUserLive(ctx, room)
+	if err != nil {
 		return err
 	}
+	// 通话已经被其他请求结束时由结束的请求保存通话记录
+	if deleted {
+		h.saveCallRecord(ctx, room, entity.CallOutcomeRejected)
+	}
 
 	data2 := map[string]interface{}{
 		"url":          h.webRtcUrl,
@@ -223,10 +227,14 @@ func (h *LiveHandler) rejectGroup(ctx context.Context, roomID string, groupID ui
 		return code.LiveErrRejectCallFailed.CustomMessage("已加入通话")
 	}
 
-	if err := h.deleteGroupLive(ctx, groupID, room); err != nil {
+	deleted, err := h.deleteGroupLive(ctx, groupID, room)
+	if err != nil {
 		h.logger.Error("DeleteGroupRoom failed", zap.Error(err))
 		return err
 	}
+	if deleted {
+		h.saveCallRecord(ctx, room, entity.CallOutcomeRejected)
+	}
 
 	// Send rejection message to all participants in the call
 
@@ -261,41 +269,27 @@ func (h *LiveHandler) rejectGroup(ctx context.Context, roomID string, groupID ui
 	return nil
 }
 
-func (h *LiveHandler) deleteUserLive(ctx context.Context, room *entity.Room) error {
+func (h *LiveHandler) deleteUserLive(ctx context.Context, room *entity.Room) (bool, error) {
 	var users []string
 	for k, _ := range room.Participants {
 		users = append(users, k)
 	}
 	if err := h.liveRepo.DeleteUsersLive(ctx, users...); err != nil {
-		return err
-	}
-	if err := h.liveRepo.DeleteRoom(ctx, room.ID); err != nil {
-		return err
-	}
-	_, err := h.roomService.DeleteRoom(ctx, &livekit.DeleteRoomRequest{Room: room.ID})
-	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
+	return h.deleteRoom(ctx, room.ID)
 }
 
-func (h *LiveHandler) deleteGroupLive(ctx context.Context, groupID uint32, room *entity.Room) error {
+func (h *LiveHandler) deleteGroupLive(ctx context.Context, groupID uint32, room *entity.Room) (bool, error) {
 	var users []string
 	for k, _ := range room.Participants {
 		users = append(users, k)
 	}
 	if err := h.liveRepo.DeleteUsersLive(ctx, users...); err != nil {
-		return err
+		return false, err
 	}
 	if err := h.liveRepo.DeleteGroupLive(ctx, strconv.Itoa(int(groupID))); err != nil {
-		return err
-	}
-	if err := h.liveRepo.DeleteRoom(ctx, room.ID); err != nil {
-		return err
+		return false, err
 	}
-	_, err := h.roomService.DeleteRoom(ctx, &livekit.DeleteRoomRequest{Room: room.ID})
-	if err != nil {
-		return err
-	}
-	return nil
+	return h.deleteRoom(ctx, room.ID)
 }
@@ -0,0 +1,215 @@
+package command
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+const (
+	// ringingScanInterval 扫描振铃超时房间的间隔
+	ringingScanInterval = time.Second
+	// ringingScanLimit 每次扫描处理的最大房间数
+	ringingScanLimit = 100
+	// ringingGracePeriod 房间和通话锁在振铃超时后额外保留的时间，保证超时处理时仍然存在
+	ringingGracePeriod = 30 * time.Second
+	// ringingClaimLease 领取振铃超时房间的租约时间，处理失败或实例退出后租约到期重新处理，需要小于 ringingGracePeriod
+	ringingClaimLease = 10 * time.Second
+)
+
+// startRinging 记录等待接听的房间，并将房间和通话锁的过期时间延长到振铃超时之后
+func (h *LiveHandler) startRinging(ctx context.Context, room *entity.Room) error {
+	expiration := h.liveTimeout + ringingGracePeriod
+	if err := h.liveRepo.UpdateRoomWithExpiration(ctx, room, expiration); err != nil {
+		h.logger.Error("更新房间过期时间失败", zap.Error(err))
+		return err
+	}
+	for userID := range room.Participants {
+		if err := h.liveRepo.UpdateUserLiveExpiration(ctx, userID, expiration); err != nil {
+			h.logger.Error("更新用户过期时间失败", zap.Error(err), zap.String("uid", userID))
+		}
+	}
+	if room.Type == entity.GroupRoomType {
+		if err := h.liveRepo.UpdateGroupLiveExpiration(ctx, strconv.Itoa(int(room.GroupID)), expiration); err != nil {
+			h.logger.Error("更新群聊过期时间失败", zap.Error(err), zap.Uint32("group_id", room.GroupID))
+		}
+	}
+
+	return h.liveRepo.AddRingingRoom(ctx, room.ID, room.CreatedAt+h.liveTimeout.Milliseconds())
+}
+
+// RunRingingWorker 定时处理振铃超时的房间，直到 ctx 结束
+func (h *LiveHandler) RunRingingWorker(ctx context.Context) {
+	ticker := time.NewTicker(ringingScanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.ExpireRingingRooms(ctx, pkgtime.Now())
+		}
+	}
+}
+
+// ExpireRingingRooms 处理振铃超时时间不晚于 now 的房间，返回处理的房间数
+func (h *LiveHandler) ExpireRingingRooms(ctx context.Context, now int64) int {
+	rooms, err := h.liveRepo.ListRingingRooms(ctx, now, ringingScanLimit)
+	if err != nil {
+		h.logger.Error("获取振铃超时房间失败", zap.Error(err))
+		return 0
+	}
+
+	n := 0
+	for _, roomID := range rooms {
+		// 多个实例同时扫描时只由领取成功的实例处理
+		ok, err := h.liveRepo.ClaimRingingRoom(ctx, roomID, now, now+ringingClaimLease.Milliseconds())
+		if err != nil {
+			h.logger.Error("领取振铃房间失败", zap.Error(err), zap.String("room", roomID))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		// 处理失败时保留在振铃列表中，租约到期后重试
+		if err := h.expireRingingRoom(ctx, roomID); err != nil {
+			h.logger.Error("处理振铃超时失败", zap.Error(err), zap.String("room", roomID))
+			continue
+		}
+		if _, err := h.liveRepo.RemoveRingingRoom(ctx, roomID); err != nil {
+			h.logger.Error("移除振铃房间失败", zap.Error(err), zap.String("room", roomID))
+		}
+		n++
+	}
+	return n
+}
+
+// expireRingingRoom 将仍在等待接听的参与者标记为未接听，没有人接听时结束通话
+func (h *LiveHandler) expireRingingRoom(ctx context.Context, roomID string) error {
+	var missed []string
+	room, err := h.liveRepo.ModifyRoom(ctx, roomID, func(room *entity.Room) error {
+		// 事务冲突重试时重新判断，期间接听的参与者不会被标记为未接听
+		missed = missed[:0]
+		for userID, ap := range room.Participants {
+			if userID != room.Creator && !ap.Connected && ap.Status == entity.ParticipantInfo_WAITING {
+				ap.Status = entity.ParticipantInfo_MISSED
+				missed = append(missed, userID)
+			}
+		}
+		// 已经有人接听的群聊通话，在同一次修改中将未接听的成员移出通话
+		if room.Type == entity.GroupRoomType && room.Answered() {
+			if room.LeftParticipants == nil {
+				room.LeftParticipants = make(map[string]*entity.ActiveParticipant)
+			}
+			for _, userID := range missed {
+				room.LeftParticipants[userID] = room.Participants[userID]
+				delete(room.Participants, userID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// 通话已经结束
+		if code.Cause(err).Code() == code.LiveErrCallNotFound.Code() {
+			return nil
+		}
+		return err
+	}
+
+	if len(missed) > 0 {
+		h.logger.Info("通话超时未接听", zap.String("room", room.ID), zap.String("type", string(room.Type)), zap.Strings("missed", missed))
+	}
+
+	switch room.Type {
+	case entity.UserRoomType:
+		// 房间还在说明之前的处理没有完成，未接通话消息在删除房间之后发送，不会重复发送
+		if !hasMissedParticipant(room) {
+			return nil
+		}
+		return h.missUserRoom(ctx, room)
+	case entity.GroupRoomType:
+		if !room.Answered() {
+			if !hasMissedParticipant(room) {
+				return nil
+			}
+			return h.missGroupRoom(ctx, room)
+		}
+		if len(missed) == 0 {
+			return nil
+		}
+		return h.removeMissedParticipants(ctx, room, missed)
+	default:
+		return nil
+	}
+}
+
+// hasMissedParticipant 房间中是否有被标记为未接听的参与者
+func hasMissedParticipant(room *entity.Room) bool {
+	for userID, ap := range room.Participants {
+		if userID != room.Creator && ap.Status == entity.ParticipantInfo_MISSED {
+			return true
+		}
+	}
+	return false
+}
+
+// missUserRoom 私聊通话无人接听，结束通话后发送未接通话消息
+func (h *LiveHandler) missUserRoom(ctx context.Context, room *entity.Room) error {
+	// 通话已经被其他请求结束
+	deleted, err := h.cleanUserRoom(ctx, room)
+	if err != nil || !deleted {
+		return err
+	}
+
+	var driverID string
+	if ap, ok := room.Participants[room.Creator]; ok {
+		driverID = ap.DriverID
+	}
+	if err := h.handleMissed(ctx, nil, room, room.Creator, driverID); err != nil {
+		h.logger.Error("发送未接通话消息失败", zap.Error(err), zap.String("room", room.ID))
+	}
+	return nil
+}
+
+// missGroupRoom 群聊通话无人接听，保存未接通话记录并结束通话
+func (h *LiveHandler) missGroupRoom(ctx context.Context, room *entity.Room) error {
+	deleted, err := h.deleteEntireGroupRoom(ctx, room)
+	if err != nil || !deleted {
+		return err
+	}
+	h.saveCallRecord(ctx, room, entity.CallOutcomeMissed)
+
+	for participant := range room.Participants {
+		data := map[string]interface{}{
+			"room":         room.ID,
+			"group_id":     room.GroupID,
+			"sender_id":    room.Creator,
+			"recipient_id": participant,
+		}
+		h.sendPushMessage(ctx, room.Creator, participant, pushgrpcv1.WSEventType_GroupCallEndEvent, data)
+	}
+	return nil
+}
+
+// removeMissedParticipants 群聊通话已经有人接听，未接听的成员已经移出通话，释放他们的通话锁
+func (h *LiveHandler) removeMissedParticipants(ctx context.Context, room *entity.Room, missed []string) error {
+	if err := h.liveRepo.DeleteUsersLive(ctx, missed...); err != nil {
+		h.logger.Error("delete user live error", zap.Error(err))
+	}
+
+	for _, userID := range missed {
+		data := map[string]interface{}{
+			"room":         room.ID,
+			"group_id":     room.GroupID,
+			"sender_id":    room.Creator,
+			"recipient_id": userID,
+		}
+		h.sendPushMessage(ctx, room.Creator, userID, pushgrpcv1.WSEventType_GroupCallEndEvent, data)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package command
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+// LiveKit 回调事件
+const (
+	WebhookParticipantJoined = "participant_joined"
+	WebhookParticipantLeft   = "participant_left"
+	WebhookRoomFinished      = "room_finished"
+	WebhookEgressEnded       = "egress_ended"
+)
+
+// ReconcileRoom 根据 LiveKit 回调修正房间状态，客户端异常退出时不会调用退出接口
+type ReconcileRoom struct {
+	Event    string
+	Room     string
+	Identity string
+}
+
+func (h *LiveHandler) ReconcileRoom(ctx context.Context, cmd *ReconcileRoom) error {
+	h.logger.Debug("received livekit webhook", zap.Any("cmd", cmd))
+
+	var err error
+	switch cmd.Event {
+	case WebhookParticipantJoined:
+		err = h.participantJoined(ctx, cmd.Room, cmd.Identity)
+	case WebhookParticipantLeft:
+		err = h.participantLeft(ctx, cmd.Room, cmd.Identity)
+	case WebhookRoomFinished:
+		var room *entity.Room
+		if room, err = h.liveRepo.GetRoom(ctx, cmd.Room); err == nil {
+			err = h.endRoom(ctx, room)
+		}
+	default:
+		return nil
+	}
+	// 房间已经通过接口正常结束
+	if err != nil && code.Cause(err).Code() == code.LiveErrCallNotFound.Code() {
+		return nil
+	}
+	return err
+}
+
+// participantJoined 和 participantLeft 的回调可能并发到达，房间的读取和修改需要在 ModifyRoom 中原子完成
+
+func (h *LiveHandler) participantJoined(ctx context.Context, roomID, userID string) error {
+	_, err := h.liveRepo.ModifyRoom(ctx, roomID, func(room *entity.Room) error {
+		ap, ok := room.Participants[userID]
+		if !ok {
+			h.logger.Warn("webhook participant not in room", zap.String("uid", userID), zap.String("room", room.ID))
+			return nil
+		}
+
+		if !ap.Connected {
+			ap.Connected = true
+			room.NumParticipants++
+		}
+		if ap.JoinedAt == 0 {
+			ap.JoinedAt = pkgtime.Now()
+		}
+		ap.Status = entity.ParticipantInfo_ACTIVE
+		return nil
+	})
+	if err != nil {
+		h.logger.Error("update room error", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (h *LiveHandler) participantLeft(ctx context.Context, roomID, userID string) error {
+	// 只有让房间变为空的回调结束通话，重复的回调不会重复结束
+	last := false
+	room, err := h.liveRepo.ModifyRoom(ctx, roomID, func(room *entity.Room) error {
+		ap, ok := room.Participants[userID]
+		if !ok {
+			return nil
+		}
+
+		if ap.Connected {
+			ap.Connected = false
+			if room.NumParticipants > 0 {
+				room.NumParticipants--
+			}
+			last = room.NumParticipants == 0
+		}
+		ap.Status = entity.ParticipantInfo_DISCONNECTED
+		if ap.JoinedAt != 0 {
+			ap.LeftAt = pkgtime.Now()
+		}
+		return nil
+	})
+	if err != nil {
+		h.logger.Error("update room error", zap.Error(err))
+		return err
+	}
+
+	// 房间内已经没有人，结束通话
+	if last {
+		return h.endRoom(ctx, room)
+	}
+	return nil
+}
+
+// endRoom 结束通话，删除房间和用户的通话记录，并通知所有参与者
+func (h *LiveHandler) endRoom(ctx context.Context, room *entity.Room) error {
+	var users []string
+	for k := range room.Participants {
+		users = append(users, k)
+	}
+	if err := h.liveRepo.DeleteUsersLive(ctx, users...); err != nil {
+		h.logger.Error("delete user live error", zap.Error(err))
+	}
+
+	event := pushgrpcv1.WSEventType_UserCallEndEvent
+	if room.Type == entity.GroupRoomType {
+		event = pushgrpcv1.WSEventType_GroupCallEndEvent
+		if err := h.liveRepo.DeleteGroupLive(ctx, strconv.Itoa(int(room.GroupID))); err != nil {
+			h.logger.Error("delete group live error", zap.Error(err))
+		}
+	}
+
+	// LiveKit 房间可能已经关闭，只删除本地记录，重复的回调或者同时挂断时只由删除房间的请求处理
+	deleted, err := h.liveRepo.DeleteRoom(ctx, room.ID)
+	if err != nil {
+		h.logger.Error("delete redis room error", zap.Error(err))
+		return err
+	}
+	if !deleted {
+		return nil
+	}
+	h.saveCallRecord(ctx, room, answeredOutcome(room))
+
+	h.logger.Info("通话已结束", zap.String("room", room.ID), zap.String("type", string(room.Type)))
+
+	for participant := range room.Participants {
+		data := map[string]interface{}{
+			"room":         room.ID,
+			"group_id":     room.GroupID,
+			"sender_id":    room.Creator,
+			"recipient_id": participant,
+		}
+		h.sendPushMessage(ctx, room.Creator, participant, event, data)
+	}
+	return nil
+}
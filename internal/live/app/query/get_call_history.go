@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+)
+
+type GetCallHistory struct {
+	UserID   string
+	Type     string
+	GroupID  uint32
+	Outcome  *int
+	StartAt  int64
+	EndAt    int64
+	PageNum  int
+	PageSize int
+}
+
+type CallHistory struct {
+	List  []*entity.CallRecord
+	Total int64
+}
+
+func (h *LiveHandler) GetCallHistory(ctx context.Context, q *GetCallHistory) (*CallHistory, error) {
+	if q.Type != "" && !entity.RoomType(q.Type).IsValid() {
+		return nil, code.InvalidParameter
+	}
+	if q.StartAt != 0 && q.EndAt != 0 && q.StartAt > q.EndAt {
+		return nil, code.InvalidParameter
+	}
+
+	query := &repository.CallRecordQuery{
+		UserID:   q.UserID,
+		Type:     entity.RoomType(q.Type),
+		GroupID:  q.GroupID,
+		StartAt:  q.StartAt,
+		EndAt:    q.EndAt,
+		PageNum:  q.PageNum,
+		PageSize: q.PageSize,
+	}
+	if q.Outcome != nil {
+		outcome := entity.CallOutcome(*q.Outcome)
+		query.Outcome = &outcome
+	}
+
+	records, total, err := h.callRecordRepo.Find(ctx, query)
+	if err != nil {
+		h.logger.Error("获取通话记录失败", zap.Error(err))
+		return nil, code.LiveErrGetCallHistoryFailed
+	}
+
+	return &CallHistory{List: records, Total: total}, nil
+}
@@ -2,26 +2,24 @@ package query
 
 import (
 	"context"
+	"github.com/cossim/coss-server/internal/live/domain/media"
 	"github.com/cossim/coss-server/internal/live/domain/repository"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/code"
 	"github.com/cossim/coss-server/pkg/config"
-	"github.com/livekit/protocol/livekit"
-	lksdk "github.com/livekit/server-sdk-go"
 	"go.uber.org/zap"
 	"strconv"
 	"time"
 )
 
 type LiveHandler struct {
-	logger   *zap.Logger
-	liveRepo repository.Repository
+	logger         *zap.Logger
+	liveRepo       repository.Repository
+	callRecordRepo repository.CallRecordRepository
 
 	webRtcUrl            string
-	liveApiKey           string
-	liveApiSecret        string
 	liveTimeout          time.Duration
-	roomService          *lksdk.RoomServiceClient
+	media                media.MediaProvider
 	relationGroupService relationgrpcv1.GroupRelationServiceClient
 }
 
@@ -41,6 +39,12 @@ func WithRepo(repo repository.Repository) LiveHandlerOption {
 	}
 }
 
+func WithCallRecordRepo(repo repository.CallRecordRepository) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.callRecordRepo = repo
+	}
+}
+
 func WithLogger(logger *zap.Logger) LiveHandlerOption {
 	return func(h *LiveHandler) {
 		h.logger = logger
@@ -50,10 +54,13 @@ func WithLogger(logger *zap.Logger) LiveHandlerOption {
 func WithLiveKit(c config.LivekitConfig) LiveHandlerOption {
 	return func(h *LiveHandler) {
 		h.webRtcUrl = c.Url
-		h.liveApiKey = c.ApiKey
-		h.liveApiSecret = c.ApiSecret
 		h.liveTimeout = c.Timeout
-		h.roomService = lksdk.NewRoomServiceClient(c.Url, c.ApiKey, c.ApiSecret)
+	}
+}
+
+func WithMediaProvider(provider media.MediaProvider) LiveHandlerOption {
+	return func(h *LiveHandler) {
+		h.media = provider
 	}
 }
 
@@ -118,39 +125,33 @@ func (h *LiveHandler) GetUserLive(ctx context.Context, query *GetUserLive) ([]*R
 	var userRooms []*Room
 
 	for _, room := range rooms {
-		livekitRooms, err := h.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{
-			Names: []string{room.ID},
-		})
+		mediaRoom, err := h.media.GetRoom(ctx, room.ID)
 		if err != nil {
-			return nil, err
-		}
-
-		if len(livekitRooms.Rooms) == 0 {
+			if !code.IsCode(err, code.LiveErrCallNotFound) {
+				return nil, err
+			}
 			h.liveRepo.DeleteRoom(ctx, room.ID)
 			h.logger.Error("房间不存在", zap.String("RoomID", room.ID))
 			continue
 		}
 
-		livekitRoom := livekitRooms.Rooms[0]
 		userRoom := &Room{
 			ID:              room.ID,
 			Type:            string(room.Type),
 			Owner:           room.Owner,
 			NumParticipants: room.NumParticipants,
 			MaxParticipants: room.MaxParticipants,
-			StartAt:         livekitRoom.CreationTime,
+			StartAt:         mediaRoom.CreationTime,
 		}
 
 		// 获取当前房间的参与者信息
-		res, err := h.roomService.ListParticipants(ctx, &livekit.ListParticipantsRequest{
-			Room: room.ID,
-		})
+		participants, err := h.media.ListParticipants(ctx, room.ID)
 		if err != nil {
 			h.logger.Error("获取通话信息失败", zap.Error(err))
 			return nil, code.LiveErrGetCallInfoFailed
 		}
 
-		for _, p := range res.Participants {
+		for _, p := range participants {
 			userRoom.Participant = append(userRoom.Participant, &ParticipantInfo{
 				Identity:    p.Identity,
 				IsPublisher: p.IsPublisher,
@@ -188,40 +189,33 @@ func (h *LiveHandler) GetGroupLive(ctx context.Context, query *GetGroupLive) ([]
 		return nil, err
 	}
 
-	// 获取 LiveKit 房间信息
-	livekitRooms, err := h.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{
-		Names: []string{room.ID},
-	})
+	// 获取媒体房间信息
+	mediaRoom, err := h.media.GetRoom(ctx, room.ID)
 	if err != nil {
+		if code.IsCode(err, code.LiveErrCallNotFound) {
+			h.liveRepo.DeleteRoom(ctx, room.ID)
+			h.logger.Error("房间不存在", zap.String("RoomID", room.ID))
+		}
 		return nil, err
 	}
 
-	if len(livekitRooms.Rooms) == 0 {
-		h.liveRepo.DeleteRoom(ctx, room.ID)
-		h.logger.Error("房间不存在", zap.String("RoomID", room.ID))
-		return nil, code.LiveErrCallNotFound
-	}
-
-	//livekitRoom := livekitRooms.Rooms[0]
 	groupRoom := &Room{
 		ID:              room.ID,
 		Type:            string(room.Type),
 		Owner:           room.Owner,
 		NumParticipants: room.NumParticipants,
 		MaxParticipants: room.MaxParticipants,
-		StartAt:         livekitRooms.Rooms[0].CreationTime,
+		StartAt:         mediaRoom.CreationTime,
 	}
 
 	// 获取当前房间的参与者信息
-	res, err := h.roomService.ListParticipants(ctx, &livekit.ListParticipantsRequest{
-		Room: room.ID,
-	})
+	participants, err := h.media.ListParticipants(ctx, room.ID)
 	if err != nil {
 		h.logger.Error("获取通话信息失败", zap.Error(err))
 		return nil, code.LiveErrGetCallInfoFailed
 	}
 
-	for _, p := range res.Participants {
+	for _, p := range participants {
 		groupRoom.Participant = append(groupRoom.Participant, &ParticipantInfo{
 			Identity:    p.Identity,
 			IsPublisher: p.IsPublisher,
@@ -245,31 +239,25 @@ func (h *LiveHandler) GetRoom(ctx context.Context, query *GetRoom) (*Room, error
 		return nil, code.Forbidden
 	}
 
-	rooms, err := h.roomService.ListRooms(ctx, &livekit.ListRoomsRequest{
-		Names: []string{room.ID},
-	})
+	mediaRoom, err := h.media.GetRoom(ctx, room.ID)
 	if err != nil {
+		if code.IsCode(err, code.LiveErrCallNotFound) {
+			h.liveRepo.DeleteRoom(ctx, query.Room)
+		}
 		return nil, err
 	}
 
-	if len(rooms.Rooms) == 0 {
-		h.liveRepo.DeleteRoom(ctx, query.Room)
-		return nil, code.LiveErrCallNotFound
-	}
-
 	var participant []*ParticipantInfo
 
-	res, err := h.roomService.ListParticipants(ctx, &livekit.ListParticipantsRequest{
-		Room: room.ID,
-	})
+	participants, err := h.media.ListParticipants(ctx, room.ID)
 	if err != nil {
 		h.logger.Error("获取通话信息失败", zap.Error(err))
 		return nil, code.LiveErrGetCallInfoFailed
 	}
 
-	for _, p := range res.Participants {
+	for _, p := range participants {
 		participant = append(participant, &ParticipantInfo{
-			Identity:    p.Sid,
+			Identity:    p.ID,
 			IsPublisher: p.IsPublisher,
 			JoinedAt:    p.JoinedAt,
 			Name:        p.Name,
@@ -283,9 +271,9 @@ func (h *LiveHandler) GetRoom(ctx context.Context, query *GetRoom) (*Room, error
 		Type:            string(room.Type),
 		Creator:         room.Creator,
 		Owner:           room.Owner,
-		NumParticipants: rooms.Rooms[0].NumParticipants,
-		MaxParticipants: rooms.Rooms[0].MaxParticipants,
-		StartAt:         rooms.Rooms[0].CreationTime,
+		NumParticipants: mediaRoom.NumParticipants,
+		MaxParticipants: mediaRoom.MaxParticipants,
+		StartAt:         mediaRoom.CreationTime,
 		Participant:     participant,
 	}, nil
 }
@@ -0,0 +1,87 @@
+package entity
+
+// CallOutcome 通话结果，与通话消息的子类型保持一致
+type CallOutcome uint
+
+const (
+	CallOutcomeNormal    CallOutcome = iota // 正常通话
+	CallOutcomeCancelled                    // 取消通话
+	CallOutcomeRejected                     // 拒绝通话
+	CallOutcomeMissed                       // 未接通话
+)
+
+// CallRecord 通话结束后保存的通话记录
+type CallRecord struct {
+	ID           uint
+	Room         string
+	Type         RoomType
+	Creator      string
+	GroupID      uint32
+	Option       RoomOption
+	Outcome      CallOutcome
+	Participants []*CallParticipant
+	StartAt      int64 // 发起通话时间，毫秒
+	ConnectAt    int64 // 接通时间，未接通时为0
+	EndAt        int64 // 结束时间，毫秒
+	Duration     int64 // 通话时长，秒
+	CreatedAt    int64
+}
+
+// CallParticipant 通话记录中的参与者
+type CallParticipant struct {
+	UserID   string
+	JoinedAt int64 // 加入时间，未加入时为0
+	LeftAt   int64 // 退出时间，未加入时为0
+}
+
+// HasParticipant 判断用户是否为通话的参与者
+func (r *CallRecord) HasParticipant(userID string) bool {
+	for _, p := range r.Participants {
+		if p.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCallRecord 根据结束的通话房间生成通话记录
+func NewCallRecord(room *Room, outcome CallOutcome, endAt int64) *CallRecord {
+	record := &CallRecord{
+		Room:    room.ID,
+		Type:    room.Type,
+		Creator: room.Creator,
+		GroupID: room.GroupID,
+		Option:  room.Option,
+		Outcome: outcome,
+		StartAt: room.CreatedAt,
+		EndAt:   endAt,
+	}
+
+	add := func(userID string, ap *ActiveParticipant) {
+		p := &CallParticipant{UserID: userID, JoinedAt: ap.JoinedAt, LeftAt: ap.LeftAt}
+		if p.JoinedAt != 0 && p.LeftAt == 0 {
+			p.LeftAt = endAt
+		}
+		record.Participants = append(record.Participants, p)
+
+		// 除发起者外第一个加入的用户接通了通话
+		if userID != room.Creator && p.JoinedAt != 0 && (record.ConnectAt == 0 || p.JoinedAt < record.ConnectAt) {
+			record.ConnectAt = p.JoinedAt
+		}
+	}
+	for userID, ap := range room.Participants {
+		add(userID, ap)
+	}
+	// 退出后重新加入的用户以当前的参与信息为准
+	for userID, ap := range room.LeftParticipants {
+		if _, ok := room.Participants[userID]; ok {
+			continue
+		}
+		add(userID, ap)
+	}
+
+	if outcome == CallOutcomeNormal && record.ConnectAt != 0 && endAt > record.ConnectAt {
+		record.Duration = (endAt - record.ConnectAt) / 1000
+	}
+	return record
+}
@@ -0,0 +1,35 @@
+package entity
+
+// RecordingStatus 通话录制状态
+type RecordingStatus uint
+
+const (
+	RecordingActive   RecordingStatus = iota // 录制中
+	RecordingStopping                        // 已停止，等待文件上传完成
+	RecordingComplete                        // 已完成，录制文件已保存
+	RecordingFailed                          // 录制失败
+)
+
+// CallRecording 通话录制，录制结束后文件保存到存储服务并发送到会话
+type CallRecording struct {
+	ID          uint
+	RecordingID string // 媒体服务的录制id
+	Room        string
+	Type        RoomType
+	Owner       string // 通话所有者，私聊录制文件只有所有者可以访问
+	Peer        string // 私聊通话的对方，用于发送录制消息
+	GroupID     uint32
+	StartedBy   string // 开始录制的用户
+	Status      RecordingStatus
+	FileID      string // 存储服务的文件id
+	Size        int64
+	Duration    int64 // 录制时长，秒
+	StartedAt   int64 // 开始录制时间，毫秒
+	EndedAt     int64 // 结束录制时间，毫秒
+	CreatedAt   int64
+}
+
+// IsFinished 判断录制是否已经处理完成
+func (r *CallRecording) IsFinished() bool {
+	return r.Status == RecordingComplete || r.Status == RecordingFailed
+}
@@ -14,6 +14,11 @@ type Room struct {
 	MaxParticipants uint32                        `json:"max_participants"`
 	Participants    map[string]*ActiveParticipant `json:"participants"`
 	Option          RoomOption                    `json:"option"`
+	CreatedAt       int64                         `json:"created_at"`
+	// LeftParticipants 已经退出群聊通话的参与者，用于结束时生成通话记录
+	LeftParticipants map[string]*ActiveParticipant `json:"left_participants,omitempty"`
+	// RecordingID 正在进行的录制id，未录制时为空
+	RecordingID string `json:"recording_id,omitempty"`
 }
 
 func (r *Room) Marshal() ([]byte, error) {
@@ -32,6 +37,18 @@ func (r *Room) String() string {
 	return string(bytes)
 }
 
+// Answered 判断除发起者外是否有用户加入过通话
+func (r *Room) Answered() bool {
+	for _, participants := range []map[string]*ActiveParticipant{r.Participants, r.LeftParticipants} {
+		for userID, ap := range participants {
+			if userID != r.Creator && ap.JoinedAt != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type RoomType string
 
 const (
@@ -51,6 +68,8 @@ type ActiveParticipant struct {
 	Connected bool
 	Status    ParticipantState
 	DriverID  string
+	JoinedAt  int64 // 加入通话时间，毫秒
+	LeftAt    int64 // 退出通话时间，毫秒
 }
 
 type RoomOption struct { // 通话选项
@@ -74,6 +93,8 @@ const (
 	ParticipantInfo_ACTIVE // 双方都已加入通话
 	// ParticipantInfo_DISCONNECTED WS disconnected
 	ParticipantInfo_DISCONNECTED // 断开连接
+	// ParticipantInfo_MISSED the participant did not answer before the ringing timeout
+	ParticipantInfo_MISSED // 超时未接听
 )
 
 type ParticipantInfo struct {
@@ -0,0 +1,67 @@
+package media
+
+import (
+	"context"
+	"time"
+)
+
+// MediaProvider 媒体服务，负责实际的音视频房间和连接，通话状态仍由 live 服务自己维护
+// 目前使用 LiveKit 实现，替换为自建 SFU（如基于 Pion）时只需要实现该接口
+type MediaProvider interface {
+	// CreateRoom 创建媒体房间
+	CreateRoom(ctx context.Context, req *CreateRoomRequest) (*Room, error)
+	// GetRoom 获取媒体房间，房间不存在时返回 code.LiveErrCallNotFound
+	GetRoom(ctx context.Context, room string) (*Room, error)
+	// DeleteRoom 删除媒体房间并断开所有参与者
+	DeleteRoom(ctx context.Context, room string) error
+	// IssueToken 签发客户端加入房间使用的凭证
+	IssueToken(ctx context.Context, req *TokenRequest) (string, error)
+	// ListParticipants 获取房间内已连接的参与者
+	ListParticipants(ctx context.Context, room string) ([]*Participant, error)
+	// RemoveParticipant 将参与者移出房间
+	RemoveParticipant(ctx context.Context, room, identity string) error
+	// StartRecording 开始录制房间，录制文件由媒体服务上传到对象存储
+	StartRecording(ctx context.Context, req *RecordingRequest) (*Recording, error)
+	// StopRecording 停止录制，文件上传完成后通过回调通知
+	StopRecording(ctx context.Context, id string) (*Recording, error)
+}
+
+type CreateRoomRequest struct {
+	Name            string
+	EmptyTimeout    time.Duration // 房间无人时自动关闭的时间
+	MaxParticipants uint32
+}
+
+type Room struct {
+	Name            string
+	NumParticipants uint32
+	NumPublishers   uint32
+	MaxParticipants uint32
+	CreationTime    int64 // 创建时间，秒
+}
+
+type TokenRequest struct {
+	Room     string
+	Identity string // 用户id
+	Name     string // 用户昵称
+	Admin    bool   // 是否拥有房间管理权限
+	ValidFor time.Duration
+}
+
+type ParticipantState int32
+
+const (
+	ParticipantJoining      ParticipantState = iota // 正在连接
+	ParticipantJoined                               // 已连接
+	ParticipantActive                               // 已建立媒体连接
+	ParticipantDisconnected                         // 已断开
+)
+
+type Participant struct {
+	ID          string // 媒体服务分配的连接id
+	Identity    string
+	Name        string
+	IsPublisher bool
+	State       ParticipantState
+	JoinedAt    int64 // 加入时间，秒
+}
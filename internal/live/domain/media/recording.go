@@ -0,0 +1,34 @@
+package media
+
+type RecordingRequest struct {
+	Room      string
+	Filepath  string // 录制文件在录制桶中的路径
+	AudioOnly bool
+}
+
+type RecordingStatus int32
+
+const (
+	RecordingStarting RecordingStatus = iota // 正在启动
+	RecordingActive                          // 录制中
+	RecordingEnding                          // 正在结束，文件上传中
+	RecordingComplete                        // 已完成
+	RecordingFailed                          // 录制失败
+)
+
+// IsFinished 判断录制是否已经结束
+func (s RecordingStatus) IsFinished() bool {
+	return s == RecordingComplete || s == RecordingFailed
+}
+
+type Recording struct {
+	ID        string // 媒体服务分配的录制id
+	Room      string
+	Status    RecordingStatus
+	Filepath  string // 录制文件的存储路径，格式为 bucket/object
+	Size      int64
+	Duration  int64 // 录制时长，秒
+	StartedAt int64 // 开始时间，秒
+	EndedAt   int64 // 结束时间，秒
+	Error     string
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+)
+
+type CallRecordQuery struct {
+	UserID   string              // 参与者
+	Type     entity.RoomType     // 通话类型，为空时不过滤
+	GroupID  uint32              // 群聊id，为0时不过滤
+	Outcome  *entity.CallOutcome // 通话结果，为nil时不过滤
+	StartAt  int64               // 发起时间下限，毫秒
+	EndAt    int64               // 发起时间上限，毫秒
+	PageNum  int
+	PageSize int
+}
+
+type CallRecordRepository interface {
+	// Create 保存通话记录，同一房间和发起时间的记录只保存一次，重复保存时返回已有的记录
+	Create(ctx context.Context, record *entity.CallRecord) error
+	// Get 获取通话记录
+	Get(ctx context.Context, id uint) (*entity.CallRecord, error)
+	// Find 按条件分页查询通话记录，按发起时间倒序，返回记录和总数
+	Find(ctx context.Context, query *CallRecordQuery) ([]*entity.CallRecord, int64, error)
+}
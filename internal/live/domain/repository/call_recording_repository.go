@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+)
+
+type CallRecordingRepository interface {
+	// Create 保存通话录制
+	Create(ctx context.Context, recording *entity.CallRecording) error
+	// GetByRecordingID 根据媒体服务的录制id获取通话录制，不存在时返回 code.NotFound
+	GetByRecordingID(ctx context.Context, recordingID string) (*entity.CallRecording, error)
+	// Update 更新通话录制
+	Update(ctx context.Context, recording *entity.CallRecording) error
+}
@@ -13,12 +13,14 @@ type Repository interface {
 	GetRoom(ctx context.Context, roomID string) (*entity.Room, error)
 	// UpdateRoom 更新房间
 	UpdateRoom(ctx context.Context, room *entity.Room) error
+	// ModifyRoom 原子地读取并修改房间，fn 返回错误时放弃修改，和其他修改冲突时重新读取后重试
+	ModifyRoom(ctx context.Context, roomID string, fn func(room *entity.Room) error) (*entity.Room, error)
 	// UpdateRoomWithExpiration 更新房间并设置过期时间
 	UpdateRoomWithExpiration(ctx context.Context, room *entity.Room, expiration time.Duration) error
 	// SetRoomPersist 设置房间永久不过期
 	SetRoomPersist(ctx context.Context, roomID string) error
-	// DeleteRoom 删除房间
-	DeleteRoom(ctx context.Context, roomID string) error
+	// DeleteRoom 删除房间，返回是否由本次调用删除，多个请求同时结束通话时只有一个返回 true
+	DeleteRoom(ctx context.Context, roomID string) (bool, error)
 	// ListRooms 获取所有房间
 	ListRooms(ctx context.Context, roomIDs []string) ([]*entity.Room, error)
 	// GetParticipant 获取房间参与者信息
@@ -40,4 +42,14 @@ type Repository interface {
 	GetGroupRoom(ctx context.Context, groupID string) (*entity.Room, error)
 	UpdateGroupLiveExpiration(ctx context.Context, groupID string, expiration time.Duration) error
 	SetGroupLivePersist(ctx context.Context, userID string) error
+
+	// AddRingingRoom 记录等待接听的房间，deadline 为振铃超时时间，毫秒
+	AddRingingRoom(ctx context.Context, roomID string, deadline int64) error
+	// ListRingingRooms 获取振铃超时时间不晚于 before 的房间，最多返回 limit 个
+	ListRingingRooms(ctx context.Context, before int64, limit int64) ([]string, error)
+	// ClaimRingingRoom 领取振铃超时时间不晚于 now 的房间，并将超时时间延后到 leaseUntil，
+	// 多个实例同时领取时只有一个返回 true，处理失败的房间在租约到期后会被重新领取
+	ClaimRingingRoom(ctx context.Context, roomID string, now, leaseUntil int64) (bool, error)
+	// RemoveRingingRoom 移除等待接听的房间，返回是否由本次调用移除，多个实例同时处理时只有一个返回 true
+	RemoveRingingRoom(ctx context.Context, roomID string) (bool, error)
 }
@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/po"
+	"github.com/cossim/coss-server/pkg/code"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.CallRecordRepository = &MySQLCallRecordRepository{}
+
+func NewMySQLCallRecordRepository(db *gorm.DB) *MySQLCallRecordRepository {
+	return &MySQLCallRecordRepository{db: db}
+}
+
+type MySQLCallRecordRepository struct {
+	db *gorm.DB
+}
+
+// 旧版本只按房间建立的唯一索引
+const legacyCallRecordRoomIndex = "idx_call_records_room"
+
+func (m *MySQLCallRecordRepository) Automigrate() error {
+	// 唯一索引改为房间和发起时间，删除旧的索引
+	if m.db.Migrator().HasIndex(&po.CallRecord{}, legacyCallRecordRoomIndex) {
+		if err := m.db.Migrator().DropIndex(&po.CallRecord{}, legacyCallRecordRoomIndex); err != nil {
+			return err
+		}
+	}
+	return m.db.AutoMigrate(&po.CallRecord{}, &po.CallRecordParticipant{})
+}
+
+func (m *MySQLCallRecordRepository) Create(ctx context.Context, record *entity.CallRecord) error {
+	model := converter.CallRecordEntityToPO(record)
+	participants := model.Participants
+	model.Participants = nil
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+		if res.Error != nil {
+			return res.Error
+		}
+		// 同一次通话的结束事件重复到达，使用已经保存的记录
+		if res.RowsAffected == 0 {
+			return tx.Where("room = ? AND start_at = ?", model.Room, model.StartAt).First(model).Error
+		}
+
+		if len(participants) == 0 {
+			return nil
+		}
+		for _, p := range participants {
+			p.RecordID = model.ID
+		}
+		return tx.Create(&participants).Error
+	})
+	if err != nil {
+		return err
+	}
+	record.ID = model.ID
+	record.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (m *MySQLCallRecordRepository) Get(ctx context.Context, id uint) (*entity.CallRecord, error) {
+	model := &po.CallRecord{}
+	if err := m.db.WithContext(ctx).Preload("Participants").First(model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, code.NotFound
+		}
+		return nil, err
+	}
+	return converter.CallRecordPOToEntity(model), nil
+}
+
+func (m *MySQLCallRecordRepository) Find(ctx context.Context, query *repository.CallRecordQuery) ([]*entity.CallRecord, int64, error) {
+	db := m.db.WithContext(ctx).Model(&po.CallRecord{})
+	if query.UserID != "" {
+		db = db.Where("id IN (?)", m.db.Model(&po.CallRecordParticipant{}).Select("record_id").Where("user_id = ?", query.UserID))
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", string(query.Type))
+	}
+	if query.GroupID != 0 {
+		db = db.Where("group_id = ?", query.GroupID)
+	}
+	if query.Outcome != nil {
+		db = db.Where("outcome = ?", uint(*query.Outcome))
+	}
+	if query.StartAt != 0 {
+		db = db.Where("start_at >= ?", query.StartAt)
+	}
+	if query.EndAt != 0 {
+		db = db.Where("start_at <= ?", query.EndAt)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if query.PageSize > 0 {
+		pageNum := query.PageNum
+		if pageNum < 1 {
+			pageNum = 1
+		}
+		db = db.Offset((pageNum - 1) * query.PageSize).Limit(query.PageSize)
+	}
+
+	var models []*po.CallRecord
+	if err := db.Preload("Participants").Order("start_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]*entity.CallRecord, 0, len(models))
+	for _, v := range models {
+		records = append(records, converter.CallRecordPOToEntity(v))
+	}
+	return records, total, nil
+}
@@ -0,0 +1,164 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/po"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newTestDB 使用内存sqlite，每个测试使用独立的数据库
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
+
+func newTestCallRecordRepository(t *testing.T) (*MySQLCallRecordRepository, *gorm.DB) {
+	t.Helper()
+	db := newTestDB(t)
+	repo := NewMySQLCallRecordRepository(db)
+	if err := repo.Automigrate(); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return repo, db
+}
+
+func testCallRecord(room string, outcome entity.CallOutcome) *entity.CallRecord {
+	r := &entity.Room{
+		ID:        room,
+		Type:      entity.UserRoomType,
+		Creator:   "alice",
+		CreatedAt: 1000,
+		Participants: map[string]*entity.ActiveParticipant{
+			"alice": {JoinedAt: 1000},
+			"bob":   {},
+		},
+	}
+	if outcome == entity.CallOutcomeNormal {
+		r.Participants["bob"].JoinedAt = 2000
+	}
+	return entity.NewCallRecord(r, outcome, 5000)
+}
+
+func TestCallRecordOutcomes(t *testing.T) {
+	repo, _ := newTestCallRecordRepository(t)
+	ctx := context.Background()
+
+	outcomes := map[string]entity.CallOutcome{
+		"answered": entity.CallOutcomeNormal,
+		"missed":   entity.CallOutcomeMissed,
+		"rejected": entity.CallOutcomeRejected,
+	}
+	for room, outcome := range outcomes {
+		if err := repo.Create(ctx, testCallRecord(room, outcome)); err != nil {
+			t.Fatalf("Create %s: %v", room, err)
+		}
+	}
+
+	for room, outcome := range outcomes {
+		outcome := outcome
+		records, total, err := repo.Find(ctx, &repository.CallRecordQuery{UserID: "bob", Outcome: &outcome})
+		if err != nil {
+			t.Fatalf("Find %s: %v", room, err)
+		}
+		if total != 1 || records[0].Room != room || len(records[0].Participants) != 2 {
+			t.Fatalf("unexpected %s records: %d %+v", room, total, records)
+		}
+		if r := records[0]; outcome == entity.CallOutcomeNormal && (r.ConnectAt != 2000 || r.Duration != 3) {
+			t.Fatalf("answered call connect at %d duration %d, want 2000 and 3", r.ConnectAt, r.Duration)
+		}
+	}
+}
+
+func TestCallRecordDuplicateEnd(t *testing.T) {
+	repo, db := newTestCallRecordRepository(t)
+	ctx := context.Background()
+
+	first := testCallRecord("r1", entity.CallOutcomeNormal)
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// 同一次通话的结束事件并发重复到达
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record := testCallRecord("r1", entity.CallOutcomeCancelled)
+			if err := repo.Create(ctx, record); err != nil {
+				t.Errorf("Create duplicate: %v", err)
+				return
+			}
+			if record.ID != first.ID {
+				t.Errorf("duplicate record id = %d, want %d", record.ID, first.ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var records, participants int64
+	db.Model(&po.CallRecord{}).Count(&records)
+	db.Model(&po.CallRecordParticipant{}).Count(&participants)
+	if records != 1 || participants != 2 {
+		t.Fatalf("got %d records and %d participants, want 1 and 2", records, participants)
+	}
+	got, err := repo.Get(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Outcome != entity.CallOutcomeNormal {
+		t.Fatalf("outcome = %d, want the first saved outcome", got.Outcome)
+	}
+
+	// 同一房间id的另一次通话单独保存
+	other := testCallRecord("r1", entity.CallOutcomeMissed)
+	other.StartAt = 9000
+	if err := repo.Create(ctx, other); err != nil {
+		t.Fatalf("Create other call: %v", err)
+	}
+	if other.ID == first.ID {
+		t.Fatal("call with a different start time should be a new record")
+	}
+}
+
+func TestCallRecordMigrateLegacyIndex(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.Exec("CREATE TABLE call_records (id integer PRIMARY KEY AUTOINCREMENT, room varchar(64))").Error; err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX " + legacyCallRecordRoomIndex + " ON call_records(room)").Error; err != nil {
+		t.Fatalf("create legacy index: %v", err)
+	}
+
+	repo := NewMySQLCallRecordRepository(db)
+	if err := repo.Automigrate(); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	if db.Migrator().HasIndex(&po.CallRecord{}, legacyCallRecordRoomIndex) {
+		t.Fatal("legacy room index should be dropped")
+	}
+	if !db.Migrator().HasIndex(&po.CallRecord{}, "idx_call_record_room_start") {
+		t.Fatal("room and start time index should be created")
+	}
+}
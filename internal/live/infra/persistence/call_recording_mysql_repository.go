@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/po"
+	"github.com/cossim/coss-server/pkg/code"
+	"gorm.io/gorm"
+)
+
+var _ repository.CallRecordingRepository = &MySQLCallRecordingRepository{}
+
+func NewMySQLCallRecordingRepository(db *gorm.DB) *MySQLCallRecordingRepository {
+	return &MySQLCallRecordingRepository{db: db}
+}
+
+type MySQLCallRecordingRepository struct {
+	db *gorm.DB
+}
+
+func (m *MySQLCallRecordingRepository) Automigrate() error {
+	return m.db.AutoMigrate(&po.CallRecording{})
+}
+
+func (m *MySQLCallRecordingRepository) Create(ctx context.Context, recording *entity.CallRecording) error {
+	model := converter.CallRecordingEntityToPO(recording)
+	if err := m.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	recording.ID = model.ID
+	recording.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (m *MySQLCallRecordingRepository) GetByRecordingID(ctx context.Context, recordingID string) (*entity.CallRecording, error) {
+	model := &po.CallRecording{}
+	if err := m.db.WithContext(ctx).Where("recording_id = ?", recordingID).First(model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, code.NotFound
+		}
+		return nil, err
+	}
+	return converter.CallRecordingPOToEntity(model), nil
+}
+
+func (m *MySQLCallRecordingRepository) Update(ctx context.Context, recording *entity.CallRecording) error {
+	model := converter.CallRecordingEntityToPO(recording)
+	return m.db.WithContext(ctx).Model(&po.CallRecording{}).Where("id = ?", recording.ID).Updates(map[string]interface{}{
+		"status":   model.Status,
+		"file_id":  model.FileID,
+		"size":     model.Size,
+		"duration": model.Duration,
+		"ended_at": model.EndedAt,
+	}).Error
+}
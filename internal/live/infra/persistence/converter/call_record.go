@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/po"
+)
+
+func CallRecordEntityToPO(e *entity.CallRecord) *po.CallRecord {
+	m := &po.CallRecord{
+		BaseModel: po.BaseModel{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+		},
+		Room:         e.Room,
+		Type:         string(e.Type),
+		Creator:      e.Creator,
+		GroupID:      e.GroupID,
+		VideoEnabled: e.Option.VideoEnabled,
+		AudioEnabled: e.Option.AudioEnabled,
+		Resolution:   e.Option.Resolution,
+		FrameRate:    e.Option.FrameRate,
+		Codec:        e.Option.Codec,
+		Outcome:      uint(e.Outcome),
+		StartAt:      e.StartAt,
+		ConnectAt:    e.ConnectAt,
+		EndAt:        e.EndAt,
+		Duration:     e.Duration,
+	}
+	for _, p := range e.Participants {
+		m.Participants = append(m.Participants, &po.CallRecordParticipant{
+			RecordID: e.ID,
+			UserID:   p.UserID,
+			JoinedAt: p.JoinedAt,
+			LeftAt:   p.LeftAt,
+		})
+	}
+	return m
+}
+
+func CallRecordPOToEntity(m *po.CallRecord) *entity.CallRecord {
+	e := &entity.CallRecord{
+		ID:      m.ID,
+		Room:    m.Room,
+		Type:    entity.RoomType(m.Type),
+		Creator: m.Creator,
+		GroupID: m.GroupID,
+		Option: entity.RoomOption{
+			VideoEnabled: m.VideoEnabled,
+			AudioEnabled: m.AudioEnabled,
+			Resolution:   m.Resolution,
+			FrameRate:    m.FrameRate,
+			Codec:        m.Codec,
+		},
+		Outcome:   entity.CallOutcome(m.Outcome),
+		StartAt:   m.StartAt,
+		ConnectAt: m.ConnectAt,
+		EndAt:     m.EndAt,
+		Duration:  m.Duration,
+		CreatedAt: m.CreatedAt,
+	}
+	for _, p := range m.Participants {
+		e.Participants = append(e.Participants, &entity.CallParticipant{
+			UserID:   p.UserID,
+			JoinedAt: p.JoinedAt,
+			LeftAt:   p.LeftAt,
+		})
+	}
+	return e
+}
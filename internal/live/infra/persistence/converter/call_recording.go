@@ -0,0 +1,48 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/infra/persistence/po"
+)
+
+func CallRecordingEntityToPO(e *entity.CallRecording) *po.CallRecording {
+	return &po.CallRecording{
+		BaseModel: po.BaseModel{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+		},
+		RecordingID: e.RecordingID,
+		Room:        e.Room,
+		Type:        string(e.Type),
+		Owner:       e.Owner,
+		Peer:        e.Peer,
+		GroupID:     e.GroupID,
+		StartedBy:   e.StartedBy,
+		Status:      uint(e.Status),
+		FileID:      e.FileID,
+		Size:        e.Size,
+		Duration:    e.Duration,
+		StartedAt:   e.StartedAt,
+		EndedAt:     e.EndedAt,
+	}
+}
+
+func CallRecordingPOToEntity(m *po.CallRecording) *entity.CallRecording {
+	return &entity.CallRecording{
+		ID:          m.ID,
+		RecordingID: m.RecordingID,
+		Room:        m.Room,
+		Type:        entity.RoomType(m.Type),
+		Owner:       m.Owner,
+		Peer:        m.Peer,
+		GroupID:     m.GroupID,
+		StartedBy:   m.StartedBy,
+		Status:      entity.RecordingStatus(m.Status),
+		FileID:      m.FileID,
+		Size:        m.Size,
+		Duration:    m.Duration,
+		StartedAt:   m.StartedAt,
+		EndedAt:     m.EndedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package po
+
+import (
+	ptime "github.com/cossim/coss-server/pkg/utils/time"
+	"gorm.io/gorm"
+)
+
+type BaseModel struct {
+	ID        uint  `gorm:"primaryKey;autoIncrement;"`
+	CreatedAt int64 `gorm:"autoCreateTime;comment:创建时间"`
+	UpdatedAt int64 `gorm:"autoUpdateTime;comment:更新时间"`
+	DeletedAt int64 `gorm:"default:0;comment:删除时间"`
+}
+
+func (bm *BaseModel) BeforeCreate(tx *gorm.DB) error {
+	now := ptime.Now()
+	bm.CreatedAt = now
+	bm.UpdatedAt = now
+	return nil
+}
+
+func (bm *BaseModel) BeforeUpdate(tx *gorm.DB) error {
+	bm.UpdatedAt = ptime.Now()
+	return nil
+}
+
+type CallRecord struct {
+	BaseModel
+	Room         string                   `gorm:"type:varchar(64);uniqueIndex:idx_call_record_room_start;comment:通话房间"`
+	Type         string                   `gorm:"type:varchar(16);index;comment:通话类型(user=私聊, group=群聊)"`
+	Creator      string                   `gorm:"type:varchar(64);comment:发起者id"`
+	GroupID      uint32                   `gorm:"default:0;index;comment:群聊id"`
+	VideoEnabled bool                     `gorm:"default:false;comment:是否启用视频"`
+	AudioEnabled bool                     `gorm:"default:false;comment:是否启用音频"`
+	Resolution   string                   `gorm:"comment:分辨率"`
+	FrameRate    int                      `gorm:"default:0;comment:帧率"`
+	Codec        string                   `gorm:"comment:编解码器"`
+	Outcome      uint                     `gorm:"default:0;comment:通话结果(0=正常, 1=取消, 2=拒绝, 3=未接)"`
+	StartAt      int64                    `gorm:"index;uniqueIndex:idx_call_record_room_start;comment:发起时间"`
+	ConnectAt    int64                    `gorm:"default:0;comment:接通时间"`
+	EndAt        int64                    `gorm:"comment:结束时间"`
+	Duration     int64                    `gorm:"default:0;comment:通话时长(秒)"`
+	Participants []*CallRecordParticipant `gorm:"foreignKey:RecordID"`
+}
+
+func (m *CallRecord) TableName() string {
+	return "call_records"
+}
+
+type CallRecordParticipant struct {
+	BaseModel
+	RecordID uint   `gorm:"index;comment:通话记录id"`
+	UserID   string `gorm:"type:varchar(64);index;comment:用户id"`
+	JoinedAt int64  `gorm:"default:0;comment:加入时间"`
+	LeftAt   int64  `gorm:"default:0;comment:退出时间"`
+}
+
+func (m *CallRecordParticipant) TableName() string {
+	return "call_record_participants"
+}
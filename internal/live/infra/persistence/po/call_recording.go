@@ -0,0 +1,22 @@
+package po
+
+type CallRecording struct {
+	BaseModel
+	RecordingID string `gorm:"type:varchar(64);uniqueIndex;comment:媒体服务录制id"`
+	Room        string `gorm:"type:varchar(64);index;comment:通话房间"`
+	Type        string `gorm:"type:varchar(16);comment:通话类型(user=私聊, group=群聊)"`
+	Owner       string `gorm:"type:varchar(64);comment:通话所有者id"`
+	Peer        string `gorm:"type:varchar(64);comment:私聊通话对方id"`
+	GroupID     uint32 `gorm:"default:0;index;comment:群聊id"`
+	StartedBy   string `gorm:"type:varchar(64);comment:开始录制的用户id"`
+	Status      uint   `gorm:"default:0;comment:录制状态(0=录制中, 1=已停止, 2=已完成, 3=失败)"`
+	FileID      string `gorm:"type:varchar(128);comment:录制文件id"`
+	Size        int64  `gorm:"default:0;comment:文件大小"`
+	Duration    int64  `gorm:"default:0;comment:录制时长(秒)"`
+	StartedAt   int64  `gorm:"comment:开始时间"`
+	EndedAt     int64  `gorm:"default:0;comment:结束时间"`
+}
+
+func (m *CallRecording) TableName() string {
+	return "call_recordings"
+}
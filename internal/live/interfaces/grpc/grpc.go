@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	livegrpcv1 "github.com/cossim/coss-server/internal/live/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/live/domain/entity"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+var _ livegrpcv1.LiveServiceServer = &LiveServiceServer{}
+
+func (s *LiveServiceServer) GetCallRecord(ctx context.Context, request *livegrpcv1.GetCallRecordRequest) (*livegrpcv1.CallRecord, error) {
+	if request == nil || request.Id == 0 {
+		return nil, code.WrapCodeToGRPC(code.InvalidParameter)
+	}
+
+	record, err := s.repo.Get(ctx, uint(request.Id))
+	if err != nil {
+		if errors.Is(err, code.NotFound) {
+			return nil, code.WrapCodeToGRPC(code.LiveErrCallNotFound.Reason(utils.FormatErrorStack(err)))
+		}
+		return nil, code.WrapCodeToGRPC(code.LiveErrGetCallHistoryFailed.Reason(utils.FormatErrorStack(err)))
+	}
+
+	return callRecordToGRPC(record), nil
+}
+
+func (s *LiveServiceServer) GetCallRecords(ctx context.Context, request *livegrpcv1.GetCallRecordsRequest) (*livegrpcv1.GetCallRecordsResponse, error) {
+	if request == nil {
+		return nil, code.WrapCodeToGRPC(code.InvalidParameter)
+	}
+	if request.Type != "" && !entity.RoomType(request.Type).IsValid() {
+		return nil, code.WrapCodeToGRPC(code.InvalidParameter)
+	}
+
+	query := &repository.CallRecordQuery{
+		UserID:   request.UserId,
+		Type:     entity.RoomType(request.Type),
+		GroupID:  request.GroupId,
+		StartAt:  request.StartAt,
+		EndAt:    request.EndAt,
+		PageNum:  int(request.PageNum),
+		PageSize: int(request.PageSize),
+	}
+	if request.FilterOutcome {
+		outcome := entity.CallOutcome(request.Outcome)
+		query.Outcome = &outcome
+	}
+
+	records, total, err := s.repo.Find(ctx, query)
+	if err != nil {
+		return nil, code.WrapCodeToGRPC(code.LiveErrGetCallHistoryFailed.Reason(utils.FormatErrorStack(err)))
+	}
+
+	resp := &livegrpcv1.GetCallRecordsResponse{
+		List:  make([]*livegrpcv1.CallRecord, 0, len(records)),
+		Total: total,
+	}
+	for _, record := range records {
+		resp.List = append(resp.List, callRecordToGRPC(record))
+	}
+	return resp, nil
+}
+
+func callRecordToGRPC(e *entity.CallRecord) *livegrpcv1.CallRecord {
+	participants := make([]*livegrpcv1.CallParticipant, 0, len(e.Participants))
+	for _, p := range e.Participants {
+		participants = append(participants, &livegrpcv1.CallParticipant{
+			UserId:   p.UserID,
+			JoinedAt: p.JoinedAt,
+			LeftAt:   p.LeftAt,
+		})
+	}
+	return &livegrpcv1.CallRecord{
+		Id:           uint32(e.ID),
+		Room:         e.Room,
+		Type:         string(e.Type),
+		Creator:      e.Creator,
+		GroupId:      e.GroupID,
+		VideoEnabled: e.Option.VideoEnabled,
+		AudioEnabled: e.Option.AudioEnabled,
+		Outcome:      livegrpcv1.CallOutcome(e.Outcome),
+		Participants: participants,
+		StartAt:      e.StartAt,
+		ConnectAt:    e.ConnectAt,
+		EndAt:        e.EndAt,
+		Duration:     e.Duration,
+	}
+}
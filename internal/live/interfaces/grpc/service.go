@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/live/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/live/domain/repository"
+	"github.com/cossim/coss-server/internal/live/infra/persistence"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"github.com/cossim/coss-server/pkg/db"
+	"github.com/cossim/coss-server/pkg/manager/server"
+	"github.com/cossim/coss-server/pkg/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"log"
+	"strconv"
+)
+
+var _ server.GRPCService = &LiveServiceServer{}
+
+const (
+	ServiceName = "live_service"
+)
+
+type LiveServiceServer struct {
+	repo repository.CallRecordRepository
+}
+
+func (s *LiveServiceServer) Init(cfg *pkgconfig.AppConfig) error {
+	mysql, err := db.NewMySQL(cfg.MySQL.Address, strconv.Itoa(cfg.MySQL.Port), cfg.MySQL.Username, cfg.MySQL.Password, cfg.MySQL.Database, int64(cfg.Log.Level), cfg.MySQL.Opts)
+	if err != nil {
+		log.Printf("init mysql error: %v\n", err)
+		return err
+	}
+	dbConn, err := mysql.GetConnection()
+	if err != nil {
+		log.Printf("get mysql connection error: %v\n", err)
+		return err
+	}
+
+	repo := persistence.NewMySQLCallRecordRepository(dbConn)
+	if err = repo.Automigrate(); err != nil {
+		log.Printf("automigrate error: %v\n", err)
+		return err
+	}
+	s.repo = repo
+	return nil
+}
+
+func (s *LiveServiceServer) Name() string {
+	return ServiceName
+}
+
+func (s *LiveServiceServer) Version() string {
+	return version.FullVersion()
+}
+
+func (s *LiveServiceServer) Register(srv *grpc.Server) {
+	v1.RegisterLiveServiceServer(srv, s)
+}
+
+func (s *LiveServiceServer) RegisterHealth(srv *grpc.Server) {
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+}
+
+func (s *LiveServiceServer) Stop(ctx context.Context) error {
+	return nil
+}
+
+func (s *LiveServiceServer) DiscoverServices(services map[string]*grpc.ClientConn) error {
+	return nil
+}
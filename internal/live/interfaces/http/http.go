@@ -9,6 +9,7 @@ import (
 	"github.com/cossim/coss-server/internal/live/app/query"
 	authv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	"github.com/cossim/coss-server/internal/user/rpc/client"
+	"github.com/cossim/coss-server/pkg/code"
 	pkgconfig "github.com/cossim/coss-server/pkg/config"
 	"github.com/cossim/coss-server/pkg/constants"
 	"github.com/cossim/coss-server/pkg/discovery"
@@ -20,6 +21,7 @@ import (
 	"github.com/cossim/coss-server/pkg/version"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/gin-gonic/gin"
+	"github.com/livekit/protocol/auth"
 	omiddleware "github.com/oapi-codegen/gin-middleware"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -46,6 +48,7 @@ type HttpServer struct {
 	enc         encryption.Encryptor
 	app         app.Application
 	authService authv1.UserAuthServiceClient
+	webhookKeys auth.KeyProvider
 }
 
 func (h *HttpServer) Init(cfg *pkgconfig.AppConfig) error {
@@ -68,6 +71,7 @@ func (h *HttpServer) Init(cfg *pkgconfig.AppConfig) error {
 		return err
 	}
 	h.authService = authClient
+	h.webhookKeys = auth.NewSimpleKeyProvider(cfg.Livekit.ApiKey, cfg.Livekit.ApiSecret)
 	return nil
 }
 
@@ -80,6 +84,9 @@ func (h *HttpServer) Version() string {
 }
 
 func (h *HttpServer) RegisterRoute(r gin.IRouter) {
+	// LiveKit 回调使用签名校验，在用户鉴权和加密中间件之前注册
+	r.POST(livekitWebhookPath, h.LivekitWebhook)
+
 	r.Use(middleware.CORSMiddleware(), middleware.GRPCErrorMiddleware(h.logger), middleware.EncryptionMiddleware(h.enc))
 	r.Use(middleware.AuthMiddleware(h.authService))
 
@@ -291,6 +298,89 @@ func getRoomToResponse(room *query.Room) *v1.Room {
 	}
 }
 
+// GetCallHistory
+// @Summary 获取通话记录
+// @Description 获取当前用户参与过的通话记录，按发起时间倒序
+// @Tags live
+// @Security BearerAuth
+// @Produce json
+// @Param type query string false "通话类型 user=私聊 group=群聊"
+// @Param group_id query int false "群组ID"
+// @Param outcome query int false "通话结果 0=正常 1=取消 2=拒绝 3=未接"
+// @Param start_at query int false "发起时间下限，毫秒时间戳"
+// @Param end_at query int false "发起时间上限，毫秒时间戳"
+// @Param page_num query int true "页码"
+// @Param page_size query int true "页大小"
+// @Success 200 {object} v1.CallHistoryResponse "获取通话记录成功"
+// @Router /live/history [get]
+func (h *HttpServer) GetCallHistory(c *gin.Context, params v1.GetCallHistoryParams) {
+	uid := c.Value(constants.UserID).(string)
+	q := &query.GetCallHistory{
+		UserID:   uid,
+		Outcome:  params.Outcome,
+		PageNum:  params.PageNum,
+		PageSize: params.PageSize,
+	}
+	if params.Type != nil {
+		q.Type = string(*params.Type)
+	}
+	if params.GroupId != nil {
+		q.GroupID = *params.GroupId
+	}
+	if params.StartAt != nil {
+		q.StartAt = *params.StartAt
+	}
+	if params.EndAt != nil {
+		q.EndAt = *params.EndAt
+	}
+
+	history, err := h.app.Queries.LiveHandler.GetCallHistory(c, q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取通话记录成功", callHistoryToResponse(history))
+}
+
+func callHistoryToResponse(history *query.CallHistory) *v1.CallHistoryResponse {
+	list := make([]v1.CallRecord, 0, len(history.List))
+	for _, r := range history.List {
+		participants := make([]v1.CallParticipant, 0, len(r.Participants))
+		for _, p := range r.Participants {
+			participants = append(participants, v1.CallParticipant{
+				UserId:   p.UserID,
+				JoinedAt: p.JoinedAt,
+				LeftAt:   p.LeftAt,
+			})
+		}
+		list = append(list, v1.CallRecord{
+			Id:      uint32(r.ID),
+			Room:    r.Room,
+			Type:    string(r.Type),
+			Creator: r.Creator,
+			GroupId: r.GroupID,
+			Option: v1.RoomOption{
+				VideoEnabled: r.Option.VideoEnabled,
+				AudioEnabled: r.Option.AudioEnabled,
+				Resolution:   r.Option.Resolution,
+				FrameRate:    r.Option.FrameRate,
+				Codec:        r.Option.Codec,
+			},
+			Outcome:      int(r.Outcome),
+			Participants: participants,
+			StartAt:      r.StartAt,
+			ConnectAt:    r.ConnectAt,
+			EndAt:        r.EndAt,
+			Duration:     r.Duration,
+		})
+	}
+	return &v1.CallHistoryResponse{
+		List:  list,
+		Total: history.Total,
+	}
+}
+
 // JoinRoom
 // @Summary 加入通话
 // @Description 加入已存在的通话房间
@@ -322,6 +412,54 @@ func (h *HttpServer) JoinRoom(c *gin.Context, id string) {
 	})
 }
 
+// RecordRoom
+// @Summary 通话录制
+// @Description 开始或停止录制通话，只有通话所有者或群聊管理员可以操作，录制文件完成后发送到会话
+// @Tags live
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "要录制的通话房间ID"
+// @Param requestBody body v1.RecordingRequest true "请求体参数"
+// @Success 200 {object} v1.RecordingResponse "操作成功"
+// @Router /live/{id}/recording [post]
+func (h *HttpServer) RecordRoom(c *gin.Context, id string) {
+	req := &v1.RecordingRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	uid := c.Value(constants.UserID).(string)
+
+	var resp *command.RecordingResponse
+	var err error
+	switch req.Action {
+	case v1.Start:
+		resp, err = h.app.Commands.LiveHandler.StartRecording(c, &command.StartRecording{
+			Room:      id,
+			UserID:    uid,
+			AudioOnly: req.AudioOnly,
+		})
+	case v1.Stop:
+		resp, err = h.app.Commands.LiveHandler.StopRecording(c, &command.StopRecording{
+			Room:   id,
+			UserID: uid,
+		})
+	default:
+		err = code.InvalidParameter
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", &v1.RecordingResponse{
+		RecordingId: resp.RecordingID,
+		Status:      int(resp.Status),
+	})
+}
+
 // RejectRoom
 // @Summary 拒绝通话
 // @Description 拒绝加入通话
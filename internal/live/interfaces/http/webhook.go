@@ -0,0 +1,57 @@
+package http
+
+import (
+	"github.com/cossim/coss-server/internal/live/adapters"
+	"github.com/cossim/coss-server/internal/live/app/command"
+	"github.com/gin-gonic/gin"
+	"github.com/livekit/protocol/webhook"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// livekitWebhookPath LiveKit 回调地址，需要在 LiveKit 的 webhook 配置中填写
+const livekitWebhookPath = "/api/v1/live/webhook"
+
+// LivekitWebhook
+// @Summary LiveKit 回调
+// @Description 接收 LiveKit 签名的 webhook 事件，修正通话房间状态
+// @Tags live
+// @Accept json
+// @Produce json
+// @Success 200 "处理成功"
+// @Router /live/webhook [post]
+func (h *HttpServer) LivekitWebhook(c *gin.Context) {
+	event, err := webhook.ReceiveWebhookEvent(c.Request, h.webhookKeys)
+	if err != nil {
+		h.logger.Warn("invalid livekit webhook", zap.Error(err))
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	// 录制结束，保存录制文件
+	if event.GetEvent() == command.WebhookEgressEnded && event.GetEgressInfo() != nil {
+		rec := adapters.EgressToRecording(event.GetEgressInfo())
+		if err := h.app.Commands.LiveHandler.FinishRecording(c, &command.FinishRecording{Recording: rec}); err != nil {
+			h.logger.Error("finish recording failed", zap.Error(err), zap.String("recording", rec.ID))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+
+	cmd := &command.ReconcileRoom{
+		Event: event.GetEvent(),
+		Room:  event.GetRoom().GetName(),
+	}
+	if event.GetParticipant() != nil {
+		cmd.Identity = event.GetParticipant().GetIdentity()
+	}
+	if err := h.app.Commands.LiveHandler.ReconcileRoom(c, cmd); err != nil {
+		h.logger.Error("reconcile room failed", zap.Error(err), zap.String("event", cmd.Event), zap.String("room", cmd.Room))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
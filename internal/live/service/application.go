@@ -7,13 +7,17 @@ import (
 	"github.com/cossim/coss-server/internal/live/app"
 	"github.com/cossim/coss-server/internal/live/app/command"
 	"github.com/cossim/coss-server/internal/live/app/query"
+	"github.com/cossim/coss-server/internal/live/infra/persistence"
 	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
 	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	storagegrpcv1 "github.com/cossim/coss-server/internal/storage/api/grpc/v1"
 	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/config"
+	"github.com/cossim/coss-server/pkg/db"
 	"github.com/cossim/coss-server/pkg/discovery"
 	"go.uber.org/zap"
+	"strconv"
 )
 
 func NewApplication(ctx context.Context, ac *config.AppConfig, logger *zap.Logger) *app.Application {
@@ -27,6 +31,32 @@ func NewApplication(ctx context.Context, ac *config.AppConfig, logger *zap.Logge
 		panic(err)
 	}
 
+	mysql, err := db.NewMySQL(ac.MySQL.Address, strconv.Itoa(ac.MySQL.Port), ac.MySQL.Username, ac.MySQL.Password, ac.MySQL.Database, int64(ac.Log.Level), ac.MySQL.Opts)
+	if err != nil {
+		panic(err)
+	}
+	dbConn, err := mysql.GetConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	scheme := "http://"
+	if ac.OSS.SSL {
+		scheme = "https://"
+	}
+	mediaProvider := adapters.NewLiveKitMediaProvider(ac.Livekit.Url, ac.Livekit.ApiKey, ac.Livekit.ApiSecret,
+		adapters.WithRecordingUpload(scheme+ac.OSS.Addr(), ac.OSS.AccessKey, ac.OSS.SecretKey),
+	)
+
+	callRecordRepository := persistence.NewMySQLCallRecordRepository(dbConn)
+	if err := callRecordRepository.Automigrate(); err != nil {
+		panic(err)
+	}
+	callRecordingRepository := persistence.NewMySQLCallRecordingRepository(dbConn)
+	if err := callRecordingRepository.Automigrate(); err != nil {
+		panic(err)
+	}
+
 	go func() {
 		<-ctx.Done()
 		for _, conn := range services {
@@ -36,26 +66,37 @@ func NewApplication(ctx context.Context, ac *config.AppConfig, logger *zap.Logge
 		}
 	}()
 
+	liveHandler := command.NewLiveHandler(
+		command.WithRepo(liveRepository),
+		command.WithLogger(logger),
+		command.WithLiveKit(ac.Livekit),
+		command.WithMediaProvider(mediaProvider),
+		command.WithMsgService(msggrpcv1.NewMsgServiceClient(services["msg_service"])),
+		command.WithUserService(usergrpcv1.NewUserServiceClient(services["user_service"])),
+		command.WithPushService(pushgrpcv1.NewPushServiceClient(services["push_service"])),
+		command.WithRelationGroupService(relationgrpcv1.NewGroupRelationServiceClient(services["relation_service"])),
+		command.WithRelationUserService(relationgrpcv1.NewUserRelationServiceClient(services["relation_service"])),
+		command.WithGroupService(groupgrpcv1.NewGroupServiceClient(services["group_service"])),
+		command.WithCallRecordRepo(callRecordRepository),
+		command.WithCallRecordingRepo(callRecordingRepository),
+		command.WithRelationDialogService(relationgrpcv1.NewDialogServiceClient(services["relation_service"])),
+		command.WithStorageService(storagegrpcv1.NewStorageServiceClient(services["storage_service"])),
+	)
+	// 处理振铃超时未接听的通话
+	go liveHandler.RunRingingWorker(ctx)
+
 	return &app.Application{
 		Commands: app.Commands{
-			LiveHandler: command.NewLiveHandler(
-				command.WithRepo(liveRepository),
-				command.WithLogger(logger),
-				command.WithLiveKit(ac.Livekit),
-				command.WithMsgService(msggrpcv1.NewMsgServiceClient(services["msg_service"])),
-				command.WithUserService(usergrpcv1.NewUserServiceClient(services["user_service"])),
-				command.WithPushService(pushgrpcv1.NewPushServiceClient(services["push_service"])),
-				command.WithRelationGroupService(relationgrpcv1.NewGroupRelationServiceClient(services["relation_service"])),
-				command.WithRelationUserService(relationgrpcv1.NewUserRelationServiceClient(services["relation_service"])),
-				command.WithGroupService(groupgrpcv1.NewGroupServiceClient(services["group_service"])),
-			),
+			LiveHandler: liveHandler,
 		},
 		Queries: app.Queries{
 			LiveHandler: query.NewLiveHandler(
 				query.WithRepo(liveRepository),
 				query.WithLogger(logger),
 				query.WithLiveKit(ac.Livekit),
+				query.WithMediaProvider(mediaProvider),
 				query.WithRelationGroupService(relationgrpcv1.NewGroupRelationServiceClient(services["relation_service"])),
+				query.WithCallRecordRepo(callRecordRepository),
 			),
 		},
 	}
@@ -83,6 +83,7 @@ const (
 	MessageType_VideoCall   MessageType = 10 // 视频通话
 	MessageType_Delete      MessageType = 11 // 撤回消息
 	MessageType_CancelLabel MessageType = 12 // 取消标注
+	MessageType_ChatRecord  MessageType = 13 // 合并转发的聊天记录
 )
 
 // Enum value maps for MessageType.
@@ -101,6 +102,7 @@ var (
 		10: "VideoCall",
 		11: "Delete",
 		12: "CancelLabel",
+		13: "ChatRecord",
 	}
 	MessageType_value = map[string]int32{
 		"Unknown":     0,
@@ -116,6 +118,7 @@ var (
 		"VideoCall":   10,
 		"Delete":      11,
 		"CancelLabel": 12,
+		"ChatRecord":  13,
 	}
 )
 
@@ -682,6 +685,453 @@ func (x *DeleteUserMessageByIdsRequest) GetIsPhysical() bool {
 	return false
 }
 
+type ConfirmMessagesDeliveredRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"user_id"
+	UserId string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"user_id"`
+	// @inject_tag: json:"user_msg_ids"
+	UserMsgIds []uint32 `protobuf:"varint,2,rep,packed,name=UserMsgIds,proto3" json:"user_msg_ids"`
+	// @inject_tag: json:"group_msg_ids"
+	GroupMsgIds []uint32 `protobuf:"varint,3,rep,packed,name=GroupMsgIds,proto3" json:"group_msg_ids"`
+}
+
+func (x *ConfirmMessagesDeliveredRequest) Reset() {
+	*x = ConfirmMessagesDeliveredRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_msg_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmMessagesDeliveredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMessagesDeliveredRequest) ProtoMessage() {}
+
+func (x *ConfirmMessagesDeliveredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_msg_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMessagesDeliveredRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmMessagesDeliveredRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_msg_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfirmMessagesDeliveredRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConfirmMessagesDeliveredRequest) GetUserMsgIds() []uint32 {
+	if x != nil {
+		return x.UserMsgIds
+	}
+	return nil
+}
+
+func (x *ConfirmMessagesDeliveredRequest) GetGroupMsgIds() []uint32 {
+	if x != nil {
+		return x.GroupMsgIds
+	}
+	return nil
+}
+
+type DeliveredMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"msg_id"
+	MsgId uint32 `protobuf:"varint,1,opt,name=MsgId,proto3" json:"msg_id"`
+	// @inject_tag: json:"dialog_id"
+	DialogId uint32 `protobuf:"varint,2,opt,name=DialogId,proto3" json:"dialog_id"`
+	// @inject_tag: json:"group_id"
+	GroupId uint32 `protobuf:"varint,3,opt,name=GroupId,proto3" json:"group_id"`
+	// @inject_tag: json:"sender_id"
+	SenderId string `protobuf:"bytes,4,opt,name=SenderId,proto3" json:"sender_id"`
+	// @inject_tag: json:"receiver_id"
+	ReceiverId string `protobuf:"bytes,5,opt,name=ReceiverId,proto3" json:"receiver_id"`
+	// @inject_tag: json:"delivered_at"
+	DeliveredAt int64 `protobuf:"varint,6,opt,name=DeliveredAt,proto3" json:"delivered_at"`
+	// @inject_tag: json:"delivered_count"
+	DeliveredCount uint32 `protobuf:"varint,7,opt,name=DeliveredCount,proto3" json:"delivered_count"`
+}
+
+func (x *DeliveredMessage) Reset() {
+	*x = DeliveredMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_msg_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveredMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveredMessage) ProtoMessage() {}
+
+func (x *DeliveredMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_msg_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveredMessage.ProtoReflect.Descriptor instead.
+func (*DeliveredMessage) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_msg_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeliveredMessage) GetMsgId() uint32 {
+	if x != nil {
+		return x.MsgId
+	}
+	return 0
+}
+
+func (x *DeliveredMessage) GetDialogId() uint32 {
+	if x != nil {
+		return x.DialogId
+	}
+	return 0
+}
+
+func (x *DeliveredMessage) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *DeliveredMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *DeliveredMessage) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *DeliveredMessage) GetDeliveredAt() int64 {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return 0
+}
+
+func (x *DeliveredMessage) GetDeliveredCount() uint32 {
+	if x != nil {
+		return x.DeliveredCount
+	}
+	return 0
+}
+
+type ConfirmMessagesDeliveredResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"list"
+	List []*DeliveredMessage `protobuf:"bytes,1,rep,name=List,proto3" json:"list"`
+}
+
+func (x *ConfirmMessagesDeliveredResponse) Reset() {
+	*x = ConfirmMessagesDeliveredResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_msg_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmMessagesDeliveredResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMessagesDeliveredResponse) ProtoMessage() {}
+
+func (x *ConfirmMessagesDeliveredResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_msg_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMessagesDeliveredResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmMessagesDeliveredResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_msg_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConfirmMessagesDeliveredResponse) GetList() []*DeliveredMessage {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type SendMessageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"client_msg_id"
+	ClientMsgId string `protobuf:"bytes,1,opt,name=ClientMsgId,proto3" json:"client_msg_id"`
+	// @inject_tag: json:"sender_id"
+	SenderId string `protobuf:"bytes,2,opt,name=SenderId,proto3" json:"sender_id"`
+	// @inject_tag: json:"driver_id"
+	DriverId string `protobuf:"bytes,3,opt,name=DriverId,proto3" json:"driver_id"`
+	// @inject_tag: json:"dialog_id"
+	DialogId uint32 `protobuf:"varint,4,opt,name=DialogId,proto3" json:"dialog_id"`
+	// @inject_tag: json:"receiver_id"
+	ReceiverId string `protobuf:"bytes,5,opt,name=ReceiverId,proto3" json:"receiver_id"`
+	// @inject_tag: json:"group_id"
+	GroupId uint32 `protobuf:"varint,6,opt,name=GroupId,proto3" json:"group_id"`
+	// @inject_tag: json:"content"
+	Content string `protobuf:"bytes,7,opt,name=Content,proto3" json:"content"`
+	// @inject_tag: json:"type"
+	Type int32 `protobuf:"varint,8,opt,name=Type,proto3" json:"type"`
+	// @inject_tag: json:"reply_id"
+	ReplyId uint32 `protobuf:"varint,9,opt,name=ReplyId,proto3" json:"reply_id"`
+	// @inject_tag: json:"is_burn_after_reading"
+	IsBurnAfterReading bool `protobuf:"varint,10,opt,name=IsBurnAfterReading,proto3" json:"is_burn_after_reading"`
+	// @inject_tag: json:"at_users"
+	AtUsers []string `protobuf:"bytes,11,rep,name=AtUsers,proto3" json:"at_users"`
+	// @inject_tag: json:"at_all_user"
+	AtAllUser bool `protobuf:"varint,12,opt,name=AtAllUser,proto3" json:"at_all_user"`
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_msg_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_msg_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_msg_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SendMessageRequest) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetDialogId() uint32 {
+	if x != nil {
+		return x.DialogId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetReplyId() uint32 {
+	if x != nil {
+		return x.ReplyId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetIsBurnAfterReading() bool {
+	if x != nil {
+		return x.IsBurnAfterReading
+	}
+	return false
+}
+
+func (x *SendMessageRequest) GetAtUsers() []string {
+	if x != nil {
+		return x.AtUsers
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetAtAllUser() bool {
+	if x != nil {
+		return x.AtAllUser
+	}
+	return false
+}
+
+type SendMessageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"client_msg_id"
+	ClientMsgId string `protobuf:"bytes,1,opt,name=ClientMsgId,proto3" json:"client_msg_id"`
+	// @inject_tag: json:"msg_id"
+	MsgId uint32 `protobuf:"varint,2,opt,name=MsgId,proto3" json:"msg_id"`
+	// @inject_tag: json:"dialog_id"
+	DialogId uint32 `protobuf:"varint,3,opt,name=DialogId,proto3" json:"dialog_id"`
+	// @inject_tag: json:"seq"
+	Seq uint64 `protobuf:"varint,4,opt,name=Seq,proto3" json:"seq"`
+	// @inject_tag: json:"duplicate"
+	Duplicate bool `protobuf:"varint,5,opt,name=Duplicate,proto3" json:"duplicate"`
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_msg_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_msg_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_msg_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SendMessageResponse) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetMsgId() uint32 {
+	if x != nil {
+		return x.MsgId
+	}
+	return 0
+}
+
+func (x *SendMessageResponse) GetDialogId() uint32 {
+	if x != nil {
+		return x.DialogId
+	}
+	return 0
+}
+
+func (x *SendMessageResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SendMessageResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
 var File_api_grpc_v1_msg_proto protoreflect.FileDescriptor
 
 var file_api_grpc_v1_msg_proto_rawDesc = []byte{
@@ -730,56 +1180,129 @@ var file_api_grpc_v1_msg_proto_rawDesc = []byte{
 	0x65, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
 	0x03, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x03, 0x49, 0x44, 0x73, 0x12,
 	0x1e, 0x0a, 0x0a, 0x49, 0x73, 0x50, 0x68, 0x79, 0x73, 0x69, 0x63, 0x61, 0x6c, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x73, 0x50, 0x68, 0x79, 0x73, 0x69, 0x63, 0x61, 0x6c, 0x2a,
-	0x23, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e,
-	0x6f, 0x74, 0x52, 0x65, 0x61, 0x64, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x73, 0x52, 0x65,
-	0x61, 0x64, 0x10, 0x01, 0x2a, 0xb1, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x56,
-	0x6f, 0x69, 0x63, 0x65, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10,
-	0x03, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06,
-	0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65,
-	0x10, 0x06, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x10, 0x07, 0x12, 0x0e, 0x0a,
-	0x0a, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x10, 0x08, 0x12, 0x0d, 0x0a,
-	0x09, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09,
-	0x56, 0x69, 0x64, 0x65, 0x6f, 0x43, 0x61, 0x6c, 0x6c, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x0b, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x10, 0x0c, 0x2a, 0x42, 0x0a, 0x0b, 0x43, 0x61, 0x6c, 0x6c,
-	0x53, 0x75, 0x62, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x6f, 0x72, 0x6d, 0x61,
-	0x6c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64,
-	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0x02,
-	0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x10, 0x03, 0x32, 0xec, 0x03, 0x0a,
-	0x0a, 0x4d, 0x73, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x53,
-	0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a,
-	0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72,
-	0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x73, 0x67,
-	0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x4d,
-	0x75, 0x6c, 0x74, 0x69, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1f, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x20, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x75, 0x0a, 0x22, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79,
-	0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x12, 0x26, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76,
-	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42,
-	0x79, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x27, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42, 0x79, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x15, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79,
-	0x49, 0x64, 0x12, 0x20, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42, 0x79, 0x49, 0x44, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x49, 0x44,
-	0x73, 0x12, 0x25, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x49, 0x64,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76,
-	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42,
-	0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x38, 0x5a, 0x36, 0x67,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x73, 0x50, 0x68, 0x79, 0x73, 0x69, 0x63, 0x61, 0x6c, 0x22,
+	0x7b, 0x0a, 0x1f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x55, 0x73,
+	0x65, 0x72, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a,
+	0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x73, 0x22, 0xe4, 0x01, 0x0a,
+	0x10, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f,
+	0x67, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f,
+	0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x50, 0x0a, 0x20, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x04, 0x4c, 0x69, 0x73, 0x74, 0x22, 0xf4, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x41, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x41, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x22, 0x99, 0x01, 0x0a,
+	0x13, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73,
+	0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x53, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x44, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x44,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2a, 0x23, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x61, 0x64, 0x10,
+	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x73, 0x52, 0x65, 0x61, 0x64, 0x10, 0x01, 0x2a, 0xc1, 0x01,
+	0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
+	0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x65,
+	0x78, 0x74, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x10, 0x02, 0x12,
+	0x09, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x10,
+	0x05, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x06, 0x12, 0x09, 0x0a, 0x05, 0x56,
+	0x69, 0x64, 0x65, 0x6f, 0x10, 0x07, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x43,
+	0x61, 0x6c, 0x6c, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x43, 0x61,
+	0x6c, 0x6c, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x0b,
+	0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x10,
+	0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x10,
+	0x0d, 0x2a, 0x42, 0x0a, 0x0b, 0x43, 0x61, 0x6c, 0x6c, 0x53, 0x75, 0x62, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x69, 0x73,
+	0x73, 0x65, 0x64, 0x10, 0x03, 0x32, 0xa3, 0x05, 0x0a, 0x0a, 0x4d, 0x73, 0x67, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e,
+	0x64, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x59, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x73, 0x65,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x73, 0x65, 0x72, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d, 0x73, 0x67, 0x5f,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x22, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49,
+	0x64, 0x12, 0x26, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42, 0x79, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x73, 0x67, 0x5f,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67,
+	0x42, 0x79, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x5c, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x49, 0x64, 0x12, 0x20, 0x2e, 0x6d, 0x73,
+	0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d,
+	0x73, 0x67, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x4d, 0x73, 0x67, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x62, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x25, 0x2e, 0x6d, 0x73, 0x67,
+	0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x18, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64,
+	0x12, 0x27, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6d, 0x73, 0x67, 0x5f,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1a, 0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x6d, 0x73, 0x67, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x38, 0x5a, 0x36, 0x67,
 	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x69, 0x6d,
 	0x2f, 0x63, 0x6f, 0x73, 0x73, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74,
 	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6d, 0x73, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72,
@@ -799,38 +1322,48 @@ func file_api_grpc_v1_msg_proto_rawDescGZIP() []byte {
 }
 
 var file_api_grpc_v1_msg_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_grpc_v1_msg_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_grpc_v1_msg_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_api_grpc_v1_msg_proto_goTypes = []interface{}{
-	(ReadType)(0),                           // 0: msg_v1.ReadType
-	(MessageType)(0),                        // 1: msg_v1.MessageType
-	(CallSubType)(0),                        // 2: msg_v1.CallSubType
-	(*SendUserMsgRequest)(nil),              // 3: msg_v1.SendUserMsgRequest
-	(*SendUserMsgResponse)(nil),             // 4: msg_v1.SendUserMsgResponse
-	(*SendMultiUserMsgRequest)(nil),         // 5: msg_v1.SendMultiUserMsgRequest
-	(*SendMultiUserMsgResponse)(nil),        // 6: msg_v1.SendMultiUserMsgResponse
-	(*DeleteUserMsgByDialogIdRequest)(nil),  // 7: msg_v1.DeleteUserMsgByDialogIdRequest
-	(*DeleteUserMsgByDialogIdResponse)(nil), // 8: msg_v1.DeleteUserMsgByDialogIdResponse
-	(*DeleteUserMsgByIDRequest)(nil),        // 9: msg_v1.DeleteUserMsgByIDRequest
-	(*DeleteUserMsgByIDResponse)(nil),       // 10: msg_v1.DeleteUserMsgByIDResponse
-	(*DeleteUserMessageByIdsRequest)(nil),   // 11: msg_v1.DeleteUserMessageByIdsRequest
+	(ReadType)(0),                            // 0: msg_v1.ReadType
+	(MessageType)(0),                         // 1: msg_v1.MessageType
+	(CallSubType)(0),                         // 2: msg_v1.CallSubType
+	(*SendUserMsgRequest)(nil),               // 3: msg_v1.SendUserMsgRequest
+	(*SendUserMsgResponse)(nil),              // 4: msg_v1.SendUserMsgResponse
+	(*SendMultiUserMsgRequest)(nil),          // 5: msg_v1.SendMultiUserMsgRequest
+	(*SendMultiUserMsgResponse)(nil),         // 6: msg_v1.SendMultiUserMsgResponse
+	(*DeleteUserMsgByDialogIdRequest)(nil),   // 7: msg_v1.DeleteUserMsgByDialogIdRequest
+	(*DeleteUserMsgByDialogIdResponse)(nil),  // 8: msg_v1.DeleteUserMsgByDialogIdResponse
+	(*DeleteUserMsgByIDRequest)(nil),         // 9: msg_v1.DeleteUserMsgByIDRequest
+	(*DeleteUserMsgByIDResponse)(nil),        // 10: msg_v1.DeleteUserMsgByIDResponse
+	(*DeleteUserMessageByIdsRequest)(nil),    // 11: msg_v1.DeleteUserMessageByIdsRequest
+	(*ConfirmMessagesDeliveredRequest)(nil),  // 12: msg_v1.ConfirmMessagesDeliveredRequest
+	(*DeliveredMessage)(nil),                 // 13: msg_v1.DeliveredMessage
+	(*ConfirmMessagesDeliveredResponse)(nil), // 14: msg_v1.ConfirmMessagesDeliveredResponse
+	(*SendMessageRequest)(nil),               // 15: msg_v1.SendMessageRequest
+	(*SendMessageResponse)(nil),              // 16: msg_v1.SendMessageResponse
 }
 var file_api_grpc_v1_msg_proto_depIdxs = []int32{
 	3,  // 0: msg_v1.SendMultiUserMsgRequest.MsgList:type_name -> msg_v1.SendUserMsgRequest
-	3,  // 1: msg_v1.MsgService.SendUserMessage:input_type -> msg_v1.SendUserMsgRequest
-	5,  // 2: msg_v1.MsgService.SendMultiUserMessage:input_type -> msg_v1.SendMultiUserMsgRequest
-	7,  // 3: msg_v1.MsgService.ConfirmDeleteUserMessageByDialogId:input_type -> msg_v1.DeleteUserMsgByDialogIdRequest
-	9,  // 4: msg_v1.MsgService.DeleteUserMessageById:input_type -> msg_v1.DeleteUserMsgByIDRequest
-	11, // 5: msg_v1.MsgService.DeleteUserMessageByIDs:input_type -> msg_v1.DeleteUserMessageByIdsRequest
-	4,  // 6: msg_v1.MsgService.SendUserMessage:output_type -> msg_v1.SendUserMsgResponse
-	6,  // 7: msg_v1.MsgService.SendMultiUserMessage:output_type -> msg_v1.SendMultiUserMsgResponse
-	8,  // 8: msg_v1.MsgService.ConfirmDeleteUserMessageByDialogId:output_type -> msg_v1.DeleteUserMsgByDialogIdResponse
-	10, // 9: msg_v1.MsgService.DeleteUserMessageById:output_type -> msg_v1.DeleteUserMsgByIDResponse
-	10, // 10: msg_v1.MsgService.DeleteUserMessageByIDs:output_type -> msg_v1.DeleteUserMsgByIDResponse
-	6,  // [6:11] is the sub-list for method output_type
-	1,  // [1:6] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	13, // 1: msg_v1.ConfirmMessagesDeliveredResponse.List:type_name -> msg_v1.DeliveredMessage
+	3,  // 2: msg_v1.MsgService.SendUserMessage:input_type -> msg_v1.SendUserMsgRequest
+	5,  // 3: msg_v1.MsgService.SendMultiUserMessage:input_type -> msg_v1.SendMultiUserMsgRequest
+	7,  // 4: msg_v1.MsgService.ConfirmDeleteUserMessageByDialogId:input_type -> msg_v1.DeleteUserMsgByDialogIdRequest
+	9,  // 5: msg_v1.MsgService.DeleteUserMessageById:input_type -> msg_v1.DeleteUserMsgByIDRequest
+	11, // 6: msg_v1.MsgService.DeleteUserMessageByIDs:input_type -> msg_v1.DeleteUserMessageByIdsRequest
+	12, // 7: msg_v1.MsgService.ConfirmMessagesDelivered:input_type -> msg_v1.ConfirmMessagesDeliveredRequest
+	15, // 8: msg_v1.MsgService.SendMessage:input_type -> msg_v1.SendMessageRequest
+	4,  // 9: msg_v1.MsgService.SendUserMessage:output_type -> msg_v1.SendUserMsgResponse
+	6,  // 10: msg_v1.MsgService.SendMultiUserMessage:output_type -> msg_v1.SendMultiUserMsgResponse
+	8,  // 11: msg_v1.MsgService.ConfirmDeleteUserMessageByDialogId:output_type -> msg_v1.DeleteUserMsgByDialogIdResponse
+	10, // 12: msg_v1.MsgService.DeleteUserMessageById:output_type -> msg_v1.DeleteUserMsgByIDResponse
+	10, // 13: msg_v1.MsgService.DeleteUserMessageByIDs:output_type -> msg_v1.DeleteUserMsgByIDResponse
+	14, // 14: msg_v1.MsgService.ConfirmMessagesDelivered:output_type -> msg_v1.ConfirmMessagesDeliveredResponse
+	16, // 15: msg_v1.MsgService.SendMessage:output_type -> msg_v1.SendMessageResponse
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_v1_msg_proto_init() }
@@ -947,6 +1480,66 @@ func file_api_grpc_v1_msg_proto_init() {
 				return nil
 			}
 		}
+		file_api_grpc_v1_msg_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmMessagesDeliveredRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_msg_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveredMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_msg_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmMessagesDeliveredResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_msg_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendMessageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_msg_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendMessageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -954,7 +1547,7 @@ func file_api_grpc_v1_msg_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_grpc_v1_msg_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   9,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,8 @@ const (
 	MsgService_ConfirmDeleteUserMessageByDialogId_FullMethodName = "/msg_v1.MsgService/ConfirmDeleteUserMessageByDialogId"
 	MsgService_DeleteUserMessageById_FullMethodName              = "/msg_v1.MsgService/DeleteUserMessageById"
 	MsgService_DeleteUserMessageByIDs_FullMethodName             = "/msg_v1.MsgService/DeleteUserMessageByIDs"
+	MsgService_ConfirmMessagesDelivered_FullMethodName           = "/msg_v1.MsgService/ConfirmMessagesDelivered"
+	MsgService_SendMessage_FullMethodName                        = "/msg_v1.MsgService/SendMessage"
 )
 
 // MsgServiceClient is the client API for MsgService service.
@@ -40,6 +42,10 @@ type MsgServiceClient interface {
 	DeleteUserMessageById(ctx context.Context, in *DeleteUserMsgByIDRequest, opts ...grpc.CallOption) (*DeleteUserMsgByIDResponse, error)
 	// 根据消息ids删除私聊消息
 	DeleteUserMessageByIDs(ctx context.Context, in *DeleteUserMessageByIdsRequest, opts ...grpc.CallOption) (*DeleteUserMsgByIDResponse, error)
+	// 确认消息已送达用户设备，返回本次新送达的消息
+	ConfirmMessagesDelivered(ctx context.Context, in *ConfirmMessagesDeliveredRequest, opts ...grpc.CallOption) (*ConfirmMessagesDeliveredResponse, error)
+	// 客户端通过长连接发送私聊或群聊消息，相同的客户端消息id只会发送一次
+	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
 }
 
 type msgServiceClient struct {
@@ -95,6 +101,24 @@ func (c *msgServiceClient) DeleteUserMessageByIDs(ctx context.Context, in *Delet
 	return out, nil
 }
 
+func (c *msgServiceClient) ConfirmMessagesDelivered(ctx context.Context, in *ConfirmMessagesDeliveredRequest, opts ...grpc.CallOption) (*ConfirmMessagesDeliveredResponse, error) {
+	out := new(ConfirmMessagesDeliveredResponse)
+	err := c.cc.Invoke(ctx, MsgService_ConfirmMessagesDelivered_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error) {
+	out := new(SendMessageResponse)
+	err := c.cc.Invoke(ctx, MsgService_SendMessage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MsgServiceServer is the server API for MsgService service.
 // All implementations should embed UnimplementedMsgServiceServer
 // for forward compatibility
@@ -109,6 +133,10 @@ type MsgServiceServer interface {
 	DeleteUserMessageById(context.Context, *DeleteUserMsgByIDRequest) (*DeleteUserMsgByIDResponse, error)
 	// 根据消息ids删除私聊消息
 	DeleteUserMessageByIDs(context.Context, *DeleteUserMessageByIdsRequest) (*DeleteUserMsgByIDResponse, error)
+	// 确认消息已送达用户设备，返回本次新送达的消息
+	ConfirmMessagesDelivered(context.Context, *ConfirmMessagesDeliveredRequest) (*ConfirmMessagesDeliveredResponse, error)
+	// 客户端通过长连接发送私聊或群聊消息，相同的客户端消息id只会发送一次
+	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
 }
 
 // UnimplementedMsgServiceServer should be embedded to have forward compatible implementations.
@@ -130,6 +158,12 @@ func (UnimplementedMsgServiceServer) DeleteUserMessageById(context.Context, *Del
 func (UnimplementedMsgServiceServer) DeleteUserMessageByIDs(context.Context, *DeleteUserMessageByIdsRequest) (*DeleteUserMsgByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserMessageByIDs not implemented")
 }
+func (UnimplementedMsgServiceServer) ConfirmMessagesDelivered(context.Context, *ConfirmMessagesDeliveredRequest) (*ConfirmMessagesDeliveredResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmMessagesDelivered not implemented")
+}
+func (UnimplementedMsgServiceServer) SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
 
 // UnsafeMsgServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to MsgServiceServer will
@@ -232,6 +266,42 @@ func _MsgService_DeleteUserMessageByIDs_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MsgService_ConfirmMessagesDelivered_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmMessagesDeliveredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServiceServer).ConfirmMessagesDelivered(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgService_ConfirmMessagesDelivered_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServiceServer).ConfirmMessagesDelivered(ctx, req.(*ConfirmMessagesDeliveredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MsgService_ServiceDesc is the grpc.ServiceDesc for MsgService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +329,14 @@ var MsgService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserMessageByIDs",
 			Handler:    _MsgService_DeleteUserMessageByIDs_Handler,
 		},
+		{
+			MethodName: "ConfirmMessagesDelivered",
+			Handler:    _MsgService_ConfirmMessagesDelivered_Handler,
+		},
+		{
+			MethodName: "SendMessage",
+			Handler:    _MsgService_SendMessage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/v1/msg.proto",
@@ -32,12 +32,39 @@ type ServerInterface interface {
 	// 获取用户标记消息列表
 	// (GET /api/v1/msg/dialog/user/{dialog_id}/label)
 	GetUserLabelMsgList(c *gin.Context, dialogId int)
+	// 按序号区间获取对话消息
+	// (GET /api/v1/msg/dialog/{dialog_id}/seq)
+	GetDialogMsgBySeq(c *gin.Context, dialogId int, params GetDialogMsgBySeqParams)
+	// 增量同步对话消息
+	// (GET /api/v1/msg/dialog/{dialog_id}/sync)
+	SyncDialogMsg(c *gin.Context, dialogId int, params SyncDialogMsgParams)
+	// 获取草稿列表
+	// (GET /api/v1/msg/draft)
+	ListDraft(c *gin.Context)
+	// 清除对话草稿
+	// (DELETE /api/v1/msg/draft/{dialog_id})
+	ClearDraft(c *gin.Context, dialogId int)
+	// 获取对话草稿
+	// (GET /api/v1/msg/draft/{dialog_id})
+	GetDraft(c *gin.Context, dialogId int)
+	// 保存对话草稿
+	// (PUT /api/v1/msg/draft/{dialog_id})
+	SaveDraft(c *gin.Context, dialogId int)
+	// 转发消息
+	// (POST /api/v1/msg/forward)
+	ForwardMsg(c *gin.Context)
 	// 获取群组消息列表
 	// (GET /api/v1/msg/group/list)
 	GetGroupMsgList(c *gin.Context, params GetGroupMsgListParams)
 	// 设置群组消息已读
 	// (PUT /api/v1/msg/group/read)
 	GroupMessageRead(c *gin.Context)
+	// 获取群聊修订记录可见策略
+	// (GET /api/v1/msg/group/revision_policy/{group_id})
+	GetGroupRevisionPolicy(c *gin.Context, groupId int)
+	// 设置群聊修订记录可见策略
+	// (PUT /api/v1/msg/group/revision_policy/{group_id})
+	SetGroupRevisionPolicy(c *gin.Context, groupId int)
 	// 发送群组消息
 	// (POST /api/v1/msg/group/send)
 	SendGroupMsg(c *gin.Context)
@@ -50,9 +77,39 @@ type ServerInterface interface {
 	// 标记群组消息
 	// (POST /api/v1/msg/group/{id}/label)
 	LabelGroupMsg(c *gin.Context, id int)
+	// 取消群组消息表情回应
+	// (DELETE /api/v1/msg/group/{id}/reactions)
+	RemoveGroupMsgReaction(c *gin.Context, id int, params RemoveGroupMsgReactionParams)
+	// 添加群组消息表情回应
+	// (POST /api/v1/msg/group/{id}/reactions)
+	AddGroupMsgReaction(c *gin.Context, id int)
 	// 获取群组消息阅读者
 	// (GET /api/v1/msg/group/{id}/read)
 	GetGroupMessageReaders(c *gin.Context, id int, params GetGroupMessageReadersParams)
+	// 获取群聊消息修订记录
+	// (GET /api/v1/msg/group/{id}/revisions)
+	GetGroupMsgRevisions(c *gin.Context, id int)
+	// 获取群组消息话题回复列表
+	// (GET /api/v1/msg/group/{id}/thread)
+	GetGroupThreadMsgList(c *gin.Context, id int, params GetGroupThreadMsgListParams)
+	// 设置群组消息话题已读
+	// (PUT /api/v1/msg/group/{id}/thread/read)
+	ReadGroupThread(c *gin.Context, id int)
+	// 获取定时消息列表
+	// (GET /api/v1/msg/scheduled)
+	ListScheduledMsg(c *gin.Context, params ListScheduledMsgParams)
+	// 创建定时消息
+	// (POST /api/v1/msg/scheduled)
+	CreateScheduledMsg(c *gin.Context)
+	// 取消定时消息
+	// (DELETE /api/v1/msg/scheduled/{id})
+	CancelScheduledMsg(c *gin.Context, id int)
+	// 编辑定时消息
+	// (PUT /api/v1/msg/scheduled/{id})
+	EditScheduledMsg(c *gin.Context, id int)
+	// 搜索消息
+	// (GET /api/v1/msg/search)
+	SearchMsg(c *gin.Context, params SearchMsgParams)
 	// 获取私信列表
 	// (GET /api/v1/msg/user/list)
 	GetUserMsgList(c *gin.Context, params GetUserMsgListParams)
@@ -71,6 +128,21 @@ type ServerInterface interface {
 	// 标记用户消息
 	// (POST /api/v1/msg/user/{id}/label)
 	LabelUserMsg(c *gin.Context, id int)
+	// 取消用户消息表情回应
+	// (DELETE /api/v1/msg/user/{id}/reactions)
+	RemoveUserMsgReaction(c *gin.Context, id int, params RemoveUserMsgReactionParams)
+	// 添加用户消息表情回应
+	// (POST /api/v1/msg/user/{id}/reactions)
+	AddUserMsgReaction(c *gin.Context, id int)
+	// 获取用户消息修订记录
+	// (GET /api/v1/msg/user/{id}/revisions)
+	GetUserMsgRevisions(c *gin.Context, id int)
+	// 获取用户消息话题回复列表
+	// (GET /api/v1/msg/user/{id}/thread)
+	GetUserThreadMsgList(c *gin.Context, id int, params GetUserThreadMsgListParams)
+	// 设置用户消息话题已读
+	// (PUT /api/v1/msg/user/{id}/thread/read)
+	ReadUserThread(c *gin.Context, id int)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -191,6 +263,204 @@ func (siw *ServerInterfaceWrapper) GetUserLabelMsgList(c *gin.Context) {
 	siw.Handler.GetUserLabelMsgList(c, dialogId)
 }
 
+// GetDialogMsgBySeq operation middleware
+func (siw *ServerInterfaceWrapper) GetDialogMsgBySeq(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "dialog_id" -------------
+	var dialogId int
+
+	err = runtime.BindStyledParameter("simple", false, "dialog_id", c.Param("dialog_id"), &dialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDialogMsgBySeqParams
+
+	// ------------- Required query parameter "start_seq" -------------
+
+	if paramValue := c.Query("start_seq"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument start_seq is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "start_seq", c.Request.URL.Query(), &params.StartSeq)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_seq: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "end_seq" -------------
+
+	if paramValue := c.Query("end_seq"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument end_seq is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "end_seq", c.Request.URL.Query(), &params.EndSeq)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_seq: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetDialogMsgBySeq(c, dialogId, params)
+}
+
+// SyncDialogMsg operation middleware
+func (siw *ServerInterfaceWrapper) SyncDialogMsg(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "dialog_id" -------------
+	var dialogId int
+
+	err = runtime.BindStyledParameter("simple", false, "dialog_id", c.Param("dialog_id"), &dialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SyncDialogMsgParams
+
+	// ------------- Optional query parameter "seq" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "seq", c.Request.URL.Query(), &params.Seq)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter seq: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SyncDialogMsg(c, dialogId, params)
+}
+
+// ListDraft operation middleware
+func (siw *ServerInterfaceWrapper) ListDraft(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListDraft(c)
+}
+
+// ClearDraft operation middleware
+func (siw *ServerInterfaceWrapper) ClearDraft(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "dialog_id" -------------
+	var dialogId int
+
+	err = runtime.BindStyledParameter("simple", false, "dialog_id", c.Param("dialog_id"), &dialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ClearDraft(c, dialogId)
+}
+
+// GetDraft operation middleware
+func (siw *ServerInterfaceWrapper) GetDraft(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "dialog_id" -------------
+	var dialogId int
+
+	err = runtime.BindStyledParameter("simple", false, "dialog_id", c.Param("dialog_id"), &dialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetDraft(c, dialogId)
+}
+
+// SaveDraft operation middleware
+func (siw *ServerInterfaceWrapper) SaveDraft(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "dialog_id" -------------
+	var dialogId int
+
+	err = runtime.BindStyledParameter("simple", false, "dialog_id", c.Param("dialog_id"), &dialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SaveDraft(c, dialogId)
+}
+
+// ForwardMsg operation middleware
+func (siw *ServerInterfaceWrapper) ForwardMsg(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ForwardMsg(c)
+}
+
 // GetGroupMsgList operation middleware
 func (siw *ServerInterfaceWrapper) GetGroupMsgList(c *gin.Context) {
 
@@ -234,15 +504,469 @@ func (siw *ServerInterfaceWrapper) GetGroupMsgList(c *gin.Context) {
 
 	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "content" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "content", c.Request.URL.Query(), &params.Content)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter content: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page_num" -------------
+
+	if paramValue := c.Query("page_num"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_num is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_num", c.Request.URL.Query(), &params.PageNum)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_num: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page_size" -------------
+
+	if paramValue := c.Query("page_size"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_size is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_size", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupMsgList(c, params)
+}
+
+// GroupMessageRead operation middleware
+func (siw *ServerInterfaceWrapper) GroupMessageRead(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GroupMessageRead(c)
+}
+
+// GetGroupRevisionPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupRevisionPolicy(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group_id" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameter("simple", false, "group_id", c.Param("group_id"), &groupId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupRevisionPolicy(c, groupId)
+}
+
+// SetGroupRevisionPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetGroupRevisionPolicy(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group_id" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameter("simple", false, "group_id", c.Param("group_id"), &groupId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetGroupRevisionPolicy(c, groupId)
+}
+
+// SendGroupMsg operation middleware
+func (siw *ServerInterfaceWrapper) SendGroupMsg(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SendGroupMsg(c)
+}
+
+// RecallGroupMsg operation middleware
+func (siw *ServerInterfaceWrapper) RecallGroupMsg(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RecallGroupMsg(c, id)
+}
+
+// EditGroupMsg operation middleware
+func (siw *ServerInterfaceWrapper) EditGroupMsg(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EditGroupMsg(c, id)
+}
+
+// LabelGroupMsg operation middleware
+func (siw *ServerInterfaceWrapper) LabelGroupMsg(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LabelGroupMsg(c, id)
+}
+
+// RemoveGroupMsgReaction operation middleware
+func (siw *ServerInterfaceWrapper) RemoveGroupMsgReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveGroupMsgReactionParams
+
+	// ------------- Required query parameter "emoji" -------------
+
+	if paramValue := c.Query("emoji"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument emoji is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "emoji", c.Request.URL.Query(), &params.Emoji)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter emoji: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveGroupMsgReaction(c, id, params)
+}
+
+// AddGroupMsgReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddGroupMsgReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddGroupMsgReaction(c, id)
+}
+
+// GetGroupMessageReaders operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupMessageReaders(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGroupMessageReadersParams
+
+	// ------------- Required query parameter "dialog_id" -------------
+
+	if paramValue := c.Query("dialog_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument dialog_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "dialog_id", c.Request.URL.Query(), &params.DialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "group_id" -------------
+
+	if paramValue := c.Query("group_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument group_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "group_id", c.Request.URL.Query(), &params.GroupId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupMessageReaders(c, id, params)
+}
+
+// GetGroupMsgRevisions operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupMsgRevisions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupMsgRevisions(c, id)
+}
+
+// GetGroupThreadMsgList operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupThreadMsgList(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGroupThreadMsgListParams
+
+	// ------------- Required query parameter "page_num" -------------
+
+	if paramValue := c.Query("page_num"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_num is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_num", c.Request.URL.Query(), &params.PageNum)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_num: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page_size" -------------
+
+	if paramValue := c.Query("page_size"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_size is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_size", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupThreadMsgList(c, id, params)
+}
+
+// ReadGroupThread operation middleware
+func (siw *ServerInterfaceWrapper) ReadGroupThread(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReadGroupThread(c, id)
+}
+
+// ListScheduledMsg operation middleware
+func (siw *ServerInterfaceWrapper) ListScheduledMsg(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListScheduledMsgParams
+
+	// ------------- Optional query parameter "dialog_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dialog_id", c.Request.URL.Query(), &params.DialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "content" -------------
+	// ------------- Optional query parameter "status" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "content", c.Request.URL.Query(), &params.Content)
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter content: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -283,24 +1007,11 @@ func (siw *ServerInterfaceWrapper) GetGroupMsgList(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetGroupMsgList(c, params)
-}
-
-// GroupMessageRead operation middleware
-func (siw *ServerInterfaceWrapper) GroupMessageRead(c *gin.Context) {
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.GroupMessageRead(c)
+	siw.Handler.ListScheduledMsg(c, params)
 }
 
-// SendGroupMsg operation middleware
-func (siw *ServerInterfaceWrapper) SendGroupMsg(c *gin.Context) {
+// CreateScheduledMsg operation middleware
+func (siw *ServerInterfaceWrapper) CreateScheduledMsg(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -309,11 +1020,11 @@ func (siw *ServerInterfaceWrapper) SendGroupMsg(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.SendGroupMsg(c)
+	siw.Handler.CreateScheduledMsg(c)
 }
 
-// RecallGroupMsg operation middleware
-func (siw *ServerInterfaceWrapper) RecallGroupMsg(c *gin.Context) {
+// CancelScheduledMsg operation middleware
+func (siw *ServerInterfaceWrapper) CancelScheduledMsg(c *gin.Context) {
 
 	var err error
 
@@ -333,11 +1044,11 @@ func (siw *ServerInterfaceWrapper) RecallGroupMsg(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RecallGroupMsg(c, id)
+	siw.Handler.CancelScheduledMsg(c, id)
 }
 
-// EditGroupMsg operation middleware
-func (siw *ServerInterfaceWrapper) EditGroupMsg(c *gin.Context) {
+// EditScheduledMsg operation middleware
+func (siw *ServerInterfaceWrapper) EditScheduledMsg(c *gin.Context) {
 
 	var err error
 
@@ -357,77 +1068,91 @@ func (siw *ServerInterfaceWrapper) EditGroupMsg(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.EditGroupMsg(c, id)
+	siw.Handler.EditScheduledMsg(c, id)
 }
 
-// LabelGroupMsg operation middleware
-func (siw *ServerInterfaceWrapper) LabelGroupMsg(c *gin.Context) {
+// SearchMsg operation middleware
+func (siw *ServerInterfaceWrapper) SearchMsg(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id int
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchMsgParams
 
-	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+	// ------------- Required query parameter "keyword" -------------
+
+	if paramValue := c.Query("keyword"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument keyword is required, but not found"), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, true, "keyword", c.Request.URL.Query(), &params.Keyword)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter keyword: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.LabelGroupMsg(c, id)
-}
+	// ------------- Optional query parameter "dialog_id" -------------
 
-// GetGroupMessageReaders operation middleware
-func (siw *ServerInterfaceWrapper) GetGroupMessageReaders(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "dialog_id", c.Request.URL.Query(), &params.DialogId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "user_id" -------------
 
-	// ------------- Path parameter "id" -------------
-	var id int
+	err = runtime.BindQueryParameter("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	// ------------- Optional query parameter "start_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_at", c.Request.URL.Query(), &params.StartAt)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_at: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetGroupMessageReadersParams
+	// ------------- Optional query parameter "end_at" -------------
 
-	// ------------- Required query parameter "dialog_id" -------------
+	err = runtime.BindQueryParameter("form", true, false, "end_at", c.Request.URL.Query(), &params.EndAt)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_at: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	if paramValue := c.Query("dialog_id"); paramValue != "" {
+	// ------------- Required query parameter "page_num" -------------
+
+	if paramValue := c.Query("page_num"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument dialog_id is required, but not found"), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_num is required, but not found"), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "dialog_id", c.Request.URL.Query(), &params.DialogId)
+	err = runtime.BindQueryParameter("form", true, true, "page_num", c.Request.URL.Query(), &params.PageNum)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dialog_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_num: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Required query parameter "group_id" -------------
+	// ------------- Required query parameter "page_size" -------------
 
-	if paramValue := c.Query("group_id"); paramValue != "" {
+	if paramValue := c.Query("page_size"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument group_id is required, but not found"), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_size is required, but not found"), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "group_id", c.Request.URL.Query(), &params.GroupId)
+	err = runtime.BindQueryParameter("form", true, true, "page_size", c.Request.URL.Query(), &params.PageSize)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -438,7 +1163,7 @@ func (siw *ServerInterfaceWrapper) GetGroupMessageReaders(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetGroupMessageReaders(c, id, params)
+	siw.Handler.SearchMsg(c, params)
 }
 
 // GetUserMsgList operation middleware
@@ -549,6 +1274,22 @@ func (siw *ServerInterfaceWrapper) GetUserMsgList(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -657,6 +1398,177 @@ func (siw *ServerInterfaceWrapper) LabelUserMsg(c *gin.Context) {
 	siw.Handler.LabelUserMsg(c, id)
 }
 
+// RemoveUserMsgReaction operation middleware
+func (siw *ServerInterfaceWrapper) RemoveUserMsgReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveUserMsgReactionParams
+
+	// ------------- Required query parameter "emoji" -------------
+
+	if paramValue := c.Query("emoji"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument emoji is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "emoji", c.Request.URL.Query(), &params.Emoji)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter emoji: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveUserMsgReaction(c, id, params)
+}
+
+// AddUserMsgReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddUserMsgReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddUserMsgReaction(c, id)
+}
+
+// GetUserMsgRevisions operation middleware
+func (siw *ServerInterfaceWrapper) GetUserMsgRevisions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserMsgRevisions(c, id)
+}
+
+// GetUserThreadMsgList operation middleware
+func (siw *ServerInterfaceWrapper) GetUserThreadMsgList(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserThreadMsgListParams
+
+	// ------------- Required query parameter "page_num" -------------
+
+	if paramValue := c.Query("page_num"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_num is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_num", c.Request.URL.Query(), &params.PageNum)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_num: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "page_size" -------------
+
+	if paramValue := c.Query("page_size"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page_size is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page_size", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page_size: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserThreadMsgList(c, id, params)
+}
+
+// ReadUserThread operation middleware
+func (siw *ServerInterfaceWrapper) ReadUserThread(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReadUserThread(c, id)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -688,55 +1600,131 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/api/v1/msg/dialog/group/:dialog_id/label", wrapper.GetGroupLabelMsgList)
 	router.GET(options.BaseURL+"/api/v1/msg/dialog/list", wrapper.GetUserDialogList)
 	router.GET(options.BaseURL+"/api/v1/msg/dialog/user/:dialog_id/label", wrapper.GetUserLabelMsgList)
+	router.GET(options.BaseURL+"/api/v1/msg/dialog/:dialog_id/seq", wrapper.GetDialogMsgBySeq)
+	router.GET(options.BaseURL+"/api/v1/msg/dialog/:dialog_id/sync", wrapper.SyncDialogMsg)
+	router.GET(options.BaseURL+"/api/v1/msg/draft", wrapper.ListDraft)
+	router.DELETE(options.BaseURL+"/api/v1/msg/draft/:dialog_id", wrapper.ClearDraft)
+	router.GET(options.BaseURL+"/api/v1/msg/draft/:dialog_id", wrapper.GetDraft)
+	router.PUT(options.BaseURL+"/api/v1/msg/draft/:dialog_id", wrapper.SaveDraft)
+	router.POST(options.BaseURL+"/api/v1/msg/forward", wrapper.ForwardMsg)
 	router.GET(options.BaseURL+"/api/v1/msg/group/list", wrapper.GetGroupMsgList)
 	router.PUT(options.BaseURL+"/api/v1/msg/group/read", wrapper.GroupMessageRead)
+	router.GET(options.BaseURL+"/api/v1/msg/group/revision_policy/:group_id", wrapper.GetGroupRevisionPolicy)
+	router.PUT(options.BaseURL+"/api/v1/msg/group/revision_policy/:group_id", wrapper.SetGroupRevisionPolicy)
 	router.POST(options.BaseURL+"/api/v1/msg/group/send", wrapper.SendGroupMsg)
 	router.DELETE(options.BaseURL+"/api/v1/msg/group/:id", wrapper.RecallGroupMsg)
 	router.PUT(options.BaseURL+"/api/v1/msg/group/:id", wrapper.EditGroupMsg)
 	router.POST(options.BaseURL+"/api/v1/msg/group/:id/label", wrapper.LabelGroupMsg)
+	router.DELETE(options.BaseURL+"/api/v1/msg/group/:id/reactions", wrapper.RemoveGroupMsgReaction)
+	router.POST(options.BaseURL+"/api/v1/msg/group/:id/reactions", wrapper.AddGroupMsgReaction)
 	router.GET(options.BaseURL+"/api/v1/msg/group/:id/read", wrapper.GetGroupMessageReaders)
+	router.GET(options.BaseURL+"/api/v1/msg/group/:id/revisions", wrapper.GetGroupMsgRevisions)
+	router.GET(options.BaseURL+"/api/v1/msg/group/:id/thread", wrapper.GetGroupThreadMsgList)
+	router.PUT(options.BaseURL+"/api/v1/msg/group/:id/thread/read", wrapper.ReadGroupThread)
+	router.GET(options.BaseURL+"/api/v1/msg/scheduled", wrapper.ListScheduledMsg)
+	router.POST(options.BaseURL+"/api/v1/msg/scheduled", wrapper.CreateScheduledMsg)
+	router.DELETE(options.BaseURL+"/api/v1/msg/scheduled/:id", wrapper.CancelScheduledMsg)
+	router.PUT(options.BaseURL+"/api/v1/msg/scheduled/:id", wrapper.EditScheduledMsg)
+	router.GET(options.BaseURL+"/api/v1/msg/search", wrapper.SearchMsg)
 	router.GET(options.BaseURL+"/api/v1/msg/user/list", wrapper.GetUserMsgList)
 	router.PUT(options.BaseURL+"/api/v1/msg/user/read", wrapper.ReadUserMsgs)
 	router.POST(options.BaseURL+"/api/v1/msg/user/send", wrapper.SendUserMsg)
 	router.DELETE(options.BaseURL+"/api/v1/msg/user/:id", wrapper.RecallUserMsg)
 	router.PUT(options.BaseURL+"/api/v1/msg/user/:id", wrapper.EditUserMsg)
 	router.POST(options.BaseURL+"/api/v1/msg/user/:id/label", wrapper.LabelUserMsg)
+	router.DELETE(options.BaseURL+"/api/v1/msg/user/:id/reactions", wrapper.RemoveUserMsgReaction)
+	router.POST(options.BaseURL+"/api/v1/msg/user/:id/reactions", wrapper.AddUserMsgReaction)
+	router.GET(options.BaseURL+"/api/v1/msg/user/:id/revisions", wrapper.GetUserMsgRevisions)
+	router.GET(options.BaseURL+"/api/v1/msg/user/:id/thread", wrapper.GetUserThreadMsgList)
+	router.PUT(options.BaseURL+"/api/v1/msg/user/:id/thread/read", wrapper.ReadUserThread)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbW2/bthf/Kgb//0eljtO7AT902FAUaIAhw56KwGBsRmWnW0k6mBcYaIG2ybr1kq3o",
-	"hvQ+BGge2nhbgXZb1vTLRLbzLQZSkiPbki3Lohy3frNk6Yg8N/5+PIeroGTqlmkgg1GQXwW0dBnpUPw8",
-	"t8wQmacq/20R00KEYST+KWOomWoRl/kFq1oI5AE2GFIRAQr4dkY1Z/jdGfoNtmZMi2HTgNqMZfJnCMgz",
-	"UkH8MVPHDOkWq4L8MtQoqilApxLE1hRPmrl0BZUYqCngizJm54lZseapuoCuVhBlvfMsmQZDBvMNhzKC",
-	"DTWBSTryUpnm11SY8SOe5XnEPhcu6bnsAqKWaVCUqusiSqHqfIX/KX78n6BlkAf/yx5GWdYNsey88wI4",
-	"nBEkBFbFtcmglo7iRBBchEtIm6fqRUxZuO407LjPSLOLOIW+w3VlLyBYRoSGDxiuQAZJ0o5tQB0lLbNC",
-	"Eel0ygTE9lXhIGOXKoQggxUtbsSkY0XlQygOHTF+4485bHhSdXLOGNU4VECGjDgoPgM+lbJqJychuavr",
-	"GL1AhmncjDR0hApthAVooAb9Id2bwFkRalqRj8U3wSXT1BA0RpwgZEJu59w6k29MVwn5oCScJQ/ROFla",
-	"gmBMi0sVYhQhh2tFgmAZGz1gMRkrY1rUeC6RIZgPPHG5UhiQAvhYi5DJkVsyK4YM0ZZWlaELigwpuuBy",
-	"OYwzls1BefIr8egF/mQ7ychI4KlAyi5IHko0ZW8bBKZy7wPJ5nInnLSk00qQegUU6tRxiH4l5brQQfkW",
-	"+6MwpimKmKIIdQogJAEISdt5MpFJCeGVWOvxxCKPctJpISak6UnPIeI5XHAJNJ3ChkRXwz6bEWY5+Sgu",
-	"QwZ9Qg/HoVNVPgTlvjewmjOFAVMYcPTIaEolss4ICUsN0jYfuPLcTBBpjzZsCmOqZcoLi7S818NCiSME",
-	"6YFRRrREsHgT5EHj7Xrjer35x6799IdMrtB4uNZ4/Mq5mZkrtOqvD569cS+PF+xHe83v19zLE4XG87XG",
-	"m+3MyUJzb8u++creuJ05xSXs7751nzldaL28dfDbhnt5poB08wq2Hz21t+5kzrriD65ttupPMrlZ92Hv",
-	"Oldo/LRlP3rqvpybK9j3HvIL8VmgAGRUdJC/lFPmlOPKCeWkcko5rZxRziq5WSWXU3Jzi0oaaaAdQxOb",
-	"BVyYOa33DjZ41Dqlm+HkaNAVXiIIMiSD/8jLz65kGT7gipZCql3ZFUPi/nziiE6UWcWSSLHWuYonswxq",
-	"kDKvpjlMudi0ZDhtwjkgtEjtr8n2xH1QHQ7ryKwkP9+PA5tNy5STWaaUCICn+4zj2mdMiT7zW97QOulI",
-	"88H2/j93mzsvmvdvudRk80bm3JcXgAIYZhpqUxb35goi1Hk1d2z22CyfgmkhA1oY5MFxcUsBFmSXRXbO",
-	"QgtnV3JZnapZJ89lRTISedx0CDDP5pAP50IZ5MF5xLwmWAq4hwmi/JlZrnbRY2hZGi6JF7NXKB+R1/wd",
-	"udmn3SDe2+lTcz6NCSq313Xigk8hd252dqgB9W0LDG3/FQPpIpA/39l//7ixft++/UwwDFrRdUiqIA9a",
-	"d99xxvbjmr2zadf/btWf7P/12t592/rwrLl5wzEjtytUKchfEjubi1xCgJUENsquttemWrad3lUUbLae",
-	"RlzhCATqiImNxUurAPMJcOcAHivxrX7dGld82uve1K4tyjVHeFNxHIs097aauzcaz9daO787VrDXf2m9",
-	"2I5oC69LMEzxnRQpROtXK4hUD9VuQRUVOZ0fSutKH2EUf4eOlA3D+kXjGPDBdmP9nRNSQ5mOQ+Thoqi7",
-	"eXSCgyi0Dza+CYaPISeRDQohf0t5tACKqfKQCHLRaJxXPRYW8K5XuAl7VTwW55ueh8T45ieQeALPJwT4",
-	"vLtcD1w3hvZ2jzBZlSBv7+p2GwFkRT3q4G+qSxlaxc05O3vN9zsd+n/3Z6u+G03/nAyEI1x/JUuS8oPK",
-	"ySkrPrBeN5wR7HsbB9eu+40QTf2ruFxzmI6GGOo1wAIqQU3zmWDwAnuUVtaY2nRqO321qQRnDP9RU3na",
-	"Sj4Kgo7ITkb6af77sLW3Ec/zDyFmcPrxteNOljlD+4gnw6YOdI1pU29F749fO06VSjGtkgYYbtdlUk25",
-	"0Q5w9j/AG7iPFG+zQHjIwa83W/Xd1rWbg1xF8Mwo+wRj5Dix2cYIDGcEojICJTsKHCdEGmWQsCJksebl",
-	"bsoftT2GRKjWy+v7H15EI1ki2PpyLH9bsCSIH9R5PFHcytnPGYJbCbUPplauUiQyq64evjEQq+4OqFi8",
-	"Snh8JKVHI1SHiv+U+JTPjYfhU9KVJYdOjdf1R2NT/SwV5vWRyNQE2jLs+ONEUam+Bq2176x6uheV3sXa",
-	"fwEAAP//kA9vBzJMAAA=",
+	"H4sIAAAAAAAC/+w9W1MbV5p/RdW7j8og7DgzQxUPGWc2m6q4asrefcpSqrZ0LDqR1HJ3izFLUQUbMAKL",
+	"my+YAWwDi8ckMWDHhJu4/Bn1RU/+C1Pn0q2WdLrVLfq0UKw3Xc/5+rud735GuISYyYlZkFVkrm+EkxOD",
+	"IMOjl1/eU4B0S07B1zlJzAFJEQD6JinwaTEVF5LwjTKcA1wfJ2QVkAISF+UefJYSP4Offib/IOQ+E3OK",
+	"IGb59Gc5Ef5G4voUKQ/gz8SMoIBMThnm+u7xaRmMRrmMzGDZ0ai5mnj3e5BQuNEod1MCvALuJAZBMp8G",
+	"yVty6ja4nwey0vi0vBLn0+l4XoabjHBJICckAe3O9XHa8p668E9eUSe3y2dP9KfbWuHw42lRP98yxme0",
+	"tWntWYGztr8rimnAZy+JI15BsMiNwPCKvjLhBANcR7ZhVlYkIZviqsjhJYkfviRsCTGrgKxCwdNBQRvf",
+	"Ux9OqrvHXLQOhsvtWcONtbuqe8fG3gshWd0xGDYV5PjdvJSN81BE4hLgk/AxHJijsjypLsypsx/0iRWM",
+	"hqA5QgK59DAdAasv1a1ZfWUCbxw8JmSQTcZ5CsGN3Q218FidX6yMjWvPDyrP9z+eFrW9X/Q3j/FbrfAh",
+	"aGDwYnTW09+X1JePPp4Wy0dzBCr0eflozJjaDxYSRJL7eUECSa7vOxuDkl2qclJF4ABNSeUlWZS+EiSQ",
+	"wM8ywoFsPgPXvAvuiRJcCbEgN1AvUqNR7iuJv0dRZ44iasxO69sX7EU0UJK3kfXzuSSvADr3a6v72tK7",
+	"cPiedrz9NSkoX0tiPud2sNk4IUhiwzPclMQQHrN7hv8OzvDugdo9UL0fqL4OUagj/lsGkpt66HxN+B+i",
+	"9HdectWBLnaysfmLcfZWnV/EJNSmx9S1bX1lgpX9nAFSCjiJt7pQUI8Pykcn5aMx7cWGMT6jbv1k7L5T",
+	"z54FLefY3UTYqVet5oPCX/EPvsHf9sZiUS4jZM23gepdhZdSQIlbZPIO1jWWUAlKmkIqO1W09anK5vLH",
+	"0yKmHOGk5wfWsZXhH3wLsillkOv74vMgDw4XQ9skLQ2vA00ESM6JWRk0SlBawHJlEeXfJXCP6+P+raca",
+	"ROkhEZSemgXzaSXQU7qZCkAbhh63oZgzUI+s7mrrU1iVlI929KevtMJC7QnqwuVMUfY1UL5CODFjXc60",
+	"Z4g7IMt8CsieOesW/kMDduB7UeHTIRw3FuJuyam/DN8B99uBOF/i6IS0S9KOfxCXwX2nyJN69kSdntXW",
+	"xtStNxbHqyfz6vwhex/wa4BdwG/5uyB9S059K8hKQLrtksh0BZesfRvwSSDJzgDzQ7zCS0GbbFk+A4Je",
+	"E3p6tfwfzNHnjMJmxE7kJQlklXiOT4HAxTIFQYj71mp24tNU2yAvxzOiRLFFiJQtHasLi9iENC6WofGx",
+	"uq9uEQ8R+kpHR9r6lLa9oZ7Os/Hqs+CBEk+goF0jlHrpjV7a0YrTdnD1i1Jl4zeoGhBwTaAMhBtzEhhy",
+	"BFIrTuurR+p8+8CTh7OJ5ioV+iYLRW3nNVam0Pbc3dQKh/ove+XSnLFHPldPx9Q3j9TNl5Wpefz7wD3k",
+	"8A5cJCD/NQiDI78/Ab8cRJIoKr63R5hs9i+M72+y98SQzSsYssAmVhsp7csocICYRun2ChIEtHOMIhK7",
+	"aiMXdE/e7sn7aZy8xFb3fbQhGXUyXV3k+iqc5l7OzvrnuyJHJwtyBX7e2M2OZnlJZjnGsBOHgRYPgLQw",
+	"BCSQjCfEPC0vqR7+WhkbN87PobosLKiLy9qzd5WpecccbjC8R+AajssKr+QpsU9t5lll7LE+c6CNjUdi",
+	"/erhrzhPFuntt0COXIOvjb1S4NAxC7uBpOBY9rA2pi7M6adLxvmilaMsH53EtOcHxsa2vnWirf2MvzYu",
+	"prgoC7+EwRNTs9P1mb1ghFaQ42lomrJY2FTaDFJoDOp6eFTz5Dsqfpv8MWgPE/Amywf9nDa9xqw0ilWJ",
+	"QuDrwkgptBiaEPsO+qlpW7gZrQ8n7VF3pI5ea+unlbHH6ubLwG3SVgwj9I6alod5+Pcvjb0Xlc1leLat",
+	"H5s5s4+nxRhWqOWjWfX9y/LJXLlUKp89wz9mVQcSvO0WSli8Lq3grUKCVX40jFynqa/SQZ8jjui9DYYE",
+	"WRCzfxPTQmK4EbXMzmazCvPuMK1k6lw/2zXGJu0VLIHYpPBh7wppQaHsWr7YNXY3cWmEOr9nvBk3ij+q",
+	"q/uRWL9+vlU+KqmPi/ruhr7wUF1cjvT2l0uT+PPINfga24jG2CT7DCEKgtVKh4NkMDJLHIGyeWhXAiZB",
+	"VlA5dUChQrQWc9cTQl1bHXv1g8hVeJkkCsKKMncd/kAcfpqPif1my7vU34yz9vC7kYdu5OEqRR66QQdG",
+	"QQdG9dqfUjQjAYShlnz4jo1WJIM+/rphkKsZBmmw8xyevl50Kd0mLMJ9ICN+LzAqXQzLpHQxpU10OrqD",
+	"1vPXdRtkhKz5tpdh7wHefsDLAzh5YZ9EKN2VxDiGRPGaLEmq0wxPZstna7gTDYZXkH0X6e3XHm+pq4Gr",
+	"NcfGyPLFrvb0mBRHsGiPdDF88d5hNQRCQESJqqUxICyCbaENBanjQ2+1Yc7BP0iO4rSdQOrslHoyz0V9",
+	"iiERi4BbF9homxqziNo/bMY3A+YTGkFhtJ/Uz8ndqH/AqHUMJorJ4D2oJK/wtkWrcGTkFPsM0h1+CJD4",
+	"b/MOYuepGjgkof90ApsjjyYr/9jC39a2SPbGYrGYBUQgGrRt/ex1RpKJJZqZ1BD57Q5x6LAhDgk0U4uJ",
+	"s81OR5OV6ZMOsPNctS9RrDnS248pGrRxdY8X0jBeJtNMXXxsqlvvjf3X6twrdXU9YOqxi2GGGBZtz7iQ",
+	"qilFpdnCHK3nmVHgLfBwVHcWCgHGIddiZVn0nWn1fLKaaEEvykc7ONFCPr+OXy9pB4XI5/12of69zm6p",
+	"H5TF2Ie7A3gpMXhLTv2nEKqt73qOxCLm0RFhc3QMCqnBtJAaVGgjGBbP4NiF6Slt9zfYrjH5ofJ019ib",
+	"LZ9d6E+3/ycfi11PZHjpB/QKaOtT+s65Wpw0/v84LGMlU7X4PDZYyQnSj0SgyOYzd1kyU0eUTtg5vzPL",
+	"JmA+o+nYum4JRTvt5Q5PdTNLM4Y056tWQkLPJEDkkYiLV019NZKSTrhs02Q4dvIZlhiF4m+EbBDDzNHS",
+	"lLb2Fn8YudZv7O1UXn0gb6/3q6vn0I7Cbz/vh/24H7YjN2A8QJ18qy7ORL6AK5RLB+Q3f+w33jysbJK5",
+	"epE/9aM0IXacIn8my1fGVoy9F5HeGPmx+d7MY5E/917rx44D3paLmsOAe6PXotejn0dvRL+I/jH6p+if",
+	"o72xaG9vtPfaQLgy1FVHl0UlKeboDjvygi6F0nngqMg7smLfHju3PQA1fD6cTVgj2doxjc15SkTNZIi1",
+	"n/E4AJgqn1/WVveDDsb585mGswk2leZoIgVV25SPHmlvNzAWsA8OcRHSUDj7A3sus8CEssLgcLI5OZL6",
+	"cXY7cs08qgpLamG98o+t4CfG+g0RMMtv33dCUFg0tBXONVYl8LISRwejD1Thg5RNWVpNXV+gK+eztc2z",
+	"tNE0OAenrf1s7JWglCHDC9fos6eT1xFORCezOePJ4jg315GpORZWSl20lgXY9ewZ7B7m3R6euswCj9nA",
+	"j7CvKQvpWvc4oEMcqjH/Ol8RcyxYPGCb1nHal30uTYOWoE2gEDJAzAf/vJ9IX1e3f+qq9E91B6x0B6ww",
+	"b0liEKnsdjt1u526Q18u4yXBj0za19XVPN0un8zhsBsJ0q9MRL782zecdSkJCd6TD4eAhLtHuN4/xP4Q",
+	"g2gWcyDL5wSuj7uOPopyOV4ZRJquh88JPUO9PRk51YNP3B50+sDvciIOIYk5IPEQnG+SXB+c2WheEyFz",
+	"ODYHZOUvYnK4LlHE53JpIYH+2PM9KebDbOBZ15obUaZJ1oQFiSEuEd8SrXstFvMFkOvQZMcLMhAgtG4c",
+	"2Ac/8wqNvZTzmQwvDXN9nDF3CHMXxSl1d8W6CUQtHRgXr6z6MUhXPiXDWCeM6Q/AFShUQs5Mz4hlJY32",
+	"WOd5CtDJ1nANAmIEic8ABeX6vxvhBPgAkDk4Myxec5tMLcajNuzV1/OPDrAlh/OVDq1QRD/f0ksT2vqU",
+	"sUtiemrhubGx7ZEWZqzVCfG1ERAHrN/PA2m4ivYcnwJxmNjyhfWoy2Ky8L/gStHQaVJ2KwREAS5y7Pkh",
+	"HfRp/UlR/djsDhYixwngrZOgZRmy04DYNIQCdTWvF09h+BJPV17b/k5WeAllGKIRaPPJ4P5A+fhR5fm+",
+	"pVBx/xn5B+o8g+V/xRPYiPZwUj89UbfeW6kH4uRh+A9/bQznN/BD7QVAjdzgeBVzcGwSbUDSb4dw7LQZ",
+	"iKepBAtvrltlhKyQyWfsPcMu2+qlJ9qLV9WJ2AjH6NqflRuxmPZiwwEWQrjWIRkI4+xvuOOJIiXkgKdJ",
+	"SZUHEVrwLzE7+Dr4awRlOJtoJim2KeXl40cwurEyYaWu1MdF0rB6MVUjMLV8b31VLr1WN5/om4t4cfvY",
+	"88rUrHHxElX5b5ePZrS3G/rKhJn+w7PnrQnotUJUk7O9GgKEHwDDix/V9jAfT4uVfy5Vk5en63Z9Z/3c",
+	"GiTvIIDoGwqDx7yImrY3r73dwLJl7Yjzb9bspUpp2djd6o3FIEHR5Vs3YjEHaNJCRlBq4eEfYHhukNse",
+	"2yN+9IS+P9Gzz9/3JXFmeoFqC1jT8DiGj984cs/fo+OvSPelt/MY7mbXMlizpIECGnFwMw14yURC+HLL",
+	"kvFcjSHUtUpX8+gr/LRWW2stwqOOtuXvE5MkAefOt1BH/bqhrU1jpMF4O1ZrP52QLk0aYzfBcy5PS4mj",
+	"5agdyDBEdvFC3VmGnXJYXRTeYcMWqtfJg3JpCV7leXJh7J6rW1ONR5nZId0+IrYWjnFVwfVt3yFHXxz5",
+	"B9OKKoaEjG7sUaf37uF7Y+2Br/rM3AK8hxlf61tYcrmfGf9GLbwjHIOgaOCV6kW1HBu6NV6GHTLhKJcJ",
+	"07QAwSjt9LLdxt2Mfjgg1iwUY78Y0lsgpnWTkrYaSWO18lcz/U75r9mT4/RX9LNW9rTdLe93z6sQwGo0",
+	"m81rpMqn6+rk6/LRDJTetxu1Nj6+nEorLNmuqrL+g5fQCkvkvq/nB+rFmf7stVp4WNn4TZ3/P+3ZOwcj",
+	"G6/UDJl1PjW6AQvv9fG0eBfcEyUA913d157/ZDlm+GcfT4soek++X3rX+D12CvAyDmAmBQng3GbUo6Df",
+	"RA/2lfW/cMK/HqJWTS1jEvn1Ea/CesbMdeXyND1TN2mfkX53GugfspZvNWqIptLX4N+qI/GCf1zuHs+h",
+	"eveeEbO4a7Sp9q+b0d/EUMONyk6GmrnrFQrrUh6xRcEwxmcaWwL0nSX92WvPtvb8z9raNOkemIfhI0z2",
+	"RsP5ahGHgRHt2qcRdj7TI5MgWrlLrx8moUoyrHxwzjbbGz05VpRp7LYOmR7UdlZ/6hRX5tnVqTf0jzSJ",
+	"8NwGCT6dtpGgebKrY6I4ztjE8W9XbEbpZ/9fk4LCHlvBS4Ed7s4yJHA5ZmucX0330tWP7WqYziKn4502",
+	"nUFTnEZukaY1NZ3Oei0jDoEqx/OmxxM8iR18Wjwq2W0lHyOc2WpRp9nNfo8o2LZtJ6qxsa39OIny+U9p",
+	"CpYqk18mk6GQjZFkOgzyDlkug6GodlhSZ9Y9U9RVZJPNw3ZVVxeSOURRDTYG2B6n0VPBJR3VVe6glWG2",
+	"VmuHuKWyPGnslUjLuGdWwW6L7Fj4UDdgGfud2qvX+tojPIa0rpUd3jmPHBl9ZcLJl7H+TixDqyDi+JV9",
+	"fVqBUFVZmYA38WptcwSvuHXtNim89aADwayNEj7Yo1qG7qpLaq6cb0YQXFhuK0EPhjLRT7bys5EEgQVx",
+	"Ma1wh7OfgK6NedyDu1An2uBvE++wJJC9R+OSIV1CDU+BXdmcfO1acWO/ydF/As9PrijAiaYOBZhKXq6B",
+	"iQwyikXJKKOBaFdp2OqgqJd4tqIv1N0VWPjhlvRxcj5uogECdUzIwk1o3KhdscmGq0gbMa4WVtXSCd3p",
+	"Q1/ZMe5ZDzSNTd7kswmQdtcIdYDa4OgEVezG5mQKmrOn7Yp054SN8eMZCanZ9B4sf6pdrjHseSUIwSYy",
+	"2iFySBrXnaOkvuQQDdJ1PIytObveTuEfwPDfRSnZrMbfJcAVvezxHnBRDe6g4JWW9iX9yV3/wGWAc6uN",
+	"Fgtr+v6mNx5H7V9e2vfaWDLWcvHWJXj7EnVfl6hwuyIlY+yEvVu61i1ds2mTywU93oyXLza8hTiQmmsa",
+	"1SCQyYw8GtotdB1VqoYbXH2UqiG0N69vIUhhWN5SN967DdUt9cORWypuQRzvCeneqlqqiP+UilpsbOyn",
+	"qIU5sth4bu1l/cuVtLhRyonrPVW0dCAtq3B3cD1LSwT1Uc5iMXu3miWsahYbSVutZgmDat1iFq/FLF4J",
+	"6iKvzQoUcFW8e5mC/zoDi426ZQbNXCcbjf2UGVRp3LzKAFKjW2TQ7ulGAdYY2PWC7xqDes7x5ox3Kwya",
+	"e+LuFQaj1icjJh7QOMGB0X8NAExJnW/yxQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
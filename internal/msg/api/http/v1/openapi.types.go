@@ -3,20 +3,35 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.16.2 DO NOT EDIT.
 package v1
 
+// Defines values for CursorDirection.
+const (
+	After  CursorDirection = "after"
+	Before CursorDirection = "before"
+)
+
 // Defines values for SendUserMsgRequestType.
 const (
-	N1  SendUserMsgRequestType = 1
-	N10 SendUserMsgRequestType = 10
-	N11 SendUserMsgRequestType = 11
-	N12 SendUserMsgRequestType = 12
-	N2  SendUserMsgRequestType = 2
-	N3  SendUserMsgRequestType = 3
-	N4  SendUserMsgRequestType = 4
-	N5  SendUserMsgRequestType = 5
-	N6  SendUserMsgRequestType = 6
-	N7  SendUserMsgRequestType = 7
-	N8  SendUserMsgRequestType = 8
-	N9  SendUserMsgRequestType = 9
+	SendUserMsgRequestTypeN1  SendUserMsgRequestType = 1
+	SendUserMsgRequestTypeN10 SendUserMsgRequestType = 10
+	SendUserMsgRequestTypeN11 SendUserMsgRequestType = 11
+	SendUserMsgRequestTypeN12 SendUserMsgRequestType = 12
+	SendUserMsgRequestTypeN2  SendUserMsgRequestType = 2
+	SendUserMsgRequestTypeN3  SendUserMsgRequestType = 3
+	SendUserMsgRequestTypeN4  SendUserMsgRequestType = 4
+	SendUserMsgRequestTypeN5  SendUserMsgRequestType = 5
+	SendUserMsgRequestTypeN6  SendUserMsgRequestType = 6
+	SendUserMsgRequestTypeN7  SendUserMsgRequestType = 7
+	SendUserMsgRequestTypeN8  SendUserMsgRequestType = 8
+	SendUserMsgRequestTypeN9  SendUserMsgRequestType = 9
+)
+
+// Defines values for ListScheduledMsgParamsStatus.
+const (
+	ListScheduledMsgParamsStatusN0 ListScheduledMsgParamsStatus = 0
+	ListScheduledMsgParamsStatusN1 ListScheduledMsgParamsStatus = 1
+	ListScheduledMsgParamsStatusN2 ListScheduledMsgParamsStatus = 2
+	ListScheduledMsgParamsStatusN3 ListScheduledMsgParamsStatus = 3
+	ListScheduledMsgParamsStatusN4 ListScheduledMsgParamsStatus = 4
 )
 
 // AfterMsg defines model for AfterMsg.
@@ -25,18 +40,112 @@ type AfterMsg struct {
 	MsgId    int `json:"msg_id"`
 }
 
+// CreateScheduledMsgRequest defines model for CreateScheduledMsgRequest.
+type CreateScheduledMsgRequest struct {
+	// AtAllUser 是否at全体用户，群聊有效
+	AtAllUser bool `json:"at_all_user"`
+
+	// AtUsers at的用户，群聊有效
+	AtUsers []string `json:"at_users"`
+
+	// Content 消息内容
+	Content string `json:"content"`
+
+	// DialogId 对话id
+	DialogId int `json:"dialog_id"`
+
+	// IsBurnAfterReading 是否阅后即焚消息
+	IsBurnAfterReading bool `json:"is_burn_after_reading"`
+
+	// ReplyId 回复的消息id
+	ReplyId int `json:"reply_id"`
+
+	// SendAt 计划发送时间，毫秒时间戳
+	SendAt int `json:"send_at"`
+
+	// Type 消息类型，与发送消息一致
+	Type int `json:"type"`
+}
+
+// CursorDirection defines model for CursorDirection.
+type CursorDirection string
+
+// Draft defines model for Draft.
+type Draft struct {
+	// Content 草稿内容
+	Content  string `json:"content"`
+	DialogId int    `json:"dialog_id"`
+
+	// ReplyId 回复的消息id
+	ReplyId int `json:"reply_id"`
+
+	// UpdatedAt 更新时间，毫秒时间戳
+	UpdatedAt int `json:"updated_at"`
+}
+
 // EditGroupMsgRequest defines model for EditGroupMsgRequest.
 type EditGroupMsgRequest struct {
 	Content string `json:"content"`
 	MsgType int    `json:"msg_type"`
 }
 
+// EditScheduledMsgRequest defines model for EditScheduledMsgRequest.
+type EditScheduledMsgRequest struct {
+	// AtAllUser 是否at全体用户，群聊有效
+	AtAllUser bool `json:"at_all_user"`
+
+	// AtUsers at的用户，群聊有效
+	AtUsers []string `json:"at_users"`
+
+	// Content 消息内容
+	Content string `json:"content"`
+
+	// IsBurnAfterReading 是否阅后即焚消息
+	IsBurnAfterReading bool `json:"is_burn_after_reading"`
+
+	// ReplyId 回复的消息id
+	ReplyId int `json:"reply_id"`
+
+	// SendAt 计划发送时间，毫秒时间戳
+	SendAt int `json:"send_at"`
+
+	// Type 消息类型，与发送消息一致
+	Type int `json:"type"`
+}
+
 // EditUserMsgRequest defines model for EditUserMsgRequest.
 type EditUserMsgRequest struct {
 	Content string `json:"content"`
 	MsgType int    `json:"msg_type"`
 }
 
+// ForwardMsgRequest defines model for ForwardMsgRequest.
+type ForwardMsgRequest struct {
+	// DialogId 被转发消息所在的对话id
+	DialogId int `json:"dialog_id"`
+
+	// Merge 是否合并为一条聊天记录
+	Merge           bool  `json:"merge"`
+	MsgIds          []int `json:"msg_ids"`
+	TargetDialogIds []int `json:"target_dialog_ids"`
+
+	// Title 聊天记录标题，合并转发时有效
+	Title string `json:"title"`
+}
+
+// ForwardMsgResponse defines model for ForwardMsgResponse.
+type ForwardMsgResponse struct {
+	List []ForwardMsgResult `json:"list"`
+}
+
+// ForwardMsgResult defines model for ForwardMsgResult.
+type ForwardMsgResult struct {
+	DialogId int `json:"dialog_id"`
+
+	// MsgIds 在目标对话中生成的消息id
+	MsgIds []int `json:"msg_ids"`
+}
+
 // GetDialogAfterMsgResponse defines model for GetDialogAfterMsgResponse.
 type GetDialogAfterMsgResponse struct {
 	DialogId int        `json:"dialog_id"`
@@ -44,6 +153,15 @@ type GetDialogAfterMsgResponse struct {
 	Total    int        `json:"total"`
 }
 
+// GetDialogMsgBySeqResponse defines model for GetDialogMsgBySeqResponse.
+type GetDialogMsgBySeqResponse struct {
+	DialogId int       `json:"dialog_id"`
+	List     []Message `json:"list"`
+
+	// MaxSeq 对话当前最大的消息序号
+	MaxSeq int `json:"max_seq"`
+}
+
 // GetGroupLabelMsgListResponse defines model for GetGroupLabelMsgListResponse.
 type GetGroupLabelMsgListResponse struct {
 	List []Message `json:"list,omitempty"`
@@ -60,7 +178,28 @@ type GetGroupMessageReadersResponse struct {
 type GetGroupMsgListResponse struct {
 	CurrentPage   int             `json:"current_page"`
 	GroupMessages *[]GroupMessage `json:"group_messages,omitempty"`
-	Total         int             `json:"total"`
+
+	// HasMore 当前方向是否还有更多消息，游标模式有效
+	HasMore bool `json:"has_more"`
+
+	// NextCursor 继续按当前方向翻页的游标，游标模式有效
+	NextCursor string `json:"next_cursor"`
+
+	// PrevCursor 按相反方向翻页的游标，游标模式有效
+	PrevCursor string `json:"prev_cursor"`
+
+	// SyncSeq 对话当前的同步序号，客户端从该序号开始增量同步
+	SyncSeq int `json:"sync_seq"`
+	Total   int `json:"total"`
+}
+
+// GetGroupThreadMsgListResponse defines model for GetGroupThreadMsgListResponse.
+type GetGroupThreadMsgListResponse struct {
+	CurrentPage   int            `json:"current_page"`
+	GroupMessages []GroupMessage `json:"group_messages"`
+	Root          *GroupMessage  `json:"root,omitempty"`
+	Thread        *ThreadInfo    `json:"thread,omitempty"`
+	Total         int            `json:"total"`
 }
 
 // GetUserDialogListResponse defines model for GetUserDialogListResponse.
@@ -77,29 +216,67 @@ type GetUserLabelMsgListResponse struct {
 
 // GetUserMsgListResponse defines model for GetUserMsgListResponse.
 type GetUserMsgListResponse struct {
-	CurrentPage  int            `json:"current_page"`
+	CurrentPage int `json:"current_page"`
+
+	// HasMore 当前方向是否还有更多消息，游标模式有效
+	HasMore bool `json:"has_more"`
+
+	// NextCursor 继续按当前方向翻页的游标，游标模式有效
+	NextCursor string `json:"next_cursor"`
+
+	// PrevCursor 按相反方向翻页的游标，游标模式有效
+	PrevCursor string `json:"prev_cursor"`
+
+	// SyncSeq 对话当前的同步序号，客户端从该序号开始增量同步
+	SyncSeq      int            `json:"sync_seq"`
 	Total        int            `json:"total"`
 	UserMessages *[]UserMessage `json:"user_messages,omitempty"`
 }
 
+// GetUserThreadMsgListResponse defines model for GetUserThreadMsgListResponse.
+type GetUserThreadMsgListResponse struct {
+	CurrentPage  int           `json:"current_page"`
+	Root         *UserMessage  `json:"root,omitempty"`
+	Thread       *ThreadInfo   `json:"thread,omitempty"`
+	Total        int           `json:"total"`
+	UserMessages []UserMessage `json:"user_messages"`
+}
+
 // GroupMessage defines model for GroupMessage.
 type GroupMessage struct {
-	AtAllUser              bool        `json:"at_all_user"`
-	AtUsers                []string    `json:"at_users"`
-	Content                string      `json:"content"`
-	DialogId               int         `json:"dialog_id"`
-	GroupId                int         `json:"group_id"`
-	IsBurnAfterReadingType bool        `json:"is_burn_after_reading_type"`
-	IsLabel                bool        `json:"is_label"`
-	IsRead                 bool        `json:"is_read"`
-	MsgId                  int         `json:"msg_id"`
-	ReadAt                 int         `json:"read_at"`
-	ReadCount              int         `json:"read_count"`
-	ReplyId                int         `json:"reply_id"`
-	SendAt                 int         `json:"send_at"`
-	SenderInfo             *SenderInfo `json:"sender_info,omitempty"`
-	Type                   int         `json:"type"`
-	UserId                 string      `json:"user_id"`
+	AtAllUser bool     `json:"at_all_user"`
+	AtUsers   []string `json:"at_users"`
+	Content   string   `json:"content"`
+
+	// DeliveredCount 已送达的成员数量，群聊有效
+	DeliveredCount int `json:"delivered_count"`
+
+	// DeliveryStatus 投递状态 0=已发送 1=已送达 2=已读
+	DeliveryStatus int `json:"delivery_status"`
+	DialogId       int `json:"dialog_id"`
+
+	// EditedAt 最后编辑时间，为0时表示未编辑过
+	EditedAt               int               `json:"edited_at"`
+	GroupId                int               `json:"group_id"`
+	IsBurnAfterReadingType bool              `json:"is_burn_after_reading_type"`
+	IsLabel                bool              `json:"is_label"`
+	IsRead                 bool              `json:"is_read"`
+	MsgId                  int               `json:"msg_id"`
+	Reactions              []MessageReaction `json:"reactions"`
+	ReadAt                 int               `json:"read_at"`
+	ReadCount              int               `json:"read_count"`
+	ReplyId                int               `json:"reply_id"`
+	SendAt                 int               `json:"send_at"`
+	SenderInfo             *SenderInfo       `json:"sender_info,omitempty"`
+
+	// Seq 对话内消息序号，严格递增
+	Seq    int         `json:"seq"`
+	Thread *ThreadInfo `json:"thread,omitempty"`
+
+	// ThreadId 所属话题的根消息id，0表示不属于任何话题
+	ThreadId int    `json:"thread_id"`
+	Type     int    `json:"type"`
+	UserId   string `json:"user_id"`
 }
 
 // GroupMessageReadRequest defines model for GroupMessageReadRequest.
@@ -109,6 +286,17 @@ type GroupMessageReadRequest struct {
 	ReadAll  bool  `json:"read_all"`
 }
 
+// GroupRevisionPolicy defines model for GroupRevisionPolicy.
+type GroupRevisionPolicy struct {
+	GroupId int `json:"group_id"`
+
+	// UpdatedBy 设置者id
+	UpdatedBy string `json:"updated_by"`
+
+	// Visibility 修订记录可见范围 0=群主和管理员 1=仅群主 2=仅发送者
+	Visibility int `json:"visibility"`
+}
+
 // LabelGroupMessageRequest defines model for LabelGroupMessageRequest.
 type LabelGroupMessageRequest struct {
 	IsLabel bool `json:"is_label"`
@@ -119,24 +307,100 @@ type LabelUserMessageRequest struct {
 	IsLabel bool `json:"is_label"`
 }
 
+// ListDraftResponse defines model for ListDraftResponse.
+type ListDraftResponse struct {
+	List []Draft `json:"list"`
+}
+
+// ListScheduledMsgResponse defines model for ListScheduledMsgResponse.
+type ListScheduledMsgResponse struct {
+	CurrentPage int                `json:"current_page"`
+	List        []ScheduledMessage `json:"list"`
+	Total       int                `json:"total"`
+}
+
 // Message defines model for Message.
 type Message struct {
-	AtAllUser          bool        `json:"at_all_user"`
-	AtUsers            []string    `json:"at_users"`
-	Content            string      `json:"content"`
-	DialogId           int         `json:"dialog_id"`
-	GroupId            int         `json:"group_id"`
-	IsBurnAfterReading bool        `json:"is_burn_after_reading"`
-	IsLabel            bool        `json:"is_label"`
-	IsRead             bool        `json:"is_read"`
-	MsgId              int         `json:"msg_id"`
-	MsgType            int         `json:"msg_type"`
-	ReadAt             int         `json:"read_at"`
-	ReceiverInfo       *SenderInfo `json:"receiver_info,omitempty"`
-	ReplyId            int         `json:"reply_id"`
-	SendAt             int         `json:"send_at"`
-	SenderId           string      `json:"sender_id"`
-	SenderInfo         *SenderInfo `json:"sender_info,omitempty"`
+	AtAllUser bool     `json:"at_all_user"`
+	AtUsers   []string `json:"at_users"`
+	Content   string   `json:"content"`
+
+	// DeliveredAt 送达时间，私聊有效
+	DeliveredAt int `json:"delivered_at"`
+
+	// DeliveredCount 已送达的成员数量，群聊有效
+	DeliveredCount int `json:"delivered_count"`
+
+	// DeliveryStatus 投递状态 0=已发送 1=已送达 2=已读
+	DeliveryStatus int `json:"delivery_status"`
+	DialogId       int `json:"dialog_id"`
+
+	// EditedAt 最后编辑时间，为0时表示未编辑过
+	EditedAt           int               `json:"edited_at"`
+	GroupId            int               `json:"group_id"`
+	IsBurnAfterReading bool              `json:"is_burn_after_reading"`
+	IsLabel            bool              `json:"is_label"`
+	IsRead             bool              `json:"is_read"`
+	MsgId              int               `json:"msg_id"`
+	MsgType            int               `json:"msg_type"`
+	Reactions          []MessageReaction `json:"reactions"`
+	ReadAt             int               `json:"read_at"`
+	ReceiverInfo       *SenderInfo       `json:"receiver_info,omitempty"`
+	ReplyId            int               `json:"reply_id"`
+	SendAt             int               `json:"send_at"`
+	SenderId           string            `json:"sender_id"`
+	SenderInfo         *SenderInfo       `json:"sender_info,omitempty"`
+
+	// Seq 对话内消息序号，严格递增
+	Seq    int         `json:"seq"`
+	Thread *ThreadInfo `json:"thread,omitempty"`
+
+	// ThreadId 所属话题的根消息id，0表示不属于任何话题
+	ThreadId int `json:"thread_id"`
+}
+
+// MessageReaction defines model for MessageReaction.
+type MessageReaction struct {
+	Count   int      `json:"count"`
+	Emoji   string   `json:"emoji"`
+	UserIds []string `json:"user_ids"`
+}
+
+// MessageReactionRequest defines model for MessageReactionRequest.
+type MessageReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+// MessageReactionResponse defines model for MessageReactionResponse.
+type MessageReactionResponse struct {
+	MsgId     int               `json:"msg_id"`
+	Reactions []MessageReaction `json:"reactions"`
+}
+
+// MessageRevision defines model for MessageRevision.
+type MessageRevision struct {
+	// Action 操作类型 0=编辑 1=撤回
+	Action int `json:"action"`
+
+	// Content 修改前的内容
+	Content string `json:"content"`
+
+	// EditedAt 修改时间，毫秒时间戳
+	EditedAt int `json:"edited_at"`
+
+	// EditorId 修改者id
+	EditorId string `json:"editor_id"`
+	MsgId    int    `json:"msg_id"`
+}
+
+// MessageRevisionListResponse defines model for MessageRevisionListResponse.
+type MessageRevisionListResponse struct {
+	// List 修订记录，按修改时间升序
+	List  []MessageRevision `json:"list"`
+	MsgId int               `json:"msg_id"`
+
+	// SenderId 消息发送者id
+	SenderId string `json:"sender_id"`
 }
 
 // ReadUserMsgsRequest defines model for ReadUserMsgsRequest.
@@ -153,6 +417,77 @@ type Response struct {
 	Msg  string                  `json:"msg"`
 }
 
+// SaveDraftRequest defines model for SaveDraftRequest.
+type SaveDraftRequest struct {
+	// Content 草稿内容，为空时清除草稿
+	Content string `json:"content"`
+
+	// ReplyId 回复的消息id
+	ReplyId int `json:"reply_id"`
+}
+
+// ScheduledMessage defines model for ScheduledMessage.
+type ScheduledMessage struct {
+	// AtAllUser 是否at全体用户，群聊有效
+	AtAllUser bool `json:"at_all_user"`
+
+	// AtUsers at的用户，群聊有效
+	AtUsers []string `json:"at_users"`
+
+	// Content 消息内容
+	Content   string `json:"content"`
+	CreatedAt int    `json:"created_at"`
+	DialogId  int    `json:"dialog_id"`
+
+	// DialogType 对话类型 0=私聊 1=群聊
+	DialogType int `json:"dialog_type"`
+
+	// FailReason 发送失败原因
+	FailReason string `json:"fail_reason"`
+	GroupId    int    `json:"group_id"`
+	Id         int    `json:"id"`
+
+	// IsBurnAfterReading 是否阅后即焚消息
+	IsBurnAfterReading bool `json:"is_burn_after_reading"`
+
+	// MsgId 发送后生成的消息id
+	MsgId      int    `json:"msg_id"`
+	ReceiverId string `json:"receiver_id"`
+
+	// ReplyId 回复的消息id
+	ReplyId int `json:"reply_id"`
+
+	// SendAt 计划发送时间，毫秒时间戳
+	SendAt int `json:"send_at"`
+
+	// Status 状态 0=等待发送 1=发送中 2=已发送 3=已取消 4=发送失败
+	Status int `json:"status"`
+
+	// Type 消息类型，与发送消息一致
+	Type      int `json:"type"`
+	UpdatedAt int `json:"updated_at"`
+}
+
+// SearchMsgHit defines model for SearchMsgHit.
+type SearchMsgHit struct {
+	DialogId int `json:"dialog_id"`
+
+	// DialogType 0 私聊 1 群聊
+	DialogType int `json:"dialog_type"`
+
+	// Highlights 命中片段，关键词使用<mark>标签包裹
+	Highlights []string `json:"highlights"`
+	Message    *Message `json:"message"`
+	Score      float32  `json:"score"`
+}
+
+// SearchMsgResponse defines model for SearchMsgResponse.
+type SearchMsgResponse struct {
+	CurrentPage int            `json:"current_page"`
+	List        []SearchMsgHit `json:"list"`
+	Total       int            `json:"total"`
+}
+
 // SendGroupMsgRequest defines model for SendGroupMsgRequest.
 type SendGroupMsgRequest struct {
 	AtAllUser          bool     `json:"at_all_user"`
@@ -169,6 +504,9 @@ type SendGroupMsgRequest struct {
 type SendGroupMsgResponse struct {
 	MsgId    int      `json:"msg_id"`
 	ReplyMsg *Message `json:"reply_msg"`
+
+	// Seq 对话内消息序号，严格递增
+	Seq int `json:"seq"`
 }
 
 // SendUserMsgRequest defines model for SendUserMsgRequest.
@@ -190,6 +528,9 @@ type SendUserMsgRequestType int
 type SendUserMsgResponse struct {
 	MsgId    int      `json:"msg_id"`
 	ReplyMsg *Message `json:"reply_msg"`
+
+	// Seq 对话内消息序号，严格递增
+	Seq int `json:"seq"`
 }
 
 // SenderInfo defines model for SenderInfo.
@@ -199,6 +540,45 @@ type SenderInfo struct {
 	UserId string `json:"user_id"`
 }
 
+// SetGroupRevisionPolicyRequest defines model for SetGroupRevisionPolicyRequest.
+type SetGroupRevisionPolicyRequest struct {
+	// Visibility 修订记录可见范围 0=群主和管理员 1=仅群主 2=仅发送者
+	Visibility int `json:"visibility"`
+}
+
+// SyncDialogMsgResponse defines model for SyncDialogMsgResponse.
+type SyncDialogMsgResponse struct {
+	DialogId int `json:"dialog_id"`
+
+	// HasMore 是否还有未同步的变更
+	HasMore bool          `json:"has_more"`
+	List    []SyncMessage `json:"list"`
+
+	// NextSeq 下次同步使用的序号
+	NextSeq int `json:"next_seq"`
+}
+
+// SyncMessage defines model for SyncMessage.
+type SyncMessage struct {
+	// Action 变更类型 0=新消息 1=修改 2=撤回或删除
+	Action  int      `json:"action"`
+	Message *Message `json:"message"`
+	MsgId   int      `json:"msg_id"`
+
+	// Seq 变更序号
+	Seq int `json:"seq"`
+}
+
+// ThreadInfo defines model for ThreadInfo.
+type ThreadInfo struct {
+	LastReply  *Message `json:"last_reply"`
+	ReplyCount int      `json:"reply_count"`
+	ThreadId   int      `json:"thread_id"`
+
+	// UnreadCount 当前用户未读的回复数量
+	UnreadCount int `json:"unread_count"`
+}
+
 // UserDialogListResponse defines model for UserDialogListResponse.
 type UserDialogListResponse struct {
 	DialogAvatar      string   `json:"dialog_avatar"`
@@ -207,6 +587,7 @@ type UserDialogListResponse struct {
 	DialogName        string   `json:"dialog_name"`
 	DialogType        int      `json:"dialog_type"`
 	DialogUnreadCount int      `json:"dialog_unread_count"`
+	Draft             *Draft   `json:"draft,omitempty"`
 	GroupId           int      `json:"group_id,omitempty"`
 	IsSilent          bool     `json:"is_silent"`
 	LastMessage       *Message `json:"last_message"`
@@ -216,21 +597,38 @@ type UserDialogListResponse struct {
 
 // UserMessage defines model for UserMessage.
 type UserMessage struct {
-	BurnAfterReadingTimeout int         `json:"burn_after_reading_timeout"`
-	Content                 string      `json:"content"`
-	DialogId                int         `json:"dialog_id"`
-	IsBurnAfterReadingType  bool        `json:"is_burn_after_reading_type"`
-	IsLabel                 bool        `json:"is_label"`
-	IsRead                  bool        `json:"is_read"`
-	MsgId                   int         `json:"msg_id"`
-	ReadAt                  int         `json:"read_at"`
-	ReceiverId              string      `json:"receiver_id"`
-	ReceiverInfo            *SenderInfo `json:"receiver_info,omitempty"`
-	ReplyId                 int         `json:"reply_id"`
-	SendAt                  int         `json:"send_at"`
-	SenderId                string      `json:"sender_id"`
-	SenderInfo              *SenderInfo `json:"sender_info,omitempty"`
-	Type                    int         `json:"type"`
+	BurnAfterReadingTimeout int    `json:"burn_after_reading_timeout"`
+	Content                 string `json:"content"`
+
+	// DeliveredAt 送达时间，私聊有效
+	DeliveredAt int `json:"delivered_at"`
+
+	// DeliveryStatus 投递状态 0=已发送 1=已送达 2=已读
+	DeliveryStatus int `json:"delivery_status"`
+	DialogId       int `json:"dialog_id"`
+
+	// EditedAt 最后编辑时间，为0时表示未编辑过
+	EditedAt               int               `json:"edited_at"`
+	IsBurnAfterReadingType bool              `json:"is_burn_after_reading_type"`
+	IsLabel                bool              `json:"is_label"`
+	IsRead                 bool              `json:"is_read"`
+	MsgId                  int               `json:"msg_id"`
+	Reactions              []MessageReaction `json:"reactions"`
+	ReadAt                 int               `json:"read_at"`
+	ReceiverId             string            `json:"receiver_id"`
+	ReceiverInfo           *SenderInfo       `json:"receiver_info,omitempty"`
+	ReplyId                int               `json:"reply_id"`
+	SendAt                 int               `json:"send_at"`
+	SenderId               string            `json:"sender_id"`
+	SenderInfo             *SenderInfo       `json:"sender_info,omitempty"`
+
+	// Seq 对话内消息序号，严格递增
+	Seq    int         `json:"seq"`
+	Thread *ThreadInfo `json:"thread,omitempty"`
+
+	// ThreadId 所属话题的根消息id，0表示不属于任何话题
+	ThreadId int `json:"thread_id"`
+	Type     int `json:"type"`
 }
 
 // GetAfterMsgsJSONBody defines parameters for GetAfterMsgs.
@@ -242,6 +640,24 @@ type GetUserDialogListParams struct {
 	PageSize int `form:"page_size" json:"page_size"`
 }
 
+// GetDialogMsgBySeqParams defines parameters for GetDialogMsgBySeq.
+type GetDialogMsgBySeqParams struct {
+	// StartSeq 起始序号
+	StartSeq int `form:"start_seq" json:"start_seq"`
+
+	// EndSeq 结束序号，区间最多500条
+	EndSeq int `form:"end_seq" json:"end_seq"`
+}
+
+// SyncDialogMsgParams defines parameters for SyncDialogMsg.
+type SyncDialogMsgParams struct {
+	// Seq 上次同步返回的next_seq，首次同步传消息列表返回的sync_seq
+	Seq *int `form:"seq,omitempty" json:"seq,omitempty"`
+
+	// Limit 每次最多返回的变更数量，默认100，最大500
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetGroupMsgListParams defines parameters for GetGroupMsgList.
 type GetGroupMsgListParams struct {
 	DialogId int     `form:"dialog_id" json:"dialog_id"`
@@ -251,6 +667,17 @@ type GetGroupMsgListParams struct {
 	Content  *string `form:"content,omitempty" json:"content,omitempty"`
 	PageNum  int     `form:"page_num" json:"page_num"`
 	PageSize int     `form:"page_size" json:"page_size"`
+
+	// Cursor 游标，传入上一次返回的next_cursor或prev_cursor，传入游标或方向时忽略分页参数
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Direction 翻页方向，before向更早的消息翻页，after向更新的消息翻页，默认before
+	Direction *CursorDirection `form:"direction,omitempty" json:"direction,omitempty"`
+}
+
+// RemoveGroupMsgReactionParams defines parameters for RemoveGroupMsgReaction.
+type RemoveGroupMsgReactionParams struct {
+	Emoji string `form:"emoji" json:"emoji"`
 }
 
 // GetGroupMessageReadersParams defines parameters for GetGroupMessageReaders.
@@ -259,6 +686,36 @@ type GetGroupMessageReadersParams struct {
 	GroupId  int `form:"group_id" json:"group_id"`
 }
 
+// GetGroupThreadMsgListParams defines parameters for GetGroupThreadMsgList.
+type GetGroupThreadMsgListParams struct {
+	PageNum  int `form:"page_num" json:"page_num"`
+	PageSize int `form:"page_size" json:"page_size"`
+}
+
+// ListScheduledMsgParams defines parameters for ListScheduledMsg.
+type ListScheduledMsgParams struct {
+	DialogId *int `form:"dialog_id,omitempty" json:"dialog_id,omitempty"`
+
+	// Status 状态 0=等待发送 1=发送中 2=已发送 3=已取消 4=发送失败
+	Status   *ListScheduledMsgParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	PageNum  int                           `form:"page_num" json:"page_num"`
+	PageSize int                           `form:"page_size" json:"page_size"`
+}
+
+// ListScheduledMsgParamsStatus defines parameters for ListScheduledMsg.
+type ListScheduledMsgParamsStatus int
+
+// SearchMsgParams defines parameters for SearchMsg.
+type SearchMsgParams struct {
+	Keyword  string  `form:"keyword" json:"keyword"`
+	DialogId *int    `form:"dialog_id,omitempty" json:"dialog_id,omitempty"`
+	UserId   *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+	StartAt  *int    `form:"start_at,omitempty" json:"start_at,omitempty"`
+	EndAt    *int    `form:"end_at,omitempty" json:"end_at,omitempty"`
+	PageNum  int     `form:"page_num" json:"page_num"`
+	PageSize int     `form:"page_size" json:"page_size"`
+}
+
 // GetUserMsgListParams defines parameters for GetUserMsgList.
 type GetUserMsgListParams struct {
 	DialogId int     `form:"dialog_id" json:"dialog_id"`
@@ -270,14 +727,40 @@ type GetUserMsgListParams struct {
 	PageSize int     `form:"page_size" json:"page_size"`
 	StartAt  *int    `form:"start_at,omitempty" json:"start_at,omitempty"`
 	EndAt    int     `form:"end_at" json:"end_at"`
+
+	// Cursor 游标，传入上一次返回的next_cursor或prev_cursor，传入游标或方向时忽略分页参数
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Direction 翻页方向，before向更早的消息翻页，after向更新的消息翻页，默认before
+	Direction *CursorDirection `form:"direction,omitempty" json:"direction,omitempty"`
+}
+
+// RemoveUserMsgReactionParams defines parameters for RemoveUserMsgReaction.
+type RemoveUserMsgReactionParams struct {
+	Emoji string `form:"emoji" json:"emoji"`
+}
+
+// GetUserThreadMsgListParams defines parameters for GetUserThreadMsgList.
+type GetUserThreadMsgListParams struct {
+	PageNum  int `form:"page_num" json:"page_num"`
+	PageSize int `form:"page_size" json:"page_size"`
 }
 
 // GetAfterMsgsJSONRequestBody defines body for GetAfterMsgs for application/json ContentType.
 type GetAfterMsgsJSONRequestBody = GetAfterMsgsJSONBody
 
+// SaveDraftJSONRequestBody defines body for SaveDraft for application/json ContentType.
+type SaveDraftJSONRequestBody = SaveDraftRequest
+
+// ForwardMsgJSONRequestBody defines body for ForwardMsg for application/json ContentType.
+type ForwardMsgJSONRequestBody = ForwardMsgRequest
+
 // GroupMessageReadJSONRequestBody defines body for GroupMessageRead for application/json ContentType.
 type GroupMessageReadJSONRequestBody = GroupMessageReadRequest
 
+// SetGroupRevisionPolicyJSONRequestBody defines body for SetGroupRevisionPolicy for application/json ContentType.
+type SetGroupRevisionPolicyJSONRequestBody = SetGroupRevisionPolicyRequest
+
 // SendGroupMsgJSONRequestBody defines body for SendGroupMsg for application/json ContentType.
 type SendGroupMsgJSONRequestBody = SendGroupMsgRequest
 
@@ -287,6 +770,15 @@ type EditGroupMsgJSONRequestBody = EditGroupMsgRequest
 // LabelGroupMsgJSONRequestBody defines body for LabelGroupMsg for application/json ContentType.
 type LabelGroupMsgJSONRequestBody = LabelGroupMessageRequest
 
+// AddGroupMsgReactionJSONRequestBody defines body for AddGroupMsgReaction for application/json ContentType.
+type AddGroupMsgReactionJSONRequestBody = MessageReactionRequest
+
+// CreateScheduledMsgJSONRequestBody defines body for CreateScheduledMsg for application/json ContentType.
+type CreateScheduledMsgJSONRequestBody = CreateScheduledMsgRequest
+
+// EditScheduledMsgJSONRequestBody defines body for EditScheduledMsg for application/json ContentType.
+type EditScheduledMsgJSONRequestBody = EditScheduledMsgRequest
+
 // ReadUserMsgsJSONRequestBody defines body for ReadUserMsgs for application/json ContentType.
 type ReadUserMsgsJSONRequestBody = ReadUserMsgsRequest
 
@@ -298,3 +790,6 @@ type EditUserMsgJSONRequestBody = EditUserMsgRequest
 
 // LabelUserMsgJSONRequestBody defines body for LabelUserMsg for application/json ContentType.
 type LabelUserMsgJSONRequestBody = LabelUserMessageRequest
+
+// AddUserMsgReactionJSONRequestBody defines body for AddUserMsgReaction for application/json ContentType.
+type AddUserMsgReactionJSONRequestBody = MessageReactionRequest
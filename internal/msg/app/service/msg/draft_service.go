@@ -0,0 +1,166 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/utils"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+	"sort"
+	"unicode/utf8"
+)
+
+type DraftService interface {
+	SaveDraft(ctx context.Context, userID string, driverId string, dialogID uint32, req *v1.SaveDraftRequest) (*v1.Draft, error)
+	GetDraft(ctx context.Context, userID string, dialogID uint32) (*v1.Draft, error)
+	ListDraft(ctx context.Context, userID string) (*v1.ListDraftResponse, error)
+	ClearDraft(ctx context.Context, userID string, driverId string, dialogID uint32) error
+}
+
+func (s *ServiceImpl) SaveDraft(ctx context.Context, userID string, driverId string, dialogID uint32, req *v1.SaveDraftRequest) (*v1.Draft, error) {
+	if utf8.RuneCountInString(req.Content) > entity.MaxDraftContentLength {
+		return nil, code.InvalidParameter
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, uint(dialogID))
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+
+	if req.Content == "" {
+		if err := s.ClearDraft(ctx, userID, driverId, dialogID); err != nil {
+			return nil, err
+		}
+		return &v1.Draft{DialogId: int(dialogID)}, nil
+	}
+
+	draft := &entity.MessageDraft{
+		UserID:   userID,
+		DialogID: uint(dialogID),
+		Content:  req.Content,
+		ReplyId:  uint(req.ReplyId),
+	}
+	if err := s.mdrd.SaveDraft(ctx, draft); err != nil {
+		s.logger.Error("保存草稿失败", zap.Error(err))
+		return nil, err
+	}
+
+	s.pushDraftUpdate(userID, driverId, &constants.MessageDraftEventData{
+		DialogId:  dialogID,
+		Content:   draft.Content,
+		ReplyId:   uint32(draft.ReplyId),
+		UpdatedAt: draft.UpdatedAt,
+	})
+
+	return draftToResponse(draft), nil
+}
+
+func (s *ServiceImpl) GetDraft(ctx context.Context, userID string, dialogID uint32) (*v1.Draft, error) {
+	draft, err := s.mdrd.GetDraft(ctx, userID, uint(dialogID))
+	if err != nil {
+		s.logger.Error("获取草稿失败", zap.Error(err))
+		return nil, err
+	}
+	if draft == nil {
+		return &v1.Draft{DialogId: int(dialogID)}, nil
+	}
+	return draftToResponse(draft), nil
+}
+
+func (s *ServiceImpl) ListDraft(ctx context.Context, userID string) (*v1.ListDraftResponse, error) {
+	drafts, err := s.mdrd.GetDrafts(ctx, userID, nil)
+	if err != nil {
+		s.logger.Error("获取草稿失败", zap.Error(err))
+		return nil, err
+	}
+
+	list := make([]v1.Draft, 0, len(drafts))
+	for _, v := range drafts {
+		list = append(list, *draftToResponse(v))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].UpdatedAt > list[j].UpdatedAt
+	})
+
+	return &v1.ListDraftResponse{List: list}, nil
+}
+
+func (s *ServiceImpl) ClearDraft(ctx context.Context, userID string, driverId string, dialogID uint32) error {
+	ok, err := s.mdrd.ClearDraft(ctx, userID, uint(dialogID))
+	if err != nil {
+		s.logger.Error("清除草稿失败", zap.Error(err))
+		return err
+	}
+
+	// 没有草稿时不需要通知其他设备
+	if ok {
+		s.pushDraftUpdate(userID, driverId, &constants.MessageDraftEventData{
+			DialogId:  dialogID,
+			UpdatedAt: pkgtime.Now(),
+			Cleared:   true,
+		})
+	}
+	return nil
+}
+
+// getDialogDrafts 批量获取对话的草稿，获取失败时只记录日志
+func (s *ServiceImpl) getDialogDrafts(ctx context.Context, userID string, dialogIDs []uint) map[uint]*v1.Draft {
+	result := make(map[uint]*v1.Draft)
+	if len(dialogIDs) == 0 {
+		return result
+	}
+
+	drafts, err := s.mdrd.GetDrafts(ctx, userID, dialogIDs)
+	if err != nil {
+		s.logger.Error("获取草稿失败", zap.Error(err))
+		return result
+	}
+	for dialogID, v := range drafts {
+		result[dialogID] = draftToResponse(v)
+	}
+	return result
+}
+
+// pushDraftUpdate 把草稿变更同步到用户的其他在线设备
+func (s *ServiceImpl) pushDraftUpdate(userID string, driverId string, data *constants.MessageDraftEventData) {
+	bytes, err := utils.StructToBytes(data)
+	if err != nil {
+		return
+	}
+
+	bytes2, err := utils.StructToBytes(&pushv1.PushWsBatchByUserIdsRequest{
+		UserIds:  []string{userID},
+		Event:    pushv1.WSEventType_MessageDraftEvent,
+		DriverId: driverId,
+		Data:     &any.Any{Value: bytes},
+		Route:    pushv1.DeviceRoute_ExcludeDevice,
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = s.pushService.Push(context.Background(), &pushv1.PushRequest{
+		Type: pushv1.Type_Ws_Batch_User,
+		Data: bytes2,
+	})
+	if err != nil {
+		s.logger.Error("推送草稿变更失败", zap.Error(err))
+	}
+}
+
+func draftToResponse(draft *entity.MessageDraft) *v1.Draft {
+	return &v1.Draft{
+		DialogId:  int(draft.DialogID),
+		Content:   draft.Content,
+		ReplyId:   int(draft.ReplyId),
+		UpdatedAt: int(draft.UpdatedAt),
+	}
+}
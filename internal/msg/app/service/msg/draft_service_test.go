@@ -0,0 +1,93 @@
+package msg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"strings"
+	"testing"
+)
+
+// draftPushes 解析推送给用户其他设备的草稿变更
+func draftPushes(t *testing.T, f *fakePushService) []*constants.MessageDraftEventData {
+	t.Helper()
+	result := make([]*constants.MessageDraftEventData, 0, len(f.requests))
+	for _, r := range f.requests {
+		if r.Type != pushv1.Type_Ws_Batch_User {
+			t.Fatalf("push type = %v", r.Type)
+		}
+		req := &pushv1.PushWsBatchByUserIdsRequest{}
+		if err := json.Unmarshal(r.Data, req); err != nil {
+			t.Fatalf("unmarshal push request: %v", err)
+		}
+		if req.Event != pushv1.WSEventType_MessageDraftEvent || req.Route != pushv1.DeviceRoute_ExcludeDevice || req.DriverId != "d1" {
+			t.Fatalf("unexpected push request: %+v", req)
+		}
+		data := &constants.MessageDraftEventData{}
+		if err := json.Unmarshal(req.Data.Value, data); err != nil {
+			t.Fatalf("unmarshal draft event: %v", err)
+		}
+		result = append(result, data)
+	}
+	return result
+}
+
+func TestDraftService(t *testing.T) {
+	s, db := newTestService(t)
+	s.mdrd = service.NewMessageDraftDomain(db, nil, s.repo)
+	s.relationDialogService = &fakeDialogService{users: map[uint32][]string{1: {"u1", "u2"}}}
+	push := s.pushService.(*fakePushService)
+	ctx := context.Background()
+
+	draft, err := s.SaveDraft(ctx, "u1", "d1", 1, &v1.SaveDraftRequest{Content: "hello", ReplyId: 5})
+	if err != nil {
+		t.Fatalf("SaveDraft: %v", err)
+	}
+	if draft.Content != "hello" || draft.ReplyId != 5 || draft.UpdatedAt == 0 {
+		t.Fatalf("unexpected draft: %+v", draft)
+	}
+
+	// 不在对话中的用户不能保存草稿，超长的草稿直接拒绝
+	if _, err := s.SaveDraft(ctx, "u3", "d1", 1, &v1.SaveDraftRequest{Content: "hello"}); !errors.Is(err, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed) {
+		t.Fatalf("expected non member to be rejected, got %v", err)
+	}
+	if _, err := s.SaveDraft(ctx, "u1", "d1", 1, &v1.SaveDraftRequest{Content: strings.Repeat("a", 10001)}); !errors.Is(err, code.InvalidParameter) {
+		t.Fatalf("expected long draft to be rejected, got %v", err)
+	}
+
+	got, err := s.GetDraft(ctx, "u1", 1)
+	if err != nil || got.Content != "hello" {
+		t.Fatalf("GetDraft = %+v, %v", got, err)
+	}
+	list, err := s.ListDraft(ctx, "u1")
+	if err != nil || len(list.List) != 1 {
+		t.Fatalf("ListDraft = %+v, %v", list, err)
+	}
+
+	// 保存空内容清除草稿，清除不存在的草稿不通知其他设备
+	if _, err := s.SaveDraft(ctx, "u1", "d1", 1, &v1.SaveDraftRequest{}); err != nil {
+		t.Fatalf("SaveDraft: %v", err)
+	}
+	if err := s.ClearDraft(ctx, "u1", "d1", 1); err != nil {
+		t.Fatalf("ClearDraft: %v", err)
+	}
+	if got, err := s.GetDraft(ctx, "u1", 1); err != nil || got.Content != "" {
+		t.Fatalf("expected draft to be cleared, got %+v, %v", got, err)
+	}
+
+	events := draftPushes(t, push)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 draft events, got %d", len(events))
+	}
+	if events[0].DialogId != 1 || events[0].Content != "hello" || events[0].ReplyId != 5 || events[0].Cleared {
+		t.Fatalf("unexpected save event: %+v", events[0])
+	}
+	if events[1].DialogId != 1 || !events[1].Cleared {
+		t.Fatalf("unexpected clear event: %+v", events[1])
+	}
+}
@@ -0,0 +1,57 @@
+package msg
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/constants"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// 阅后即焚消息扫描间隔
+	expirySweepInterval = time.Second
+	// 每次扫描最多删除的消息数量
+	expirySweepBatchSize = 200
+)
+
+func (s *ServiceImpl) sweepExpiredMsgs(ctx context.Context) {
+	msgs, err := s.med.DeleteExpiredMessages(ctx, pkgtime.Now(), expirySweepBatchSize)
+	if err != nil {
+		s.logger.Error("删除过期阅后即焚消息失败", zap.Error(err))
+	}
+	if len(msgs) == 0 || s.pushService == nil {
+		return
+	}
+
+	groupUsers := make(map[uint][]string)
+	for _, msg := range msgs {
+		data := &constants.BurnAfterReadingExpiredEventData{
+			MsgId:    uint32(msg.ID),
+			DialogId: uint32(msg.DialogID),
+			GroupId:  uint32(msg.GroupID),
+		}
+
+		if msg.Kind == entity.UserMessageKind {
+			s.SendMsgToUsers([]string{msg.SenderID, msg.ReceiverID}, "", pushv1.WSEventType_BurnAfterReadingExpiredEvent, data, true)
+			continue
+		}
+
+		uids, ok := groupUsers[msg.GroupID]
+		if !ok && s.relationGroupService != nil {
+			resp, err := s.relationGroupService.GetGroupUserIDs(ctx, &relationgrpcv1.GroupIDRequest{
+				GroupId: uint32(msg.GroupID),
+			})
+			if err != nil {
+				s.logger.Error("获取群聊成员失败", zap.Uint("group_id", msg.GroupID), zap.Error(err))
+			} else {
+				uids = resp.UserIds
+			}
+			groupUsers[msg.GroupID] = uids
+		}
+		s.SendMsgToUsers(uids, "", pushv1.WSEventType_BurnAfterReadingExpiredEvent, data, true)
+	}
+}
@@ -0,0 +1,260 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	storagegrpcv1 "github.com/cossim/coss-server/internal/storage/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+	"sort"
+)
+
+type ForwardService interface {
+	ForwardMsg(ctx context.Context, userID string, driverId string, req *v1.ForwardMsgRequest) (*v1.ForwardMsgResponse, error)
+}
+
+func (s *ServiceImpl) ForwardMsg(ctx context.Context, userID string, driverId string, req *v1.ForwardMsgRequest) (*v1.ForwardMsgResponse, error) {
+	if len(req.MsgIds) == 0 || len(req.TargetDialogIds) == 0 || len(req.Title) > entity.MaxChatRecordTitleLength {
+		return nil, code.InvalidParameter
+	}
+
+	source, items, err := s.getForwardItems(ctx, userID, uint32(req.DialogId), req.MsgIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查所有目标对话，避免部分转发成功
+	targets := make([]*relationgrpcv1.Dialog, 0, len(req.TargetDialogIds))
+	receivers := make(map[uint32]string)
+	// 目标对话中的其他用户，需要授权访问转发内容引用的非公开文件
+	grantees := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, id := range req.TargetDialogIds {
+		dialog, err := s.relationDialogService.GetDialogById(ctx, &relationgrpcv1.GetDialogByIdRequest{
+			DialogId: uint32(id),
+		})
+		if err != nil {
+			s.logger.Error("获取会话失败", zap.Error(err))
+			return nil, err
+		}
+
+		userIds, err := s.getDialogUserIds(ctx, userID, uint(dialog.Id))
+		if err != nil {
+			return nil, err
+		}
+		if userIds == nil {
+			return nil, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+		}
+		for _, uid := range userIds {
+			if _, ok := seen[uid]; ok || uid == userID {
+				continue
+			}
+			seen[uid] = struct{}{}
+			grantees = append(grantees, uid)
+		}
+
+		if dialog.Type == uint32(relationgrpcv1.DialogType_USER_DIALOG) {
+			for _, uid := range userIds {
+				if uid != userID {
+					receivers[dialog.Id] = uid
+				}
+			}
+			if receivers[dialog.Id] == "" {
+				return nil, code.DialogErrGetTargetIdFailed
+			}
+		}
+		targets = append(targets, dialog)
+	}
+
+	contents := make([]*entity.ChatRecordItem, 0, len(items))
+	if req.Merge {
+		record := &entity.ChatRecord{Title: req.Title, Messages: items}
+		content, err := record.Marshal()
+		if err != nil {
+			return nil, code.MsgErrForwardMessageFailed
+		}
+		contents = append(contents, &entity.ChatRecordItem{
+			MsgType: uint(entity.MessageTypeChatRecord),
+			Content: content,
+		})
+	} else {
+		contents = items
+	}
+
+	if err := s.shareForwardFiles(ctx, userID, source.GroupId, contents, grantees); err != nil {
+		return nil, err
+	}
+
+	resp := &v1.ForwardMsgResponse{List: make([]v1.ForwardMsgResult, 0, len(targets))}
+	for _, dialog := range targets {
+		result := v1.ForwardMsgResult{DialogId: int(dialog.Id), MsgIds: make([]int, 0, len(contents))}
+		for _, c := range contents {
+			var msgID int
+			if dialog.Type == uint32(relationgrpcv1.DialogType_USER_DIALOG) {
+				r, err := s.SendUserMsg(ctx, userID, driverId, &v1.SendUserMsgRequest{
+					DialogId:   int(dialog.Id),
+					ReceiverId: receivers[dialog.Id],
+					Content:    c.Content,
+					Type:       v1.SendUserMsgRequestType(c.MsgType),
+				})
+				if err != nil {
+					s.revertForwardedMsgs(ctx, targets, append(resp.List, result))
+					return nil, err
+				}
+				msgID = r.MsgId
+			} else {
+				r, err := s.SendGroupMsg(ctx, userID, driverId, &v1.SendGroupMsgRequest{
+					DialogId: int(dialog.Id),
+					GroupId:  int(dialog.GroupId),
+					Content:  c.Content,
+					Type:     int(c.MsgType),
+				})
+				if err != nil {
+					s.revertForwardedMsgs(ctx, targets, append(resp.List, result))
+					return nil, err
+				}
+				msgID = r.MsgId
+			}
+			result.MsgIds = append(result.MsgIds, msgID)
+		}
+		resp.List = append(resp.List, result)
+	}
+
+	return resp, nil
+}
+
+// revertForwardedMsgs 转发中途失败时撤销已经发送的消息，客户端同步时会收到删除记录
+func (s *ServiceImpl) revertForwardedMsgs(ctx context.Context, targets []*relationgrpcv1.Dialog, results []v1.ForwardMsgResult) {
+	userDialogs := make(map[int]bool, len(targets))
+	for _, dialog := range targets {
+		userDialogs[int(dialog.Id)] = dialog.Type == uint32(relationgrpcv1.DialogType_USER_DIALOG)
+	}
+	for _, result := range results {
+		for _, id := range result.MsgIds {
+			var err error
+			if userDialogs[result.DialogId] {
+				err = s.ud.SendUserMessageRevert(ctx, uint(id))
+			} else {
+				err = s.gmd.SendGroupMessageRevert(ctx, uint(id))
+			}
+			if err != nil {
+				s.logger.Error("撤销已转发的消息失败", zap.Int("dialog_id", result.DialogId), zap.Int("msg_id", id), zap.Error(err))
+			}
+		}
+	}
+}
+
+// getForwardItems 获取需要转发的消息，按发送顺序排列，同时返回消息所在的对话
+func (s *ServiceImpl) getForwardItems(ctx context.Context, userID string, dialogID uint32, msgIds []int) (*relationgrpcv1.Dialog, []*entity.ChatRecordItem, error) {
+	dialog, err := s.relationDialogService.GetDialogById(ctx, &relationgrpcv1.GetDialogByIdRequest{
+		DialogId: dialogID,
+	})
+	if err != nil {
+		s.logger.Error("获取会话失败", zap.Error(err))
+		return nil, nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, uint(dialogID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if userIds == nil {
+		return nil, nil, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+
+	ids := make([]uint, 0, len(msgIds))
+	seen := make(map[uint]struct{}, len(msgIds))
+	for _, id := range msgIds {
+		if _, ok := seen[uint(id)]; ok {
+			continue
+		}
+		seen[uint(id)] = struct{}{}
+		ids = append(ids, uint(id))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	items := make(map[uint]*entity.ChatRecordItem, len(ids))
+	// 阅后即焚消息不能被转发
+	check := func(msgDialogID uint, msgType entity.UserMessageType, isBurn bool, deletedAt int64) error {
+		if msgDialogID != uint(dialogID) || deletedAt != 0 {
+			return code.MsgErrForwardMessageFailed
+		}
+		if isBurn || !entity.IsForwardableMessageType(msgType) {
+			return code.MsgErrMessageCannotForward
+		}
+		return nil
+	}
+	if dialog.Type == uint32(relationgrpcv1.DialogType_USER_DIALOG) {
+		msgs, err := s.ud.GetUserMessagesByIds(ctx, ids)
+		if err != nil {
+			s.logger.Error("获取用户消息失败", zap.Error(err))
+			return nil, nil, err
+		}
+		for _, m := range msgs {
+			if err := check(m.DialogId, m.Type, m.IsBurnAfterReading, m.DeletedAt); err != nil {
+				return nil, nil, err
+			}
+			items[m.ID] = &entity.ChatRecordItem{MsgType: uint(m.Type), Content: m.Content, SenderId: m.SendID, SendAt: m.CreatedAt}
+		}
+	} else {
+		msgs, err := s.gmd.GetGroupMessagesByIds(ctx, ids)
+		if err != nil {
+			s.logger.Error("获取群聊消息失败", zap.Error(err))
+			return nil, nil, err
+		}
+		for _, m := range msgs {
+			if err := check(m.DialogID, m.Type, m.IsBurnAfterReading, m.DeletedAt); err != nil {
+				return nil, nil, err
+			}
+			items[m.ID] = &entity.ChatRecordItem{MsgType: uint(m.Type), Content: m.Content, SenderId: m.UserID, SendAt: m.CreatedAt}
+		}
+	}
+	if len(items) != len(ids) {
+		return nil, nil, code.MsgErrForwardMessageFailed
+	}
+
+	senders := make([]string, 0, len(items))
+	for _, v := range items {
+		senders = append(senders, v.SenderId)
+	}
+	infos := s.getSenderInfos(ctx, senders)
+
+	result := make([]*entity.ChatRecordItem, 0, len(ids))
+	for _, id := range ids {
+		item := items[id]
+		if info, ok := infos[item.SenderId]; ok {
+			item.SenderName = info.Name
+			item.SenderAvatar = info.Avatar
+		}
+		result = append(result, item)
+	}
+	return dialog, result, nil
+}
+
+// shareForwardFiles 授权新的接收者访问转发内容引用的文件，groupID 为转发的消息所在的群聊
+func (s *ServiceImpl) shareForwardFiles(ctx context.Context, userID string, groupID uint32, contents []*entity.ChatRecordItem, grantees []string) error {
+	fileIds := make([]string, 0)
+	for _, c := range contents {
+		fileIds = append(fileIds, entity.ExtractStorageFileIDs(c.Content)...)
+	}
+	if len(fileIds) == 0 {
+		return nil
+	}
+
+	if s.storageService == nil {
+		s.logger.Warn("存储服务未初始化，跳过共享转发文件", zap.Strings("file_ids", fileIds))
+		return nil
+	}
+	if _, err := s.storageService.ShareFiles(ctx, &storagegrpcv1.ShareFilesRequest{
+		FileIDs:  fileIds,
+		UserID:   userID,
+		GroupID:  groupID,
+		Grantees: grantees,
+	}); err != nil {
+		s.logger.Error("共享转发文件失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -159,7 +159,17 @@ func (s *ServiceImpl) SendGroupMsg(ctx context.Context, userID string, driverId
 		GroupId: uint32(req.GroupId),
 	})
 
+	// 回复普通消息时归入被回复消息所在的话题
+	var threadID uint
+	if req.ReplyId != 0 && !isPromptMessageType(uint32(req.Type)) {
+		threadID, err = s.mtd.GetGroupThreadRootID(ctx, uint(dialogID), uint(req.ReplyId))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var msgID uint32
+	var msgSeq uint64
 	var groupID uint32
 	workflow.InitGrpc(s.dtmGrpcServer, "", grpc.NewServer())
 	gid := shortuuid.New()
@@ -195,6 +205,7 @@ func (s *ServiceImpl) SendGroupMsg(ctx context.Context, userID string, driverId
 			Content:   req.Content,
 			Type:      entity.UserMessageType(req.Type),
 			ReplyId:   uint(req.ReplyId),
+			ThreadId:  threadID,
 			AtUsers:   req.AtUsers,
 			AtAllUser: entity.AtAllUserType(isAtAll),
 		})
@@ -206,6 +217,7 @@ func (s *ServiceImpl) SendGroupMsg(ctx context.Context, userID string, driverId
 		fmt.Println("发送消息成功", mg.ID)
 
 		msgID = uint32(mg.ID)
+		msgSeq = mg.Seq
 		groupID = uint32(mg.GroupID)
 		wf.NewBranch().OnRollback(func(bb *dtmcli.BranchBarrier) error {
 			err := s.gmd.SendGroupMessageRevert(wf.Context, mg.ID)
@@ -236,46 +248,37 @@ func (s *ServiceImpl) SendGroupMsg(ctx context.Context, userID string, driverId
 		return nil, code.MsgErrInsertGroupMessageFailed
 	}
 
+	// 消息已经写入，后面的失败只记录日志，不能让调用方当作发送失败处理
 	//查询发送者信息
-	info, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
-		UserId: userID,
-	})
-	if err != nil {
-		return nil, err
-	}
+	info := s.senderInfo(ctx, userID)
 
 	resp := &v1.SendGroupMsgResponse{
 		MsgId: int(msgID),
+		Seq:   int(msgSeq),
 	}
 
 	if req.ReplyId != 0 {
 		msg, err := s.gmd.GetGroupMessageById(ctx, uint(req.ReplyId))
 		if err != nil {
-			return nil, err
-		}
-
-		userInfo, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
-			UserId: msg.UserID,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		resp.ReplyMsg = &v1.Message{
-			MsgType:  int(msg.Type),
-			Content:  msg.Content,
-			SenderId: msg.UserID,
-			SendAt:   int(msg.CreatedAt),
-			MsgId:    int(msg.ID),
-			SenderInfo: &v1.SenderInfo{
-				UserId: userInfo.UserId,
-				Name:   userInfo.NickName,
-				Avatar: userInfo.Avatar,
-			},
-			ReplyId: int(msg.ReplyId),
-		}
-		if msg.IsLabel != 0 {
-			resp.ReplyMsg.IsLabel = true
+			s.logger.Error("获取消息失败", zap.Error(err))
+		} else {
+			userInfo := s.senderInfo(ctx, msg.UserID)
+			resp.ReplyMsg = &v1.Message{
+				MsgType:  int(msg.Type),
+				Content:  msg.Content,
+				SenderId: msg.UserID,
+				SendAt:   int(msg.CreatedAt),
+				MsgId:    int(msg.ID),
+				SenderInfo: &v1.SenderInfo{
+					UserId: userInfo.UserId,
+					Name:   userInfo.NickName,
+					Avatar: userInfo.Avatar,
+				},
+				ReplyId: int(msg.ReplyId),
+			}
+			if msg.IsLabel != 0 {
+				resp.ReplyMsg.IsLabel = true
+			}
 		}
 	}
 
@@ -319,8 +322,14 @@ func (s *ServiceImpl) SendGroupMsg(ctx context.Context, userID string, driverId
 			UserId: userID,
 		},
 		ReplyMsg: rmsg,
+		ThreadId: uint32(threadID),
+		Seq:      msgSeq,
 	})
 
+	if threadID != 0 {
+		s.pushThreadUpdate(ctx, entity.GroupMessageKind, uids.UserIds, driverId, uint(dialogID), uint(req.GroupId), threadID, userID)
+	}
+
 	return resp, nil
 }
 
@@ -361,14 +370,20 @@ func (s *ServiceImpl) EditGroupMsg(ctx context.Context, userID string, driverId
 	//		Content: content,
 	//	},
 	//})
-	err = s.gmd.EditGroupMessage(ctx, &entity.GroupMessage{BaseModel: entity.BaseModel{ID: uint(msgID)}, Content: content})
+	editedAt := pkgtime.Now()
+	if err := s.addGroupMsgRevision(ctx, msginfo, userID, entity.RevisionActionEdit, editedAt); err != nil {
+		return nil, err
+	}
+
+	err = s.gmd.EditGroupMessage(ctx, &entity.GroupMessage{BaseModel: entity.BaseModel{ID: uint(msgID)}, Content: content, EditedAt: editedAt})
 	if err != nil {
 		s.logger.Error("编辑群消息失败", zap.Error(err))
 		return nil, err
 	}
 
 	msginfo.Content = content
-	s.SendMsgToUsers(userIds.UserIds, driverId, pushv1.WSEventType_EditMsgEvent, msginfo, true)
+	msginfo.EditedAt = editedAt
+	s.SendMsgToUsersAndOtherDevices(userIds.UserIds, userID, driverId, pushv1.WSEventType_EditMsgEvent, msginfo, true)
 
 	return msgID, nil
 }
@@ -425,6 +440,10 @@ func (s *ServiceImpl) RecallGroupMsg(ctx context.Context, userID string, driverI
 		return nil, err
 	}
 
+	if err := s.addGroupMsgRevision(ctx, msginfo, userID, entity.RevisionActionRecall, pkgtime.Now()); err != nil {
+		return nil, err
+	}
+
 	// 调用相应的 gRPC 客户端方法来撤回群消息
 	err = s.gmd.DeleteGroupMessage(ctx, uint(msgID), false)
 	if err != nil {
@@ -568,13 +587,31 @@ func (s *ServiceImpl) GetGroupMessageList(c context.Context, id string, request
 		return nil, err
 	}
 
-	msg, total, err := s.gmd.GetGroupMessageList(c, &entity.GroupMessage{
-		DialogID:  uint(request.DialogId),
-		UserID:    *request.UserId,
-		Content:   *request.Content,
-		Type:      entity.UserMessageType(*request.Type),
-		BaseModel: entity.BaseModel{ID: uint(*request.MsgId)},
-	}, request.PageSize, request.PageNum)
+	//先取同步序号，客户端从该序号增量同步不会漏掉之后的变更
+	syncSeq := s.getDialogSyncSeq(c, uint(request.DialogId))
+
+	var msg []*entity.GroupMessage
+	var total int64
+	var hasMore bool
+	var cursorQuery *entity.MessageCursorQuery
+	if request.Cursor != nil || request.Direction != nil {
+		cursorQuery, err = newMessageCursorQuery(request.DialogId, request.Cursor, request.Direction, request.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		cursorQuery.SendID = *request.UserId
+		cursorQuery.MsgType = entity.UserMessageType(*request.Type)
+		cursorQuery.Content = *request.Content
+		msg, hasMore, err = s.mcd.GetGroupMsgsByCursor(c, cursorQuery)
+	} else {
+		msg, total, err = s.gmd.GetGroupMessageList(c, &entity.GroupMessage{
+			DialogID:  uint(request.DialogId),
+			UserID:    *request.UserId,
+			Content:   *request.Content,
+			Type:      entity.UserMessageType(*request.Type),
+			BaseModel: entity.BaseModel{ID: uint(*request.MsgId)},
+		}, request.PageSize, request.PageNum)
+	}
 	if err != nil {
 		s.logger.Error("获取群聊消息列表失败", zap.Error(err))
 		return nil, err
@@ -583,6 +620,18 @@ func (s *ServiceImpl) GetGroupMessageList(c context.Context, id string, request
 	resp := &v1.GetGroupMsgListResponse{}
 	resp.CurrentPage = request.PageNum
 	resp.Total = int(total)
+	resp.SyncSeq = syncSeq
+
+	msgIds := make([]uint, 0, len(msg))
+	for _, v := range msg {
+		msgIds = append(msgIds, v.ID)
+	}
+	if cursorQuery != nil {
+		resp.NextCursor, resp.PrevCursor = messageCursors(cursorQuery, msgIds)
+		resp.HasMore = hasMore
+	}
+	reactions := s.getMsgReactions(c, entity.GroupMessageKind, msgIds)
+	threads := s.getThreadInfos(c, entity.GroupMessageKind, id, msgIds)
 
 	msgList := make([]v1.GroupMessage, 0)
 	for _, v := range msg {
@@ -637,25 +686,32 @@ func (s *ServiceImpl) GetGroupMessageList(c context.Context, id string, request
 			isAtAll = true
 		}
 		msgList = append(msgList, v1.GroupMessage{
-			MsgId:     int(v.ID),
-			Content:   v.Content,
-			GroupId:   int(v.GroupID),
-			Type:      int(v.Type),
-			SendAt:    int(v.CreatedAt),
-			DialogId:  int(v.DialogID),
-			IsLabel:   isLabel,
-			ReadCount: v.ReadCount,
-			ReplyId:   int(v.ReplyId),
-			UserId:    v.UserID,
-			AtUsers:   v.AtUsers,
-			ReadAt:    ReadAt,
-			IsRead:    isReadFlag,
-			AtAllUser: isAtAll,
+			MsgId:          int(v.ID),
+			Content:        v.Content,
+			GroupId:        int(v.GroupID),
+			Type:           int(v.Type),
+			SendAt:         int(v.CreatedAt),
+			DialogId:       int(v.DialogID),
+			Seq:            int(v.Seq),
+			EditedAt:       int(v.EditedAt),
+			IsLabel:        isLabel,
+			ReadCount:      v.ReadCount,
+			ReplyId:        int(v.ReplyId),
+			DeliveredCount: v.DeliveredCount,
+			DeliveryStatus: int(v.DeliveryStatus()),
+			ThreadId:       int(v.ThreadId),
+			Thread:         threads[v.ID],
+			UserId:         v.UserID,
+			AtUsers:        v.AtUsers,
+			ReadAt:         ReadAt,
+			IsRead:         isReadFlag,
+			AtAllUser:      isAtAll,
 			SenderInfo: &v1.SenderInfo{
 				Name:   name,
 				UserId: info.UserId,
 				Avatar: info.Avatar,
 			},
+			Reactions: reactions[v.ID],
 		})
 	}
 	resp.GroupMessages = &msgList
@@ -719,6 +775,14 @@ func (s *ServiceImpl) SetGroupMessagesRead(c context.Context, uid string, driver
 			return err
 		}
 
+		readIds := make([]uint, 0, len(resp1))
+		for _, v := range resp1 {
+			readIds = append(readIds, v.MsgID)
+		}
+		if err := s.med.StartBurnCountdown(c, entity.GroupMessageKind, readIds, uid, pkgtime.Now(), entity.DefaultBurnAfterReadingTimeout); err != nil {
+			s.logger.Error("设置阅后即焚过期时间失败", zap.Error(err))
+		}
+
 		//给消息发送者推送谁读了消息
 		for _, v := range resp1 {
 			if v.UserID != uid {
@@ -782,6 +846,15 @@ func (s *ServiceImpl) SetGroupMessagesRead(c context.Context, uid string, driver
 		return err
 	}
 
+	readIds := make([]uint, 0, len(msgList))
+	for _, v := range msgList {
+		readIds = append(readIds, v.MsgID)
+	}
+	// 群聊没有单独的阅后即焚时间设置，由发送者以外的成员首次阅读后开始计时
+	if err := s.med.StartBurnCountdown(c, entity.GroupMessageKind, readIds, uid, pkgtime.Now(), entity.DefaultBurnAfterReadingTimeout); err != nil {
+		s.logger.Error("设置阅后即焚过期时间失败", zap.Error(err))
+	}
+
 	ids := make([]uint, 0)
 	for _, v := range req.MsgIds {
 		ids = append(ids, uint(v))
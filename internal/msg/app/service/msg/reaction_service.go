@@ -0,0 +1,182 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"go.uber.org/zap"
+	"strings"
+)
+
+type ReactionService interface {
+	AddUserMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error)
+	RemoveUserMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error)
+	AddGroupMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error)
+	RemoveGroupMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error)
+}
+
+func (s *ServiceImpl) AddUserMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error) {
+	return s.reactUserMsg(ctx, userID, driverId, msgID, emoji, true)
+}
+
+func (s *ServiceImpl) RemoveUserMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error) {
+	return s.reactUserMsg(ctx, userID, driverId, msgID, emoji, false)
+}
+
+func (s *ServiceImpl) AddGroupMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error) {
+	return s.reactGroupMsg(ctx, userID, driverId, msgID, emoji, true)
+}
+
+func (s *ServiceImpl) RemoveGroupMsgReaction(ctx context.Context, userID string, driverId string, msgID uint32, emoji string) (*v1.MessageReactionResponse, error) {
+	return s.reactGroupMsg(ctx, userID, driverId, msgID, emoji, false)
+}
+
+func (s *ServiceImpl) reactUserMsg(ctx context.Context, userID string, driverId string, msgID uint32, emoji string, add bool) (*v1.MessageReactionResponse, error) {
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" || len(emoji) > entity.MaxReactionEmojiLength {
+		return nil, code.InvalidParameter
+	}
+
+	msginfo, err := s.ud.GetUserMessageById(ctx, uint(msgID))
+	if err != nil {
+		s.logger.Error("获取用户消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	if isPromptMessageType(uint32(msginfo.Type)) {
+		return nil, code.MsgErrAddReactionFailed
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, msginfo.DialogId)
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.RelationUserErrFriendRelationNotFound
+	}
+
+	return s.react(ctx, entity.UserMessageKind, msginfo.ID, msginfo.DialogId, 0, userIds, userID, driverId, emoji, add)
+}
+
+func (s *ServiceImpl) reactGroupMsg(ctx context.Context, userID string, driverId string, msgID uint32, emoji string, add bool) (*v1.MessageReactionResponse, error) {
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" || len(emoji) > entity.MaxReactionEmojiLength {
+		return nil, code.InvalidParameter
+	}
+
+	msginfo, err := s.gmd.GetGroupMessageById(ctx, uint(msgID))
+	if err != nil {
+		s.logger.Error("获取群聊消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	if isPromptMessageType(uint32(msginfo.Type)) {
+		return nil, code.MsgErrAddReactionFailed
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, msginfo.DialogID)
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.RelationGroupErrNotInGroup
+	}
+
+	return s.react(ctx, entity.GroupMessageKind, msginfo.ID, msginfo.DialogID, msginfo.GroupID, userIds, userID, driverId, emoji, add)
+}
+
+func (s *ServiceImpl) react(ctx context.Context, kind entity.MessageKind, msgID, dialogID, groupID uint, userIds []string, userID string, driverId string, emoji string, add bool) (*v1.MessageReactionResponse, error) {
+	var err error
+	if add {
+		err = s.mrd.AddReaction(ctx, &entity.MessageReaction{
+			Kind:     kind,
+			MsgID:    msgID,
+			DialogID: dialogID,
+			UserID:   userID,
+			Emoji:    emoji,
+		})
+	} else {
+		err = s.mrd.RemoveReaction(ctx, kind, msgID, userID, emoji)
+	}
+	if err != nil {
+		s.logger.Error("修改消息表情回应失败", zap.Error(err))
+		return nil, err
+	}
+
+	reactions := s.getMsgReactions(ctx, kind, []uint{msgID})[msgID]
+	if reactions == nil {
+		reactions = make([]v1.MessageReaction, 0)
+	}
+
+	data := &constants.MessageReactionEventData{
+		MsgId:     uint32(msgID),
+		DialogId:  uint32(dialogID),
+		GroupId:   uint32(groupID),
+		UserId:    userID,
+		Emoji:     emoji,
+		Add:       add,
+		Reactions: make([]constants.MessageReaction, 0, len(reactions)),
+	}
+	for _, r := range reactions {
+		data.Reactions = append(data.Reactions, constants.MessageReaction{
+			Emoji:   r.Emoji,
+			Count:   r.Count,
+			UserIds: r.UserIds,
+		})
+	}
+	s.SendMsgToUsersAndOtherDevices(userIds, userID, driverId, pushv1.WSEventType_MessageReactionEvent, data, false)
+
+	return &v1.MessageReactionResponse{
+		MsgId:     int(msgID),
+		Reactions: reactions,
+	}, nil
+}
+
+// getDialogUserIds 获取对话内所有成员，当前用户不在对话内时返回nil
+func (s *ServiceImpl) getDialogUserIds(ctx context.Context, userID string, dialogID uint) ([]string, error) {
+	users, err := s.relationDialogService.GetDialogUsersByDialogID(ctx, &relationgrpcv1.GetDialogUsersByDialogIDRequest{
+		DialogId: uint32(dialogID),
+	})
+	if err != nil {
+		s.logger.Error("获取对话用户失败", zap.Error(err))
+		return nil, err
+	}
+
+	for _, v := range users.UserIds {
+		if v == userID {
+			return users.UserIds, nil
+		}
+	}
+	return nil, nil
+}
+
+// getMsgReactions 批量获取消息的表情回应，获取失败时只记录日志
+func (s *ServiceImpl) getMsgReactions(ctx context.Context, kind entity.MessageKind, msgIDs []uint) map[uint][]v1.MessageReaction {
+	result := make(map[uint][]v1.MessageReaction)
+	if len(msgIDs) == 0 {
+		return result
+	}
+
+	summaries, err := s.mrd.GetReactions(ctx, kind, msgIDs)
+	if err != nil {
+		s.logger.Error("获取消息表情回应失败", zap.Error(err))
+		return result
+	}
+
+	for msgID, list := range summaries {
+		reactions := make([]v1.MessageReaction, 0, len(list))
+		for _, r := range list {
+			reactions = append(reactions, v1.MessageReaction{
+				Emoji:   r.Emoji,
+				Count:   r.Count,
+				UserIds: r.UserIDs,
+			})
+		}
+		result[msgID] = reactions
+	}
+	return result
+}
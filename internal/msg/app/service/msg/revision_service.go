@@ -0,0 +1,219 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+)
+
+type RevisionService interface {
+	GetUserMsgRevisions(ctx context.Context, userID string, msgID uint32) (*v1.MessageRevisionListResponse, error)
+	GetGroupMsgRevisions(ctx context.Context, userID string, msgID uint32) (*v1.MessageRevisionListResponse, error)
+	GetGroupRevisionPolicy(ctx context.Context, userID string, groupID uint32) (*v1.GroupRevisionPolicy, error)
+	SetGroupRevisionPolicy(ctx context.Context, userID string, groupID uint32, req *v1.SetGroupRevisionPolicyRequest) (*v1.GroupRevisionPolicy, error)
+}
+
+// addUserMsgRevision 在私聊消息被编辑或撤回前保存原内容，阅后即焚消息不保存
+func (s *ServiceImpl) addUserMsgRevision(ctx context.Context, msg *entity.UserMessage, editorID string, action entity.RevisionAction, editedAt int64) error {
+	if msg.IsBurnAfterReading {
+		return nil
+	}
+	err := s.mrvd.AddRevision(ctx, &entity.MessageRevision{
+		Kind:     entity.UserMessageKind,
+		MsgID:    msg.ID,
+		DialogID: msg.DialogId,
+		SenderID: msg.SendID,
+		EditorID: editorID,
+		Action:   action,
+		Content:  msg.Content,
+		EditedAt: editedAt,
+	})
+	if err != nil {
+		s.logger.Error("保存消息修订记录失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// addGroupMsgRevision 在群聊消息被编辑或撤回前保存原内容，阅后即焚消息不保存
+func (s *ServiceImpl) addGroupMsgRevision(ctx context.Context, msg *entity.GroupMessage, editorID string, action entity.RevisionAction, editedAt int64) error {
+	if msg.IsBurnAfterReading {
+		return nil
+	}
+	err := s.mrvd.AddRevision(ctx, &entity.MessageRevision{
+		Kind:     entity.GroupMessageKind,
+		MsgID:    msg.ID,
+		DialogID: msg.DialogID,
+		GroupID:  msg.GroupID,
+		SenderID: msg.UserID,
+		EditorID: editorID,
+		Action:   action,
+		Content:  msg.Content,
+		EditedAt: editedAt,
+	})
+	if err != nil {
+		s.logger.Error("保存消息修订记录失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *ServiceImpl) GetUserMsgRevisions(ctx context.Context, userID string, msgID uint32) (*v1.MessageRevisionListResponse, error) {
+	revisions, err := s.mrvd.GetRevisions(ctx, entity.UserMessageKind, uint(msgID))
+	if err != nil {
+		s.logger.Error("获取消息修订记录失败", zap.Error(err))
+		return nil, err
+	}
+
+	// 修订记录中保存了发送者，撤回后消息不可见时也能校验权限
+	var senderID string
+	if len(revisions) > 0 {
+		senderID = revisions[0].SenderID
+	} else {
+		msginfo, err := s.ud.GetUserMessageById(ctx, uint(msgID))
+		if err != nil {
+			s.logger.Error("获取用户消息失败", zap.Error(err))
+			return nil, err
+		}
+		senderID = msginfo.SendID
+	}
+
+	if senderID != userID {
+		return nil, code.Forbidden
+	}
+
+	return revisionsToResponse(uint(msgID), senderID, revisions), nil
+}
+
+func (s *ServiceImpl) GetGroupMsgRevisions(ctx context.Context, userID string, msgID uint32) (*v1.MessageRevisionListResponse, error) {
+	revisions, err := s.mrvd.GetRevisions(ctx, entity.GroupMessageKind, uint(msgID))
+	if err != nil {
+		s.logger.Error("获取消息修订记录失败", zap.Error(err))
+		return nil, err
+	}
+
+	var senderID string
+	var groupID uint
+	if len(revisions) > 0 {
+		senderID = revisions[0].SenderID
+		groupID = revisions[0].GroupID
+	} else {
+		msginfo, err := s.gmd.GetGroupMessageById(ctx, uint(msgID))
+		if err != nil {
+			s.logger.Error("获取群聊消息失败", zap.Error(err))
+			return nil, err
+		}
+		senderID = msginfo.UserID
+		groupID = msginfo.GroupID
+	}
+
+	if senderID != userID {
+		if err := s.checkGroupRevisionAccess(ctx, userID, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return revisionsToResponse(uint(msgID), senderID, revisions), nil
+}
+
+// checkGroupRevisionAccess 校验群主或管理员能否按群聊的可见策略查看他人消息的修订记录
+func (s *ServiceImpl) checkGroupRevisionAccess(ctx context.Context, userID string, groupID uint) error {
+	relation, err := s.relationGroupService.GetGroupRelation(ctx, &relationgrpcv1.GetGroupRelationRequest{
+		GroupId: uint32(groupID),
+		UserId:  userID,
+	})
+	if err != nil {
+		s.logger.Error("获取群聊关系失败", zap.Error(err))
+		return err
+	}
+
+	policy, err := s.mrvd.GetGroupPolicy(ctx, groupID)
+	if err != nil {
+		s.logger.Error("获取修订记录可见策略失败", zap.Error(err))
+		return err
+	}
+
+	owner := relation.Identity == relationgrpcv1.GroupIdentity_IDENTITY_OWNER
+	admin := relation.Identity == relationgrpcv1.GroupIdentity_IDENTITY_ADMIN
+	if !policy.CanView(owner, admin) {
+		return code.Forbidden
+	}
+	return nil
+}
+
+func (s *ServiceImpl) GetGroupRevisionPolicy(ctx context.Context, userID string, groupID uint32) (*v1.GroupRevisionPolicy, error) {
+	_, err := s.relationGroupService.GetGroupRelation(ctx, &relationgrpcv1.GetGroupRelationRequest{
+		GroupId: groupID,
+		UserId:  userID,
+	})
+	if err != nil {
+		s.logger.Error("获取群聊关系失败", zap.Error(err))
+		return nil, err
+	}
+
+	policy, err := s.mrvd.GetGroupPolicy(ctx, uint(groupID))
+	if err != nil {
+		s.logger.Error("获取修订记录可见策略失败", zap.Error(err))
+		return nil, err
+	}
+	return revisionPolicyToResponse(policy), nil
+}
+
+func (s *ServiceImpl) SetGroupRevisionPolicy(ctx context.Context, userID string, groupID uint32, req *v1.SetGroupRevisionPolicyRequest) (*v1.GroupRevisionPolicy, error) {
+	relation, err := s.relationGroupService.GetGroupRelation(ctx, &relationgrpcv1.GetGroupRelationRequest{
+		GroupId: groupID,
+		UserId:  userID,
+	})
+	if err != nil {
+		s.logger.Error("获取群聊关系失败", zap.Error(err))
+		return nil, err
+	}
+
+	if relation.Identity != relationgrpcv1.GroupIdentity_IDENTITY_OWNER {
+		return nil, code.Forbidden
+	}
+
+	if req.Visibility < 0 || !entity.GroupRevisionVisibility(req.Visibility).IsValid() {
+		return nil, code.InvalidParameter
+	}
+
+	policy := &entity.GroupRevisionPolicy{
+		GroupID:    uint(groupID),
+		Visibility: entity.GroupRevisionVisibility(req.Visibility),
+		UpdatedBy:  userID,
+	}
+	if err := s.mrvd.SetGroupPolicy(ctx, policy); err != nil {
+		s.logger.Error("设置修订记录可见策略失败", zap.Error(err))
+		return nil, err
+	}
+	return revisionPolicyToResponse(policy), nil
+}
+
+func revisionsToResponse(msgID uint, senderID string, revisions []*entity.MessageRevision) *v1.MessageRevisionListResponse {
+	list := make([]v1.MessageRevision, 0, len(revisions))
+	for _, v := range revisions {
+		list = append(list, v1.MessageRevision{
+			MsgId:    int(v.MsgID),
+			EditorId: v.EditorID,
+			Action:   int(v.Action),
+			Content:  v.Content,
+			EditedAt: int(v.EditedAt),
+		})
+	}
+	return &v1.MessageRevisionListResponse{
+		MsgId:    int(msgID),
+		SenderId: senderID,
+		List:     list,
+	}
+}
+
+func revisionPolicyToResponse(policy *entity.GroupRevisionPolicy) *v1.GroupRevisionPolicy {
+	return &v1.GroupRevisionPolicy{
+		GroupId:    int(policy.GroupID),
+		Visibility: int(policy.Visibility),
+		UpdatedBy:  policy.UpdatedBy,
+	}
+}
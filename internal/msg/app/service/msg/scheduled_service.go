@@ -0,0 +1,317 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"github.com/lithammer/shortuuid/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// 定时消息扫描间隔
+	scheduledDispatchInterval = 5 * time.Second
+	// 每次扫描最多发送的定时消息数量
+	scheduledDispatchBatchSize = 100
+	// 每次领取的定时消息数量，逐条发送的最长时间不能超过领取超时时间
+	scheduledClaimBatchSize = 5
+	// 领取后必须在该时间内完成发送，超时的消息会被标记为失败而不是重发
+	scheduledClaimTimeout = 5 * time.Minute
+	// 单条定时消息的发送超时时间
+	scheduledSendTimeout = 30 * time.Second
+)
+
+type ScheduledService interface {
+	CreateScheduledMsg(ctx context.Context, userID string, driverId string, req *v1.CreateScheduledMsgRequest) (*v1.ScheduledMessage, error)
+	ListScheduledMsg(ctx context.Context, userID string, req v1.ListScheduledMsgParams) (*v1.ListScheduledMsgResponse, error)
+	EditScheduledMsg(ctx context.Context, userID string, id uint32, req *v1.EditScheduledMsgRequest) (*v1.ScheduledMessage, error)
+	CancelScheduledMsg(ctx context.Context, userID string, id uint32) error
+}
+
+func (s *ServiceImpl) CreateScheduledMsg(ctx context.Context, userID string, driverId string, req *v1.CreateScheduledMsgRequest) (*v1.ScheduledMessage, error) {
+	if err := checkScheduledMsg(entity.UserMessageType(req.Type), req.Content, int64(req.SendAt)); err != nil {
+		return nil, err
+	}
+
+	dialog, err := s.relationDialogService.GetDialogById(ctx, &relationgrpcv1.GetDialogByIdRequest{
+		DialogId: uint32(req.DialogId),
+	})
+	if err != nil {
+		s.logger.Error("获取会话失败", zap.Error(err))
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, uint(dialog.Id))
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+
+	msg := &entity.ScheduledMessage{
+		UserID:             userID,
+		DriverID:           driverId,
+		DialogID:           uint(dialog.Id),
+		Type:               entity.UserMessageType(req.Type),
+		Content:            req.Content,
+		ReplyId:            uint(req.ReplyId),
+		IsBurnAfterReading: req.IsBurnAfterReading,
+		SendAt:             int64(req.SendAt),
+	}
+
+	if dialog.Type == uint32(relationgrpcv1.DialogType_USER_DIALOG) {
+		msg.Kind = entity.UserMessageKind
+		for _, uid := range userIds {
+			if uid != userID {
+				msg.ReceiverID = uid
+			}
+		}
+		if msg.ReceiverID == "" {
+			return nil, code.DialogErrGetTargetIdFailed
+		}
+	} else {
+		msg.Kind = entity.GroupMessageKind
+		msg.GroupID = uint(dialog.GroupId)
+		msg.AtUsers = req.AtUsers
+		msg.AtAllUser = req.AtAllUser
+	}
+
+	if err := s.smd.CreateScheduledMessage(ctx, msg); err != nil {
+		s.logger.Error("创建定时消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	return scheduledMsgToResponse(msg), nil
+}
+
+func (s *ServiceImpl) ListScheduledMsg(ctx context.Context, userID string, req v1.ListScheduledMsgParams) (*v1.ListScheduledMsgResponse, error) {
+	query := &entity.ScheduledMessageQuery{
+		UserID:   userID,
+		PageNum:  req.PageNum,
+		PageSize: req.PageSize,
+	}
+	if req.DialogId != nil {
+		query.DialogID = uint(*req.DialogId)
+	}
+	if req.Status != nil {
+		st := entity.ScheduledMessageStatus(*req.Status)
+		query.Status = &st
+	}
+
+	list, total, err := s.smd.ListScheduledMessages(ctx, query)
+	if err != nil {
+		s.logger.Error("获取定时消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	resp := &v1.ListScheduledMsgResponse{
+		List:        make([]v1.ScheduledMessage, 0, len(list)),
+		Total:       int(total),
+		CurrentPage: req.PageNum,
+	}
+	for _, v := range list {
+		resp.List = append(resp.List, *scheduledMsgToResponse(v))
+	}
+	return resp, nil
+}
+
+func (s *ServiceImpl) EditScheduledMsg(ctx context.Context, userID string, id uint32, req *v1.EditScheduledMsgRequest) (*v1.ScheduledMessage, error) {
+	if err := checkScheduledMsg(entity.UserMessageType(req.Type), req.Content, int64(req.SendAt)); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.smd.GetScheduledMessage(ctx, uint(id), userID)
+	if err != nil {
+		return nil, err
+	}
+	if !msg.IsPending() {
+		return nil, code.MsgErrScheduledMsgNotPending
+	}
+
+	msg.Type = entity.UserMessageType(req.Type)
+	msg.Content = req.Content
+	msg.ReplyId = uint(req.ReplyId)
+	msg.IsBurnAfterReading = req.IsBurnAfterReading
+	msg.SendAt = int64(req.SendAt)
+	if msg.Kind == entity.GroupMessageKind {
+		msg.AtUsers = req.AtUsers
+		msg.AtAllUser = req.AtAllUser
+	}
+
+	if err := s.smd.UpdateScheduledMessage(ctx, msg); err != nil {
+		s.logger.Error("修改定时消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	msg, err = s.smd.GetScheduledMessage(ctx, uint(id), userID)
+	if err != nil {
+		return nil, err
+	}
+	return scheduledMsgToResponse(msg), nil
+}
+
+func (s *ServiceImpl) CancelScheduledMsg(ctx context.Context, userID string, id uint32) error {
+	if _, err := s.smd.GetScheduledMessage(ctx, uint(id), userID); err != nil {
+		return err
+	}
+	if err := s.smd.CancelScheduledMessage(ctx, uint(id), userID); err != nil {
+		s.logger.Error("取消定时消息失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func checkScheduledMsg(msgType entity.UserMessageType, content string, sendAt int64) error {
+	if !entity.IsSchedulableMessageType(msgType) || content == "" {
+		return code.InvalidParameter
+	}
+	now := pkgtime.Now()
+	if sendAt <= now || sendAt > now+entity.MaxScheduledMessageDelay {
+		return code.MsgErrInvalidScheduledSendAt
+	}
+	return nil
+}
+
+func scheduledMsgToResponse(msg *entity.ScheduledMessage) *v1.ScheduledMessage {
+	atUsers := msg.AtUsers
+	if atUsers == nil {
+		atUsers = []string{}
+	}
+	return &v1.ScheduledMessage{
+		Id:                 int(msg.ID),
+		DialogId:           int(msg.DialogID),
+		DialogType:         int(msg.Kind),
+		ReceiverId:         msg.ReceiverID,
+		GroupId:            int(msg.GroupID),
+		Type:               int(msg.Type),
+		Content:            msg.Content,
+		ReplyId:            int(msg.ReplyId),
+		AtUsers:            atUsers,
+		AtAllUser:          msg.AtAllUser,
+		IsBurnAfterReading: msg.IsBurnAfterReading,
+		SendAt:             int(msg.SendAt),
+		Status:             int(msg.Status),
+		MsgId:              int(msg.MsgID),
+		FailReason:         msg.FailReason,
+		CreatedAt:          int(msg.CreatedAt),
+		UpdatedAt:          int(msg.UpdatedAt),
+	}
+}
+
+// scheduledDispatcher 返回定时消息分发任务，每个实例使用不同的标识领取消息，多个实例同时运行时不会重复发送
+func (s *ServiceImpl) scheduledDispatcher() func(ctx context.Context) {
+	owner := shortuuid.New()
+	return func(ctx context.Context) {
+		s.dispatchScheduledMsgs(ctx, owner)
+	}
+}
+
+func (s *ServiceImpl) dispatchScheduledMsgs(ctx context.Context, owner string) {
+	// 依赖的服务还没有连接时跳过，等待下次扫描
+	if s.relationUserService == nil || s.relationDialogService == nil || s.relationGroupService == nil ||
+		s.groupService == nil || s.userService == nil || s.pushService == nil {
+		return
+	}
+
+	now := pkgtime.Now()
+	// 领取后长时间未完成的消息可能已经发出，为避免重复发送直接标记为失败
+	if n, err := s.smd.FailExpiredScheduledMessages(ctx, now, "发送超时"); err != nil {
+		s.logger.Error("处理超时定时消息失败", zap.Error(err))
+	} else if n > 0 {
+		s.logger.Warn("定时消息发送超时", zap.Int64("count", n))
+	}
+
+	// 分批领取，每批都在领取超时前发送完成，避免排队等待的消息被当作超时
+	for dispatched := 0; dispatched < scheduledDispatchBatchSize && ctx.Err() == nil; {
+		now = pkgtime.Now()
+		msgs, err := s.smd.ClaimDueScheduledMessages(ctx, owner, now, now+scheduledClaimTimeout.Milliseconds(), scheduledClaimBatchSize)
+		if err != nil {
+			s.logger.Error("领取定时消息失败", zap.Error(err))
+			return
+		}
+		if len(msgs) == 0 {
+			return
+		}
+
+		for _, msg := range msgs {
+			s.sendScheduledMsg(ctx, owner, msg)
+		}
+		dispatched += len(msgs)
+	}
+}
+
+func (s *ServiceImpl) sendScheduledMsg(ctx context.Context, owner string, msg *entity.ScheduledMessage) {
+	sendCtx, cancel := context.WithTimeout(ctx, scheduledSendTimeout)
+	defer cancel()
+
+	msgID, err := s.deliverScheduledMsg(sendCtx, msg)
+	s.finishScheduledMsg(owner, msg, msgID, err)
+}
+
+// 走普通发送流程，发送时重新校验好友关系、群状态和禁言
+func (s *ServiceImpl) deliverScheduledMsg(ctx context.Context, msg *entity.ScheduledMessage) (int, error) {
+	if msg.Kind == entity.UserMessageKind {
+		resp, err := s.SendUserMsg(ctx, msg.UserID, msg.DriverID, &v1.SendUserMsgRequest{
+			DialogId:           int(msg.DialogID),
+			ReceiverId:         msg.ReceiverID,
+			Content:            msg.Content,
+			Type:               v1.SendUserMsgRequestType(msg.Type),
+			ReplyId:            int(msg.ReplyId),
+			IsBurnAfterReading: msg.IsBurnAfterReading,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return resp.MsgId, nil
+	}
+
+	resp, err := s.SendGroupMsg(ctx, msg.UserID, msg.DriverID, &v1.SendGroupMsgRequest{
+		DialogId:           int(msg.DialogID),
+		GroupId:            int(msg.GroupID),
+		Content:            msg.Content,
+		Type:               int(msg.Type),
+		ReplyId:            int(msg.ReplyId),
+		AtUsers:            msg.AtUsers,
+		AtAllUser:          msg.AtAllUser,
+		IsBurnAfterReading: msg.IsBurnAfterReading,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.MsgId, nil
+}
+
+// 保存发送结果并通知发送者，消息id不为0时消息已经写入，即使同时返回错误也按已发送处理
+func (s *ServiceImpl) finishScheduledMsg(owner string, msg *entity.ScheduledMessage, msgID int, err error) {
+	st := entity.ScheduledMessageSent
+	reason := ""
+	if err != nil && msgID == 0 {
+		s.logger.Error("发送定时消息失败", zap.Uint("id", msg.ID), zap.Error(err))
+		st = entity.ScheduledMessageFailed
+		reason = code.Cause(err).Message()
+	} else if err != nil {
+		s.logger.Error("定时消息已发送，处理发送结果失败", zap.Uint("id", msg.ID), zap.Int("msg_id", msgID), zap.Error(err))
+	}
+
+	// 使用独立的上下文保存结果，避免停止服务时丢失已发送消息的状态
+	if ok, err := s.smd.FinishScheduledMessage(context.Background(), msg.ID, owner, st, uint(msgID), reason); err != nil {
+		s.logger.Error("更新定时消息状态失败", zap.Uint("id", msg.ID), zap.Error(err))
+	} else if !ok {
+		s.logger.Warn("定时消息已不属于当前实例，未更新发送结果", zap.Uint("id", msg.ID), zap.Int("msg_id", msgID))
+	}
+
+	s.SendMsg(msg.UserID, "", pushv1.WSEventType_ScheduledMessageEvent, &constants.ScheduledMessageEventData{
+		Id:         uint32(msg.ID),
+		DialogId:   uint32(msg.DialogID),
+		Status:     uint32(st),
+		MsgId:      uint32(msgID),
+		FailReason: reason,
+	}, false)
+}
@@ -0,0 +1,69 @@
+package msg
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"testing"
+)
+
+// claimScheduledMsg 创建一条已到期的定时消息并由owner领取
+func claimScheduledMsg(t *testing.T, s *ServiceImpl, owner string) *entity.ScheduledMessage {
+	t.Helper()
+	ctx := context.Background()
+	now := pkgtime.Now()
+	if err := s.smd.CreateScheduledMessage(ctx, &entity.ScheduledMessage{
+		UserID:     "u1",
+		Kind:       entity.UserMessageKind,
+		DialogID:   1,
+		ReceiverID: "u2",
+		Content:    "hello",
+		SendAt:     now - 1000,
+	}); err != nil {
+		t.Fatalf("create scheduled message: %v", err)
+	}
+	msgs, err := s.smd.ClaimDueScheduledMessages(ctx, owner, now, now+scheduledClaimTimeout.Milliseconds(), 10)
+	if err != nil {
+		t.Fatalf("claim scheduled messages: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("claimed %d messages, want 1", len(msgs))
+	}
+	return msgs[0]
+}
+
+func TestFinishScheduledMsgSentWithEnrichmentError(t *testing.T) {
+	s, _ := newTestService(t)
+	msg := claimScheduledMsg(t, s, "node-1")
+
+	// 消息已经写入，只是后续处理失败
+	s.finishScheduledMsg("node-1", msg, 42, errors.New("user service unavailable"))
+
+	got, err := s.smd.GetScheduledMessage(context.Background(), msg.ID, "u1")
+	if err != nil {
+		t.Fatalf("get scheduled message: %v", err)
+	}
+	if got.Status != entity.ScheduledMessageSent || got.MsgID != 42 || got.FailReason != "" {
+		t.Fatalf("status = %d, msg id = %d, reason = %q, want sent with msg 42", got.Status, got.MsgID, got.FailReason)
+	}
+	if s.pushService.(*fakePushService).pushed == 0 {
+		t.Fatal("sender was not notified")
+	}
+}
+
+func TestFinishScheduledMsgFailedWhenNothingStored(t *testing.T) {
+	s, _ := newTestService(t)
+	msg := claimScheduledMsg(t, s, "node-1")
+
+	s.finishScheduledMsg("node-1", msg, 0, code.RelationUserErrFriendRelationNotFound)
+
+	got, err := s.smd.GetScheduledMessage(context.Background(), msg.ID, "u1")
+	if err != nil {
+		t.Fatalf("get scheduled message: %v", err)
+	}
+	if got.Status != entity.ScheduledMessageFailed || got.MsgID != 0 || got.FailReason != code.RelationUserErrFriendRelationNotFound.Message() {
+		t.Fatalf("status = %d, msg id = %d, reason = %q, want failed", got.Status, got.MsgID, got.FailReason)
+	}
+}
@@ -0,0 +1,184 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// 历史消息补建索引的检查间隔
+	searchBackfillInterval = time.Minute
+	// 每批补建索引的消息数量
+	searchBackfillBatchSize = 500
+	// 同步其他节点消息变更到本地索引的间隔
+	searchSyncInterval = 5 * time.Second
+	// 每批同步的消息变更数量
+	searchSyncBatchSize = 500
+)
+
+type SearchService interface {
+	SearchMsg(ctx context.Context, userID string, req v1.SearchMsgParams) (*v1.SearchMsgResponse, error)
+}
+
+// backfillSearchIndex 为索引上线前的历史消息补建索引，进度保存在索引中，重启后继续
+func (s *ServiceImpl) backfillSearchIndex(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := s.msd.BackfillIndex(ctx, searchBackfillBatchSize)
+		if err != nil {
+			s.logger.Error("补建消息索引失败", zap.Error(err))
+			return
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+// syncSearchIndex 把所有节点上的消息变更同步到本节点的索引，进度保存在索引中，重启后继续
+func (s *ServiceImpl) syncSearchIndex(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := s.msd.SyncIndex(ctx, searchSyncBatchSize)
+		if err != nil {
+			s.logger.Error("同步消息索引失败", zap.Error(err))
+			return
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+func (s *ServiceImpl) SearchMsg(ctx context.Context, userID string, req v1.SearchMsgParams) (*v1.SearchMsgResponse, error) {
+	resp := &v1.SearchMsgResponse{
+		List:        make([]v1.SearchMsgHit, 0),
+		CurrentPage: req.PageNum,
+	}
+
+	// 只能搜索自己所在的对话
+	ids, err := s.relationDialogService.GetUserDialogList(ctx, &relationgrpcv1.GetUserDialogListRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		s.logger.Error("获取用户会话id失败", zap.Error(err))
+		return nil, err
+	}
+
+	dialogIds := make([]uint, 0, len(ids.DialogIds))
+	for _, id := range ids.DialogIds {
+		if req.DialogId != nil && *req.DialogId != int(id) {
+			continue
+		}
+		dialogIds = append(dialogIds, uint(id))
+	}
+	if req.DialogId != nil && len(dialogIds) == 0 {
+		return nil, code.Forbidden
+	}
+	if len(dialogIds) == 0 {
+		return resp, nil
+	}
+
+	query := &entity.MessageSearchQuery{
+		Keyword:   req.Keyword,
+		DialogIds: dialogIds,
+		PageNum:   req.PageNum,
+		PageSize:  req.PageSize,
+	}
+	if req.UserId != nil {
+		query.SendID = *req.UserId
+	}
+	if req.StartAt != nil {
+		query.StartAt = int64(*req.StartAt)
+	}
+	if req.EndAt != nil {
+		query.EndAt = int64(*req.EndAt)
+	}
+
+	res, err := s.msd.SearchMessages(ctx, query)
+	if err != nil {
+		s.logger.Error("搜索消息失败", zap.Error(err))
+		return nil, err
+	}
+	resp.Total = int(res.Total)
+	if len(res.Hits) == 0 {
+		return resp, nil
+	}
+
+	userMsgIds := make([]uint, 0)
+	groupMsgIds := make([]uint, 0)
+	for _, hit := range res.Hits {
+		if hit.Kind == entity.SearchGroupMessage {
+			groupMsgIds = append(groupMsgIds, hit.MsgID)
+		} else {
+			userMsgIds = append(userMsgIds, hit.MsgID)
+		}
+	}
+
+	userMsgs, err := s.ud.GetUserMessagesByIds(ctx, userMsgIds)
+	if err != nil {
+		s.logger.Error("获取私聊消息失败", zap.Error(err))
+		return nil, err
+	}
+	groupMsgs, err := s.gmd.GetGroupMessagesByIds(ctx, groupMsgIds)
+	if err != nil {
+		s.logger.Error("获取群聊消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	// 索引与数据库可能短暂不一致，例如其他节点上的编辑和撤回还没有同步到本节点的索引
+	// 以数据库中仍存在、仍可搜索并且内容与索引相同的消息为准
+	msgs := make(map[entity.SearchMessageKind]map[uint]*v1.Message)
+	msgs[entity.SearchUserMessage] = make(map[uint]*v1.Message)
+	msgs[entity.SearchGroupMessage] = make(map[uint]*v1.Message)
+	contents := make(map[entity.SearchMessageKind]map[uint]string)
+	contents[entity.SearchUserMessage] = make(map[uint]string)
+	contents[entity.SearchGroupMessage] = make(map[uint]string)
+	senders := make(map[string]struct{})
+	for _, m := range userMsgs {
+		if !entity.IsSearchable(m.Type, m.IsBurnAfterReading) {
+			continue
+		}
+		msgs[entity.SearchUserMessage][m.ID] = m.ToMessage()
+		contents[entity.SearchUserMessage][m.ID] = m.Content
+		senders[m.SendID] = struct{}{}
+	}
+	for _, m := range groupMsgs {
+		if !entity.IsSearchable(m.Type, m.IsBurnAfterReading) {
+			continue
+		}
+		msgs[entity.SearchGroupMessage][m.ID] = m.ToMessage()
+		contents[entity.SearchGroupMessage][m.ID] = m.Content
+		senders[m.UserID] = struct{}{}
+	}
+
+	senderIds := make([]string, 0, len(senders))
+	for id := range senders {
+		senderIds = append(senderIds, id)
+	}
+	infos := s.getSenderInfos(ctx, senderIds)
+
+	for _, hit := range res.Hits {
+		msg, ok := msgs[hit.Kind][hit.MsgID]
+		if !ok || contents[hit.Kind][hit.MsgID] != hit.Content {
+			continue
+		}
+		msg.SenderInfo = infos[msg.SenderId]
+		dialogType := 0
+		if hit.Kind == entity.SearchGroupMessage {
+			dialogType = 1
+		}
+		resp.List = append(resp.List, v1.SearchMsgHit{
+			DialogId:   int(hit.DialogID),
+			DialogType: dialogType,
+			Score:      float32(hit.Score),
+			Highlights: hit.Highlights,
+			Message:    msg,
+		})
+	}
+
+	return resp, nil
+}
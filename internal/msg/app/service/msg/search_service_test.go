@@ -0,0 +1,64 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"google.golang.org/grpc"
+	"path/filepath"
+	"testing"
+)
+
+type fakeDialogService struct {
+	relationgrpcv1.DialogServiceClient
+	dialogIds []uint32
+	users     map[uint32][]string
+}
+
+func (f *fakeDialogService) GetDialogUsersByDialogID(ctx context.Context, in *relationgrpcv1.GetDialogUsersByDialogIDRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetDialogUsersByDialogIDResponse, error) {
+	return &relationgrpcv1.GetDialogUsersByDialogIDResponse{UserIds: f.users[in.DialogId]}, nil
+}
+
+func (f *fakeDialogService) GetUserDialogList(ctx context.Context, in *relationgrpcv1.GetUserDialogListRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetUserDialogListResponse, error) {
+	return &relationgrpcv1.GetUserDialogListResponse{DialogIds: f.dialogIds}, nil
+}
+
+func TestSearchMsgSkipsStaleHits(t *testing.T) {
+	s, db := newTestService(t)
+	if err := s.repo.OpenSearchIndex(filepath.Join(t.TempDir(), "index")); err != nil {
+		t.Fatalf("open search index: %v", err)
+	}
+	t.Cleanup(func() { _ = s.repo.Msr.Close() })
+	s.msd = service.NewMessageSearchDomain(db, nil, s.repo)
+	s.relationDialogService = &fakeDialogService{dialogIds: []uint32{1}}
+	ctx := context.Background()
+
+	msgs := make([]*entity.UserMessage, 0, 3)
+	for i := 0; i < 3; i++ {
+		msg, err := s.ud.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, Type: entity.MessageTypeText, SendID: "u1", ReceiveID: "u2", Content: "hello world"})
+		if err != nil {
+			t.Fatalf("SendUserMessage: %v", err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	// 其他节点上的编辑和撤回只修改了数据库，本节点的索引还没有同步
+	edited := *msgs[0]
+	edited.Content = "goodbye"
+	if err := s.repo.Umr.UpdateUserMessage(&edited); err != nil {
+		t.Fatalf("UpdateUserMessage: %v", err)
+	}
+	if err := s.repo.Umr.LogicalDeleteUserMessage(msgs[1].ID); err != nil {
+		t.Fatalf("LogicalDeleteUserMessage: %v", err)
+	}
+
+	resp, err := s.SearchMsg(ctx, "u1", v1.SearchMsgParams{Keyword: "hello", PageNum: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("SearchMsg: %v", err)
+	}
+	if len(resp.List) != 1 || resp.List[0].Message.MsgId != int(msgs[2].ID) {
+		t.Fatalf("expected only the unchanged message, got %+v", resp.List)
+	}
+}
@@ -0,0 +1,114 @@
+package msg
+
+import (
+	"context"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"go.uber.org/zap"
+	"time"
+)
+
+// 去重记录清理间隔
+const clientMsgCleanupInterval = time.Hour
+
+type SendService interface {
+	// SendMessage 客户端通过长连接发送消息，相同的客户端消息id重试时返回第一次发送的结果
+	SendMessage(ctx context.Context, req *msggrpcv1.SendMessageRequest) (*msggrpcv1.SendMessageResponse, error)
+}
+
+func (s *ServiceImpl) SendMessage(ctx context.Context, req *msggrpcv1.SendMessageRequest) (*msggrpcv1.SendMessageResponse, error) {
+	return s.sendClientMsg(ctx, req, s.deliverClientMsg)
+}
+
+// 占用客户端消息id后调用send发送，send返回的消息id不为0时说明消息已经写入，即使同时返回错误也不能释放
+func (s *ServiceImpl) sendClientMsg(ctx context.Context, req *msggrpcv1.SendMessageRequest, send func(ctx context.Context, req *msggrpcv1.SendMessageRequest) (msgID, seq int, err error)) (*msggrpcv1.SendMessageResponse, error) {
+	isGroup := req.GroupId != 0
+	cm, err := s.cmd.Reserve(ctx, req.SenderId, req.ClientMsgId, isGroup)
+	if err != nil {
+		return nil, err
+	}
+	if cm.IsSent() {
+		return &msggrpcv1.SendMessageResponse{
+			ClientMsgId: req.ClientMsgId,
+			MsgId:       uint32(cm.MsgID),
+			DialogId:    uint32(cm.DialogID),
+			Seq:         cm.Seq,
+			Duplicate:   true,
+		}, nil
+	}
+
+	// 发送必须在其他请求可以接管之前结束，避免同一条消息被发送两次
+	sendCtx, cancel := context.WithTimeout(ctx, entity.ClientMsgSendTimeout*time.Millisecond)
+	defer cancel()
+
+	msgID, seq, err := send(sendCtx, req)
+	if err != nil && msgID == 0 {
+		// 使用独立的上下文释放，客户端断开后也能重试
+		if err := s.cmd.Release(context.Background(), cm); err != nil {
+			s.logger.Error("释放客户端消息id失败", zap.String("client_msg_id", req.ClientMsgId), zap.Error(err))
+		}
+		return nil, err
+	}
+	if err != nil {
+		s.logger.Error("消息已发送，处理发送结果失败", zap.String("client_msg_id", req.ClientMsgId), zap.Error(err))
+	}
+
+	cm.DialogID = uint(req.DialogId)
+	cm.MsgID = uint(msgID)
+	cm.Seq = uint64(seq)
+	if err := s.cmd.Complete(context.Background(), cm); err != nil {
+		// 消息已经发送成功，只是重试时无法去重
+		s.logger.Error("保存客户端消息id失败", zap.String("client_msg_id", req.ClientMsgId), zap.Error(err))
+	}
+
+	return &msggrpcv1.SendMessageResponse{
+		ClientMsgId: req.ClientMsgId,
+		MsgId:       uint32(msgID),
+		DialogId:    req.DialogId,
+		Seq:         uint64(seq),
+	}, nil
+}
+
+// 走普通发送流程，与http发送的校验和推送一致
+func (s *ServiceImpl) deliverClientMsg(ctx context.Context, req *msggrpcv1.SendMessageRequest) (int, int, error) {
+	if req.GroupId != 0 {
+		resp, err := s.SendGroupMsg(ctx, req.SenderId, req.DriverId, &v1.SendGroupMsgRequest{
+			DialogId:           int(req.DialogId),
+			GroupId:            int(req.GroupId),
+			Content:            req.Content,
+			Type:               int(req.Type),
+			ReplyId:            int(req.ReplyId),
+			AtUsers:            req.AtUsers,
+			AtAllUser:          req.AtAllUser,
+			IsBurnAfterReading: req.IsBurnAfterReading,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		return resp.MsgId, resp.Seq, nil
+	}
+	resp, err := s.SendUserMsg(ctx, req.SenderId, req.DriverId, &v1.SendUserMsgRequest{
+		DialogId:           int(req.DialogId),
+		ReceiverId:         req.ReceiverId,
+		Content:            req.Content,
+		Type:               v1.SendUserMsgRequestType(req.Type),
+		ReplyId:            int(req.ReplyId),
+		IsBurnAfterReading: req.IsBurnAfterReading,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return resp.MsgId, resp.Seq, nil
+}
+
+func (s *ServiceImpl) cleanupClientMsgs(ctx context.Context) {
+	n, err := s.cmd.DeleteExpired(ctx)
+	if err != nil {
+		s.logger.Error("清理客户端消息id失败", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		s.logger.Debug("清理客户端消息id", zap.Int64("count", n))
+	}
+}
@@ -0,0 +1,206 @@
+package msg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"strings"
+	"testing"
+)
+
+type fakeUserService struct {
+	usergrpcv1.UserServiceClient
+	err error
+}
+
+func (f *fakeUserService) UserInfo(ctx context.Context, in *usergrpcv1.UserInfoRequest, opts ...grpc.CallOption) (*usergrpcv1.UserInfoResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &usergrpcv1.UserInfoResponse{UserId: in.UserId, NickName: in.UserId}, nil
+}
+
+func (f *fakeUserService) GetBatchUserInfo(ctx context.Context, in *usergrpcv1.GetBatchUserInfoRequest, opts ...grpc.CallOption) (*usergrpcv1.GetBatchUserInfoResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	users := make([]*usergrpcv1.UserInfoResponse, 0, len(in.UserIds))
+	for _, id := range in.UserIds {
+		users = append(users, &usergrpcv1.UserInfoResponse{UserId: id, NickName: id})
+	}
+	return &usergrpcv1.GetBatchUserInfoResponse{Users: users}, nil
+}
+
+type fakePushService struct {
+	pushv1.PushServiceClient
+	pushed   int
+	requests []*pushv1.PushRequest
+}
+
+func (f *fakePushService) Push(ctx context.Context, in *pushv1.PushRequest, opts ...grpc.CallOption) (*pushv1.PushResponse, error) {
+	f.pushed++
+	f.requests = append(f.requests, in)
+	return &pushv1.PushResponse{}, nil
+}
+
+// newTestService 使用内存sqlite创建消息服务，外部服务由调用方替换
+func newTestService(t *testing.T) (*ServiceImpl, *gorm.DB) {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	repos := persistence.NewRepositories(db)
+	if err := repos.Automigrate(); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return &ServiceImpl{
+		logger:      zap.NewNop(),
+		userService: &fakeUserService{},
+		pushService: &fakePushService{},
+		repo:        repos,
+		ud:          service.NewUserMsgDomain(db, nil, repos),
+		gmd:         service.NewGroupMsgDomain(db, nil, repos),
+		cmd:         service.NewClientMessageDomain(db, nil, repos),
+		smd:         service.NewScheduledMessageDomain(db, nil, repos),
+	}, db
+}
+
+func TestSendClientMsgKeepsReservationAfterInsert(t *testing.T) {
+	s, db := newTestService(t)
+	s.userService = &fakeUserService{err: errors.New("user service unavailable")}
+	ctx := context.Background()
+
+	sends := 0
+	// 与SendUserMsg一致：工作流提交后再查询发送者信息并推送
+	send := func(ctx context.Context, req *msggrpcv1.SendMessageRequest) (int, int, error) {
+		sends++
+		msg, err := s.ud.SendUserMessage(ctx, &entity.UserMessage{
+			DialogId:  uint(req.DialogId),
+			SendID:    req.SenderId,
+			ReceiveID: req.ReceiverId,
+			Content:   req.Content,
+			Type:      entity.UserMessageType(req.Type),
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		resp := s.userMsgSent(ctx, req.SenderId, req.DriverId, &v1.SendUserMsgRequest{
+			DialogId:   int(req.DialogId),
+			ReceiverId: req.ReceiverId,
+			Content:    req.Content,
+		}, uint32(msg.ID), msg.Seq, 0, false, 0)
+		return resp.MsgId, resp.Seq, nil
+	}
+
+	req := &msggrpcv1.SendMessageRequest{
+		ClientMsgId: "c1",
+		SenderId:    "u1",
+		ReceiverId:  "u2",
+		DialogId:    1,
+		Content:     "hello",
+		Type:        1,
+	}
+	first, err := s.sendClientMsg(ctx, req, send)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if first.MsgId == 0 || first.Duplicate {
+		t.Fatalf("unexpected first response: %+v", first)
+	}
+	if n := s.pushService.(*fakePushService).pushed; n == 0 {
+		t.Fatal("message was not pushed")
+	}
+
+	retry, err := s.sendClientMsg(ctx, req, send)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !retry.Duplicate || retry.MsgId != first.MsgId || retry.Seq != first.Seq {
+		t.Fatalf("retry = %+v, want duplicate of %+v", retry, first)
+	}
+	if sends != 1 {
+		t.Fatalf("send called %d times, want 1", sends)
+	}
+
+	var count int64
+	if err := db.Model(&po.UserMessage{}).Where("dialog_id = ?", 1).Count(&count).Error; err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("message count = %d, want 1", count)
+	}
+}
+
+func TestSendClientMsgKeepsReservationWhenSendFailsAfterInsert(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := context.Background()
+
+	req := &msggrpcv1.SendMessageRequest{ClientMsgId: "c1", SenderId: "u1", ReceiverId: "u2", DialogId: 1}
+	resp, err := s.sendClientMsg(ctx, req, func(ctx context.Context, req *msggrpcv1.SendMessageRequest) (int, int, error) {
+		return 7, 3, errors.New("push failed")
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if resp.MsgId != 7 || resp.Seq != 3 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	retry, err := s.sendClientMsg(ctx, req, func(ctx context.Context, req *msggrpcv1.SendMessageRequest) (int, int, error) {
+		t.Fatal("message sent twice")
+		return 0, 0, nil
+	})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !retry.Duplicate || retry.MsgId != 7 {
+		t.Fatalf("retry = %+v, want duplicate of msg 7", retry)
+	}
+}
+
+func TestSendClientMsgReleasesWhenNothingStored(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := context.Background()
+
+	req := &msggrpcv1.SendMessageRequest{ClientMsgId: "c1", SenderId: "u1", ReceiverId: "u2", DialogId: 1}
+	sendErr := errors.New("relation check failed")
+	if _, err := s.sendClientMsg(ctx, req, func(ctx context.Context, req *msggrpcv1.SendMessageRequest) (int, int, error) {
+		return 0, 0, sendErr
+	}); !errors.Is(err, sendErr) {
+		t.Fatalf("send err = %v, want %v", err, sendErr)
+	}
+
+	resp, err := s.sendClientMsg(ctx, req, func(ctx context.Context, req *msggrpcv1.SendMessageRequest) (int, int, error) {
+		return 9, 1, nil
+	})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if resp.Duplicate || resp.MsgId != 9 {
+		t.Fatalf("retry = %+v, want a fresh send", resp)
+	}
+}
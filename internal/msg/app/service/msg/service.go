@@ -7,16 +7,28 @@ import (
 	"github.com/cossim/coss-server/internal/msg/infra/persistence"
 	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	storagegrpcv1 "github.com/cossim/coss-server/internal/storage/api/grpc/v1"
 	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	pkgconfig "github.com/cossim/coss-server/pkg/config"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"gorm.io/gorm"
+	"sync"
+	"time"
 )
 
 type Service interface {
 	UserService
 	GroupService
+	SearchService
+	ReactionService
+	ThreadService
+	ForwardService
+	ScheduledService
+	DraftService
+	RevisionService
+	SyncService
+	SendService
 	Init(db *gorm.DB, cfg *pkgconfig.AppConfig) error
 	HandlerGrpcClient(serviceName string, conn *grpc.ClientConn) error
 	Stop(ctx context.Context) error
@@ -31,13 +43,31 @@ type ServiceImpl struct {
 	logger                *zap.Logger
 	relationGroupService  relationgrpcv1.GroupRelationServiceClient
 	groupService          groupApi.GroupServiceClient
+	storageService        storagegrpcv1.StorageServiceClient
 
+	repo *persistence.Repositories
 	ud   service.UserMsgDomain
 	gmd  service.GroupMsgDomain
 	gmrd service.GroupMsgReadDomain
+	msd  service.MessageSearchDomain
+	mrd  service.MessageReactionDomain
+	mtd  service.MessageThreadDomain
+	smd  service.ScheduledMessageDomain
+	med  service.MessageExpiryDomain
+	mdrd service.MessageDraftDomain
+	mcd  service.MessageSyncDomain
+	cmd  service.ClientMessageDomain
+	mrvd service.MessageRevisionDomain
+
+	workerCancel context.CancelFunc
+	workers      sync.WaitGroup
 }
 
 func (s *ServiceImpl) Stop(ctx context.Context) error {
+	s.stopWorkers(ctx)
+	if s.repo != nil && s.repo.Msr != nil {
+		return s.repo.Msr.Close()
+	}
 	return nil
 }
 
@@ -54,12 +84,69 @@ func (s *ServiceImpl) Init(db *gorm.DB, cfg *pkgconfig.AppConfig) error {
 	if err != nil {
 		return err
 	}
+	if err := repo.OpenSearchIndex(cfg.Search.IndexPath); err != nil {
+		return err
+	}
+	s.repo = repo
 	s.ud = service.NewUserMsgDomain(db, cfg, repo)
 	s.gmd = service.NewGroupMsgDomain(db, cfg, repo)
 	s.gmrd = service.NewGroupMsgReadDomain(db, cfg, repo)
+	s.msd = service.NewMessageSearchDomain(db, cfg, repo)
+	s.mrd = service.NewMessageReactionDomain(db, cfg, repo)
+	s.mtd = service.NewMessageThreadDomain(db, cfg, repo)
+	s.smd = service.NewScheduledMessageDomain(db, cfg, repo)
+	s.med = service.NewMessageExpiryDomain(db, cfg, repo)
+	s.mdrd = service.NewMessageDraftDomain(db, cfg, repo)
+	s.mcd = service.NewMessageSyncDomain(db, cfg, repo)
+	s.cmd = service.NewClientMessageDomain(db, cfg, repo)
+	s.mrvd = service.NewMessageRevisionDomain(db, cfg, repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.workerCancel = cancel
+	s.startWorker(ctx, scheduledDispatchInterval, s.scheduledDispatcher())
+	s.startWorker(ctx, expirySweepInterval, s.sweepExpiredMsgs)
+	s.startWorker(ctx, clientMsgCleanupInterval, s.cleanupClientMsgs)
+	s.startWorker(ctx, searchBackfillInterval, s.backfillSearchIndex)
+	s.startWorker(ctx, searchSyncInterval, s.syncSearchIndex)
 	return nil
 }
 
+// startWorker 启动后台定时任务，任务状态都保存在数据库中，服务重启后会继续处理
+func (s *ServiceImpl) startWorker(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fn(ctx)
+			}
+		}
+	}()
+}
+
+// stopWorkers 停止后台任务并等待正在执行的任务完成
+func (s *ServiceImpl) stopWorkers(ctx context.Context) {
+	if s.workerCancel == nil {
+		return
+	}
+	s.workerCancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
 func (s *ServiceImpl) HandlerGrpcClient(serviceName string, conn *grpc.ClientConn) error {
 	addr := conn.Target()
 	switch serviceName {
@@ -73,6 +160,8 @@ func (s *ServiceImpl) HandlerGrpcClient(serviceName string, conn *grpc.ClientCon
 		s.groupService = groupApi.NewGroupServiceClient(conn)
 	case "push_service":
 		s.pushService = pushv1.NewPushServiceClient(conn)
+	case "storage_service":
+		s.storageService = storagegrpcv1.NewStorageServiceClient(conn)
 	default:
 		return nil
 	}
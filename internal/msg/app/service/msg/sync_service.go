@@ -0,0 +1,259 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+)
+
+type SyncService interface {
+	SyncDialogMsg(ctx context.Context, userID string, dialogID uint32, params v1.SyncDialogMsgParams) (*v1.SyncDialogMsgResponse, error)
+	GetDialogMsgBySeq(ctx context.Context, userID string, dialogID uint32, params v1.GetDialogMsgBySeqParams) (*v1.GetDialogMsgBySeqResponse, error)
+}
+
+func (s *ServiceImpl) SyncDialogMsg(ctx context.Context, userID string, dialogID uint32, params v1.SyncDialogMsgParams) (*v1.SyncDialogMsgResponse, error) {
+	dialog, err := s.relationDialogService.GetDialogById(ctx, &relationgrpcv1.GetDialogByIdRequest{
+		DialogId: dialogID,
+	})
+	if err != nil {
+		s.logger.Error("获取对话失败", zap.Error(err))
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, uint(dialogID))
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+
+	var afterSeq uint64
+	if params.Seq != nil && *params.Seq > 0 {
+		afterSeq = uint64(*params.Seq)
+	}
+	limit := entity.DefaultSyncLimit
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+	if limit > entity.MaxSyncLimit {
+		limit = entity.MaxSyncLimit
+	}
+
+	changes, nextSeq, hasMore, err := s.mcd.GetChanges(ctx, uint(dialogID), afterSeq, limit)
+	if err != nil {
+		s.logger.Error("同步对话消息失败", zap.Error(err))
+		return nil, err
+	}
+
+	kind := entity.UserMessageKind
+	if dialog.GroupId != 0 {
+		kind = entity.GroupMessageKind
+	}
+	msgs, err := s.getSyncMessages(ctx, kind, userID, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]v1.SyncMessage, 0, len(changes))
+	for _, c := range changes {
+		item := v1.SyncMessage{
+			Seq:    int(c.SyncSeq),
+			Action: int(c.Action),
+			MsgId:  int(c.MsgID),
+		}
+		if c.Action != entity.MessageChangeDelete {
+			// 消息在变更之后已经被删除，按墓碑返回
+			if m, ok := msgs[c.MsgID]; ok {
+				item.Message = m
+			} else {
+				item.Action = int(entity.MessageChangeDelete)
+			}
+		}
+		list = append(list, item)
+	}
+
+	return &v1.SyncDialogMsgResponse{
+		DialogId: int(dialogID),
+		List:     list,
+		NextSeq:  int(nextSeq),
+		HasMore:  hasMore,
+	}, nil
+}
+
+// getSyncMessages 获取新增和修改过的消息内容，已经删除的消息不会返回
+func (s *ServiceImpl) getSyncMessages(ctx context.Context, kind entity.MessageKind, userID string, changes []*entity.MessageChange) (map[uint]*v1.Message, error) {
+	result := make(map[uint]*v1.Message)
+	msgIds := make([]uint, 0, len(changes))
+	for _, c := range changes {
+		if c.Action != entity.MessageChangeDelete {
+			msgIds = append(msgIds, c.MsgID)
+		}
+	}
+	if len(msgIds) == 0 {
+		return result, nil
+	}
+
+	list := make([]*v1.Message, 0, len(msgIds))
+	if kind == entity.GroupMessageKind {
+		msgs, err := s.gmd.GetGroupMessagesByIds(ctx, msgIds)
+		if err != nil {
+			s.logger.Error("获取群聊消息失败", zap.Error(err))
+			return nil, err
+		}
+		for _, m := range msgs {
+			list = append(list, m.ToMessage())
+		}
+	} else {
+		msgs, err := s.ud.GetUserMessagesByIds(ctx, msgIds)
+		if err != nil {
+			s.logger.Error("获取私聊消息失败", zap.Error(err))
+			return nil, err
+		}
+		for _, m := range msgs {
+			list = append(list, m.ToMessage())
+		}
+	}
+
+	s.fillMessageInfos(ctx, kind, userID, list)
+	for _, m := range list {
+		result[uint(m.MsgId)] = m
+	}
+	return result, nil
+}
+
+func (s *ServiceImpl) GetDialogMsgBySeq(ctx context.Context, userID string, dialogID uint32, params v1.GetDialogMsgBySeqParams) (*v1.GetDialogMsgBySeqResponse, error) {
+	if params.StartSeq <= 0 || params.EndSeq < params.StartSeq || params.EndSeq-params.StartSeq >= entity.MaxSeqRange {
+		return nil, code.InvalidParameter
+	}
+
+	dialog, err := s.relationDialogService.GetDialogById(ctx, &relationgrpcv1.GetDialogByIdRequest{
+		DialogId: dialogID,
+	})
+	if err != nil {
+		s.logger.Error("获取对话失败", zap.Error(err))
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, uint(dialogID))
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+
+	maxSeq, err := s.mcd.GetMaxMsgSeq(ctx, uint(dialogID))
+	if err != nil {
+		s.logger.Error("获取对话消息序号失败", zap.Error(err))
+		return nil, err
+	}
+
+	startSeq, endSeq := uint64(params.StartSeq), uint64(params.EndSeq)
+	kind := entity.UserMessageKind
+	list := make([]*v1.Message, 0)
+	if dialog.GroupId != 0 {
+		kind = entity.GroupMessageKind
+		msgs, err := s.mcd.GetGroupMsgsBySeq(ctx, uint(dialogID), startSeq, endSeq)
+		if err != nil {
+			s.logger.Error("获取群聊消息失败", zap.Error(err))
+			return nil, err
+		}
+		for _, m := range msgs {
+			list = append(list, m.ToMessage())
+		}
+	} else {
+		msgs, err := s.mcd.GetUserMsgsBySeq(ctx, uint(dialogID), startSeq, endSeq)
+		if err != nil {
+			s.logger.Error("获取私聊消息失败", zap.Error(err))
+			return nil, err
+		}
+		for _, m := range msgs {
+			list = append(list, m.ToMessage())
+		}
+	}
+	s.fillMessageInfos(ctx, kind, userID, list)
+
+	resp := &v1.GetDialogMsgBySeqResponse{
+		DialogId: int(dialogID),
+		List:     make([]v1.Message, 0, len(list)),
+		MaxSeq:   int(maxSeq),
+	}
+	for _, m := range list {
+		resp.List = append(resp.List, *m)
+	}
+	return resp, nil
+}
+
+// fillMessageInfos 批量补充消息的发送者、表情回应和话题信息
+func (s *ServiceImpl) fillMessageInfos(ctx context.Context, kind entity.MessageKind, userID string, list []*v1.Message) {
+	if len(list) == 0 {
+		return
+	}
+
+	msgIds := make([]uint, 0, len(list))
+	senderIds := make([]string, 0, len(list))
+	for _, m := range list {
+		msgIds = append(msgIds, uint(m.MsgId))
+		senderIds = append(senderIds, m.SenderId)
+	}
+
+	senders := s.getSenderInfos(ctx, senderIds)
+	reactions := s.getMsgReactions(ctx, kind, msgIds)
+	threads := s.getThreadInfos(ctx, kind, userID, msgIds)
+	for _, m := range list {
+		m.SenderInfo = senders[m.SenderId]
+		m.Reactions = reactions[uint(m.MsgId)]
+		m.Thread = threads[uint(m.MsgId)]
+	}
+}
+
+// newMessageCursorQuery 解析游标参数，未指定方向时向更早的消息翻页
+func newMessageCursorQuery(dialogID int, cursor *string, direction *v1.CursorDirection, limit int) (*entity.MessageCursorQuery, error) {
+	query := &entity.MessageCursorQuery{
+		DialogID: uint(dialogID),
+		Before:   direction == nil || *direction != v1.After,
+		Limit:    limit,
+	}
+	if query.Limit <= 0 {
+		query.Limit = entity.DefaultSyncLimit
+	}
+	if query.Limit > entity.MaxSyncLimit {
+		query.Limit = entity.MaxSyncLimit
+	}
+	if cursor != nil {
+		id, err := entity.DecodeMessageCursor(*cursor)
+		if err != nil {
+			return nil, code.MsgErrInvalidMsgCursor
+		}
+		query.Cursor = id
+	}
+	return query, nil
+}
+
+// messageCursors 根据本页消息id（降序）生成继续翻页和反向翻页的游标，本页为空时沿用请求的游标
+func messageCursors(query *entity.MessageCursorQuery, msgIds []uint) (next string, prev string) {
+	if len(msgIds) == 0 {
+		cursor := entity.EncodeMessageCursor(query.Cursor)
+		return cursor, cursor
+	}
+	newest := entity.EncodeMessageCursor(msgIds[0])
+	oldest := entity.EncodeMessageCursor(msgIds[len(msgIds)-1])
+	if query.Before {
+		return oldest, newest
+	}
+	return newest, oldest
+}
+
+// getDialogSyncSeq 获取对话当前的同步序号，获取失败时只记录日志
+func (s *ServiceImpl) getDialogSyncSeq(ctx context.Context, dialogID uint) int {
+	seq, err := s.mcd.GetMaxSyncSeq(ctx, dialogID)
+	if err != nil {
+		s.logger.Error("获取对话同步序号失败", zap.Error(err))
+		return 0
+	}
+	return int(seq)
+}
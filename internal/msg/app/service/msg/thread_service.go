@@ -0,0 +1,356 @@
+package msg
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/msg/api/http/v1"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"go.uber.org/zap"
+)
+
+type ThreadService interface {
+	GetUserThreadMsgList(ctx context.Context, userID string, msgID uint32, req v1.GetUserThreadMsgListParams) (*v1.GetUserThreadMsgListResponse, error)
+	GetGroupThreadMsgList(ctx context.Context, userID string, msgID uint32, req v1.GetGroupThreadMsgListParams) (*v1.GetGroupThreadMsgListResponse, error)
+	ReadUserThread(ctx context.Context, userID string, driverId string, msgID uint32) (*v1.ThreadInfo, error)
+	ReadGroupThread(ctx context.Context, userID string, driverId string, msgID uint32) (*v1.ThreadInfo, error)
+}
+
+func (s *ServiceImpl) GetUserThreadMsgList(ctx context.Context, userID string, msgID uint32, req v1.GetUserThreadMsgListParams) (*v1.GetUserThreadMsgListResponse, error) {
+	root, err := s.getUserThreadRoot(ctx, uint(msgID))
+	if err != nil {
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, root.DialogId)
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.RelationUserErrFriendRelationNotFound
+	}
+
+	list, total, err := s.mtd.GetUserThreadMessages(ctx, root.ID, req.PageNum, req.PageSize)
+	if err != nil {
+		s.logger.Error("获取话题回复失败", zap.Error(err))
+		return nil, err
+	}
+
+	msgIds := []uint{root.ID}
+	for _, v := range list {
+		msgIds = append(msgIds, v.ID)
+	}
+	reactions := s.getMsgReactions(ctx, entity.UserMessageKind, msgIds)
+	infos := s.getSenderInfos(ctx, userIds)
+
+	toUserMessage := func(v *entity.UserMessage) v1.UserMessage {
+		return v1.UserMessage{
+			MsgId:                  int(v.ID),
+			SenderId:               v.SendID,
+			ReceiverId:             v.ReceiveID,
+			Content:                v.Content,
+			Type:                   int(v.Type),
+			ReplyId:                int(v.ReplyId),
+			ThreadId:               int(v.ThreadId),
+			IsRead:                 v.IsRead == entity.IsRead,
+			ReadAt:                 int(v.ReadAt),
+			DeliveredAt:            int(v.DeliveredAt),
+			DeliveryStatus:         int(v.DeliveryStatus()),
+			SendAt:                 int(v.CreatedAt),
+			DialogId:               int(v.DialogId),
+			Seq:                    int(v.Seq),
+			EditedAt:               int(v.EditedAt),
+			IsLabel:                v.IsLabel,
+			IsBurnAfterReadingType: v.IsBurnAfterReading,
+			SenderInfo:             infos[v.SendID],
+			ReceiverInfo:           infos[v.ReceiveID],
+			Reactions:              reactions[v.ID],
+		}
+	}
+
+	rootMsg := toUserMessage(root)
+	msgList := make([]v1.UserMessage, 0, len(list))
+	for _, v := range list {
+		msgList = append(msgList, toUserMessage(v))
+	}
+
+	resp := &v1.GetUserThreadMsgListResponse{
+		Root:         &rootMsg,
+		UserMessages: msgList,
+		Thread:       s.getThreadInfos(ctx, entity.UserMessageKind, userID, []uint{root.ID})[root.ID],
+		Total:        int(total),
+		CurrentPage:  req.PageNum,
+	}
+	rootMsg.Thread = resp.Thread
+	return resp, nil
+}
+
+func (s *ServiceImpl) GetGroupThreadMsgList(ctx context.Context, userID string, msgID uint32, req v1.GetGroupThreadMsgListParams) (*v1.GetGroupThreadMsgListResponse, error) {
+	root, err := s.getGroupThreadRoot(ctx, uint(msgID))
+	if err != nil {
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, root.DialogID)
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.RelationGroupErrNotInGroup
+	}
+
+	list, total, err := s.mtd.GetGroupThreadMessages(ctx, root.ID, req.PageNum, req.PageSize)
+	if err != nil {
+		s.logger.Error("获取话题回复失败", zap.Error(err))
+		return nil, err
+	}
+
+	msgIds := []uint{root.ID}
+	senders := []string{root.UserID}
+	for _, v := range list {
+		msgIds = append(msgIds, v.ID)
+		senders = append(senders, v.UserID)
+	}
+	reactions := s.getMsgReactions(ctx, entity.GroupMessageKind, msgIds)
+	infos := s.getSenderInfos(ctx, senders)
+
+	toGroupMessage := func(v *entity.GroupMessage) v1.GroupMessage {
+		return v1.GroupMessage{
+			MsgId:                  int(v.ID),
+			GroupId:                int(v.GroupID),
+			Type:                   int(v.Type),
+			ReplyId:                int(v.ReplyId),
+			ThreadId:               int(v.ThreadId),
+			ReadCount:              v.ReadCount,
+			DeliveredCount:         v.DeliveredCount,
+			DeliveryStatus:         int(v.DeliveryStatus()),
+			UserId:                 v.UserID,
+			Content:                v.Content,
+			SendAt:                 int(v.CreatedAt),
+			DialogId:               int(v.DialogID),
+			Seq:                    int(v.Seq),
+			EditedAt:               int(v.EditedAt),
+			IsLabel:                v.IsLabel != uint(entity.NotLabel),
+			IsBurnAfterReadingType: v.IsBurnAfterReading,
+			AtUsers:                v.AtUsers,
+			AtAllUser:              v.AtAllUser == entity.AtAllUser,
+			SenderInfo:             infos[v.UserID],
+			Reactions:              reactions[v.ID],
+		}
+	}
+
+	rootMsg := toGroupMessage(root)
+	msgList := make([]v1.GroupMessage, 0, len(list))
+	for _, v := range list {
+		msgList = append(msgList, toGroupMessage(v))
+	}
+
+	resp := &v1.GetGroupThreadMsgListResponse{
+		Root:          &rootMsg,
+		GroupMessages: msgList,
+		Thread:        s.getThreadInfos(ctx, entity.GroupMessageKind, userID, []uint{root.ID})[root.ID],
+		Total:         int(total),
+		CurrentPage:   req.PageNum,
+	}
+	rootMsg.Thread = resp.Thread
+	return resp, nil
+}
+
+func (s *ServiceImpl) ReadUserThread(ctx context.Context, userID string, driverId string, msgID uint32) (*v1.ThreadInfo, error) {
+	root, err := s.getUserThreadRoot(ctx, uint(msgID))
+	if err != nil {
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, root.DialogId)
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.RelationUserErrFriendRelationNotFound
+	}
+
+	return s.readThread(ctx, entity.UserMessageKind, root.DialogId, 0, root.ID, userID, driverId)
+}
+
+func (s *ServiceImpl) ReadGroupThread(ctx context.Context, userID string, driverId string, msgID uint32) (*v1.ThreadInfo, error) {
+	root, err := s.getGroupThreadRoot(ctx, uint(msgID))
+	if err != nil {
+		return nil, err
+	}
+
+	userIds, err := s.getDialogUserIds(ctx, userID, root.DialogID)
+	if err != nil {
+		return nil, err
+	}
+	if userIds == nil {
+		return nil, code.RelationGroupErrNotInGroup
+	}
+
+	return s.readThread(ctx, entity.GroupMessageKind, root.DialogID, root.GroupID, root.ID, userID, driverId)
+}
+
+func (s *ServiceImpl) readThread(ctx context.Context, kind entity.MessageKind, dialogID, groupID, threadID uint, userID string, driverId string) (*v1.ThreadInfo, error) {
+	summary, err := s.mtd.ReadThread(ctx, kind, dialogID, threadID, userID)
+	if err != nil {
+		s.logger.Error("设置话题已读失败", zap.Error(err))
+		return nil, err
+	}
+
+	// 同步已读状态到用户的其他设备
+	s.SendMsgToOtherDevices(userID, driverId, pushv1.WSEventType_ThreadUpdateEvent, &constants.ThreadUpdateEventData{
+		ThreadId:    uint32(threadID),
+		DialogId:    uint32(dialogID),
+		GroupId:     uint32(groupID),
+		ReplyCount:  summary.ReplyCount,
+		LastReplyId: uint32(summary.LastReplyID),
+		ReadUserId:  userID,
+	})
+
+	if info, ok := s.getThreadInfos(ctx, kind, userID, []uint{threadID})[threadID]; ok {
+		return info, nil
+	}
+	return &v1.ThreadInfo{ThreadId: int(threadID)}, nil
+}
+
+// getUserThreadRoot 获取话题根消息，传入的是话题内的回复时返回其所属的根消息
+func (s *ServiceImpl) getUserThreadRoot(ctx context.Context, msgID uint) (*entity.UserMessage, error) {
+	msg, err := s.ud.GetUserMessageById(ctx, msgID)
+	if err != nil {
+		s.logger.Error("获取用户消息失败", zap.Error(err))
+		return nil, err
+	}
+	if msg.ThreadId != 0 {
+		msg, err = s.ud.GetUserMessageById(ctx, msg.ThreadId)
+		if err != nil {
+			s.logger.Error("获取用户消息失败", zap.Error(err))
+			return nil, err
+		}
+	}
+	if msg.DeletedAt != 0 {
+		return nil, code.MsgErrGetThreadFailed
+	}
+	return msg, nil
+}
+
+// getGroupThreadRoot 获取话题根消息，传入的是话题内的回复时返回其所属的根消息
+func (s *ServiceImpl) getGroupThreadRoot(ctx context.Context, msgID uint) (*entity.GroupMessage, error) {
+	msg, err := s.gmd.GetGroupMessageById(ctx, msgID)
+	if err != nil {
+		s.logger.Error("获取群聊消息失败", zap.Error(err))
+		return nil, err
+	}
+	if msg.ThreadId != 0 {
+		msg, err = s.gmd.GetGroupMessageById(ctx, msg.ThreadId)
+		if err != nil {
+			s.logger.Error("获取群聊消息失败", zap.Error(err))
+			return nil, err
+		}
+	}
+	if msg.DeletedAt != 0 {
+		return nil, code.MsgErrGetThreadFailed
+	}
+	return msg, nil
+}
+
+// getThreadInfos 批量获取话题摘要，获取失败时只记录日志
+func (s *ServiceImpl) getThreadInfos(ctx context.Context, kind entity.MessageKind, userID string, msgIDs []uint) map[uint]*v1.ThreadInfo {
+	result := make(map[uint]*v1.ThreadInfo)
+	if len(msgIDs) == 0 {
+		return result
+	}
+
+	summaries, err := s.mtd.GetThreadSummaries(ctx, kind, userID, msgIDs)
+	if err != nil {
+		s.logger.Error("获取话题摘要失败", zap.Error(err))
+		return result
+	}
+	if len(summaries) == 0 {
+		return result
+	}
+
+	lastIds := make([]uint, 0, len(summaries))
+	for _, v := range summaries {
+		lastIds = append(lastIds, v.LastReplyID)
+	}
+	lastReplies := make(map[uint]*v1.Message)
+	senders := make([]string, 0, len(lastIds))
+	if kind == entity.GroupMessageKind {
+		msgs, err := s.gmd.GetGroupMessagesByIds(ctx, lastIds)
+		if err != nil {
+			s.logger.Error("获取话题最新回复失败", zap.Error(err))
+		}
+		for _, m := range msgs {
+			lastReplies[m.ID] = m.ToMessage()
+			senders = append(senders, m.UserID)
+		}
+	} else {
+		msgs, err := s.ud.GetUserMessagesByIds(ctx, lastIds)
+		if err != nil {
+			s.logger.Error("获取话题最新回复失败", zap.Error(err))
+		}
+		for _, m := range msgs {
+			lastReplies[m.ID] = m.ToMessage()
+			senders = append(senders, m.SendID)
+		}
+	}
+	infos := s.getSenderInfos(ctx, senders)
+
+	for id, v := range summaries {
+		last := lastReplies[v.LastReplyID]
+		if last != nil {
+			last.SenderInfo = infos[last.SenderId]
+		}
+		result[id] = &v1.ThreadInfo{
+			ThreadId:    int(v.ThreadID),
+			ReplyCount:  int(v.ReplyCount),
+			UnreadCount: int(v.UnreadCount),
+			LastReply:   last,
+		}
+	}
+	return result
+}
+
+// pushThreadUpdate 话题有新回复时通知对话内的成员
+func (s *ServiceImpl) pushThreadUpdate(ctx context.Context, kind entity.MessageKind, userIds []string, driverId string, dialogID, groupID, threadID uint, senderID string) {
+	info, ok := s.getThreadInfos(ctx, kind, senderID, []uint{threadID})[threadID]
+	if !ok {
+		return
+	}
+
+	data := &constants.ThreadUpdateEventData{
+		ThreadId:   uint32(threadID),
+		DialogId:   uint32(dialogID),
+		GroupId:    uint32(groupID),
+		ReplyCount: int64(info.ReplyCount),
+	}
+	if info.LastReply != nil {
+		data.LastReplyId = uint32(info.LastReply.MsgId)
+		data.LastReply = info.LastReply
+	}
+	s.SendMsgToUsers(userIds, driverId, pushv1.WSEventType_ThreadUpdateEvent, data, false)
+}
+
+// getSenderInfos 批量获取用户信息，获取失败时只记录日志
+func (s *ServiceImpl) getSenderInfos(ctx context.Context, userIds []string) map[string]*v1.SenderInfo {
+	result := make(map[string]*v1.SenderInfo)
+	if len(userIds) == 0 {
+		return result
+	}
+
+	users, err := s.userService.GetBatchUserInfo(ctx, &usergrpcv1.GetBatchUserInfoRequest{UserIds: userIds})
+	if err != nil {
+		s.logger.Error("批量获取用户信息失败", zap.Error(err))
+		return result
+	}
+	for _, u := range users.Users {
+		result[u.UserId] = &v1.SenderInfo{
+			UserId: u.UserId,
+			Avatar: u.Avatar,
+			Name:   u.NickName,
+		}
+	}
+	return result
+}
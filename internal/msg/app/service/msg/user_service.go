@@ -94,7 +94,17 @@ func (s *ServiceImpl) SendUserMsg(ctx context.Context, userID string, driverId s
 		return nil, err
 	}
 
+	// 回复普通消息时归入被回复消息所在的话题
+	var threadID uint
+	if req.ReplyId != 0 && !isPromptMessageType(uint32(req.Type)) {
+		threadID, err = s.mtd.GetUserThreadRootID(ctx, uint(req.DialogId), uint(req.ReplyId))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	message := &msggrpcv1.SendUserMsgResponse{}
+	var msgSeq uint64
 	workflow.InitGrpc(s.dtmGrpcServer, "", grpc.NewServer())
 	gid := shortuuid.New()
 	wfName := "send_user_msg_workflow_" + gid
@@ -145,6 +155,7 @@ func (s *ServiceImpl) SendUserMsg(ctx context.Context, userID string, driverId s
 			Content:            req.Content,
 			Type:               entity.UserMessageType(req.Type),
 			ReplyId:            uint(req.ReplyId),
+			ThreadId:           threadID,
 			IsBurnAfterReading: req.IsBurnAfterReading,
 		})
 		if err != nil {
@@ -152,6 +163,7 @@ func (s *ServiceImpl) SendUserMsg(ctx context.Context, userID string, driverId s
 			return err
 		}
 		message.MsgId = uint32(mg.ID)
+		msgSeq = mg.Seq
 		//message, err = s.ud.SendUserMessage(ctx, &msggrpcv1.SendUserMsgRequest{
 		//	DialogID:               uint32(req.DialogID),
 		//	SenderId:               userID,
@@ -180,17 +192,17 @@ func (s *ServiceImpl) SendUserMsg(ctx context.Context, userID string, driverId s
 		return nil, code.MsgErrInsertUserMessageFailed
 	}
 
+	return s.userMsgSent(ctx, userID, driverId, req, message.MsgId, msgSeq, threadID, userRelationStatus2.IsSilent, userRelationStatus1.OpenBurnAfterReadingTimeOut), nil
+}
+
+// 消息已经写入后组装响应并推送，这里的失败只记录日志，不能让调用方当作发送失败处理
+func (s *ServiceImpl) userMsgSent(ctx context.Context, userID, driverId string, req *v1.SendUserMsgRequest, msgID uint32, msgSeq uint64, threadID uint, silent bool, burnAfterReadingTimeOut int64) *v1.SendUserMsgResponse {
 	//查询发送者信息
-	info, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
-		UserId: userID,
-	})
-	if err != nil {
-		s.logger.Error("获取用户信息失败", zap.Error(err))
-		return nil, err
-	}
+	info := s.senderInfo(ctx, userID)
 
 	resp := &v1.SendUserMsgResponse{
-		MsgId:    int(message.MsgId),
+		MsgId:    int(msgID),
+		Seq:      int(msgSeq),
 		ReplyMsg: &v1.Message{},
 	}
 
@@ -198,33 +210,25 @@ func (s *ServiceImpl) SendUserMsg(ctx context.Context, userID string, driverId s
 		msg, err := s.ud.GetUserMessageById(ctx, uint(req.ReplyId))
 		if err != nil {
 			s.logger.Error("获取消息失败", zap.Error(err))
-			return nil, err
-		}
-		userInfo, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
-			UserId: msg.SendID,
-		})
-		if err != nil {
-			s.logger.Error("获取用户信息失败", zap.Error(err))
-			return nil, err
-		}
+		} else {
+			userInfo := s.senderInfo(ctx, msg.SendID)
+			resp.ReplyMsg = &v1.Message{
+				MsgType:  int(msg.Type),
+				Content:  msg.Content,
+				SenderId: msg.SendID,
+				SendAt:   int(msg.CreatedAt),
+				MsgId:    int(msg.ID),
+				SenderInfo: &v1.SenderInfo{
+					UserId: userInfo.UserId,
+					Name:   userInfo.NickName,
+					Avatar: userInfo.Avatar,
+				},
+				ReplyId: int(msg.ReplyId),
+			}
 
-		resp.ReplyMsg = &v1.Message{
-			MsgType:  int(msg.Type),
-			Content:  msg.Content,
-			SenderId: msg.SendID,
-			SendAt:   int(msg.CreatedAt),
-			MsgId:    int(msg.ID),
-			SenderInfo: &v1.SenderInfo{
-				UserId: userInfo.UserId,
-				Name:   userInfo.NickName,
-				Avatar: userInfo.Avatar,
-			},
-			ReplyId: int(msg.ReplyId),
+			resp.ReplyMsg.IsBurnAfterReading = msg.IsBurnAfterReading
+			resp.ReplyMsg.IsLabel = msg.IsLabel
 		}
-
-		resp.ReplyMsg.IsBurnAfterReading = msg.IsBurnAfterReading
-		resp.ReplyMsg.IsLabel = msg.IsLabel
-
 	}
 	rmsg := &pushv1.MessageInfo{}
 	if resp.ReplyMsg != nil {
@@ -258,26 +262,44 @@ func (s *ServiceImpl) SendUserMsg(ctx context.Context, userID string, driverId s
 	}
 
 	//推送
-	s.sendWsUserMsg(userID, req.ReceiverId, driverId, userRelationStatus2.IsSilent, &pushv1.SendWsUserMsg{
+	s.sendWsUserMsg(userID, req.ReceiverId, driverId, silent, &pushv1.SendWsUserMsg{
 		SenderId:                userID,
 		Content:                 req.Content,
 		MsgType:                 uint32(req.Type),
 		ReplyId:                 uint32(req.ReplyId),
-		MsgId:                   message.MsgId,
+		MsgId:                   msgID,
 		ReceiverId:              req.ReceiverId,
 		SendAt:                  pkgtime.Now(),
 		DialogId:                uint32(req.DialogId),
 		IsBurnAfterReading:      resp.ReplyMsg.IsBurnAfterReading,
-		BurnAfterReadingTimeOut: userRelationStatus1.OpenBurnAfterReadingTimeOut,
+		BurnAfterReadingTimeOut: burnAfterReadingTimeOut,
 		SenderInfo: &pushv1.SenderInfo{
 			Avatar: info.Avatar,
 			Name:   info.NickName,
 			UserId: userID,
 		},
 		ReplyMsg: rmsg,
+		ThreadId: uint32(threadID),
+		Seq:      msgSeq,
 	})
 
-	return resp, nil
+	if threadID != 0 {
+		s.pushThreadUpdate(ctx, entity.UserMessageKind, []string{userID, req.ReceiverId}, driverId, uint(req.DialogId), 0, threadID, userID)
+	}
+
+	return resp
+}
+
+// 查询发送者信息，失败时只返回用户id
+func (s *ServiceImpl) senderInfo(ctx context.Context, userID string) *usergrpcv1.UserInfoResponse {
+	info, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		s.logger.Error("获取用户信息失败", zap.String("user_id", userID), zap.Error(err))
+		return &usergrpcv1.UserInfoResponse{UserId: userID}
+	}
+	return info
 }
 
 // 推送私聊消息
@@ -331,13 +353,32 @@ func (s *ServiceImpl) sendWsUserMsg(senderId, receiverId, driverId string, silen
 }
 
 func (s *ServiceImpl) GetUserMessageList(ctx context.Context, userID string, req v1.GetUserMsgListParams) (*v1.GetUserMsgListResponse, error) {
-	list, total, err := s.ud.GetUserMessageList(ctx, &entity.UserMessage{
-		BaseModel: entity.BaseModel{ID: uint(*req.MsgId)},
-		Type:      entity.UserMessageType(*req.Type),
-		DialogId:  uint(req.DialogId),
-		SendID:    *req.UserId,
-		Content:   *req.Content,
-	}, req.PageSize, req.PageNum, int64(*req.StartAt), int64(req.EndAt))
+	//先取同步序号，客户端从该序号增量同步不会漏掉之后的变更
+	syncSeq := s.getDialogSyncSeq(ctx, uint(req.DialogId))
+
+	var list []*entity.UserMessage
+	var total int64
+	var hasMore bool
+	var err error
+	var cursorQuery *entity.MessageCursorQuery
+	if req.Cursor != nil || req.Direction != nil {
+		cursorQuery, err = newMessageCursorQuery(req.DialogId, req.Cursor, req.Direction, req.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		cursorQuery.SendID = *req.UserId
+		cursorQuery.MsgType = entity.UserMessageType(*req.Type)
+		cursorQuery.Content = *req.Content
+		list, hasMore, err = s.mcd.GetUserMsgsByCursor(ctx, cursorQuery)
+	} else {
+		list, total, err = s.ud.GetUserMessageList(ctx, &entity.UserMessage{
+			BaseModel: entity.BaseModel{ID: uint(*req.MsgId)},
+			Type:      entity.UserMessageType(*req.Type),
+			DialogId:  uint(req.DialogId),
+			SendID:    *req.UserId,
+			Content:   *req.Content,
+		}, req.PageSize, req.PageNum, int64(*req.StartAt), int64(req.EndAt))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -368,6 +409,18 @@ func (s *ServiceImpl) GetUserMessageList(ctx context.Context, userID string, req
 	resp := v1.GetUserMsgListResponse{}
 	resp.CurrentPage = req.PageNum
 	resp.Total = int(total)
+	resp.SyncSeq = syncSeq
+
+	msgIds := make([]uint, 0, len(list))
+	for _, v := range list {
+		msgIds = append(msgIds, v.ID)
+	}
+	if cursorQuery != nil {
+		resp.NextCursor, resp.PrevCursor = messageCursors(cursorQuery, msgIds)
+		resp.HasMore = hasMore
+	}
+	reactions := s.getMsgReactions(ctx, entity.UserMessageKind, msgIds)
+	threads := s.getThreadInfos(ctx, entity.UserMessageKind, userID, msgIds)
 
 	msgList := make([]v1.UserMessage, 0)
 	for _, v := range list {
@@ -419,15 +472,22 @@ func (s *ServiceImpl) GetUserMessageList(ctx context.Context, userID string, req
 			Content:                 v.Content,
 			Type:                    int(v.Type),
 			ReplyId:                 int(v.ReplyId),
+			ThreadId:                int(v.ThreadId),
+			Thread:                  threads[v.ID],
 			IsRead:                  read,
 			ReadAt:                  int(v.ReadAt),
+			DeliveredAt:             int(v.DeliveredAt),
+			DeliveryStatus:          int(v.DeliveryStatus()),
 			SendAt:                  int(v.CreatedAt),
 			DialogId:                int(v.DialogId),
+			Seq:                     int(v.Seq),
+			EditedAt:                int(v.EditedAt),
 			IsLabel:                 label,
 			IsBurnAfterReadingType:  isBurnAfterReadingType,
 			BurnAfterReadingTimeout: int(relation.OpenBurnAfterReadingTimeOut),
 			SenderInfo:              sendinfo,
 			ReceiverInfo:            receinfo,
+			Reactions:               reactions[v.ID],
 		})
 	}
 
@@ -481,6 +541,8 @@ func (s *ServiceImpl) GetUserDialogList(ctx context.Context, userID string, page
 		return nil, err
 	}
 
+	drafts := s.getDialogDrafts(ctx, userID, dids)
+
 	dialogIds := make([]*v1.Message, 0)
 	for _, v := range userLastMsgs {
 		dialogIds = append(dialogIds, v.ToMessage())
@@ -502,6 +564,7 @@ func (s *ServiceImpl) GetUserDialogList(ctx context.Context, userID string, page
 			return nil, err
 		}
 		re.TopAt = int(du.TopAt)
+		re.Draft = drafts[uint(v.Id)]
 		//用户
 		if v.Type == 0 {
 			users, _ := s.relationDialogService.GetAllUsersInConversation(ctx, &relationgrpcv1.GetAllUsersInConversationRequest{
@@ -746,6 +809,10 @@ func (s *ServiceImpl) RecallUserMsg(ctx context.Context, userID string, driverId
 		return nil, err
 	}
 
+	if err := s.addUserMsgRevision(ctx, msginfo, userID, entity.RevisionActionRecall, pkgtime.Now()); err != nil {
+		return nil, err
+	}
+
 	// 调用相应的 gRPC 客户端方法来撤回用户消息
 	//msg, err := s.ud.DeleteUserMessage(ctx, &msggrpcv1.DeleteUserMsgRequest{
 	//	MsgId: msgID,
@@ -828,14 +895,20 @@ func (s *ServiceImpl) EditUserMsg(c *gin.Context, userID string, driverId string
 	//		Content: content,
 	//	},
 	//})
-	err = s.ud.EditUserMessage(c, &entity.UserMessage{BaseModel: entity.BaseModel{ID: uint(msgID)}, Content: content})
+	editedAt := pkgtime.Now()
+	if err := s.addUserMsgRevision(c, msginfo, userID, entity.RevisionActionEdit, editedAt); err != nil {
+		return nil, err
+	}
+
+	err = s.ud.EditUserMessage(c, &entity.UserMessage{BaseModel: entity.BaseModel{ID: uint(msgID)}, Content: content, EditedAt: editedAt})
 	if err != nil {
 		s.logger.Error("编辑用户消息失败", zap.Error(err))
 		return nil, err
 	}
 	msginfo.Content = content
+	msginfo.EditedAt = editedAt
 
-	s.SendMsgToUsers(userIds.UserIds, driverId, pushv1.WSEventType_EditMsgEvent, msginfo, true)
+	s.SendMsgToUsersAndOtherDevices(userIds.UserIds, userID, driverId, pushv1.WSEventType_EditMsgEvent, msginfo, true)
 
 	return msgID, nil
 }
@@ -908,7 +981,7 @@ func (s *ServiceImpl) ReadUserMsgs(ctx context.Context, userid string, driverId
 		//	DialogID:                    uint32(req.DialogID),
 		//	OpenBurnAfterReadingTimeOut: relation.OpenBurnAfterReadingTimeOut,
 		//})
-		err = s.ud.SetUserMsgsReadStatus(ctx, msgIdList, uint(req.DialogId), relation.OpenBurnAfterReadingTimeOut)
+		err = s.ud.SetUserMsgsReadStatus(ctx, msgIdList, uint(req.DialogId), userid, relation.OpenBurnAfterReadingTimeOut)
 		if err != nil {
 			s.logger.Error("批量设置私聊消息状态为已读", zap.Error(err))
 			return nil, err
@@ -954,7 +1027,7 @@ func (s *ServiceImpl) ReadUserMsgs(ctx context.Context, userid string, driverId
 		wsms = append(wsms, wsm)
 	}
 
-	s.SendMsgToUsers(ids.UserIds, driverId, pushv1.WSEventType_UserMsgReadEvent, map[string]interface{}{"msgs": wsms, "operator_info": v1.SenderInfo{
+	s.SendMsgToUsersAndOtherDevices(ids.UserIds, userid, driverId, pushv1.WSEventType_UserMsgReadEvent, map[string]interface{}{"msgs": wsms, "operator_info": v1.SenderInfo{
 		Avatar: info.Avatar,
 		Name:   info.NickName,
 		UserId: info.UserId,
@@ -1112,6 +1185,38 @@ func (s *ServiceImpl) SendMsgToUsers(uids []string, driverId string, event pushv
 	}
 }
 
+// SendMsgToOtherDevices 把操作同步到用户的其他设备，不推送到发起操作的设备
+func (s *ServiceImpl) SendMsgToOtherDevices(uid string, driverId string, event pushv1.WSEventType, data interface{}) {
+	bytes, err := utils.StructToBytes(data)
+	if err != nil {
+		return
+	}
+
+	m := &pushv1.WsMsg{Uid: uid, DriverId: driverId, Event: event, Data: &any.Any{Value: bytes}, Route: pushv1.DeviceRoute_ExcludeDevice, SendAt: pkgtime.Now()}
+	bytes2, err := utils.StructToBytes(m)
+	if err != nil {
+		return
+	}
+	_, err = s.pushService.Push(context.Background(), &pushv1.PushRequest{
+		Type: pushv1.Type_Ws,
+		Data: bytes2,
+	})
+	if err != nil {
+		s.logger.Error("同步消息到其他设备失败", zap.Error(err))
+	}
+}
+
+// SendMsgToUsersAndOtherDevices 推送多个用户消息，操作者自己只推送到其他设备
+func (s *ServiceImpl) SendMsgToUsersAndOtherDevices(uids []string, operatorId string, driverId string, event pushv1.WSEventType, data interface{}, pushOffline bool) {
+	for _, uid := range uids {
+		if uid == operatorId {
+			s.SendMsgToOtherDevices(uid, driverId, event, data)
+			continue
+		}
+		s.SendMsg(uid, driverId, event, data, pushOffline)
+	}
+}
+
 // 获取对话落后信息
 func (s *ServiceImpl) GetDialogAfterMsg(ctx context.Context, userID string, request []v1.AfterMsg) ([]*v1.GetDialogAfterMsgResponse, error) {
 	var responses = make([]*v1.GetDialogAfterMsgResponse, 0)
@@ -1175,6 +1280,11 @@ func (s *ServiceImpl) GetDialogAfterMsg(ctx context.Context, userID string, requ
 			s.logger.Error("获取群聊消息", zap.Error(err))
 			return nil, err
 		}
+		msgIds := make([]uint, 0, len(grouplist))
+		for _, i3 := range grouplist {
+			msgIds = append(msgIds, i3.ID)
+		}
+		reactions := s.getMsgReactions(ctx, entity.GroupMessageKind, msgIds)
 		msgs := make([]v1.Message, 0)
 		for _, i3 := range grouplist {
 			info, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
@@ -1210,11 +1320,15 @@ func (s *ServiceImpl) GetDialogAfterMsg(ctx context.Context, userID string, requ
 			if i3.AtAllUser != 0 {
 				msg.AtAllUser = true
 			}
+			msg.DeliveredCount = i3.DeliveredCount
+			msg.EditedAt = int(i3.EditedAt)
+			msg.DeliveryStatus = int(i3.DeliveryStatus())
 			if i3.IsLabel != 0 {
 				msg.IsLabel = true
 			}
 
 			msg.IsBurnAfterReading = i3.IsBurnAfterReading
+			msg.Reactions = reactions[i3.ID]
 			msgs = append(msgs, msg)
 		}
 		responses = append(responses, &v1.GetDialogAfterMsgResponse{
@@ -1230,6 +1344,11 @@ func (s *ServiceImpl) GetDialogAfterMsg(ctx context.Context, userID string, requ
 		if err != nil {
 			return nil, err
 		}
+		msgIds := make([]uint, 0, len(userlist))
+		for _, i3 := range userlist {
+			msgIds = append(msgIds, i3.ID)
+		}
+		reactions := s.getMsgReactions(ctx, entity.UserMessageKind, msgIds)
 		msgs := make([]v1.Message, 0)
 		for _, i3 := range userlist {
 			//查询发送者信息
@@ -1268,8 +1387,12 @@ func (s *ServiceImpl) GetDialogAfterMsg(ctx context.Context, userID string, requ
 				msg.IsRead = true
 			}
 
+			msg.DeliveredAt = int(i3.DeliveredAt)
+			msg.EditedAt = int(i3.EditedAt)
+			msg.DeliveryStatus = int(i3.DeliveryStatus())
 			msg.IsLabel = i3.IsLabel
 			msg.IsBurnAfterReading = i3.IsBurnAfterReading
+			msg.Reactions = reactions[i3.ID]
 			msgs = append(msgs, msg)
 		}
 		responses = append(responses, &v1.GetDialogAfterMsgResponse{
@@ -1293,6 +1416,11 @@ func (s *ServiceImpl) getGroupDialogLast20Msg(ctx context.Context, thisID string
 	if err != nil {
 		return responses, err
 	}
+	msgIds := make([]uint, 0, len(list))
+	for _, gm := range list {
+		msgIds = append(msgIds, gm.ID)
+	}
+	reactions := s.getMsgReactions(ctx, entity.GroupMessageKind, msgIds)
 	msgs := make([]v1.Message, 0)
 	for _, gm := range list {
 		info, err := s.userService.UserInfo(ctx, &usergrpcv1.UserInfoRequest{
@@ -1335,6 +1463,10 @@ func (s *ServiceImpl) getGroupDialogLast20Msg(ctx context.Context, thisID string
 		if gm.AtAllUser != 0 {
 			msg.AtAllUser = true
 		}
+		msg.DeliveredCount = gm.DeliveredCount
+		msg.EditedAt = int(gm.EditedAt)
+		msg.DeliveryStatus = int(gm.DeliveryStatus())
+		msg.Reactions = reactions[gm.ID]
 		msgs = append(msgs, msg)
 	}
 	responses = append(responses, &v1.GetDialogAfterMsgResponse{
@@ -1357,6 +1489,11 @@ func (s *ServiceImpl) getUserDialogLast20Msg(ctx context.Context, dialogId uint3
 	if err != nil {
 		return responses, err
 	}
+	msgIds := make([]uint, 0, len(list))
+	for _, um := range list {
+		msgIds = append(msgIds, um.ID)
+	}
+	reactions := s.getMsgReactions(ctx, entity.UserMessageKind, msgIds)
 	msgs := make([]v1.Message, 0)
 	for _, um := range list {
 		//查询发送者信息
@@ -1395,8 +1532,12 @@ func (s *ServiceImpl) getUserDialogLast20Msg(ctx context.Context, dialogId uint3
 			msg.IsRead = true
 		}
 
+		msg.DeliveredAt = int(um.DeliveredAt)
+		msg.EditedAt = int(um.EditedAt)
+		msg.DeliveryStatus = int(um.DeliveryStatus())
 		msg.IsBurnAfterReading = um.IsBurnAfterReading
 		msg.IsLabel = um.IsLabel
+		msg.Reactions = reactions[um.ID]
 		msgs = append(msgs, msg)
 	}
 	responses = append(responses, &v1.GetDialogAfterMsgResponse{
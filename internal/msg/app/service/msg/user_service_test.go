@@ -0,0 +1,36 @@
+package msg
+
+import (
+	"encoding/json"
+	pushv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"testing"
+)
+
+func TestSendMsgToUsersAndOtherDevices(t *testing.T) {
+	s, _ := newTestService(t)
+	push := s.pushService.(*fakePushService)
+
+	s.SendMsgToUsersAndOtherDevices([]string{"u1", "u2"}, "u1", "d1", pushv1.WSEventType_EditMsgEvent, map[string]interface{}{"msg_id": 1}, true)
+
+	if len(push.requests) != 2 {
+		t.Fatalf("expected 2 pushes, got %d", len(push.requests))
+	}
+	msgs := make(map[string]*pushv1.WsMsg)
+	for _, r := range push.requests {
+		if r.Type != pushv1.Type_Ws {
+			t.Fatalf("push type = %v", r.Type)
+		}
+		m := &pushv1.WsMsg{}
+		if err := json.Unmarshal(r.Data, m); err != nil {
+			t.Fatalf("unmarshal ws msg: %v", err)
+		}
+		msgs[m.Uid] = m
+	}
+	// 操作者只同步到其他设备，不保存离线消息
+	if m := msgs["u1"]; m == nil || m.Route != pushv1.DeviceRoute_ExcludeDevice || m.DriverId != "d1" || m.PushOffline {
+		t.Fatalf("unexpected push to operator: %+v", m)
+	}
+	if m := msgs["u2"]; m == nil || m.Route != pushv1.DeviceRoute_AllDevices || !m.PushOffline {
+		t.Fatalf("unexpected push to other user: %+v", m)
+	}
+}
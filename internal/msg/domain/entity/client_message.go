@@ -0,0 +1,31 @@
+package entity
+
+const (
+	// MaxClientMsgIdLength 客户端消息id的最大长度
+	MaxClientMsgIdLength = 64
+	// ClientMsgSendTimeout 占用客户端消息id后发送消息的超时时间（毫秒）
+	ClientMsgSendTimeout = 10 * 1000
+	// ClientMsgPendingTimeout 发送中的记录超过该时间（毫秒）没有完成，认为发送方已经失败，允许重新发送
+	// 必须大于ClientMsgSendTimeout，保证接管时之前的发送已经结束
+	ClientMsgPendingTimeout = 30 * 1000
+	// ClientMsgRetention 去重记录的保留时间（毫秒），超过后相同的客户端消息id会被当作新消息
+	ClientMsgRetention = 24 * 60 * 60 * 1000
+)
+
+// ClientMessage 客户端发送消息时携带的消息id与服务端消息的对应关系，用于重试时去重
+type ClientMessage struct {
+	BaseModel
+	SenderID    string
+	ClientMsgID string
+	DialogID    uint
+	// 0表示消息正在发送中
+	MsgID   uint
+	Seq     uint64
+	IsGroup bool
+	// 每次占用或接管时生成的标识，只有持有者可以完成或释放
+	Token string
+}
+
+func (m *ClientMessage) IsSent() bool {
+	return m.MsgID != 0
+}
@@ -7,10 +7,13 @@ import (
 type GroupMessage struct {
 	BaseModel
 	DialogID           uint
+	Seq                uint64 // 对话内消息序号，严格递增
 	GroupID            uint
 	Type               UserMessageType
 	ReplyId            uint
+	ThreadId           uint
 	ReadCount          int
+	DeliveredCount     int
 	UserID             string
 	Content            string
 	IsLabel            uint
@@ -18,6 +21,8 @@ type GroupMessage struct {
 	AtAllUser          AtAllUserType
 	AtUsers            []string
 	IsBurnAfterReading bool
+	ExpireAt           int64
+	EditedAt           int64
 }
 
 type AtAllUserType uint
@@ -43,11 +48,16 @@ func (gm *GroupMessage) ToMessage() *v1.Message {
 		IsBurnAfterReading: gm.IsBurnAfterReading,
 		IsLabel:            gm.IsLabel == uint(IsLabel),
 		IsRead:             gm.ReadCount > 0, // 根据 ReadCount 判断是否已读
+		DeliveredCount:     gm.DeliveredCount,
+		DeliveryStatus:     int(gm.DeliveryStatus()),
 		MsgId:              int(gm.ID),
 		MsgType:            int(gm.Type),
 		ReplyId:            int(gm.ReplyId),
+		ThreadId:           int(gm.ThreadId),
 		SendAt:             int(gm.CreatedAt),
 		SenderId:           gm.UserID, // 或者根据实际情况选择其他字段
 		DialogId:           int(gm.DialogID),
+		Seq:                int(gm.Seq),
+		EditedAt:           int(gm.EditedAt),
 	}
 }
@@ -0,0 +1,52 @@
+package entity
+
+// DeliveryStatus 消息投递状态
+type DeliveryStatus uint
+
+const (
+	DeliverySent      DeliveryStatus = iota // 已发送
+	DeliveryDelivered                       // 已送达设备
+	DeliveryRead                            // 已读
+)
+
+// GroupMessageDelivery 群聊消息送达记录，每个成员只记录第一次送达
+type GroupMessageDelivery struct {
+	BaseModel
+	MsgID       uint
+	DialogID    uint
+	GroupID     uint
+	UserID      string
+	DeliveredAt int64
+}
+
+// DeliveredMessage 本次新送达的消息，用于通知发送者
+type DeliveredMessage struct {
+	Kind           MessageKind
+	ID             uint
+	DialogID       uint
+	GroupID        uint
+	SenderID       string
+	ReceiverID     string
+	DeliveredAt    int64
+	DeliveredCount int
+}
+
+func (um *UserMessage) DeliveryStatus() DeliveryStatus {
+	if um.IsRead == IsRead {
+		return DeliveryRead
+	}
+	if um.DeliveredAt > 0 {
+		return DeliveryDelivered
+	}
+	return DeliverySent
+}
+
+func (gm *GroupMessage) DeliveryStatus() DeliveryStatus {
+	if gm.ReadCount > 0 {
+		return DeliveryRead
+	}
+	if gm.DeliveredCount > 0 {
+		return DeliveryDelivered
+	}
+	return DeliverySent
+}
@@ -0,0 +1,13 @@
+package entity
+
+// MaxDraftContentLength 草稿内容的最大长度
+const MaxDraftContentLength = 10000
+
+// MessageDraft 用户在对话中未发送的草稿，每个用户每个对话只保存一份，多设备共享
+type MessageDraft struct {
+	BaseModel
+	UserID   string
+	DialogID uint
+	Content  string
+	ReplyId  uint
+}
@@ -0,0 +1,23 @@
+package entity
+
+// DefaultBurnAfterReadingTimeout 未设置阅后即焚时间时的默认值，单位秒
+const DefaultBurnAfterReadingTimeout = int64(10)
+
+// ExpiredMessage 到期被删除的阅后即焚消息
+type ExpiredMessage struct {
+	Kind       MessageKind
+	ID         uint
+	DialogID   uint
+	GroupID    uint
+	SenderID   string
+	ReceiverID string
+	ExpireAt   int64
+}
+
+// BurnAfterReadingExpireAt 根据阅读时间和超时时间(秒)计算过期时间(毫秒)
+func BurnAfterReadingExpireAt(readAt int64, timeout int64) int64 {
+	if timeout <= 0 {
+		timeout = DefaultBurnAfterReadingTimeout
+	}
+	return readAt + timeout*1000
+}
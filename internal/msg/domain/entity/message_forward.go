@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/cossim/coss-server/pkg/constants"
+	"regexp"
+)
+
+// MaxChatRecordTitleLength 聊天记录标题的最大长度
+const MaxChatRecordTitleLength = 64
+
+// storageFileRegexp 匹配消息内容中存储服务的下载地址，捕获文件id
+var storageFileRegexp = regexp.MustCompile(regexp.QuoteMeta(constants.DownLoadAddress) + `/[^/\s"]+/([^/?#\s"]+)`)
+
+// ChatRecord 合并转发的聊天记录，序列化后作为 MessageTypeChatRecord 消息的内容
+type ChatRecord struct {
+	Title    string            `json:"title"`
+	Messages []*ChatRecordItem `json:"messages"`
+}
+
+// ChatRecordItem 聊天记录中的单条消息
+type ChatRecordItem struct {
+	MsgType      uint   `json:"msg_type"`
+	Content      string `json:"content"`
+	SenderId     string `json:"sender_id"`
+	SenderName   string `json:"sender_name"`
+	SenderAvatar string `json:"sender_avatar"`
+	SendAt       int64  `json:"send_at"`
+}
+
+// Marshal 序列化为消息内容
+func (r *ChatRecord) Marshal() (string, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// IsForwardableMessageType 判断消息是否允许被转发，提示类消息与通话记录不能转发
+func IsForwardableMessageType(msgType UserMessageType) bool {
+	switch msgType {
+	case MessageTypeText, MessageTypeVoice, MessageTypeImage, MessageTypeFile, MessageTypeVideo, MessageTypeChatRecord:
+		return true
+	}
+	return false
+}
+
+// ExtractStorageFileIDs 提取消息内容中引用的存储文件id
+func ExtractStorageFileIDs(content string) []string {
+	matches := storageFileRegexp.FindAllStringSubmatch(content, -1)
+	ids := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		ids = append(ids, m[1])
+	}
+	return ids
+}
@@ -0,0 +1,49 @@
+package entity
+
+// MessageKind 消息所属的对话类型
+type MessageKind uint
+
+const (
+	UserMessageKind  MessageKind = iota // 私聊消息
+	GroupMessageKind                    // 群聊消息
+)
+
+// MaxReactionEmojiLength 单个表情回应的最大长度
+const MaxReactionEmojiLength = 64
+
+// MessageReaction 用户对消息的表情回应，同一用户可以对同一消息回应多个不同的表情
+type MessageReaction struct {
+	BaseModel
+	Kind     MessageKind
+	MsgID    uint
+	DialogID uint
+	UserID   string
+	Emoji    string
+}
+
+// ReactionSummary 按表情聚合后的回应
+type ReactionSummary struct {
+	Emoji   string
+	Count   int
+	UserIDs []string
+}
+
+// AggregateReactions 按消息id聚合回应，表情按第一次出现的顺序排列
+func AggregateReactions(reactions []*MessageReaction) map[uint][]*ReactionSummary {
+	result := make(map[uint][]*ReactionSummary)
+	index := make(map[uint]map[string]*ReactionSummary)
+	for _, r := range reactions {
+		if _, ok := index[r.MsgID]; !ok {
+			index[r.MsgID] = make(map[string]*ReactionSummary)
+		}
+		summary, ok := index[r.MsgID][r.Emoji]
+		if !ok {
+			summary = &ReactionSummary{Emoji: r.Emoji, UserIDs: make([]string, 0)}
+			index[r.MsgID][r.Emoji] = summary
+			result[r.MsgID] = append(result[r.MsgID], summary)
+		}
+		summary.Count++
+		summary.UserIDs = append(summary.UserIDs, r.UserID)
+	}
+	return result
+}
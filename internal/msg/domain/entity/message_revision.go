@@ -0,0 +1,61 @@
+package entity
+
+// RevisionAction 产生消息修订记录的操作
+type RevisionAction uint
+
+const (
+	RevisionActionEdit   RevisionAction = iota // 编辑消息
+	RevisionActionRecall                       // 撤回消息
+)
+
+// MessageRevision 消息被编辑或撤回前的内容，按时间顺序保存每一次修改
+type MessageRevision struct {
+	BaseModel
+	Kind     MessageKind
+	MsgID    uint
+	DialogID uint
+	GroupID  uint
+	SenderID string
+	EditorID string
+	Action   RevisionAction
+	Content  string
+	EditedAt int64
+}
+
+// GroupRevisionVisibility 群聊中消息修订记录对管理员的可见范围，发送者始终可以查看自己消息的修订记录
+type GroupRevisionVisibility uint
+
+const (
+	RevisionVisibleToAdmins GroupRevisionVisibility = iota // 群主和管理员可见
+	RevisionVisibleToOwner                                 // 仅群主可见
+	RevisionHidden                                         // 仅发送者可见
+)
+
+// IsValid 是否为已定义的可见范围
+func (v GroupRevisionVisibility) IsValid() bool {
+	return v <= RevisionHidden
+}
+
+// GroupRevisionPolicy 群聊的消息修订记录可见策略，未设置时群主和管理员可见
+type GroupRevisionPolicy struct {
+	BaseModel
+	GroupID    uint
+	Visibility GroupRevisionVisibility
+	UpdatedBy  string
+}
+
+// CanView 判断群组身份为owner或admin的用户能否查看修订记录
+func (p *GroupRevisionPolicy) CanView(owner, admin bool) bool {
+	visibility := RevisionVisibleToAdmins
+	if p != nil {
+		visibility = p.Visibility
+	}
+	switch visibility {
+	case RevisionVisibleToAdmins:
+		return owner || admin
+	case RevisionVisibleToOwner:
+		return owner
+	default:
+		return false
+	}
+}
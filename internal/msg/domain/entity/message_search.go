@@ -0,0 +1,78 @@
+package entity
+
+// SearchMessageKind 索引中的消息类别
+type SearchMessageKind string
+
+const (
+	SearchUserMessage  SearchMessageKind = "user"
+	SearchGroupMessage SearchMessageKind = "group"
+)
+
+// MessageDocument 全文索引文档
+type MessageDocument struct {
+	Kind      SearchMessageKind
+	MsgID     uint
+	DialogID  uint
+	GroupID   uint
+	SendID    string
+	MsgType   uint
+	Content   string
+	CreatedAt int64
+}
+
+type MessageSearchQuery struct {
+	Keyword   string
+	DialogIds []uint
+	SendID    string
+	StartAt   int64
+	EndAt     int64
+	PageNum   int
+	PageSize  int
+}
+
+type MessageSearchHit struct {
+	Kind       SearchMessageKind
+	MsgID      uint
+	DialogID   uint
+	Content    string // 索引中的消息内容，与数据库不一致时说明索引还没有同步
+	Score      float64
+	Highlights []string // 命中片段，关键词使用<mark>标记
+}
+
+type MessageSearchResult struct {
+	Total int64
+	Hits  []*MessageSearchHit
+}
+
+// IsSearchable 是否需要写入全文索引，阅后即焚与提示类消息不进入索引
+func IsSearchable(msgType UserMessageType, isBurnAfterReading bool) bool {
+	if isBurnAfterReading {
+		return false
+	}
+	return msgType == MessageTypeText
+}
+
+func (um *UserMessage) ToSearchDocument() *MessageDocument {
+	return &MessageDocument{
+		Kind:      SearchUserMessage,
+		MsgID:     um.ID,
+		DialogID:  um.DialogId,
+		SendID:    um.SendID,
+		MsgType:   uint(um.Type),
+		Content:   um.Content,
+		CreatedAt: um.CreatedAt,
+	}
+}
+
+func (gm *GroupMessage) ToSearchDocument() *MessageDocument {
+	return &MessageDocument{
+		Kind:      SearchGroupMessage,
+		MsgID:     gm.ID,
+		DialogID:  gm.DialogID,
+		GroupID:   gm.GroupID,
+		SendID:    gm.UserID,
+		MsgType:   uint(gm.Type),
+		Content:   gm.Content,
+		CreatedAt: gm.CreatedAt,
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MessageChangeAction 消息变更类型
+type MessageChangeAction uint
+
+const (
+	MessageChangeNew    MessageChangeAction = iota // 新消息
+	MessageChangeEdit                              // 编辑、标注等修改
+	MessageChangeDelete                            // 撤回、删除或阅后即焚过期，同步时作为墓碑返回
+)
+
+const (
+	// DefaultSyncLimit 每次同步默认返回的变更数量
+	DefaultSyncLimit = 100
+	// MaxSyncLimit 每次同步最多返回的变更数量
+	MaxSyncLimit = 500
+	// MaxSeqRange 按序号区间获取消息时区间的最大长度
+	MaxSeqRange = 500
+)
+
+// MessageChange 对话内消息的变更记录，SyncSeq 在对话内严格递增，客户端保存最后一次同步到的 SyncSeq 做增量同步
+type MessageChange struct {
+	BaseModel
+	DialogID uint
+	SyncSeq  uint64
+	Kind     MessageKind
+	MsgID    uint
+	Action   MessageChangeAction
+}
+
+// LatestMessageChanges 同一条消息只保留最后一次变更，按 SyncSeq 升序返回
+func LatestMessageChanges(changes []*MessageChange) []*MessageChange {
+	last := make(map[uint]int, len(changes))
+	for i, c := range changes {
+		last[c.MsgID] = i
+	}
+	result := make([]*MessageChange, 0, len(last))
+	for i, c := range changes {
+		if last[c.MsgID] == i {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// MessageCursorQuery 按消息id游标分页查询
+type MessageCursorQuery struct {
+	DialogID uint
+	Cursor   uint // 游标位置的消息id，0表示从头开始
+	Before   bool // true 向更早的消息翻页，false 向更新的消息翻页
+	Limit    int
+	SendID   string
+	MsgType  UserMessageType
+	Content  string
+}
+
+const messageCursorPrefix = "m:"
+
+var ErrInvalidMessageCursor = errors.New("invalid message cursor")
+
+// EncodeMessageCursor 将消息id编码为不透明的游标，客户端不应解析游标内容
+func EncodeMessageCursor(msgID uint) string {
+	if msgID == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(messageCursorPrefix + strconv.FormatUint(uint64(msgID), 10)))
+}
+
+// DecodeMessageCursor 解析游标，空游标返回0
+func DecodeMessageCursor(cursor string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidMessageCursor
+	}
+	s := string(b)
+	if !strings.HasPrefix(s, messageCursorPrefix) {
+		return 0, ErrInvalidMessageCursor
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(s, messageCursorPrefix), 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidMessageCursor
+	}
+	return uint(id), nil
+}
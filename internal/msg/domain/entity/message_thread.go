@@ -0,0 +1,42 @@
+package entity
+
+// MessageThreadRead 用户在话题中的阅读进度
+type MessageThreadRead struct {
+	BaseModel
+	Kind          MessageKind
+	ThreadID      uint
+	DialogID      uint
+	UserID        string
+	LastReadMsgID uint
+}
+
+// ThreadStat 话题的回复统计
+type ThreadStat struct {
+	ThreadID    uint
+	ReplyCount  int64
+	LastReplyID uint
+}
+
+// ThreadSummary 返回给用户的话题摘要
+type ThreadSummary struct {
+	ThreadID    uint
+	ReplyCount  int64
+	UnreadCount int64
+	LastReplyID uint
+}
+
+// ThreadRootID 获取回复当前消息时所属话题的根消息id
+func (um *UserMessage) ThreadRootID() uint {
+	if um.ThreadId != 0 {
+		return um.ThreadId
+	}
+	return um.ID
+}
+
+// ThreadRootID 获取回复当前消息时所属话题的根消息id
+func (gm *GroupMessage) ThreadRootID() uint {
+	if gm.ThreadId != 0 {
+		return gm.ThreadId
+	}
+	return gm.ID
+}
@@ -0,0 +1,61 @@
+package entity
+
+// ScheduledMessageStatus 定时消息状态
+type ScheduledMessageStatus uint
+
+const (
+	ScheduledMessagePending  ScheduledMessageStatus = iota // 等待发送
+	ScheduledMessageSending                                // 发送中，已被某个实例领取
+	ScheduledMessageSent                                   // 已发送
+	ScheduledMessageCanceled                               // 已取消
+	ScheduledMessageFailed                                 // 发送失败
+)
+
+// MaxScheduledMessageDelay 定时消息最多可以提前多久创建，单位毫秒
+const MaxScheduledMessageDelay = int64(365 * 24 * 60 * 60 * 1000)
+
+// ScheduledMessage 定时发送的消息，到达发送时间后按普通消息的流程发送
+type ScheduledMessage struct {
+	BaseModel
+	UserID             string
+	DriverID           string
+	Kind               MessageKind
+	DialogID           uint
+	ReceiverID         string
+	GroupID            uint
+	Type               UserMessageType
+	Content            string
+	ReplyId            uint
+	AtUsers            []string
+	AtAllUser          bool
+	IsBurnAfterReading bool
+	SendAt             int64
+	Status             ScheduledMessageStatus
+	MsgID              uint
+	FailReason         string
+	LockedBy           string
+	LockedUntil        int64
+}
+
+// ScheduledMessageQuery 定时消息查询条件
+type ScheduledMessageQuery struct {
+	UserID   string
+	DialogID uint
+	Status   *ScheduledMessageStatus
+	PageNum  int
+	PageSize int
+}
+
+// IsPending 是否仍可以编辑或取消
+func (sm *ScheduledMessage) IsPending() bool {
+	return sm.Status == ScheduledMessagePending
+}
+
+// IsSchedulableMessageType 只有普通内容消息可以定时发送
+func IsSchedulableMessageType(msgType UserMessageType) bool {
+	switch msgType {
+	case MessageTypeText, MessageTypeVoice, MessageTypeImage, MessageTypeFile, MessageTypeVideo:
+		return true
+	}
+	return false
+}
@@ -7,8 +7,10 @@ type UserMessage struct {
 	Type               UserMessageType
 	SubType            UserMessageSubType
 	DialogId           uint
+	Seq                uint64 // 对话内消息序号，严格递增
 	IsRead             ReadType
 	ReplyId            uint
+	ThreadId           uint
 	ReadAt             int64
 	ReceiveID          string
 	SendID             string
@@ -16,6 +18,9 @@ type UserMessage struct {
 	IsLabel            bool
 	IsBurnAfterReading bool
 	ReplyEmoji         string
+	ExpireAt           int64
+	DeliveredAt        int64
+	EditedAt           int64
 }
 
 //type BurnAfterReadingType uint
@@ -40,6 +45,7 @@ const (
 	MessageTypeVideoCall                              // 视频通话
 	MessageTypeDelete                                 // 撤回消息
 	MessageTypeCancelLabel                            // 取消标注
+	MessageTypeChatRecord                             // 合并转发的聊天记录
 )
 
 type UserMessageSubType uint
@@ -73,6 +79,7 @@ func IsValidMessageType(msgType UserMessageType) bool {
 		MessageTypeEmojiReply:  {},
 		MessageTypeDelete:      {},
 		MessageTypeCancelLabel: {},
+		MessageTypeChatRecord:  {},
 	}
 
 	_, isValid := validTypes[msgType]
@@ -95,11 +102,16 @@ func (um *UserMessage) ToMessage() *v1.Message {
 		MsgId:              int(um.ID),
 		MsgType:            int(um.Type),
 		ReadAt:             int(um.ReadAt),
+		DeliveredAt:        int(um.DeliveredAt),
+		DeliveryStatus:     int(um.DeliveryStatus()),
 		ReplyId:            int(um.ReplyId),
+		ThreadId:           int(um.ThreadId),
 		SendAt:             int(um.CreatedAt),
 		SenderId:           um.SendID,
 		SenderInfo:         nil, // 需要确定如何设置 SenderInfo
 		ReceiverInfo:       nil, // 需要确定如何设置 RecipientInfo
 		DialogId:           int(um.DialogId),
+		Seq:                int(um.Seq),
+		EditedAt:           int(um.EditedAt),
 	}
 }
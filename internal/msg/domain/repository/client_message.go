@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type ClientMessageRepository interface {
+	// 创建发送中的记录，记录已存在时返回false
+	CreateClientMessage(ctx context.Context, m *entity.ClientMessage) (bool, error)
+	// 获取发送者的客户端消息记录，不存在时返回nil
+	GetClientMessage(ctx context.Context, senderID, clientMsgID string) (*entity.ClientMessage, error)
+	// 接管超时未完成的发送中记录，token与记录中的不一致时返回false
+	TakeOverClientMessage(ctx context.Context, id uint, token, newToken string) (bool, error)
+	// 记录发送成功后的消息id，记录已被接管时返回false
+	UpdateClientMessageSent(ctx context.Context, id uint, token string, dialogID, msgID uint, seq uint64) (bool, error)
+	// 删除发送中的记录，记录已被接管时不会删除
+	DeletePendingClientMessage(ctx context.Context, id uint, token string) error
+	// 删除创建时间早于before的记录
+	DeleteClientMessagesBefore(ctx context.Context, before int64) (int64, error)
+}
@@ -27,6 +27,8 @@ type GroupMsgReadRepositoryQuery interface {
 	GetLastGroupMsgsByDialogIDs([]uint) ([]*entity.GroupMessage, error)
 	GetGroupMsgByID(uint) (*entity.GroupMessage, error)
 	GetGroupMsgsByIDs([]uint) ([]*entity.GroupMessage, error)
+	// 按id升序获取id大于msgId的未删除消息
+	GetGroupMsgsAfterID(msgId uint, limit int) ([]*entity.GroupMessage, error)
 	GetLastMsgsForGroupsWithIDs([]uint) ([]*entity.GroupMessage, error)
 	GetGroupMsgList(response dataTransformers.GroupMsgList) (*dataTransformers.GroupMsgListResponse, error)
 	GetGroupMsgLabelByDialogId(dialogId uint) ([]*entity.GroupMessage, error)
@@ -35,4 +37,7 @@ type GroupMsgReadRepositoryQuery interface {
 	GetGroupMsgIdsByDialogID(dialogId uint) ([]uint, error)
 	GetGroupUnreadMsgList(dialogId uint, msgIds []uint) ([]*entity.GroupMessage, error)
 	GetGroupDialogLastMsgs(dialogId uint, pageNumber, pageSize int) ([]*entity.GroupMessage, int64, error)
+	GetGroupThreadMsgs(threadId uint, pageNumber, pageSize int) ([]*entity.GroupMessage, int64, error)
+	GetGroupThreadStats(threadIds []uint) ([]*entity.ThreadStat, error)
+	CountGroupThreadUnread(userID string, lastRead map[uint]uint) (map[uint]int64, error)
 }
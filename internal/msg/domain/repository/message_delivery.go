@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageDeliveryRepository interface {
+	// 设置私聊消息已送达，只返回本次新送达的消息
+	SetUserMsgsDelivered(ctx context.Context, userID string, msgIDs []uint, deliveredAt int64) ([]*entity.UserMessage, error)
+	// 记录群聊消息送达，只返回本次新送达的消息
+	SetGroupMsgsDelivered(ctx context.Context, userID string, msgIDs []uint, deliveredAt int64) ([]*entity.GroupMessage, error)
+}
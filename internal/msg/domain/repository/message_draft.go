@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageDraftRepository interface {
+	// 保存草稿，已存在时覆盖
+	SaveDraft(ctx context.Context, draft *entity.MessageDraft) error
+	// 获取用户在对话中的草稿，不存在时返回nil
+	GetDraft(ctx context.Context, userID string, dialogID uint) (*entity.MessageDraft, error)
+	// 根据对话id批量获取用户的草稿，dialogIDs为空时获取所有草稿
+	GetDraftsByDialogIDs(ctx context.Context, userID string, dialogIDs []uint) ([]*entity.MessageDraft, error)
+	// 删除草稿，返回是否删除了草稿
+	DeleteDraft(ctx context.Context, userID string, dialogID uint) (bool, error)
+}
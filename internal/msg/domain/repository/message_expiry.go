@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageExpiryRepository interface {
+	// 为阅读者已读的阅后即焚消息设置过期时间，已设置过期时间的消息不会被修改
+	SetBurnExpireAt(ctx context.Context, kind entity.MessageKind, msgIDs []uint, readerID string, expireAt int64) error
+	// 获取已到期但未删除的阅后即焚消息
+	GetExpiredMessages(ctx context.Context, kind entity.MessageKind, now int64, limit int) ([]*entity.ExpiredMessage, error)
+	// 删除到期消息及其修订记录，并在同一个事务中记录删除变更，返回是否由本次调用删除
+	DeleteExpiredMessage(ctx context.Context, kind entity.MessageKind, msgID uint, now int64) (bool, error)
+}
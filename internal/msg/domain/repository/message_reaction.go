@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageReactionRepository interface {
+	// 添加回应，已存在时不做处理
+	AddReaction(ctx context.Context, reaction *entity.MessageReaction) error
+	// 取消回应
+	RemoveReaction(ctx context.Context, kind entity.MessageKind, msgID uint, userID string, emoji string) error
+	// 根据消息id获取所有回应
+	GetReactionsByMsgIDs(ctx context.Context, kind entity.MessageKind, msgIDs []uint) ([]*entity.MessageReaction, error)
+	// 根据消息id删除所有回应
+	DeleteReactionsByMsgIDs(ctx context.Context, kind entity.MessageKind, msgIDs []uint) error
+}
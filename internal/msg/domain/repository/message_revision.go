@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageRevisionRepository interface {
+	// 添加修订记录
+	AddRevision(ctx context.Context, revision *entity.MessageRevision) error
+	// 获取消息的所有修订记录，按修改时间升序
+	GetRevisionsByMsgID(ctx context.Context, kind entity.MessageKind, msgID uint) ([]*entity.MessageRevision, error)
+}
+
+type GroupRevisionPolicyRepository interface {
+	// 保存群聊的修订记录可见策略，已存在时覆盖
+	SavePolicy(ctx context.Context, policy *entity.GroupRevisionPolicy) error
+	// 获取群聊的修订记录可见策略，未设置时返回nil
+	GetPolicy(ctx context.Context, groupID uint) (*entity.GroupRevisionPolicy, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageSearchRepository interface {
+	// 写入或更新消息索引
+	IndexMessages(ctx context.Context, docs ...*entity.MessageDocument) error
+	// 根据消息id删除索引
+	DeleteMessages(ctx context.Context, kind entity.SearchMessageKind, msgIds ...uint) error
+	// 根据对话id删除索引
+	DeleteMessagesByDialogID(ctx context.Context, kind entity.SearchMessageKind, dialogId uint) error
+	// 获取历史消息补建索引的进度，返回已处理的最大消息id
+	GetIndexCursor(ctx context.Context, kind entity.SearchMessageKind) (uint, error)
+	// 保存历史消息补建索引的进度
+	SetIndexCursor(ctx context.Context, kind entity.SearchMessageKind, msgId uint) error
+	// 获取按消息变更记录更新索引的进度，返回已处理的最大变更id
+	GetChangeCursor(ctx context.Context) (uint, error)
+	// 保存按消息变更记录更新索引的进度
+	SetChangeCursor(ctx context.Context, changeId uint) error
+	// 搜索消息
+	Search(ctx context.Context, query *entity.MessageSearchQuery) (*entity.MessageSearchResult, error)
+	Close() error
+}
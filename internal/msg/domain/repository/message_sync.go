@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageSyncRepository interface {
+	// 记录消息变更，按对话分配严格递增的变更序号
+	AppendChanges(ctx context.Context, changes []*entity.MessageChange) error
+	// 获取对话内的消息id，deleted为true时只返回已删除的消息，否则只返回未删除的消息
+	GetDialogMsgIDs(ctx context.Context, kind entity.MessageKind, dialogID uint, deleted bool) ([]uint, error)
+	// 获取对话内序号大于afterSeq的变更，按序号升序
+	GetChanges(ctx context.Context, dialogID uint, afterSeq uint64, limit int) ([]*entity.MessageChange, error)
+	// 获取所有对话中id大于afterID且创建时间不晚于before（毫秒）的变更，按id升序
+	GetChangesAfterID(ctx context.Context, afterID uint, before int64, limit int) ([]*entity.MessageChange, error)
+	// 获取对话当前最大的变更序号
+	GetMaxSyncSeq(ctx context.Context, dialogID uint) (uint64, error)
+	// 按消息id游标分页获取私聊消息，按id降序
+	GetUserMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) ([]*entity.UserMessage, error)
+	// 按消息id游标分页获取群聊消息，按id降序
+	GetGroupMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) ([]*entity.GroupMessage, error)
+	// 获取对话当前最大的消息序号
+	GetMaxMsgSeq(ctx context.Context, dialogID uint) (uint64, error)
+	// 获取序号在[startSeq, endSeq]之间的私聊消息，按序号升序
+	GetUserMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.UserMessage, error)
+	// 获取序号在[startSeq, endSeq]之间的群聊消息，按序号升序
+	GetGroupMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.GroupMessage, error)
+}
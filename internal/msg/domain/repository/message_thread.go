@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type MessageThreadReadRepository interface {
+	// 获取用户在多个话题中的阅读进度
+	GetThreadReads(ctx context.Context, kind entity.MessageKind, userID string, threadIDs []uint) ([]*entity.MessageThreadRead, error)
+	// 更新阅读进度，只会向前推进
+	SetThreadRead(ctx context.Context, read *entity.MessageThreadRead) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+)
+
+type ScheduledMessageRepository interface {
+	// 创建定时消息
+	CreateScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) error
+	// 根据id获取定时消息
+	GetScheduledMessage(ctx context.Context, id uint) (*entity.ScheduledMessage, error)
+	// 分页获取定时消息
+	ListScheduledMessages(ctx context.Context, query *entity.ScheduledMessageQuery) ([]*entity.ScheduledMessage, int64, error)
+	// 修改等待发送的定时消息，返回是否修改成功
+	UpdatePendingScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) (bool, error)
+	// 取消等待发送的定时消息，返回是否取消成功
+	CancelScheduledMessage(ctx context.Context, id uint, userID string) (bool, error)
+	// 领取到期的定时消息，同一条消息只会被一个实例领取
+	ClaimDueScheduledMessages(ctx context.Context, owner string, now, lockUntil int64, limit int) ([]*entity.ScheduledMessage, error)
+	// 将领取后超时未完成的消息标记为失败
+	FailExpiredScheduledMessages(ctx context.Context, now int64, reason string) (int64, error)
+	// 完成已领取的定时消息，领取已超时被标记为失败时仍然记录实际结果，返回是否更新
+	FinishScheduledMessage(ctx context.Context, id uint, owner string, status entity.ScheduledMessageStatus, msgID uint, reason string) (bool, error)
+}
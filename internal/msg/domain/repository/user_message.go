@@ -39,5 +39,10 @@ type UserMessageRepositoryQuery interface {
 	GetUserMsgIdBeforeMsgList(dialogId uint, msgId uint, pageSize int) ([]*entity.UserMessage, int32, error)
 	GetUserDialogLastMsgs(dialogId uint, pageNumber, pageSize int) ([]*entity.UserMessage, int64, error)
 	GetLastUserMsgsByDialogIDs(dialogIds []uint) ([]*entity.UserMessage, error)
+	GetUserThreadMsgs(threadId uint, pageNumber, pageSize int) ([]*entity.UserMessage, int64, error)
+	GetUserThreadStats(threadIds []uint) ([]*entity.ThreadStat, error)
+	CountUserThreadUnread(userID string, lastRead map[uint]uint) (map[uint]int64, error)
 	Find(ctx context.Context, query *entity.UserMsgQuery) (*entity.UserMsgQueryResult, error)
+	// 按id升序获取id大于msgId的未删除消息
+	GetUserMsgsAfterID(msgId uint, limit int) ([]*entity.UserMessage, error)
 }
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"github.com/cossim/coss-server/pkg/utils/time"
+	"github.com/lithammer/shortuuid/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ClientMessageDomain interface {
+	// 占用客户端消息id，已经发送过时返回之前的记录，正在发送中时返回MsgErrClientMsgSending
+	Reserve(ctx context.Context, senderID, clientMsgID string, isGroup bool) (*entity.ClientMessage, error)
+	// 记录发送成功的消息，客户端消息id已被其他请求接管时返回错误
+	Complete(ctx context.Context, m *entity.ClientMessage) error
+	// 发送失败时释放客户端消息id，允许客户端重试，已被其他请求接管时不做处理
+	Release(ctx context.Context, m *entity.ClientMessage) error
+	// 清理过期的去重记录
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type ClientMessageDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewClientMessageDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) ClientMessageDomain {
+	return &ClientMessageDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *ClientMessageDomainImpl) Reserve(ctx context.Context, senderID, clientMsgID string, isGroup bool) (*entity.ClientMessage, error) {
+	if clientMsgID == "" || len(clientMsgID) > entity.MaxClientMsgIdLength {
+		return nil, code.MsgErrInvalidClientMsgId
+	}
+
+	cm := &entity.ClientMessage{
+		SenderID:    senderID,
+		ClientMsgID: clientMsgID,
+		IsGroup:     isGroup,
+		Token:       shortuuid.New(),
+	}
+	created, err := m.repo.Cmr.CreateClientMessage(ctx, cm)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrSaveClientMsgFailed.Code()), err.Error())
+	}
+	if created {
+		return cm, nil
+	}
+
+	existing, err := m.repo.Cmr.GetClientMessage(ctx, senderID, clientMsgID)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrSaveClientMsgFailed.Code()), err.Error())
+	}
+	if existing == nil {
+		// 记录刚好被清理，让客户端重试
+		return nil, code.MsgErrClientMsgSending
+	}
+	if existing.IsSent() {
+		return existing, nil
+	}
+
+	// 上一次发送的实例可能已经退出，超过发送超时后由当前请求接管，之前的持有者不能再完成或释放
+	if time.Now()-existing.UpdatedAt < entity.ClientMsgPendingTimeout {
+		return nil, code.MsgErrClientMsgSending
+	}
+	token := shortuuid.New()
+	ok, err := m.repo.Cmr.TakeOverClientMessage(ctx, existing.ID, existing.Token, token)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrSaveClientMsgFailed.Code()), err.Error())
+	}
+	if !ok {
+		return nil, code.MsgErrClientMsgSending
+	}
+	existing.IsGroup = isGroup
+	existing.Token = token
+	return existing, nil
+}
+
+func (m *ClientMessageDomainImpl) Complete(ctx context.Context, cm *entity.ClientMessage) error {
+	ok, err := m.repo.Cmr.UpdateClientMessageSent(ctx, cm.ID, cm.Token, cm.DialogID, cm.MsgID, cm.Seq)
+	if err != nil {
+		return status.Error(codes.Code(code.MsgErrSaveClientMsgFailed.Code()), err.Error())
+	}
+	if !ok {
+		return code.MsgErrSaveClientMsgFailed.CustomMessage("client message id has been taken over")
+	}
+	return nil
+}
+
+func (m *ClientMessageDomainImpl) Release(ctx context.Context, cm *entity.ClientMessage) error {
+	if err := m.repo.Cmr.DeletePendingClientMessage(ctx, cm.ID, cm.Token); err != nil {
+		return status.Error(codes.Code(code.MsgErrSaveClientMsgFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *ClientMessageDomainImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	return m.repo.Cmr.DeleteClientMessagesBefore(ctx, time.Now()-entity.ClientMsgRetention)
+}
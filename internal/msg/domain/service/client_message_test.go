@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"github.com/cossim/coss-server/pkg/code"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// expireClientMessage 模拟发送方超过发送超时仍未完成
+func expireClientMessage(t *testing.T, db *gorm.DB, cm *entity.ClientMessage) {
+	t.Helper()
+	if err := db.Model(&po.ClientMessage{}).Where("id = ?", cm.ID).
+		UpdateColumn("updated_at", cm.UpdatedAt-entity.ClientMsgPendingTimeout-1).Error; err != nil {
+		t.Fatalf("expire client message: %v", err)
+	}
+}
+
+func TestClientMessageReserve(t *testing.T) {
+	db, repos := newTestDB(t)
+	cmd := service.NewClientMessageDomain(db, nil, repos)
+	ctx := context.Background()
+
+	cm, err := cmd.Reserve(ctx, "u1", "c1", false)
+	if err != nil || cm.IsSent() {
+		t.Fatalf("Reserve: %+v, %v", cm, err)
+	}
+	// 发送中的重试需要等待
+	if _, err := cmd.Reserve(ctx, "u1", "c1", false); !code.IsCode(err, code.MsgErrClientMsgSending) {
+		t.Fatalf("expected MsgErrClientMsgSending, got %v", err)
+	}
+	// 不同的发送者互不影响
+	if _, err := cmd.Reserve(ctx, "u2", "c1", false); err != nil {
+		t.Fatalf("Reserve other sender: %v", err)
+	}
+
+	cm.DialogID, cm.MsgID, cm.Seq = 1, 10, 3
+	if err := cmd.Complete(ctx, cm); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	dup, err := cmd.Reserve(ctx, "u1", "c1", false)
+	if err != nil || !dup.IsSent() || dup.MsgID != 10 || dup.Seq != 3 {
+		t.Fatalf("retry should return sent message, got %+v, %v", dup, err)
+	}
+
+	// 发送失败释放后可以重新发送
+	failed, err := cmd.Reserve(ctx, "u1", "c2", false)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if err := cmd.Release(ctx, failed); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, err := cmd.Reserve(ctx, "u1", "c2", false); err != nil {
+		t.Fatalf("Reserve after release: %v", err)
+	}
+}
+
+func TestClientMessageTakeOver(t *testing.T) {
+	db, repos := newTestDB(t)
+	cmd := service.NewClientMessageDomain(db, nil, repos)
+	ctx := context.Background()
+
+	first, err := cmd.Reserve(ctx, "u1", "c1", false)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	expireClientMessage(t, db, first)
+
+	second, err := cmd.Reserve(ctx, "u1", "c1", false)
+	if err != nil || second.IsSent() {
+		t.Fatalf("expected take over, got %+v, %v", second, err)
+	}
+	if second.Token == first.Token {
+		t.Fatal("take over should change token")
+	}
+	// 同时接管只有一个成功
+	if _, err := cmd.Reserve(ctx, "u1", "c1", false); !code.IsCode(err, code.MsgErrClientMsgSending) {
+		t.Fatalf("expected MsgErrClientMsgSending, got %v", err)
+	}
+
+	// 之前的持有者不能释放或完成被接管的记录
+	if err := cmd.Release(ctx, first); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	first.DialogID, first.MsgID = 1, 10
+	if err := cmd.Complete(ctx, first); err == nil {
+		t.Fatal("stale owner should not complete")
+	}
+	if _, err := cmd.Reserve(ctx, "u1", "c1", false); !code.IsCode(err, code.MsgErrClientMsgSending) {
+		t.Fatalf("reservation should still belong to new owner, got %v", err)
+	}
+
+	second.DialogID, second.MsgID = 1, 20
+	if err := cmd.Complete(ctx, second); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	dup, err := cmd.Reserve(ctx, "u1", "c1", false)
+	if err != nil || dup.MsgID != 20 {
+		t.Fatalf("retry should return new owner's message, got %+v, %v", dup, err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cossim/coss-server/pkg/code"
 	pkgconfig "github.com/cossim/coss-server/pkg/config"
 	"github.com/cossim/coss-server/pkg/utils"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
@@ -65,18 +66,23 @@ func NewGroupMsgDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.R
 }
 
 func (g GroupMsgDomainImpl) SendGroupMessage(ctx context.Context, msg *entity.GroupMessage) (*entity.GroupMessage, error) {
-	mg, err := g.repo.Gmr.InsertGroupMessage(msg)
+	var mg *entity.GroupMessage
+	err := inTransaction(ctx, g.db, func(repo *persistence.Repositories) error {
+		var err error
+		if mg, err = repo.Gmr.InsertGroupMessage(msg); err != nil {
+			return err
+		}
+		return recordGroupMsgChanges(ctx, repo, entity.MessageChangeNew, mg)
+	})
 	if err != nil {
 		return nil, status.Error(codes.Code(code.MsgErrInsertGroupMessageFailed.Code()), err.Error())
 	}
+	indexGroupMessages(ctx, g.repo, mg)
 	return mg, nil
 }
 
 func (g GroupMsgDomainImpl) SendGroupMessageRevert(ctx context.Context, id uint) error {
-	if err := g.repo.Gmr.PhysicalDeleteGroupMessage(id); err != nil {
-		return status.Error(codes.Code(code.MsgErrDeleteGroupMessageFailed.Code()), err.Error())
-	}
-	return nil
+	return g.DeleteGroupMessage(ctx, id, true)
 }
 
 func (g GroupMsgDomainImpl) GetGroupLastMessageList(ctx context.Context, dialogID uint, pageSize, pageNum int) ([]*entity.GroupMessage, int64, error) {
@@ -119,22 +125,46 @@ func (g GroupMsgDomainImpl) GetGroupMessageList(ctx context.Context, msg *entity
 }
 
 func (g GroupMsgDomainImpl) EditGroupMessage(ctx context.Context, msg *entity.GroupMessage) error {
-	if err := g.repo.Gmr.UpdateGroupMessage(msg); err != nil {
+	var mg *entity.GroupMessage
+	err := inTransaction(ctx, g.db, func(repo *persistence.Repositories) error {
+		if err := repo.Gmr.UpdateGroupMessage(msg); err != nil {
+			return err
+		}
+		var err error
+		if mg, err = repo.Gmr.GetGroupMsgByID(msg.ID); err != nil {
+			return err
+		}
+		return recordGroupMsgChanges(ctx, repo, entity.MessageChangeEdit, mg)
+	})
+	if err != nil {
 		return status.Error(codes.Code(code.MsgErrEditGroupMessageFailed.Code()), err.Error())
 	}
+	indexGroupMessages(ctx, g.repo, mg)
 	return nil
 }
 
 func (g GroupMsgDomainImpl) DeleteGroupMessage(ctx context.Context, id uint, isPhysical bool) error {
-	if isPhysical {
-		if err := g.repo.Gmr.PhysicalDeleteGroupMessage(id); err != nil {
-			return status.Error(codes.Code(code.MsgErrDeleteGroupMessageFailed.Code()), err.Error())
+	err := inTransaction(ctx, g.db, func(repo *persistence.Repositories) error {
+		msgs, err := repo.Gmr.GetGroupMsgsByIDs([]uint{id})
+		if err != nil {
+			return err
 		}
-		return nil
-	}
-	if err := g.repo.Gmr.LogicalDeleteGroupMessage(id); err != nil {
+		if isPhysical {
+			if err := repo.Gmr.PhysicalDeleteGroupMessage(id); err != nil {
+				return err
+			}
+		} else if err := repo.Gmr.LogicalDeleteGroupMessage(id); err != nil {
+			return err
+		}
+		if err := repo.Mrr.DeleteReactionsByMsgIDs(ctx, entity.GroupMessageKind, []uint{id}); err != nil {
+			return err
+		}
+		return recordGroupMsgChanges(ctx, repo, entity.MessageChangeDelete, msgs...)
+	})
+	if err != nil {
 		return status.Error(codes.Code(code.MsgErrDeleteGroupMessageFailed.Code()), err.Error())
 	}
+	unindexMessages(ctx, g.repo, entity.SearchGroupMessage, id)
 	return nil
 }
 
@@ -161,7 +191,17 @@ func (g GroupMsgDomainImpl) GetGroupMessagesByIds(ctx context.Context, ids []uin
 }
 
 func (g GroupMsgDomainImpl) SetGroupMsgLabel(ctx context.Context, id uint, isLabel bool) error {
-	if err := g.repo.Gmr.UpdateGroupMsgColumn(id, "is_label", isLabel); err != nil {
+	err := inTransaction(ctx, g.db, func(repo *persistence.Repositories) error {
+		if err := repo.Gmr.UpdateGroupMsgColumn(id, "is_label", isLabel); err != nil {
+			return err
+		}
+		mg, err := repo.Gmr.GetGroupMsgByID(id)
+		if err != nil {
+			return err
+		}
+		return recordGroupMsgChanges(ctx, repo, entity.MessageChangeEdit, mg)
+	})
+	if err != nil {
 		return status.Error(codes.Code(code.SetMsgErrSetGroupMsgLabelFailed.Code()), err.Error())
 	}
 	return nil
@@ -184,25 +224,45 @@ func (g GroupMsgDomainImpl) GetGroupMsgIdAfterMsgList(ctx context.Context, msgID
 }
 
 func (g GroupMsgDomainImpl) DeleteGroupMessageByDialogId(ctx context.Context, dialogID uint, isPhysical bool) error {
-	if isPhysical {
-		err := g.repo.Gmr.PhysicalDeleteGroupMessagesByDialogID(dialogID)
+	err := inTransaction(ctx, g.db, func(repo *persistence.Repositories) error {
+		ids, err := recordDialogMsgChanges(ctx, repo, entity.GroupMessageKind, dialogID, false, entity.MessageChangeDelete)
 		if err != nil {
-			return status.Error(codes.Aborted, fmt.Sprintf("failed to delete group msg: %v", err))
+			return err
 		}
-		return nil
-	}
-	err := g.repo.Gmr.DeleteGroupMessagesByDialogID(dialogID)
+		if isPhysical {
+			if err := deleteDialogReactions(ctx, repo, entity.GroupMessageKind, dialogID, ids); err != nil {
+				return err
+			}
+			return repo.Gmr.PhysicalDeleteGroupMessagesByDialogID(dialogID)
+		}
+		return repo.Gmr.DeleteGroupMessagesByDialogID(dialogID)
+	})
 	if err != nil {
 		return status.Error(codes.Aborted, fmt.Sprintf("failed to delete group msg: %v", err))
 	}
+	unindexDialogMessages(ctx, g.repo, entity.SearchGroupMessage, dialogID)
 	return nil
 }
 
 func (g GroupMsgDomainImpl) DeleteGroupMessageByDialogIdRollback(ctx context.Context, dialogID uint) error {
-	err := g.repo.Gmr.UpdateGroupMsgColumnByDialogId(dialogID, "deleted_at", 0)
+	var ids []uint
+	err := inTransaction(ctx, g.db, func(repo *persistence.Repositories) error {
+		// 恢复的消息重新作为新消息同步给客户端
+		var err error
+		if ids, err = recordDialogMsgChanges(ctx, repo, entity.GroupMessageKind, dialogID, true, entity.MessageChangeNew); err != nil {
+			return err
+		}
+		return repo.Gmr.UpdateGroupMsgColumnByDialogId(dialogID, "deleted_at", 0)
+	})
 	if err != nil {
 		return status.Error(codes.Code(code.MsgErrDeleteGroupMessageFailed.Code()), err.Error())
 	}
+	// 恢复的消息重新写入索引
+	if msgs, err := g.repo.Gmr.GetGroupMsgsByIDs(ids); err != nil {
+		zap.L().Error("get restored messages failed", zap.Uint("dialog_id", dialogID), zap.Error(err))
+	} else {
+		indexGroupMessages(ctx, g.repo, msgs...)
+	}
 	return nil
 }
 
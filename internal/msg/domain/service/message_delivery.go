@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageDeliveryDomain interface {
+	// 消息推送到用户设备后记录送达，只返回本次新送达的消息
+	ConfirmDelivered(ctx context.Context, userID string, userMsgIDs, groupMsgIDs []uint, deliveredAt int64) ([]*entity.DeliveredMessage, error)
+}
+
+type MessageDeliveryDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageDeliveryDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageDeliveryDomain {
+	return &MessageDeliveryDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageDeliveryDomainImpl) ConfirmDelivered(ctx context.Context, userID string, userMsgIDs, groupMsgIDs []uint, deliveredAt int64) ([]*entity.DeliveredMessage, error) {
+	result := make([]*entity.DeliveredMessage, 0)
+
+	userMsgs, err := m.repo.Mdr.SetUserMsgsDelivered(ctx, userID, userMsgIDs, deliveredAt)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrSetMsgDeliveredFailed.Code()), err.Error())
+	}
+	for _, msg := range userMsgs {
+		result = append(result, &entity.DeliveredMessage{
+			Kind:           entity.UserMessageKind,
+			ID:             msg.ID,
+			DialogID:       msg.DialogId,
+			SenderID:       msg.SendID,
+			ReceiverID:     msg.ReceiveID,
+			DeliveredAt:    msg.DeliveredAt,
+			DeliveredCount: 1,
+		})
+	}
+
+	groupMsgs, err := m.repo.Mdr.SetGroupMsgsDelivered(ctx, userID, groupMsgIDs, deliveredAt)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrSetMsgDeliveredFailed.Code()), err.Error())
+	}
+	for _, msg := range groupMsgs {
+		result = append(result, &entity.DeliveredMessage{
+			Kind:           entity.GroupMessageKind,
+			ID:             msg.ID,
+			DialogID:       msg.DialogID,
+			GroupID:        msg.GroupID,
+			SenderID:       msg.UserID,
+			ReceiverID:     userID,
+			DeliveredAt:    deliveredAt,
+			DeliveredCount: msg.DeliveredCount,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"sync"
+	"testing"
+)
+
+func TestConfirmUserMsgsDelivered(t *testing.T) {
+	db, repos := newTestDB(t)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	mdd := service.NewMessageDeliveryDomain(db, nil, repos)
+	ctx := context.Background()
+
+	msg, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "hello"})
+	if err != nil {
+		t.Fatalf("SendUserMessage: %v", err)
+	}
+	if s := msg.DeliveryStatus(); s != entity.DeliverySent {
+		t.Fatalf("status = %d, want sent", s)
+	}
+
+	// 只有接收者的设备可以确认送达
+	delivered, err := mdd.ConfirmDelivered(ctx, "u1", []uint{msg.ID}, nil, 100)
+	if err != nil {
+		t.Fatalf("ConfirmDelivered: %v", err)
+	}
+	if len(delivered) != 0 {
+		t.Fatalf("expected sender confirmation to be ignored, got %d", len(delivered))
+	}
+
+	delivered, err = mdd.ConfirmDelivered(ctx, "u2", []uint{msg.ID}, nil, 100)
+	if err != nil {
+		t.Fatalf("ConfirmDelivered: %v", err)
+	}
+	if len(delivered) != 1 {
+		t.Fatalf("expected 1 delivered message, got %d", len(delivered))
+	}
+	d := delivered[0]
+	if d.Kind != entity.UserMessageKind || d.ID != msg.ID || d.DialogID != 1 || d.SenderID != "u1" || d.ReceiverID != "u2" || d.DeliveredAt != 100 || d.DeliveredCount != 1 {
+		t.Fatalf("unexpected delivered message: %+v", d)
+	}
+
+	// 其他设备再次确认时不会重复通知发送者，送达时间保持第一次的时间
+	delivered, err = mdd.ConfirmDelivered(ctx, "u2", []uint{msg.ID}, nil, 200)
+	if err != nil {
+		t.Fatalf("ConfirmDelivered: %v", err)
+	}
+	if len(delivered) != 0 {
+		t.Fatalf("expected repeated confirmation to be ignored, got %d", len(delivered))
+	}
+	got, err := umd.GetUserMessageById(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetUserMessageById: %v", err)
+	}
+	if got.DeliveredAt != 100 || got.DeliveryStatus() != entity.DeliveryDelivered {
+		t.Fatalf("unexpected message after delivery: delivered_at %d, status %d", got.DeliveredAt, got.DeliveryStatus())
+	}
+
+	got.IsRead = entity.IsRead
+	if s := got.DeliveryStatus(); s != entity.DeliveryRead {
+		t.Fatalf("status = %d, want read", s)
+	}
+}
+
+func TestConfirmGroupMsgsDelivered(t *testing.T) {
+	db, repos := newTestDB(t)
+	gmd := service.NewGroupMsgDomain(db, nil, repos)
+	mdd := service.NewMessageDeliveryDomain(db, nil, repos)
+	ctx := context.Background()
+
+	msg, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 1, GroupID: 2, UserID: "u1", Content: "hello"})
+	if err != nil {
+		t.Fatalf("SendGroupMessage: %v", err)
+	}
+
+	// 发送者自己的设备不计入送达
+	delivered, err := mdd.ConfirmDelivered(ctx, "u1", nil, []uint{msg.ID}, 100)
+	if err != nil {
+		t.Fatalf("ConfirmDelivered: %v", err)
+	}
+	if len(delivered) != 0 {
+		t.Fatalf("expected sender confirmation to be ignored, got %d", len(delivered))
+	}
+
+	// 同一成员的多个设备同时确认，每个成员只计一次
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var results []*entity.DeliveredMessage
+	for _, uid := range []string{"u2", "u2", "u3", "u3"} {
+		wg.Add(1)
+		go func(uid string) {
+			defer wg.Done()
+			list, err := mdd.ConfirmDelivered(ctx, uid, nil, []uint{msg.ID}, 100)
+			if err != nil {
+				t.Errorf("ConfirmDelivered: %v", err)
+				return
+			}
+			mu.Lock()
+			results = append(results, list...)
+			mu.Unlock()
+		}(uid)
+	}
+	wg.Wait()
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 new deliveries, got %d", len(results))
+	}
+	counts := map[int]bool{}
+	for _, d := range results {
+		if d.Kind != entity.GroupMessageKind || d.GroupID != 2 || d.SenderID != "u1" {
+			t.Fatalf("unexpected delivered message: %+v", d)
+		}
+		counts[d.DeliveredCount] = true
+	}
+	if !counts[1] || !counts[2] {
+		t.Fatalf("expected delivered counts 1 and 2, got %v", counts)
+	}
+
+	got, err := gmd.GetGroupMessageById(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetGroupMessageById: %v", err)
+	}
+	if got.DeliveredCount != 2 || got.DeliveryStatus() != entity.DeliveryDelivered {
+		t.Fatalf("unexpected message after delivery: count %d, status %d", got.DeliveredCount, got.DeliveryStatus())
+	}
+}
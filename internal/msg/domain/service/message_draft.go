@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageDraftDomain interface {
+	// 保存草稿，内容为空时清除草稿
+	SaveDraft(ctx context.Context, draft *entity.MessageDraft) error
+	// 获取用户在对话中的草稿，不存在时返回nil
+	GetDraft(ctx context.Context, userID string, dialogID uint) (*entity.MessageDraft, error)
+	// 批量获取用户的草稿，dialogIDs为空时获取所有草稿
+	GetDrafts(ctx context.Context, userID string, dialogIDs []uint) (map[uint]*entity.MessageDraft, error)
+	// 清除草稿，返回是否清除了草稿
+	ClearDraft(ctx context.Context, userID string, dialogID uint) (bool, error)
+}
+
+type MessageDraftDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageDraftDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageDraftDomain {
+	return &MessageDraftDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageDraftDomainImpl) SaveDraft(ctx context.Context, draft *entity.MessageDraft) error {
+	if draft.Content == "" {
+		_, err := m.ClearDraft(ctx, draft.UserID, draft.DialogID)
+		return err
+	}
+	if err := m.repo.Mdrr.SaveDraft(ctx, draft); err != nil {
+		return status.Error(codes.Code(code.MsgErrSaveDraftFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *MessageDraftDomainImpl) GetDraft(ctx context.Context, userID string, dialogID uint) (*entity.MessageDraft, error) {
+	draft, err := m.repo.Mdrr.GetDraft(ctx, userID, dialogID)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetDraftFailed.Code()), err.Error())
+	}
+	return draft, nil
+}
+
+func (m *MessageDraftDomainImpl) GetDrafts(ctx context.Context, userID string, dialogIDs []uint) (map[uint]*entity.MessageDraft, error) {
+	drafts, err := m.repo.Mdrr.GetDraftsByDialogIDs(ctx, userID, dialogIDs)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetDraftFailed.Code()), err.Error())
+	}
+	result := make(map[uint]*entity.MessageDraft, len(drafts))
+	for _, v := range drafts {
+		result[v.DialogID] = v
+	}
+	return result, nil
+}
+
+func (m *MessageDraftDomainImpl) ClearDraft(ctx context.Context, userID string, dialogID uint) (bool, error) {
+	ok, err := m.repo.Mdrr.DeleteDraft(ctx, userID, dialogID)
+	if err != nil {
+		return false, status.Error(codes.Code(code.MsgErrClearDraftFailed.Code()), err.Error())
+	}
+	return ok, nil
+}
@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"testing"
+)
+
+func TestMessageDraft(t *testing.T) {
+	db, repos := newTestDB(t)
+	mdrd := service.NewMessageDraftDomain(db, nil, repos)
+	ctx := context.Background()
+
+	draft := &entity.MessageDraft{UserID: "u1", DialogID: 1, Content: "hello", ReplyId: 3}
+	if err := mdrd.SaveDraft(ctx, draft); err != nil {
+		t.Fatalf("SaveDraft: %v", err)
+	}
+	if draft.ID == 0 || draft.UpdatedAt == 0 {
+		t.Fatalf("expected saved draft to be filled, got %+v", draft)
+	}
+
+	// 其他设备再次保存时覆盖同一份草稿
+	if err := mdrd.SaveDraft(ctx, &entity.MessageDraft{UserID: "u1", DialogID: 1, Content: "hello world"}); err != nil {
+		t.Fatalf("SaveDraft: %v", err)
+	}
+	got, err := mdrd.GetDraft(ctx, "u1", 1)
+	if err != nil {
+		t.Fatalf("GetDraft: %v", err)
+	}
+	if got == nil || got.Content != "hello world" || got.ReplyId != 0 {
+		t.Fatalf("unexpected draft: %+v", got)
+	}
+	var count int64
+	if err := db.Model(&po.MessageDraft{}).Count(&count).Error; err != nil {
+		t.Fatalf("count drafts: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 draft row, got %d", count)
+	}
+
+	// 草稿按用户隔离
+	if got, err := mdrd.GetDraft(ctx, "u2", 1); err != nil || got != nil {
+		t.Fatalf("expected no draft for u2, got %+v, %v", got, err)
+	}
+
+	if err := mdrd.SaveDraft(ctx, &entity.MessageDraft{UserID: "u1", DialogID: 2, Content: "second"}); err != nil {
+		t.Fatalf("SaveDraft: %v", err)
+	}
+	drafts, err := mdrd.GetDrafts(ctx, "u1", nil)
+	if err != nil {
+		t.Fatalf("GetDrafts: %v", err)
+	}
+	if len(drafts) != 2 || drafts[2].Content != "second" {
+		t.Fatalf("unexpected drafts: %+v", drafts)
+	}
+	drafts, err = mdrd.GetDrafts(ctx, "u1", []uint{2, 3})
+	if err != nil {
+		t.Fatalf("GetDrafts: %v", err)
+	}
+	if len(drafts) != 1 || drafts[2] == nil {
+		t.Fatalf("expected only the draft of dialog 2, got %+v", drafts)
+	}
+
+	// 保存空内容等同于清除
+	if err := mdrd.SaveDraft(ctx, &entity.MessageDraft{UserID: "u1", DialogID: 1}); err != nil {
+		t.Fatalf("SaveDraft: %v", err)
+	}
+	if got, err := mdrd.GetDraft(ctx, "u1", 1); err != nil || got != nil {
+		t.Fatalf("expected draft to be cleared, got %+v, %v", got, err)
+	}
+
+	ok, err := mdrd.ClearDraft(ctx, "u1", 2)
+	if err != nil || !ok {
+		t.Fatalf("ClearDraft = %v, %v", ok, err)
+	}
+	if ok, err := mdrd.ClearDraft(ctx, "u1", 2); err != nil || ok {
+		t.Fatalf("expected clearing a missing draft to report false, got %v, %v", ok, err)
+	}
+}
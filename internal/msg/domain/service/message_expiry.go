@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageExpiryDomain interface {
+	// 阅读者阅读阅后即焚消息后开始计时，timeout单位为秒
+	StartBurnCountdown(ctx context.Context, kind entity.MessageKind, msgIDs []uint, readerID string, readAt, timeout int64) error
+	// 删除到期的阅后即焚消息，只返回由本次调用删除的消息
+	DeleteExpiredMessages(ctx context.Context, now int64, limit int) ([]*entity.ExpiredMessage, error)
+}
+
+type MessageExpiryDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageExpiryDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageExpiryDomain {
+	return &MessageExpiryDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageExpiryDomainImpl) StartBurnCountdown(ctx context.Context, kind entity.MessageKind, msgIDs []uint, readerID string, readAt, timeout int64) error {
+	if len(msgIDs) == 0 {
+		return nil
+	}
+	if err := m.repo.Mer.SetBurnExpireAt(ctx, kind, msgIDs, readerID, entity.BurnAfterReadingExpireAt(readAt, timeout)); err != nil {
+		return status.Error(codes.Code(code.MsgErrSetBurnExpireFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *MessageExpiryDomainImpl) DeleteExpiredMessages(ctx context.Context, now int64, limit int) ([]*entity.ExpiredMessage, error) {
+	result := make([]*entity.ExpiredMessage, 0)
+	for _, kind := range []entity.MessageKind{entity.UserMessageKind, entity.GroupMessageKind} {
+		msgs, err := m.repo.Mer.GetExpiredMessages(ctx, kind, now, limit)
+		if err != nil {
+			return result, err
+		}
+		for _, msg := range msgs {
+			// 多个实例可能同时扫描到同一条消息，只有删除成功的实例负责推送
+			deleted, err := m.repo.Mer.DeleteExpiredMessage(ctx, kind, msg.ID, now)
+			if err != nil {
+				return result, err
+			}
+			if deleted {
+				result = append(result, msg)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,205 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+)
+
+func insertUserMsg(t *testing.T, repos *persistence.Repositories, msg *entity.UserMessage) uint {
+	t.Helper()
+	m, err := repos.Umr.InsertUserMessage(msg)
+	if err != nil {
+		t.Fatalf("InsertUserMessage: %v", err)
+	}
+	return m.ID
+}
+
+func insertGroupMsg(t *testing.T, repos *persistence.Repositories, msg *entity.GroupMessage) uint {
+	t.Helper()
+	m, err := repos.Gmr.InsertGroupMessage(msg)
+	if err != nil {
+		t.Fatalf("InsertGroupMessage: %v", err)
+	}
+	return m.ID
+}
+
+// msgColumn 读取消息表中的过期时间和删除时间
+func msgColumn(t *testing.T, db *gorm.DB, model interface{}, id uint, column string) int64 {
+	t.Helper()
+	var v []int64
+	if err := db.Model(model).Where("id = ?", id).Pluck(column, &v).Error; err != nil || len(v) != 1 {
+		t.Fatalf("pluck %s of %d: %v, %v", column, id, v, err)
+	}
+	return v[0]
+}
+
+func TestBurnAfterReadingExpireAt(t *testing.T) {
+	if got := entity.BurnAfterReadingExpireAt(1000, 5); got != 6000 {
+		t.Fatalf("expected 6000, got %d", got)
+	}
+	if got := entity.BurnAfterReadingExpireAt(1000, 0); got != 1000+entity.DefaultBurnAfterReadingTimeout*1000 {
+		t.Fatalf("expected default timeout, got %d", got)
+	}
+}
+
+func TestStartBurnCountdown(t *testing.T) {
+	db, repos := newTestDB(t)
+	d := service.NewMessageExpiryDomain(db, nil, repos)
+	ctx := context.Background()
+
+	burn := insertUserMsg(t, repos, &entity.UserMessage{DialogId: 1, SendID: "a", ReceiveID: "b", IsBurnAfterReading: true})
+	normal := insertUserMsg(t, repos, &entity.UserMessage{DialogId: 1, SendID: "a", ReceiveID: "b"})
+	group := insertGroupMsg(t, repos, &entity.GroupMessage{DialogID: 2, GroupID: 9, UserID: "a", IsBurnAfterReading: true})
+
+	// 发送者自己阅读不会开始计时
+	if err := d.StartBurnCountdown(ctx, entity.UserMessageKind, []uint{burn, normal}, "a", 1000, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v := msgColumn(t, db, &po.UserMessage{}, burn, "expire_at"); v != 0 {
+		t.Fatalf("sender read should not start countdown, got %d", v)
+	}
+
+	if err := d.StartBurnCountdown(ctx, entity.UserMessageKind, []uint{burn, normal}, "b", 1000, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v := msgColumn(t, db, &po.UserMessage{}, burn, "expire_at"); v != 11000 {
+		t.Fatalf("expected expire at 11000, got %d", v)
+	}
+	if v := msgColumn(t, db, &po.UserMessage{}, normal, "expire_at"); v != 0 {
+		t.Fatalf("normal message should not expire, got %d", v)
+	}
+
+	// 再次阅读不会推迟过期时间
+	if err := d.StartBurnCountdown(ctx, entity.UserMessageKind, []uint{burn}, "b", 5000, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v := msgColumn(t, db, &po.UserMessage{}, burn, "expire_at"); v != 11000 {
+		t.Fatalf("countdown should not be reset, got %d", v)
+	}
+
+	if err := d.StartBurnCountdown(ctx, entity.GroupMessageKind, []uint{group}, "a", 1000, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v := msgColumn(t, db, &po.GroupMessage{}, group, "expire_at"); v != 0 {
+		t.Fatalf("group sender read should not start countdown, got %d", v)
+	}
+	if err := d.StartBurnCountdown(ctx, entity.GroupMessageKind, []uint{group}, "c", 2000, entity.DefaultBurnAfterReadingTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if v := msgColumn(t, db, &po.GroupMessage{}, group, "expire_at"); v != 2000+entity.DefaultBurnAfterReadingTimeout*1000 {
+		t.Fatalf("unexpected group expire at %d", v)
+	}
+}
+
+func TestDeleteExpiredMessages(t *testing.T) {
+	db, repos := newTestDB(t)
+	d := service.NewMessageExpiryDomain(db, nil, repos)
+	ctx := context.Background()
+
+	expired := insertUserMsg(t, repos, &entity.UserMessage{DialogId: 3, SendID: "a", ReceiveID: "b", IsBurnAfterReading: true})
+	later := insertUserMsg(t, repos, &entity.UserMessage{DialogId: 3, SendID: "a", ReceiveID: "b", IsBurnAfterReading: true})
+	unread := insertUserMsg(t, repos, &entity.UserMessage{DialogId: 3, SendID: "a", ReceiveID: "b", IsBurnAfterReading: true})
+	group := insertGroupMsg(t, repos, &entity.GroupMessage{DialogID: 4, GroupID: 9, UserID: "a", IsBurnAfterReading: true})
+	if err := d.StartBurnCountdown(ctx, entity.UserMessageKind, []uint{expired}, "b", 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.StartBurnCountdown(ctx, entity.UserMessageKind, []uint{later}, "b", 8000, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.StartBurnCountdown(ctx, entity.GroupMessageKind, []uint{group}, "b", 1000, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := d.DeleteExpiredMessages(ctx, 5000, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 expired messages, got %d", len(msgs))
+	}
+	if msgs[0].Kind != entity.UserMessageKind || msgs[0].ID != expired || msgs[0].ReceiverID != "b" {
+		t.Fatalf("unexpected user message %+v", msgs[0])
+	}
+	if msgs[1].Kind != entity.GroupMessageKind || msgs[1].ID != group || msgs[1].GroupID != 9 {
+		t.Fatalf("unexpected group message %+v", msgs[1])
+	}
+	if msgColumn(t, db, &po.UserMessage{}, expired, "deleted_at") != 5000 || msgColumn(t, db, &po.GroupMessage{}, group, "deleted_at") != 5000 {
+		t.Fatalf("expired messages should be deleted")
+	}
+	if msgColumn(t, db, &po.UserMessage{}, later, "deleted_at") != 0 || msgColumn(t, db, &po.UserMessage{}, unread, "deleted_at") != 0 {
+		t.Fatalf("unexpired messages should be kept")
+	}
+	assertChanges(t, repos, 3, entity.MessageChangeDelete)
+	assertChanges(t, repos, 4, entity.MessageChangeDelete)
+
+	// 已删除的消息不会重复返回
+	msgs, err = d.DeleteExpiredMessages(ctx, 5000, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("expected no messages, got %d", len(msgs))
+	}
+}
+
+func TestDeleteExpiredMessagesConcurrent(t *testing.T) {
+	db, repos := newTestDB(t)
+	ctx := context.Background()
+
+	ids := make([]uint, 0, 50)
+	for i := 0; i < 50; i++ {
+		ids = append(ids, insertGroupMsg(t, repos, &entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: "a", IsBurnAfterReading: true}))
+	}
+	if err := service.NewMessageExpiryDomain(db, nil, repos).StartBurnCountdown(ctx, entity.GroupMessageKind, ids, "b", 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// 多个实例同时扫描到相同的消息时每条消息只会被删除一次
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		total = make(map[uint]int)
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := service.NewMessageExpiryDomain(db, nil, persistence.NewRepositories(db))
+			msgs, err := d.DeleteExpiredMessages(ctx, 2000, 100)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, m := range msgs {
+				total[m.ID]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(total) != 50 {
+		t.Fatalf("expected 50 deleted messages, got %d", len(total))
+	}
+	for id, n := range total {
+		if n != 1 {
+			t.Fatalf("message %d deleted %d times", id, n)
+		}
+	}
+	// 每条消息只记录一次删除变更
+	changes, err := repos.Mcr.GetChanges(ctx, 1, 0, entity.MaxSyncLimit)
+	if err != nil {
+		t.Fatalf("GetChanges: %v", err)
+	}
+	if len(changes) != 50 {
+		t.Fatalf("expected 50 delete changes, got %d", len(changes))
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageReactionDomain interface {
+	// 添加表情回应
+	AddReaction(ctx context.Context, reaction *entity.MessageReaction) error
+	// 取消表情回应
+	RemoveReaction(ctx context.Context, kind entity.MessageKind, msgID uint, userID string, emoji string) error
+	// 获取消息聚合后的表情回应
+	GetReactions(ctx context.Context, kind entity.MessageKind, msgIDs []uint) (map[uint][]*entity.ReactionSummary, error)
+}
+
+type MessageReactionDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageReactionDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageReactionDomain {
+	return &MessageReactionDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageReactionDomainImpl) AddReaction(ctx context.Context, reaction *entity.MessageReaction) error {
+	if err := m.repo.Mrr.AddReaction(ctx, reaction); err != nil {
+		return status.Error(codes.Code(code.MsgErrAddReactionFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *MessageReactionDomainImpl) RemoveReaction(ctx context.Context, kind entity.MessageKind, msgID uint, userID string, emoji string) error {
+	if err := m.repo.Mrr.RemoveReaction(ctx, kind, msgID, userID, emoji); err != nil {
+		return status.Error(codes.Code(code.MsgErrRemoveReactionFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *MessageReactionDomainImpl) GetReactions(ctx context.Context, kind entity.MessageKind, msgIDs []uint) (map[uint][]*entity.ReactionSummary, error) {
+	reactions, err := m.repo.Mrr.GetReactionsByMsgIDs(ctx, kind, msgIDs)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetReactionsFailed.Code()), err.Error())
+	}
+	return entity.AggregateReactions(reactions), nil
+}
+
+// deleteDialogReactions 物理删除对话时删除所有消息的表态，ids 为对话中未删除的消息，
+// 逻辑删除对话时保留表态以便回滚
+func deleteDialogReactions(ctx context.Context, repo *persistence.Repositories, kind entity.MessageKind, dialogID uint, ids []uint) error {
+	deleted, err := repo.Mcr.GetDialogMsgIDs(ctx, kind, dialogID, true)
+	if err != nil {
+		return err
+	}
+	return repo.Mrr.DeleteReactionsByMsgIDs(ctx, kind, append(ids, deleted...))
+}
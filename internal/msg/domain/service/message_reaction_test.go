@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"testing"
+)
+
+func addReaction(t *testing.T, mrd service.MessageReactionDomain, kind entity.MessageKind, msgID, dialogID uint) {
+	t.Helper()
+	if err := mrd.AddReaction(context.Background(), &entity.MessageReaction{Kind: kind, MsgID: msgID, DialogID: dialogID, UserID: "u2", Emoji: "👍"}); err != nil {
+		t.Fatalf("AddReaction: %v", err)
+	}
+}
+
+func reactionCount(t *testing.T, repos *persistence.Repositories, kind entity.MessageKind, msgIDs ...uint) int {
+	t.Helper()
+	reactions, err := repos.Mrr.GetReactionsByMsgIDs(context.Background(), kind, msgIDs)
+	if err != nil {
+		t.Fatalf("GetReactionsByMsgIDs: %v", err)
+	}
+	return len(reactions)
+}
+
+func TestUserMessageDeleteRemovesReactions(t *testing.T) {
+	db, repos := newTestDB(t)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	mrd := service.NewMessageReactionDomain(db, nil, repos)
+	ctx := context.Background()
+
+	ids := make([]uint, 0, 3)
+	for i := 0; i < 3; i++ {
+		msg, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "hello"})
+		if err != nil {
+			t.Fatalf("SendUserMessage: %v", err)
+		}
+		addReaction(t, mrd, entity.UserMessageKind, msg.ID, 1)
+		ids = append(ids, msg.ID)
+	}
+
+	if err := umd.DeleteUserMessageById(ctx, ids[0], false); err != nil {
+		t.Fatalf("DeleteUserMessageById: %v", err)
+	}
+	if n := reactionCount(t, repos, entity.UserMessageKind, ids[0]); n != 0 {
+		t.Fatalf("expected reactions of deleted message to be removed, got %d", n)
+	}
+	// 撤回
+	if err := umd.SendUserMessageRevert(ctx, ids[1]); err != nil {
+		t.Fatalf("SendUserMessageRevert: %v", err)
+	}
+	if n := reactionCount(t, repos, entity.UserMessageKind, ids[1]); n != 0 {
+		t.Fatalf("expected reactions of recalled message to be removed, got %d", n)
+	}
+	if n := reactionCount(t, repos, entity.UserMessageKind, ids[2]); n != 1 {
+		t.Fatalf("expected other reactions to be kept, got %d", n)
+	}
+}
+
+func TestDialogDeleteRemovesReactions(t *testing.T) {
+	db, repos := newTestDB(t)
+	gmd := service.NewGroupMsgDomain(db, nil, repos)
+	mrd := service.NewMessageReactionDomain(db, nil, repos)
+	ctx := context.Background()
+
+	ids := make([]uint, 0, 2)
+	for i := 0; i < 2; i++ {
+		msg, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: "u1", Content: "hello"})
+		if err != nil {
+			t.Fatalf("SendGroupMessage: %v", err)
+		}
+		addReaction(t, mrd, entity.GroupMessageKind, msg.ID, 1)
+		ids = append(ids, msg.ID)
+	}
+
+	// 逻辑删除对话可以回滚，保留表态
+	if err := gmd.DeleteGroupMessageByDialogId(ctx, 1, false); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogId: %v", err)
+	}
+	if n := reactionCount(t, repos, entity.GroupMessageKind, ids...); n != 2 {
+		t.Fatalf("expected reactions to be kept after logical delete, got %d", n)
+	}
+
+	// 物理删除时已逻辑删除的消息的表态也一并删除
+	if err := gmd.DeleteGroupMessageByDialogId(ctx, 1, true); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogId physical: %v", err)
+	}
+	if n := reactionCount(t, repos, entity.GroupMessageKind, ids...); n != 0 {
+		t.Fatalf("expected reactions to be removed, got %d", n)
+	}
+}
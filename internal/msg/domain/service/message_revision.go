@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"github.com/cossim/coss-server/pkg/utils/time"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageRevisionDomain interface {
+	// 记录消息被编辑或撤回前的内容
+	AddRevision(ctx context.Context, revision *entity.MessageRevision) error
+	// 获取消息的所有修订记录，按修改时间升序
+	GetRevisions(ctx context.Context, kind entity.MessageKind, msgID uint) ([]*entity.MessageRevision, error)
+	// 设置群聊的修订记录可见策略
+	SetGroupPolicy(ctx context.Context, policy *entity.GroupRevisionPolicy) error
+	// 获取群聊的修订记录可见策略，未设置时返回默认策略
+	GetGroupPolicy(ctx context.Context, groupID uint) (*entity.GroupRevisionPolicy, error)
+}
+
+type MessageRevisionDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageRevisionDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageRevisionDomain {
+	return &MessageRevisionDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageRevisionDomainImpl) AddRevision(ctx context.Context, revision *entity.MessageRevision) error {
+	if revision.EditedAt == 0 {
+		revision.EditedAt = time.Now()
+	}
+	if err := m.repo.Mrvr.AddRevision(ctx, revision); err != nil {
+		return status.Error(codes.Code(code.MsgErrSaveRevisionFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *MessageRevisionDomainImpl) GetRevisions(ctx context.Context, kind entity.MessageKind, msgID uint) ([]*entity.MessageRevision, error) {
+	revisions, err := m.repo.Mrvr.GetRevisionsByMsgID(ctx, kind, msgID)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetRevisionsFailed.Code()), err.Error())
+	}
+	return revisions, nil
+}
+
+func (m *MessageRevisionDomainImpl) SetGroupPolicy(ctx context.Context, policy *entity.GroupRevisionPolicy) error {
+	switch policy.Visibility {
+	case entity.RevisionVisibleToAdmins, entity.RevisionVisibleToOwner, entity.RevisionHidden:
+	default:
+		return code.InvalidParameter
+	}
+	if err := m.repo.Grpr.SavePolicy(ctx, policy); err != nil {
+		return status.Error(codes.Code(code.MsgErrSetRevisionPolicyFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *MessageRevisionDomainImpl) GetGroupPolicy(ctx context.Context, groupID uint) (*entity.GroupRevisionPolicy, error) {
+	policy, err := m.repo.Grpr.GetPolicy(ctx, groupID)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetRevisionsFailed.Code()), err.Error())
+	}
+	if policy == nil {
+		policy = &entity.GroupRevisionPolicy{GroupID: groupID, Visibility: entity.RevisionVisibleToAdmins}
+	}
+	return policy, nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MessageSearchDomain interface {
+	// 全文搜索消息
+	SearchMessages(ctx context.Context, query *entity.MessageSearchQuery) (*entity.MessageSearchResult, error)
+	// 为索引上线前的历史消息补建索引，每次最多处理batchSize条，返回本次处理的数量
+	BackfillIndex(ctx context.Context, batchSize int) (int, error)
+	// 按消息变更记录更新本节点的索引，每次最多处理batchSize条变更，返回本次处理的数量
+	SyncIndex(ctx context.Context, batchSize int) (int, error)
+}
+
+// 索引保存在每个节点本地，其他节点上的发送、编辑、撤回和删除通过消息变更记录同步到本节点
+// 变更id在事务提交前分配，只处理创建一段时间后的变更，避免跳过提交较晚的变更
+const searchSyncDelay = 10 * time.Second
+
+type MessageSearchDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageSearchDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageSearchDomain {
+	return &MessageSearchDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageSearchDomainImpl) SearchMessages(ctx context.Context, query *entity.MessageSearchQuery) (*entity.MessageSearchResult, error) {
+	if m.repo.Msr == nil {
+		return &entity.MessageSearchResult{Hits: make([]*entity.MessageSearchHit, 0)}, nil
+	}
+	res, err := m.repo.Msr.Search(ctx, query)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrSearchMessageFailed.Code()), err.Error())
+	}
+	return res, nil
+}
+
+func (m *MessageSearchDomainImpl) BackfillIndex(ctx context.Context, batchSize int) (int, error) {
+	if m.repo.Msr == nil {
+		return 0, nil
+	}
+
+	userCursor, err := m.repo.Msr.GetIndexCursor(ctx, entity.SearchUserMessage)
+	if err != nil {
+		return 0, err
+	}
+	userMsgs, err := m.repo.Umr.GetUserMsgsAfterID(userCursor, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(userMsgs) > 0 {
+		indexUserMessages(ctx, m.repo, userMsgs...)
+		if err := m.repo.Msr.SetIndexCursor(ctx, entity.SearchUserMessage, userMsgs[len(userMsgs)-1].ID); err != nil {
+			return 0, err
+		}
+	}
+
+	groupCursor, err := m.repo.Msr.GetIndexCursor(ctx, entity.SearchGroupMessage)
+	if err != nil {
+		return len(userMsgs), err
+	}
+	groupMsgs, err := m.repo.Gmr.GetGroupMsgsAfterID(groupCursor, batchSize)
+	if err != nil {
+		return len(userMsgs), err
+	}
+	if len(groupMsgs) > 0 {
+		indexGroupMessages(ctx, m.repo, groupMsgs...)
+		if err := m.repo.Msr.SetIndexCursor(ctx, entity.SearchGroupMessage, groupMsgs[len(groupMsgs)-1].ID); err != nil {
+			return len(userMsgs), err
+		}
+	}
+	return len(userMsgs) + len(groupMsgs), nil
+}
+
+func (m *MessageSearchDomainImpl) SyncIndex(ctx context.Context, batchSize int) (int, error) {
+	if m.repo.Msr == nil {
+		return 0, nil
+	}
+
+	cursor, err := m.repo.Msr.GetChangeCursor(ctx)
+	if err != nil {
+		return 0, err
+	}
+	changes, err := m.repo.Mcr.GetChangesAfterID(ctx, cursor, pkgtime.Now()-searchSyncDelay.Milliseconds(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	// 重新读取消息的当前内容，已经删除的消息从索引中删除
+	userIds := make(map[uint]struct{})
+	groupIds := make(map[uint]struct{})
+	for _, c := range changes {
+		if c.Kind == entity.GroupMessageKind {
+			groupIds[c.MsgID] = struct{}{}
+		} else {
+			userIds[c.MsgID] = struct{}{}
+		}
+	}
+	if len(userIds) > 0 {
+		msgs, err := m.repo.Umr.GetUserMsgByIDs(msgIDList(userIds))
+		if err != nil {
+			return 0, err
+		}
+		for _, msg := range msgs {
+			delete(userIds, msg.ID)
+		}
+		indexUserMessages(ctx, m.repo, msgs...)
+		unindexMessages(ctx, m.repo, entity.SearchUserMessage, msgIDList(userIds)...)
+	}
+	if len(groupIds) > 0 {
+		msgs, err := m.repo.Gmr.GetGroupMsgsByIDs(msgIDList(groupIds))
+		if err != nil {
+			return 0, err
+		}
+		for _, msg := range msgs {
+			delete(groupIds, msg.ID)
+		}
+		indexGroupMessages(ctx, m.repo, msgs...)
+		unindexMessages(ctx, m.repo, entity.SearchGroupMessage, msgIDList(groupIds)...)
+	}
+
+	if err := m.repo.Msr.SetChangeCursor(ctx, changes[len(changes)-1].ID); err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+func msgIDList(ids map[uint]struct{}) []uint {
+	list := make([]uint, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+	return list
+}
+
+// 索引失败不影响消息本身的读写，只记录日志
+
+func indexUserMessages(ctx context.Context, repo *persistence.Repositories, msgs ...*entity.UserMessage) {
+	if repo.Msr == nil {
+		return
+	}
+	docs := make([]*entity.MessageDocument, 0, len(msgs))
+	ids := make([]uint, 0)
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		if !entity.IsSearchable(msg.Type, msg.IsBurnAfterReading) {
+			ids = append(ids, msg.ID)
+			continue
+		}
+		docs = append(docs, msg.ToSearchDocument())
+	}
+	if err := repo.Msr.IndexMessages(ctx, docs...); err != nil {
+		zap.L().Error("index user messages failed", zap.Error(err))
+	}
+	unindexMessages(ctx, repo, entity.SearchUserMessage, ids...)
+}
+
+func indexGroupMessages(ctx context.Context, repo *persistence.Repositories, msgs ...*entity.GroupMessage) {
+	if repo.Msr == nil {
+		return
+	}
+	docs := make([]*entity.MessageDocument, 0, len(msgs))
+	ids := make([]uint, 0)
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		if !entity.IsSearchable(msg.Type, msg.IsBurnAfterReading) {
+			ids = append(ids, msg.ID)
+			continue
+		}
+		docs = append(docs, msg.ToSearchDocument())
+	}
+	if err := repo.Msr.IndexMessages(ctx, docs...); err != nil {
+		zap.L().Error("index group messages failed", zap.Error(err))
+	}
+	unindexMessages(ctx, repo, entity.SearchGroupMessage, ids...)
+}
+
+func unindexMessages(ctx context.Context, repo *persistence.Repositories, kind entity.SearchMessageKind, ids ...uint) {
+	if repo.Msr == nil || len(ids) == 0 {
+		return
+	}
+	if err := repo.Msr.DeleteMessages(ctx, kind, ids...); err != nil {
+		zap.L().Error("delete message index failed", zap.String("kind", string(kind)), zap.Error(err))
+	}
+}
+
+func unindexDialogMessages(ctx context.Context, repo *persistence.Repositories, kind entity.SearchMessageKind, dialogID uint) {
+	if repo.Msr == nil {
+		return
+	}
+	if err := repo.Msr.DeleteMessagesByDialogID(ctx, kind, dialogID); err != nil {
+		zap.L().Error("delete dialog message index failed", zap.String("kind", string(kind)), zap.Uint("dialog_id", dialogID), zap.Error(err))
+	}
+}
@@ -0,0 +1,193 @@
+package service_test
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// openTestIndex 在临时目录中打开消息全文索引
+func openTestIndex(t *testing.T, repos *persistence.Repositories) {
+	t.Helper()
+	if err := repos.OpenSearchIndex(filepath.Join(t.TempDir(), "index")); err != nil {
+		t.Fatalf("open search index: %v", err)
+	}
+	t.Cleanup(func() { _ = repos.Msr.Close() })
+}
+
+func searchTotal(t *testing.T, msd service.MessageSearchDomain, keyword string, dialogIDs ...uint) int64 {
+	t.Helper()
+	res, err := msd.SearchMessages(context.Background(), &entity.MessageSearchQuery{Keyword: keyword, DialogIds: dialogIDs})
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	return res.Total
+}
+
+func TestDialogDeleteUpdatesSearchIndex(t *testing.T) {
+	db, repos := newTestDB(t)
+	openTestIndex(t, repos)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	gmd := service.NewGroupMsgDomain(db, nil, repos)
+	msd := service.NewMessageSearchDomain(db, nil, repos)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, Type: entity.MessageTypeText, SendID: "u1", ReceiveID: "u2", Content: "hello world"}); err != nil {
+			t.Fatalf("SendUserMessage: %v", err)
+		}
+		if _, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 2, GroupID: 1, Type: entity.MessageTypeText, UserID: "u1", Content: "hello world"}); err != nil {
+			t.Fatalf("SendGroupMessage: %v", err)
+		}
+	}
+	if n := searchTotal(t, msd, "hello", 1, 2); n != 4 {
+		t.Fatalf("expected 4 hits, got %d", n)
+	}
+
+	// 逻辑删除的对话消息不能再被搜到
+	if err := umd.DeleteUserMessageByDialogId(ctx, 1, false); err != nil {
+		t.Fatalf("DeleteUserMessageByDialogId: %v", err)
+	}
+	if err := gmd.DeleteGroupMessageByDialogId(ctx, 2, false); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogId: %v", err)
+	}
+	if n := searchTotal(t, msd, "hello", 1, 2); n != 0 {
+		t.Fatalf("expected deleted messages to be unindexed, got %d hits", n)
+	}
+
+	// 回滚后恢复的消息重新写入索引
+	if err := umd.DeleteUserMessageByDialogIdRollback(ctx, 1); err != nil {
+		t.Fatalf("DeleteUserMessageByDialogIdRollback: %v", err)
+	}
+	if err := gmd.DeleteGroupMessageByDialogIdRollback(ctx, 2); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogIdRollback: %v", err)
+	}
+	if n := searchTotal(t, msd, "hello", 1, 2); n != 4 {
+		t.Fatalf("expected restored messages to be indexed, got %d hits", n)
+	}
+}
+
+func TestBackfillIndex(t *testing.T) {
+	db, repos := newTestDB(t)
+	msd := service.NewMessageSearchDomain(db, nil, repos)
+	ctx := context.Background()
+
+	// 索引上线前写入的历史消息
+	var last *entity.UserMessage
+	for i := 0; i < 3; i++ {
+		msg, err := repos.Umr.InsertUserMessage(&entity.UserMessage{DialogId: 1, Type: entity.MessageTypeText, SendID: "u1", ReceiveID: "u2", Content: "history"})
+		if err != nil {
+			t.Fatalf("InsertUserMessage: %v", err)
+		}
+		last = msg
+	}
+	if _, err := repos.Gmr.InsertGroupMessage(&entity.GroupMessage{DialogID: 2, GroupID: 1, Type: entity.MessageTypeText, UserID: "u1", Content: "history"}); err != nil {
+		t.Fatalf("InsertGroupMessage: %v", err)
+	}
+	if _, err := repos.Umr.InsertUserMessage(&entity.UserMessage{DialogId: 1, Type: entity.MessageTypeText, SendID: "u1", ReceiveID: "u2", Content: "history", IsBurnAfterReading: true}); err != nil {
+		t.Fatalf("InsertUserMessage: %v", err)
+	}
+	if err := repos.Umr.LogicalDeleteUserMessage(last.ID); err != nil {
+		t.Fatalf("LogicalDeleteUserMessage: %v", err)
+	}
+
+	openTestIndex(t, repos)
+	total := 0
+	for {
+		n, err := msd.BackfillIndex(ctx, 1)
+		if err != nil {
+			t.Fatalf("BackfillIndex: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+		total += n
+	}
+	// 已删除的消息不补建，阅后即焚消息不进入索引
+	if total != 4 {
+		t.Fatalf("expected 4 messages to be processed, got %d", total)
+	}
+	if n := searchTotal(t, msd, "history", 1, 2); n != 3 {
+		t.Fatalf("expected 3 hits, got %d", n)
+	}
+
+	// 进度已保存，再次补建不会重复处理
+	if n, err := msd.BackfillIndex(ctx, 10); err != nil || n != 0 {
+		t.Fatalf("expected backfill to be finished, got %d, %v", n, err)
+	}
+}
+
+// syncIndex 把变更记录改为已经创建了一段时间，然后同步到节点的索引
+func syncIndex(t *testing.T, db *gorm.DB, msd service.MessageSearchDomain) {
+	t.Helper()
+	if err := db.Model(&po.MessageChange{}).Where("1 = 1").Update("created_at", gorm.Expr("created_at - ?", time.Minute.Milliseconds())).Error; err != nil {
+		t.Fatalf("age message changes: %v", err)
+	}
+	for {
+		n, err := msd.SyncIndex(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("SyncIndex: %v", err)
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+func TestSyncIndexFromOtherNode(t *testing.T) {
+	db, a := newTestDB(t)
+	openTestIndex(t, a)
+	// 另一个节点使用同一个数据库和自己的索引
+	b := persistence.NewRepositories(db)
+	openTestIndex(t, b)
+	umd := service.NewUserMsgDomain(db, nil, a)
+	gmd := service.NewGroupMsgDomain(db, nil, a)
+	msdA := service.NewMessageSearchDomain(db, nil, a)
+	msdB := service.NewMessageSearchDomain(db, nil, b)
+	ctx := context.Background()
+
+	userMsg, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, Type: entity.MessageTypeText, SendID: "u1", ReceiveID: "u2", Content: "hello world"})
+	if err != nil {
+		t.Fatalf("SendUserMessage: %v", err)
+	}
+	groupMsg, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 2, GroupID: 1, Type: entity.MessageTypeText, UserID: "u1", Content: "hello world"})
+	if err != nil {
+		t.Fatalf("SendGroupMessage: %v", err)
+	}
+	if n := searchTotal(t, msdB, "hello", 1, 2); n != 0 {
+		t.Fatalf("expected node b not to be synced yet, got %d hits", n)
+	}
+	syncIndex(t, db, msdB)
+	if n := searchTotal(t, msdB, "hello", 1, 2); n != 2 {
+		t.Fatalf("expected 2 hits on node b, got %d", n)
+	}
+
+	// 在节点a上编辑和撤回，节点b同步前仍然是旧的结果
+	userMsg.Content = "goodbye"
+	if err := umd.EditUserMessage(ctx, userMsg); err != nil {
+		t.Fatalf("EditUserMessage: %v", err)
+	}
+	if err := gmd.DeleteGroupMessage(ctx, groupMsg.ID, false); err != nil {
+		t.Fatalf("DeleteGroupMessage: %v", err)
+	}
+	if n := searchTotal(t, msdA, "hello", 1, 2); n != 0 {
+		t.Fatalf("expected node a to be updated, got %d hits", n)
+	}
+	if n := searchTotal(t, msdB, "hello", 1, 2); n != 2 {
+		t.Fatalf("expected stale hits on node b before sync, got %d", n)
+	}
+
+	syncIndex(t, db, msdB)
+	if n := searchTotal(t, msdB, "hello", 1, 2); n != 0 {
+		t.Fatalf("expected edited and recalled messages not to be found on node b, got %d hits", n)
+	}
+	if n := searchTotal(t, msdB, "goodbye", 1, 2); n != 1 {
+		t.Fatalf("expected edited message to be found on node b, got %d hits", n)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageSyncDomain interface {
+	// 获取对话内afterSeq之后的变更，同一条消息只返回最后一次变更
+	GetChanges(ctx context.Context, dialogID uint, afterSeq uint64, limit int) (changes []*entity.MessageChange, nextSeq uint64, hasMore bool, err error)
+	// 获取对话当前的同步序号
+	GetMaxSyncSeq(ctx context.Context, dialogID uint) (uint64, error)
+	// 按游标分页获取私聊消息，按id降序
+	GetUserMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) (msgs []*entity.UserMessage, hasMore bool, err error)
+	// 按游标分页获取群聊消息，按id降序
+	GetGroupMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) (msgs []*entity.GroupMessage, hasMore bool, err error)
+	// 获取对话当前最大的消息序号
+	GetMaxMsgSeq(ctx context.Context, dialogID uint) (uint64, error)
+	// 按序号区间获取私聊消息，已删除的消息不返回
+	GetUserMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.UserMessage, error)
+	// 按序号区间获取群聊消息，已删除的消息不返回
+	GetGroupMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.GroupMessage, error)
+}
+
+type MessageSyncDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageSyncDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageSyncDomain {
+	return &MessageSyncDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageSyncDomainImpl) GetChanges(ctx context.Context, dialogID uint, afterSeq uint64, limit int) ([]*entity.MessageChange, uint64, bool, error) {
+	changes, err := m.repo.Mcr.GetChanges(ctx, dialogID, afterSeq, limit+1)
+	if err != nil {
+		return nil, afterSeq, false, status.Error(codes.Code(code.MsgErrSyncMsgFailed.Code()), err.Error())
+	}
+
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+	nextSeq := afterSeq
+	if len(changes) > 0 {
+		nextSeq = changes[len(changes)-1].SyncSeq
+	}
+	return entity.LatestMessageChanges(changes), nextSeq, hasMore, nil
+}
+
+func (m *MessageSyncDomainImpl) GetMaxSyncSeq(ctx context.Context, dialogID uint) (uint64, error) {
+	seq, err := m.repo.Mcr.GetMaxSyncSeq(ctx, dialogID)
+	if err != nil {
+		return 0, status.Error(codes.Code(code.MsgErrSyncMsgFailed.Code()), err.Error())
+	}
+	return seq, nil
+}
+
+func (m *MessageSyncDomainImpl) GetUserMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) ([]*entity.UserMessage, bool, error) {
+	limit := query.Limit
+	q := *query
+	q.Limit = limit + 1
+	msgs, err := m.repo.Mcr.GetUserMsgsByCursor(ctx, &q)
+	if err != nil {
+		return nil, false, status.Error(codes.Code(code.MsgErrGetUserMessageListFailed.Code()), err.Error())
+	}
+	if len(msgs) <= limit {
+		return msgs, false, nil
+	}
+	// 结果按id降序，多取的一条在远离游标的一端
+	if query.Before {
+		return msgs[:limit], true, nil
+	}
+	return msgs[1:], true, nil
+}
+
+func (m *MessageSyncDomainImpl) GetGroupMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) ([]*entity.GroupMessage, bool, error) {
+	limit := query.Limit
+	q := *query
+	q.Limit = limit + 1
+	msgs, err := m.repo.Mcr.GetGroupMsgsByCursor(ctx, &q)
+	if err != nil {
+		return nil, false, status.Error(codes.Code(code.MsgErrGetGroupMsgListFailed.Code()), err.Error())
+	}
+	if len(msgs) <= limit {
+		return msgs, false, nil
+	}
+	if query.Before {
+		return msgs[:limit], true, nil
+	}
+	return msgs[1:], true, nil
+}
+
+func (m *MessageSyncDomainImpl) GetMaxMsgSeq(ctx context.Context, dialogID uint) (uint64, error) {
+	seq, err := m.repo.Mcr.GetMaxMsgSeq(ctx, dialogID)
+	if err != nil {
+		return 0, status.Error(codes.Code(code.MsgErrSyncMsgFailed.Code()), err.Error())
+	}
+	return seq, nil
+}
+
+func (m *MessageSyncDomainImpl) GetUserMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.UserMessage, error) {
+	msgs, err := m.repo.Mcr.GetUserMsgsBySeq(ctx, dialogID, startSeq, endSeq)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetUserMessageListFailed.Code()), err.Error())
+	}
+	return msgs, nil
+}
+
+func (m *MessageSyncDomainImpl) GetGroupMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.GroupMessage, error) {
+	msgs, err := m.repo.Mcr.GetGroupMsgsBySeq(ctx, dialogID, startSeq, endSeq)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetGroupMsgListFailed.Code()), err.Error())
+	}
+	return msgs, nil
+}
+
+// inTransaction 在事务中修改消息并记录变更，变更记录失败时消息的修改一起回滚
+func inTransaction(ctx context.Context, db *gorm.DB, fn func(repo *persistence.Repositories) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(persistence.NewRepositories(tx))
+	})
+}
+
+// recordUserMsgChanges 记录私聊消息变更，需要和消息的修改在同一个事务中调用
+func recordUserMsgChanges(ctx context.Context, repo *persistence.Repositories, action entity.MessageChangeAction, msgs ...*entity.UserMessage) error {
+	changes := make([]*entity.MessageChange, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		changes = append(changes, &entity.MessageChange{
+			DialogID: msg.DialogId,
+			Kind:     entity.UserMessageKind,
+			MsgID:    msg.ID,
+			Action:   action,
+		})
+	}
+	return recordMsgChanges(ctx, repo, changes)
+}
+
+func recordGroupMsgChanges(ctx context.Context, repo *persistence.Repositories, action entity.MessageChangeAction, msgs ...*entity.GroupMessage) error {
+	changes := make([]*entity.MessageChange, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		changes = append(changes, &entity.MessageChange{
+			DialogID: msg.DialogID,
+			Kind:     entity.GroupMessageKind,
+			MsgID:    msg.ID,
+			Action:   action,
+		})
+	}
+	return recordMsgChanges(ctx, repo, changes)
+}
+
+// recordDialogMsgChanges 记录对话内所有消息的变更，deleted为true时只记录已删除的消息，否则只记录未删除的消息，返回记录的消息id
+func recordDialogMsgChanges(ctx context.Context, repo *persistence.Repositories, kind entity.MessageKind, dialogID uint, deleted bool, action entity.MessageChangeAction) ([]uint, error) {
+	ids, err := repo.Mcr.GetDialogMsgIDs(ctx, kind, dialogID, deleted)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*entity.MessageChange, 0, len(ids))
+	for _, id := range ids {
+		changes = append(changes, &entity.MessageChange{
+			DialogID: dialogID,
+			Kind:     kind,
+			MsgID:    id,
+			Action:   action,
+		})
+	}
+	return ids, recordMsgChanges(ctx, repo, changes)
+}
+
+func recordMsgChanges(ctx context.Context, repo *persistence.Repositories, changes []*entity.MessageChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return repo.Mcr.AppendChanges(ctx, changes)
+}
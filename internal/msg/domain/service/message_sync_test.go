@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"testing"
+)
+
+// assertChanges 按序号检查对话内的变更记录
+func assertChanges(t *testing.T, repos *persistence.Repositories, dialogID uint, want ...entity.MessageChangeAction) []*entity.MessageChange {
+	t.Helper()
+	changes, err := repos.Mcr.GetChanges(context.Background(), dialogID, 0, entity.MaxSyncLimit)
+	if err != nil {
+		t.Fatalf("GetChanges: %v", err)
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d", len(want), len(changes))
+	}
+	for i, c := range changes {
+		if c.Action != want[i] || c.SyncSeq != uint64(i+1) {
+			t.Fatalf("change %d: expected action %d seq %d, got %+v", i, want[i], i+1, c)
+		}
+	}
+	return changes
+}
+
+func TestUserMessageChanges(t *testing.T) {
+	db, repos := newTestDB(t)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	ctx := context.Background()
+
+	msg, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "hello"})
+	if err != nil {
+		t.Fatalf("SendUserMessage: %v", err)
+	}
+	if err := umd.EditUserMessage(ctx, &entity.UserMessage{BaseModel: entity.BaseModel{ID: msg.ID}, Content: "edited"}); err != nil {
+		t.Fatalf("EditUserMessage: %v", err)
+	}
+	if err := umd.SetUserMsgLabel(ctx, msg.ID, true); err != nil {
+		t.Fatalf("SetUserMsgLabel: %v", err)
+	}
+	if err := umd.DeleteUserMessageById(ctx, msg.ID, false); err != nil {
+		t.Fatalf("DeleteUserMessageById: %v", err)
+	}
+	changes := assertChanges(t, repos, 1, entity.MessageChangeNew, entity.MessageChangeEdit, entity.MessageChangeEdit, entity.MessageChangeDelete)
+	for _, c := range changes {
+		if c.MsgID != msg.ID || c.Kind != entity.UserMessageKind {
+			t.Fatalf("unexpected change %+v", c)
+		}
+	}
+}
+
+func TestDialogMessageChanges(t *testing.T) {
+	db, repos := newTestDB(t)
+	gmd := service.NewGroupMsgDomain(db, nil, repos)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: "u1", Content: "hello"}); err != nil {
+			t.Fatalf("SendGroupMessage: %v", err)
+		}
+	}
+	// 删除对话时每条消息都要生成墓碑
+	if err := gmd.DeleteGroupMessageByDialogId(ctx, 1, false); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogId: %v", err)
+	}
+	assertChanges(t, repos, 1,
+		entity.MessageChangeNew, entity.MessageChangeNew,
+		entity.MessageChangeDelete, entity.MessageChangeDelete)
+
+	// 回滚后恢复的消息重新同步
+	if err := gmd.DeleteGroupMessageByDialogIdRollback(ctx, 1); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogIdRollback: %v", err)
+	}
+	assertChanges(t, repos, 1,
+		entity.MessageChangeNew, entity.MessageChangeNew,
+		entity.MessageChangeDelete, entity.MessageChangeDelete,
+		entity.MessageChangeNew, entity.MessageChangeNew)
+
+	if err := gmd.DeleteGroupMessageByDialogId(ctx, 1, true); err != nil {
+		t.Fatalf("DeleteGroupMessageByDialogId physical: %v", err)
+	}
+	changes := assertChanges(t, repos, 1,
+		entity.MessageChangeNew, entity.MessageChangeNew,
+		entity.MessageChangeDelete, entity.MessageChangeDelete,
+		entity.MessageChangeNew, entity.MessageChangeNew,
+		entity.MessageChangeDelete, entity.MessageChangeDelete)
+	if changes[6].Kind != entity.GroupMessageKind {
+		t.Fatalf("unexpected change kind %+v", changes[6])
+	}
+}
+
+func TestMessageChangeFailureRollsBackMessage(t *testing.T) {
+	db, repos := newTestDB(t)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	ctx := context.Background()
+
+	// 变更记录无法写入时消息也不能写入，否则客户端永远同步不到这条消息
+	if err := db.Migrator().DropTable(&po.MessageChange{}); err != nil {
+		t.Fatalf("drop table: %v", err)
+	}
+	if _, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "hello"}); err == nil {
+		t.Fatal("expected SendUserMessage to fail")
+	}
+	var count int64
+	if err := db.Model(&po.UserMessage{}).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected message to be rolled back, got %d", count)
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type MessageThreadDomain interface {
+	// 根据被回复的私聊消息获取话题根消息id
+	GetUserThreadRootID(ctx context.Context, dialogID, replyID uint) (uint, error)
+	// 根据被回复的群聊消息获取话题根消息id
+	GetGroupThreadRootID(ctx context.Context, dialogID, replyID uint) (uint, error)
+	// 分页获取私聊话题回复
+	GetUserThreadMessages(ctx context.Context, threadID uint, pageNum, pageSize int) ([]*entity.UserMessage, int64, error)
+	// 分页获取群聊话题回复
+	GetGroupThreadMessages(ctx context.Context, threadID uint, pageNum, pageSize int) ([]*entity.GroupMessage, int64, error)
+	// 获取话题摘要，没有回复的话题不会返回
+	GetThreadSummaries(ctx context.Context, kind entity.MessageKind, userID string, threadIDs []uint) (map[uint]*entity.ThreadSummary, error)
+	// 将话题内当前所有回复设置为已读
+	ReadThread(ctx context.Context, kind entity.MessageKind, dialogID, threadID uint, userID string) (*entity.ThreadSummary, error)
+}
+
+type MessageThreadDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewMessageThreadDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) MessageThreadDomain {
+	return &MessageThreadDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *MessageThreadDomainImpl) GetUserThreadRootID(ctx context.Context, dialogID, replyID uint) (uint, error) {
+	msg, err := m.repo.Umr.GetUserMsgByID(replyID)
+	if err != nil || msg.DialogId != dialogID || msg.DeletedAt != 0 {
+		return 0, code.MsgErrReplyMsgNotFound
+	}
+	return msg.ThreadRootID(), nil
+}
+
+func (m *MessageThreadDomainImpl) GetGroupThreadRootID(ctx context.Context, dialogID, replyID uint) (uint, error) {
+	msg, err := m.repo.Gmr.GetGroupMsgByID(replyID)
+	if err != nil || msg.DialogID != dialogID || msg.DeletedAt != 0 {
+		return 0, code.MsgErrReplyMsgNotFound
+	}
+	return msg.ThreadRootID(), nil
+}
+
+func (m *MessageThreadDomainImpl) GetUserThreadMessages(ctx context.Context, threadID uint, pageNum, pageSize int) ([]*entity.UserMessage, int64, error) {
+	msgs, total, err := m.repo.Umr.GetUserThreadMsgs(threadID, pageNum, pageSize)
+	if err != nil {
+		return nil, 0, status.Error(codes.Code(code.MsgErrGetThreadFailed.Code()), err.Error())
+	}
+	return msgs, total, nil
+}
+
+func (m *MessageThreadDomainImpl) GetGroupThreadMessages(ctx context.Context, threadID uint, pageNum, pageSize int) ([]*entity.GroupMessage, int64, error) {
+	msgs, total, err := m.repo.Gmr.GetGroupThreadMsgs(threadID, pageNum, pageSize)
+	if err != nil {
+		return nil, 0, status.Error(codes.Code(code.MsgErrGetThreadFailed.Code()), err.Error())
+	}
+	return msgs, total, nil
+}
+
+func (m *MessageThreadDomainImpl) GetThreadSummaries(ctx context.Context, kind entity.MessageKind, userID string, threadIDs []uint) (map[uint]*entity.ThreadSummary, error) {
+	result := make(map[uint]*entity.ThreadSummary)
+	if len(threadIDs) == 0 {
+		return result, nil
+	}
+
+	stats, err := m.getThreadStats(kind, threadIDs)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetThreadFailed.Code()), err.Error())
+	}
+	if len(stats) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, 0, len(stats))
+	for _, v := range stats {
+		ids = append(ids, v.ThreadID)
+	}
+	reads, err := m.repo.Mtrr.GetThreadReads(ctx, kind, userID, ids)
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetThreadFailed.Code()), err.Error())
+	}
+
+	// 没有阅读记录的话题所有回复都算未读
+	lastRead := make(map[uint]uint, len(ids))
+	for _, id := range ids {
+		lastRead[id] = 0
+	}
+	for _, v := range reads {
+		lastRead[v.ThreadID] = v.LastReadMsgID
+	}
+
+	var unread map[uint]int64
+	if kind == entity.GroupMessageKind {
+		unread, err = m.repo.Gmr.CountGroupThreadUnread(userID, lastRead)
+	} else {
+		unread, err = m.repo.Umr.CountUserThreadUnread(userID, lastRead)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrGetThreadFailed.Code()), err.Error())
+	}
+
+	for _, v := range stats {
+		result[v.ThreadID] = &entity.ThreadSummary{
+			ThreadID:    v.ThreadID,
+			ReplyCount:  v.ReplyCount,
+			UnreadCount: unread[v.ThreadID],
+			LastReplyID: v.LastReplyID,
+		}
+	}
+	return result, nil
+}
+
+func (m *MessageThreadDomainImpl) ReadThread(ctx context.Context, kind entity.MessageKind, dialogID, threadID uint, userID string) (*entity.ThreadSummary, error) {
+	summary := &entity.ThreadSummary{ThreadID: threadID}
+
+	stats, err := m.getThreadStats(kind, []uint{threadID})
+	if err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrReadThreadFailed.Code()), err.Error())
+	}
+	if len(stats) == 0 {
+		return summary, nil
+	}
+	summary.ReplyCount = stats[0].ReplyCount
+	summary.LastReplyID = stats[0].LastReplyID
+
+	if err := m.repo.Mtrr.SetThreadRead(ctx, &entity.MessageThreadRead{
+		Kind:          kind,
+		ThreadID:      threadID,
+		DialogID:      dialogID,
+		UserID:        userID,
+		LastReadMsgID: stats[0].LastReplyID,
+	}); err != nil {
+		return nil, status.Error(codes.Code(code.MsgErrReadThreadFailed.Code()), err.Error())
+	}
+	return summary, nil
+}
+
+func (m *MessageThreadDomainImpl) getThreadStats(kind entity.MessageKind, threadIDs []uint) ([]*entity.ThreadStat, error) {
+	if kind == entity.GroupMessageKind {
+		return m.repo.Gmr.GetGroupThreadStats(threadIDs)
+	}
+	return m.repo.Umr.GetUserThreadStats(threadIDs)
+}
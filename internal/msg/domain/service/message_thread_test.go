@@ -0,0 +1,184 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/service"
+	"github.com/cossim/coss-server/pkg/code"
+	"testing"
+)
+
+// replyUserMsg 回复私聊消息，回复的回复也归入根消息的话题
+func replyUserMsg(t *testing.T, umd service.UserMsgDomain, mtd service.MessageThreadDomain, replyID uint, sendID string) *entity.UserMessage {
+	t.Helper()
+	ctx := context.Background()
+	threadID, err := mtd.GetUserThreadRootID(ctx, 1, replyID)
+	if err != nil {
+		t.Fatalf("GetUserThreadRootID: %v", err)
+	}
+	msg, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: sendID, ReplyId: replyID, ThreadId: threadID, Content: "reply"})
+	if err != nil {
+		t.Fatalf("SendUserMessage: %v", err)
+	}
+	return msg
+}
+
+func threadSummary(t *testing.T, mtd service.MessageThreadDomain, kind entity.MessageKind, userID string, threadID uint) *entity.ThreadSummary {
+	t.Helper()
+	summaries, err := mtd.GetThreadSummaries(context.Background(), kind, userID, []uint{threadID})
+	if err != nil {
+		t.Fatalf("GetThreadSummaries: %v", err)
+	}
+	if summaries[threadID] == nil {
+		return &entity.ThreadSummary{ThreadID: threadID}
+	}
+	return summaries[threadID]
+}
+
+func TestUserThread(t *testing.T) {
+	db, repos := newTestDB(t)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	mtd := service.NewMessageThreadDomain(db, nil, repos)
+	ctx := context.Background()
+
+	root, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: "u1", Content: "root"})
+	if err != nil {
+		t.Fatalf("SendUserMessage: %v", err)
+	}
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID); s.ReplyCount != 0 {
+		t.Fatalf("expected no replies, got %+v", s)
+	}
+
+	r1 := replyUserMsg(t, umd, mtd, root.ID, "u2")
+	r2 := replyUserMsg(t, umd, mtd, r1.ID, "u2")
+	r3 := replyUserMsg(t, umd, mtd, r2.ID, "u1")
+	if r2.ThreadId != root.ID || r3.ThreadId != root.ID {
+		t.Fatalf("expected replies of replies to join the root thread, got %d and %d", r2.ThreadId, r3.ThreadId)
+	}
+
+	// 自己的回复不算未读
+	s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID)
+	if s.ReplyCount != 3 || s.UnreadCount != 2 || s.LastReplyID != r3.ID {
+		t.Fatalf("unexpected summary for u1: %+v", s)
+	}
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u2", root.ID); s.UnreadCount != 1 {
+		t.Fatalf("expected 1 unread reply for u2, got %+v", s)
+	}
+
+	msgs, total, err := mtd.GetUserThreadMessages(ctx, root.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("GetUserThreadMessages: %v", err)
+	}
+	if total != 3 || len(msgs) != 2 || msgs[0].ID != r1.ID || msgs[1].ID != r2.ID {
+		t.Fatalf("unexpected first page: total %d, %d messages", total, len(msgs))
+	}
+
+	if _, err := mtd.ReadThread(ctx, entity.UserMessageKind, 1, root.ID, "u1"); err != nil {
+		t.Fatalf("ReadThread: %v", err)
+	}
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID); s.UnreadCount != 0 {
+		t.Fatalf("expected thread to be read, got %+v", s)
+	}
+	r4 := replyUserMsg(t, umd, mtd, root.ID, "u2")
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID); s.UnreadCount != 1 || s.LastReplyID != r4.ID {
+		t.Fatalf("expected new reply to be unread, got %+v", s)
+	}
+
+	// 阅读进度只会向前推进
+	if err := repos.Mtrr.SetThreadRead(ctx, &entity.MessageThreadRead{Kind: entity.UserMessageKind, ThreadID: root.ID, DialogID: 1, UserID: "u1", LastReadMsgID: r1.ID}); err != nil {
+		t.Fatalf("SetThreadRead: %v", err)
+	}
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID); s.UnreadCount != 1 {
+		t.Fatalf("expected read progress not to move back, got %+v", s)
+	}
+
+	// 删除的回复不计入话题
+	if err := umd.DeleteUserMessageById(ctx, r4.ID, false); err != nil {
+		t.Fatalf("DeleteUserMessageById: %v", err)
+	}
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID); s.ReplyCount != 3 || s.UnreadCount != 0 {
+		t.Fatalf("expected deleted reply to be excluded, got %+v", s)
+	}
+}
+
+func TestThreadRootIDRequiresSameDialog(t *testing.T) {
+	db, repos := newTestDB(t)
+	umd := service.NewUserMsgDomain(db, nil, repos)
+	gmd := service.NewGroupMsgDomain(db, nil, repos)
+	mtd := service.NewMessageThreadDomain(db, nil, repos)
+	ctx := context.Background()
+
+	msg, err := umd.SendUserMessage(ctx, &entity.UserMessage{DialogId: 1, SendID: "u1", Content: "root"})
+	if err != nil {
+		t.Fatalf("SendUserMessage: %v", err)
+	}
+	if _, err := mtd.GetUserThreadRootID(ctx, 2, msg.ID); !errors.Is(err, code.MsgErrReplyMsgNotFound) {
+		t.Fatalf("expected reply in another dialog to fail, got %v", err)
+	}
+	if err := umd.DeleteUserMessageById(ctx, msg.ID, false); err != nil {
+		t.Fatalf("DeleteUserMessageById: %v", err)
+	}
+	if _, err := mtd.GetUserThreadRootID(ctx, 1, msg.ID); !errors.Is(err, code.MsgErrReplyMsgNotFound) {
+		t.Fatalf("expected reply to a deleted message to fail, got %v", err)
+	}
+
+	groupMsg, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 3, GroupID: 1, UserID: "u1", Content: "root"})
+	if err != nil {
+		t.Fatalf("SendGroupMessage: %v", err)
+	}
+	id, err := mtd.GetGroupThreadRootID(ctx, 3, groupMsg.ID)
+	if err != nil || id != groupMsg.ID {
+		t.Fatalf("GetGroupThreadRootID = %d, %v", id, err)
+	}
+	if _, err := mtd.GetGroupThreadRootID(ctx, 1, groupMsg.ID); !errors.Is(err, code.MsgErrReplyMsgNotFound) {
+		t.Fatalf("expected group reply in another dialog to fail, got %v", err)
+	}
+}
+
+func TestGroupThread(t *testing.T) {
+	db, repos := newTestDB(t)
+	gmd := service.NewGroupMsgDomain(db, nil, repos)
+	mtd := service.NewMessageThreadDomain(db, nil, repos)
+	ctx := context.Background()
+
+	root, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: "u1", Content: "root"})
+	if err != nil {
+		t.Fatalf("SendGroupMessage: %v", err)
+	}
+	for _, sender := range []string{"u2", "u3", "u1"} {
+		if _, err := gmd.SendGroupMessage(ctx, &entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: sender, ReplyId: root.ID, ThreadId: root.ID, Content: "reply"}); err != nil {
+			t.Fatalf("SendGroupMessage: %v", err)
+		}
+	}
+
+	if s := threadSummary(t, mtd, entity.GroupMessageKind, "u1", root.ID); s.ReplyCount != 3 || s.UnreadCount != 2 {
+		t.Fatalf("unexpected summary for u1: %+v", s)
+	}
+	// 私聊和群聊的话题分开统计
+	if s := threadSummary(t, mtd, entity.UserMessageKind, "u1", root.ID); s.ReplyCount != 0 {
+		t.Fatalf("expected no user thread, got %+v", s)
+	}
+
+	summary, err := mtd.ReadThread(ctx, entity.GroupMessageKind, 1, root.ID, "u2")
+	if err != nil {
+		t.Fatalf("ReadThread: %v", err)
+	}
+	if summary.ReplyCount != 3 || summary.UnreadCount != 0 {
+		t.Fatalf("unexpected read summary: %+v", summary)
+	}
+	if s := threadSummary(t, mtd, entity.GroupMessageKind, "u2", root.ID); s.UnreadCount != 0 {
+		t.Fatalf("expected thread to be read by u2, got %+v", s)
+	}
+	if s := threadSummary(t, mtd, entity.GroupMessageKind, "u3", root.ID); s.UnreadCount != 2 {
+		t.Fatalf("expected read state to be per user, got %+v", s)
+	}
+
+	msgs, total, err := mtd.GetGroupThreadMessages(ctx, root.ID, 2, 2)
+	if err != nil {
+		t.Fatalf("GetGroupThreadMessages: %v", err)
+	}
+	if total != 3 || len(msgs) != 1 || msgs[0].UserID != "u1" {
+		t.Fatalf("unexpected second page: total %d, %d messages", total, len(msgs))
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ScheduledMessageDomain interface {
+	// 创建定时消息
+	CreateScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) error
+	// 获取用户自己的定时消息
+	GetScheduledMessage(ctx context.Context, id uint, userID string) (*entity.ScheduledMessage, error)
+	// 分页获取定时消息
+	ListScheduledMessages(ctx context.Context, query *entity.ScheduledMessageQuery) ([]*entity.ScheduledMessage, int64, error)
+	// 修改等待发送的定时消息
+	UpdateScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) error
+	// 取消等待发送的定时消息
+	CancelScheduledMessage(ctx context.Context, id uint, userID string) error
+	// 领取到期的定时消息
+	ClaimDueScheduledMessages(ctx context.Context, owner string, now, lockUntil int64, limit int) ([]*entity.ScheduledMessage, error)
+	// 将领取超时的定时消息标记为失败
+	FailExpiredScheduledMessages(ctx context.Context, now int64, reason string) (int64, error)
+	// 完成已领取的定时消息，返回是否更新，消息已被其他实例领取时不会更新
+	FinishScheduledMessage(ctx context.Context, id uint, owner string, status entity.ScheduledMessageStatus, msgID uint, reason string) (bool, error)
+}
+
+type ScheduledMessageDomainImpl struct {
+	db   *gorm.DB
+	ac   *pkgconfig.AppConfig
+	repo *persistence.Repositories
+}
+
+func NewScheduledMessageDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Repositories) ScheduledMessageDomain {
+	return &ScheduledMessageDomainImpl{
+		db:   db,
+		ac:   ac,
+		repo: repo,
+	}
+}
+
+func (m *ScheduledMessageDomainImpl) CreateScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) error {
+	msg.Status = entity.ScheduledMessagePending
+	if err := m.repo.Smr.CreateScheduledMessage(ctx, msg); err != nil {
+		return status.Error(codes.Code(code.MsgErrCreateScheduledMsgFailed.Code()), err.Error())
+	}
+	return nil
+}
+
+func (m *ScheduledMessageDomainImpl) GetScheduledMessage(ctx context.Context, id uint, userID string) (*entity.ScheduledMessage, error) {
+	msg, err := m.repo.Smr.GetScheduledMessage(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, code.NotFound
+		}
+		return nil, status.Error(codes.Code(code.MsgErrGetScheduledMsgFailed.Code()), err.Error())
+	}
+	if msg.UserID != userID {
+		return nil, code.NotFound
+	}
+	return msg, nil
+}
+
+func (m *ScheduledMessageDomainImpl) ListScheduledMessages(ctx context.Context, query *entity.ScheduledMessageQuery) ([]*entity.ScheduledMessage, int64, error) {
+	list, total, err := m.repo.Smr.ListScheduledMessages(ctx, query)
+	if err != nil {
+		return nil, 0, status.Error(codes.Code(code.MsgErrGetScheduledMsgFailed.Code()), err.Error())
+	}
+	return list, total, nil
+}
+
+func (m *ScheduledMessageDomainImpl) UpdateScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) error {
+	ok, err := m.repo.Smr.UpdatePendingScheduledMessage(ctx, msg)
+	if err != nil {
+		return status.Error(codes.Code(code.MsgErrUpdateScheduledMsgFailed.Code()), err.Error())
+	}
+	if !ok {
+		return code.MsgErrScheduledMsgNotPending
+	}
+	return nil
+}
+
+func (m *ScheduledMessageDomainImpl) CancelScheduledMessage(ctx context.Context, id uint, userID string) error {
+	ok, err := m.repo.Smr.CancelScheduledMessage(ctx, id, userID)
+	if err != nil {
+		return status.Error(codes.Code(code.MsgErrUpdateScheduledMsgFailed.Code()), err.Error())
+	}
+	if !ok {
+		return code.MsgErrScheduledMsgNotPending
+	}
+	return nil
+}
+
+func (m *ScheduledMessageDomainImpl) ClaimDueScheduledMessages(ctx context.Context, owner string, now, lockUntil int64, limit int) ([]*entity.ScheduledMessage, error) {
+	return m.repo.Smr.ClaimDueScheduledMessages(ctx, owner, now, lockUntil, limit)
+}
+
+func (m *ScheduledMessageDomainImpl) FailExpiredScheduledMessages(ctx context.Context, now int64, reason string) (int64, error) {
+	return m.repo.Smr.FailExpiredScheduledMessages(ctx, now, reason)
+}
+
+func (m *ScheduledMessageDomainImpl) FinishScheduledMessage(ctx context.Context, id uint, owner string, status entity.ScheduledMessageStatus, msgID uint, reason string) (bool, error) {
+	return m.repo.Smr.FinishScheduledMessage(ctx, id, owner, status, msgID, reason)
+}
@@ -7,10 +7,11 @@ import (
 	"github.com/cossim/coss-server/internal/msg/infra/persistence"
 	"github.com/cossim/coss-server/pkg/code"
 	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
-	"time"
 )
 
 type UserMsgDomain interface {
@@ -41,7 +42,7 @@ type UserMsgDomain interface {
 	// 一键已读用户消息
 	ReadAllUserMsg(ctx context.Context, dialogID uint, userID string) error
 	// 批量设置私聊消息id为已读
-	SetUserMsgsReadStatus(ctx context.Context, ids []uint, dialogID uint, openBurnAfterReadingTimeOut int64) error
+	SetUserMsgsReadStatus(ctx context.Context, ids []uint, dialogID uint, userID string, openBurnAfterReadingTimeOut int64) error
 	// 修改指定私聊消息的已读状态
 	SetUserMsgReadStatus(ctx context.Context, id uint, isRead entity.ReadType) error
 	// 获取私聊对话未读消息
@@ -74,31 +75,67 @@ func NewUserMsgDomain(db *gorm.DB, ac *pkgconfig.AppConfig, repo *persistence.Re
 }
 
 func (u *UserMsgDomainImpl) SendUserMessage(ctx context.Context, message *entity.UserMessage) (*entity.UserMessage, error) {
-	msg, err := u.repo.Umr.InsertUserMessage(message)
+	var msg *entity.UserMessage
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		var err error
+		if msg, err = repo.Umr.InsertUserMessage(message); err != nil {
+			return err
+		}
+		return recordUserMsgChanges(ctx, repo, entity.MessageChangeNew, msg)
+	})
 	if err != nil {
 		return nil, status.Error(codes.Code(code.MsgErrInsertUserMessageFailed.Code()), err.Error())
 	}
+	indexUserMessages(ctx, u.repo, msg)
 	return msg, nil
 }
 
 func (u *UserMsgDomainImpl) SendUserMessageRevert(ctx context.Context, id uint) error {
-	err := u.repo.Umr.PhysicalDeleteUserMessage(id)
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		msgs, err := repo.Umr.GetUserMsgByIDs([]uint{id})
+		if err != nil {
+			return err
+		}
+		if err := repo.Umr.PhysicalDeleteUserMessage(id); err != nil {
+			return err
+		}
+		if err := repo.Mrr.DeleteReactionsByMsgIDs(ctx, entity.UserMessageKind, []uint{id}); err != nil {
+			return err
+		}
+		return recordUserMsgChanges(ctx, repo, entity.MessageChangeDelete, msgs...)
+	})
 	if err != nil {
 		return status.Error(codes.Code(code.MsgErrDeleteUserMessageFailed.Code()), err.Error())
 	}
+	unindexMessages(ctx, u.repo, entity.SearchUserMessage, id)
 	return nil
 }
 
 func (u *UserMsgDomainImpl) SendMultiUserMessage(ctx context.Context, messages []*entity.UserMessage) error {
-	err := u.repo.Umr.InsertUserMessages(messages)
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		if err := repo.Umr.InsertUserMessages(messages); err != nil {
+			return err
+		}
+		return recordUserMsgChanges(ctx, repo, entity.MessageChangeNew, messages...)
+	})
 	if err != nil {
 		return status.Error(codes.Code(code.MsgErrSendMultipleFailed.Code()), err.Error())
 	}
+	indexUserMessages(ctx, u.repo, messages...)
 	return nil
 }
 
 func (u *UserMsgDomainImpl) SetUserMsgLabel(ctx context.Context, id uint, isLabel bool) error {
-	err := u.repo.Umr.UpdateUserMsgColumn(id, "is_label", isLabel)
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		if err := repo.Umr.UpdateUserMsgColumn(id, "is_label", isLabel); err != nil {
+			return err
+		}
+		msg, err := repo.Umr.GetUserMsgByID(id)
+		if err != nil {
+			return err
+		}
+		return recordUserMsgChanges(ctx, repo, entity.MessageChangeEdit, msg)
+	})
 	if err != nil {
 		return status.Error(codes.Code(code.SetMsgErrSetUserMsgLabelFailed.Code()), err.Error())
 	}
@@ -159,9 +196,21 @@ func (u *UserMsgDomainImpl) GetLastUserMsgsByDialogIds(ctx context.Context, dial
 }
 
 func (u *UserMsgDomainImpl) EditUserMessage(ctx context.Context, message *entity.UserMessage) error {
-	if err := u.repo.Umr.UpdateUserMessage(message); err != nil {
+	var msg *entity.UserMessage
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		if err := repo.Umr.UpdateUserMessage(message); err != nil {
+			return err
+		}
+		var err error
+		if msg, err = repo.Umr.GetUserMsgByID(message.ID); err != nil {
+			return err
+		}
+		return recordUserMsgChanges(ctx, repo, entity.MessageChangeEdit, msg)
+	})
+	if err != nil {
 		return status.Error(codes.Code(code.MsgErrEditUserMessageFailed.Code()), err.Error())
 	}
+	indexUserMessages(ctx, u.repo, msg)
 	return nil
 }
 
@@ -208,39 +257,15 @@ func (u *UserMsgDomainImpl) ReadAllUserMsg(ctx context.Context, dialogID uint, u
 	return nil
 }
 
-func (u *UserMsgDomainImpl) SetUserMsgsReadStatus(ctx context.Context, ids []uint, dialogID uint, openBurnAfterReadingTimeOut int64) error {
-	//获取阅后即焚消息id
-	messages, err := u.repo.Umr.GetBatchUserMsgsBurnAfterReadingMessages(ids, dialogID)
-	if err != nil {
-		return err
-	}
-	rids := make([]uint, 0)
-	if len(messages) > 0 {
-		for _, msg := range messages {
-			rids = append(rids, uint(msg.ID))
-		}
-	}
-	if openBurnAfterReadingTimeOut == 0 {
-		openBurnAfterReadingTimeOut = 10
-	}
-	err = u.db.Transaction(func(tx *gorm.DB) error {
+func (u *UserMsgDomainImpl) SetUserMsgsReadStatus(ctx context.Context, ids []uint, dialogID uint, userID string, openBurnAfterReadingTimeOut int64) error {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
 		npo := persistence.NewRepositories(tx)
 		if err := npo.Umr.SetUserMsgsReadStatus(ids, dialogID); err != nil {
 			return err
 		}
-		if len(rids) > 0 {
-			//起一个携程，定时器根据超时时间删除
-			go func(rpo *persistence.Repositories) {
-				time.Sleep(time.Duration(openBurnAfterReadingTimeOut) * time.Second)
-				err := rpo.Umr.LogicalDeleteUserMessages(rids)
-				if err != nil {
-					fmt.Println(err.Error())
-					return
-				}
-			}(npo)
-		}
-
-		return nil
+		// 阅后即焚消息记录过期时间，由后台任务到期删除，服务重启也不会丢失
+		expireAt := entity.BurnAfterReadingExpireAt(pkgtime.Now(), openBurnAfterReadingTimeOut)
+		return npo.Mer.SetBurnExpireAt(ctx, entity.UserMessageKind, ids, userID, expireAt)
 	})
 	if err != nil {
 		return status.Error(codes.Code(code.SetMsgErrSetUserMsgsReadStatusFailed.Code()), err.Error())
@@ -264,48 +289,73 @@ func (u *UserMsgDomainImpl) GetUnreadUserMsgs(ctx context.Context, dialogID uint
 }
 
 func (u *UserMsgDomainImpl) DeleteUserMessageByDialogId(ctx context.Context, dialogID uint, isPhysical bool) error {
-	if isPhysical {
-		err := u.repo.Umr.PhysicalDeleteUserMessagesByDialogID(dialogID)
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		ids, err := recordDialogMsgChanges(ctx, repo, entity.UserMessageKind, dialogID, false, entity.MessageChangeDelete)
 		if err != nil {
-			return status.Error(codes.Aborted, fmt.Sprintf("failed to delete user msg: %v", err))
+			return err
 		}
-	}
-	err := u.repo.Umr.DeleteUserMessagesByDialogID(dialogID)
+		if isPhysical {
+			if err := deleteDialogReactions(ctx, repo, entity.UserMessageKind, dialogID, ids); err != nil {
+				return err
+			}
+			return repo.Umr.PhysicalDeleteUserMessagesByDialogID(dialogID)
+		}
+		return repo.Umr.DeleteUserMessagesByDialogID(dialogID)
+	})
 	if err != nil {
 		return status.Error(codes.Aborted, fmt.Sprintf("failed to delete user msg: %v", err))
 	}
+	unindexDialogMessages(ctx, u.repo, entity.SearchUserMessage, dialogID)
 	return nil
 }
 
 func (u *UserMsgDomainImpl) DeleteUserMessageByDialogIdRollback(ctx context.Context, dialogID uint) error {
-	err := u.repo.Umr.UpdateUserMsgColumnByDialogId(dialogID, "deleted_at", 0)
+	var ids []uint
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		// 恢复的消息重新作为新消息同步给客户端
+		var err error
+		if ids, err = recordDialogMsgChanges(ctx, repo, entity.UserMessageKind, dialogID, true, entity.MessageChangeNew); err != nil {
+			return err
+		}
+		return repo.Umr.UpdateUserMsgColumnByDialogId(dialogID, "deleted_at", 0)
+	})
 	if err != nil {
 		return status.Error(codes.Code(code.MsgErrDeleteUserMessageFailed.Code()), err.Error())
 	}
+	// 恢复的消息重新写入索引
+	if msgs, err := u.repo.Umr.GetUserMsgByIDs(ids); err != nil {
+		zap.L().Error("get restored messages failed", zap.Uint("dialog_id", dialogID), zap.Error(err))
+	} else {
+		indexUserMessages(ctx, u.repo, msgs...)
+	}
 	return nil
 }
 
 func (u *UserMsgDomainImpl) DeleteUserMessageById(ctx context.Context, id uint, isPhysical bool) error {
-	if isPhysical {
-		if err := u.repo.Umr.PhysicalDeleteUserMessage(id); err != nil {
-			return status.Error(codes.Code(code.MsgErrDeleteUserMessageFailed.Code()), err.Error())
-		}
-	}
-	if err := u.repo.Umr.LogicalDeleteUserMessage(id); err != nil {
-		return status.Error(codes.Code(code.MsgErrDeleteUserMessageFailed.Code()), err.Error())
-	}
-	return nil
+	return u.DeleteUserMessageByIds(ctx, []uint{id}, isPhysical)
 }
 
 func (u *UserMsgDomainImpl) DeleteUserMessageByIds(ctx context.Context, ids []uint, isPhysical bool) error {
-	if isPhysical {
-		if err := u.repo.Umr.PhysicalDeleteUserMessages(ids); err != nil {
-			return status.Error(codes.Code(code.MsgErrDeleteUserMessageFailed.Code()), err.Error())
+	err := inTransaction(ctx, u.db, func(repo *persistence.Repositories) error {
+		msgs, err := repo.Umr.GetUserMsgByIDs(ids)
+		if err != nil {
+			return err
 		}
-	}
-
-	if err := u.repo.Umr.LogicalDeleteUserMessages(ids); err != nil {
+		if isPhysical {
+			if err := repo.Umr.PhysicalDeleteUserMessages(ids); err != nil {
+				return err
+			}
+		} else if err := repo.Umr.LogicalDeleteUserMessages(ids); err != nil {
+			return err
+		}
+		if err := repo.Mrr.DeleteReactionsByMsgIDs(ctx, entity.UserMessageKind, ids); err != nil {
+			return err
+		}
+		return recordUserMsgChanges(ctx, repo, entity.MessageChangeDelete, msgs...)
+	})
+	if err != nil {
 		return status.Error(codes.Code(code.MsgErrDeleteUserMessageFailed.Code()), err.Error())
 	}
+	unindexMessages(ctx, u.repo, entity.SearchUserMessage, ids...)
 	return nil
 }
@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"github.com/cossim/coss-server/pkg/utils/time"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.ClientMessageRepository = &ClientMessageRepo{}
+
+type ClientMessageRepo struct {
+	db *gorm.DB
+}
+
+func NewClientMessageRepo(db *gorm.DB) *ClientMessageRepo {
+	return &ClientMessageRepo{db: db}
+}
+
+func (m *ClientMessageRepo) CreateClientMessage(ctx context.Context, cm *entity.ClientMessage) (bool, error) {
+	model := converter.ClientMessageEntityToPO(cm)
+	result := m.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	*cm = *converter.ClientMessagePOToEntity(model)
+	return true, nil
+}
+
+func (m *ClientMessageRepo) GetClientMessage(ctx context.Context, senderID, clientMsgID string) (*entity.ClientMessage, error) {
+	model := &po.ClientMessage{}
+	err := m.db.WithContext(ctx).
+		Where("sender_id = ? AND client_msg_id = ?", senderID, clientMsgID).
+		First(model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return converter.ClientMessagePOToEntity(model), nil
+}
+
+func (m *ClientMessageRepo) TakeOverClientMessage(ctx context.Context, id uint, token, newToken string) (bool, error) {
+	result := m.db.WithContext(ctx).Model(&po.ClientMessage{}).
+		Where("id = ? AND msg_id = 0 AND token = ?", id, token).
+		Updates(map[string]interface{}{
+			"token":      newToken,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (m *ClientMessageRepo) UpdateClientMessageSent(ctx context.Context, id uint, token string, dialogID, msgID uint, seq uint64) (bool, error) {
+	result := m.db.WithContext(ctx).Model(&po.ClientMessage{}).
+		Where("id = ? AND msg_id = 0 AND token = ?", id, token).
+		Updates(map[string]interface{}{
+			"dialog_id":  dialogID,
+			"msg_id":     msgID,
+			"seq":        seq,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (m *ClientMessageRepo) DeletePendingClientMessage(ctx context.Context, id uint, token string) error {
+	return m.db.WithContext(ctx).
+		Where("id = ? AND msg_id = 0 AND token = ?", id, token).
+		Delete(&po.ClientMessage{}).Error
+}
+
+func (m *ClientMessageRepo) DeleteClientMessagesBefore(ctx context.Context, before int64) (int64, error) {
+	result := m.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&po.ClientMessage{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func ClientMessagePOToEntity(cm *po.ClientMessage) *entity.ClientMessage {
+	return &entity.ClientMessage{
+		BaseModel: entity.BaseModel{
+			ID:        cm.ID,
+			CreatedAt: cm.CreatedAt,
+			UpdatedAt: cm.UpdatedAt,
+			DeletedAt: cm.DeletedAt,
+		},
+		SenderID:    cm.SenderID,
+		ClientMsgID: cm.ClientMsgID,
+		DialogID:    cm.DialogID,
+		MsgID:       cm.MsgID,
+		Seq:         cm.Seq,
+		IsGroup:     cm.IsGroup,
+		Token:       cm.Token,
+	}
+}
+
+func ClientMessageEntityToPO(cm *entity.ClientMessage) *po.ClientMessage {
+	return &po.ClientMessage{
+		BaseModel: po.BaseModel{
+			ID:        cm.ID,
+			CreatedAt: cm.CreatedAt,
+			UpdatedAt: cm.UpdatedAt,
+			DeletedAt: cm.DeletedAt,
+		},
+		SenderID:    cm.SenderID,
+		ClientMsgID: cm.ClientMsgID,
+		DialogID:    cm.DialogID,
+		MsgID:       cm.MsgID,
+		Seq:         cm.Seq,
+		IsGroup:     cm.IsGroup,
+		Token:       cm.Token,
+	}
+}
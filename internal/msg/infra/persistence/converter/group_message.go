@@ -8,10 +8,13 @@ import (
 func GroupMessageEntityToPO(gm *entity.GroupMessage) *po.GroupMessage {
 	return &po.GroupMessage{
 		DialogId:           gm.DialogID,
+		Seq:                gm.Seq,
 		GroupID:            gm.GroupID,
 		Type:               uint(gm.Type),
 		ReplyId:            gm.ReplyId,
+		ThreadId:           gm.ThreadId,
 		ReadCount:          gm.ReadCount,
+		DeliveredCount:     gm.DeliveredCount,
 		UserID:             gm.UserID,
 		Content:            gm.Content,
 		IsLabel:            gm.IsLabel,
@@ -19,6 +22,8 @@ func GroupMessageEntityToPO(gm *entity.GroupMessage) *po.GroupMessage {
 		AtAllUser:          uint(gm.AtAllUser),
 		AtUsers:            gm.AtUsers,
 		IsBurnAfterReading: gm.IsBurnAfterReading,
+		ExpireAt:           gm.ExpireAt,
+		EditedAt:           gm.EditedAt,
 		BaseModel: po.BaseModel{
 			ID:        gm.ID,
 			CreatedAt: gm.CreatedAt,
@@ -31,10 +36,13 @@ func GroupMessageEntityToPO(gm *entity.GroupMessage) *po.GroupMessage {
 func GroupMessagePOToEntity(model *po.GroupMessage) *entity.GroupMessage {
 	return &entity.GroupMessage{
 		DialogID:           model.DialogId,
+		Seq:                model.Seq,
 		GroupID:            model.GroupID,
 		Type:               entity.UserMessageType(model.Type),
 		ReplyId:            model.ReplyId,
+		ThreadId:           model.ThreadId,
 		ReadCount:          model.ReadCount,
+		DeliveredCount:     model.DeliveredCount,
 		UserID:             model.UserID,
 		Content:            model.Content,
 		IsLabel:            model.IsLabel,
@@ -42,6 +50,8 @@ func GroupMessagePOToEntity(model *po.GroupMessage) *entity.GroupMessage {
 		AtAllUser:          entity.AtAllUserType(model.AtAllUser),
 		AtUsers:            model.AtUsers,
 		IsBurnAfterReading: model.IsBurnAfterReading,
+		ExpireAt:           model.ExpireAt,
+		EditedAt:           model.EditedAt,
 		BaseModel: entity.BaseModel{
 			ID:        model.ID,
 			CreatedAt: model.CreatedAt,
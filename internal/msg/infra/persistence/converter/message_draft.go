@@ -0,0 +1,44 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func MessageDraftPOToEntity(md *po.MessageDraft) *entity.MessageDraft {
+	return &entity.MessageDraft{
+		BaseModel: entity.BaseModel{
+			ID:        md.ID,
+			CreatedAt: md.CreatedAt,
+			UpdatedAt: md.UpdatedAt,
+			DeletedAt: md.DeletedAt,
+		},
+		UserID:   md.UserID,
+		DialogID: md.DialogID,
+		Content:  md.Content,
+		ReplyId:  md.ReplyId,
+	}
+}
+
+func MessageDraftEntityToPO(md *entity.MessageDraft) *po.MessageDraft {
+	return &po.MessageDraft{
+		BaseModel: po.BaseModel{
+			ID:        md.ID,
+			CreatedAt: md.CreatedAt,
+			UpdatedAt: md.UpdatedAt,
+			DeletedAt: md.DeletedAt,
+		},
+		UserID:   md.UserID,
+		DialogID: md.DialogID,
+		Content:  md.Content,
+		ReplyId:  md.ReplyId,
+	}
+}
+
+func MessageDraftPOToEntityList(list []*po.MessageDraft) []*entity.MessageDraft {
+	result := make([]*entity.MessageDraft, 0, len(list))
+	for _, v := range list {
+		result = append(result, MessageDraftPOToEntity(v))
+	}
+	return result
+}
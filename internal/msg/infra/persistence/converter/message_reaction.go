@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func MessageReactionPOToEntity(mr *po.MessageReaction) *entity.MessageReaction {
+	return &entity.MessageReaction{
+		BaseModel: entity.BaseModel{
+			ID:        mr.ID,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			DeletedAt: mr.DeletedAt,
+		},
+		Kind:     entity.MessageKind(mr.Kind),
+		MsgID:    mr.MsgID,
+		DialogID: mr.DialogID,
+		UserID:   mr.UserID,
+		Emoji:    mr.Emoji,
+	}
+}
+
+func MessageReactionEntityToPO(mr *entity.MessageReaction) *po.MessageReaction {
+	return &po.MessageReaction{
+		BaseModel: po.BaseModel{
+			ID:        mr.ID,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			DeletedAt: mr.DeletedAt,
+		},
+		Kind:     uint(mr.Kind),
+		MsgID:    mr.MsgID,
+		DialogID: mr.DialogID,
+		UserID:   mr.UserID,
+		Emoji:    mr.Emoji,
+	}
+}
+
+func MessageReactionPOToEntityList(list []*po.MessageReaction) []*entity.MessageReaction {
+	result := make([]*entity.MessageReaction, 0, len(list))
+	for _, v := range list {
+		result = append(result, MessageReactionPOToEntity(v))
+	}
+	return result
+}
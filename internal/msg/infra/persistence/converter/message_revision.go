@@ -0,0 +1,82 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func MessageRevisionPOToEntity(mr *po.MessageRevision) *entity.MessageRevision {
+	return &entity.MessageRevision{
+		BaseModel: entity.BaseModel{
+			ID:        mr.ID,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			DeletedAt: mr.DeletedAt,
+		},
+		Kind:     entity.MessageKind(mr.Kind),
+		MsgID:    mr.MsgID,
+		DialogID: mr.DialogID,
+		GroupID:  mr.GroupID,
+		SenderID: mr.SenderID,
+		EditorID: mr.EditorID,
+		Action:   entity.RevisionAction(mr.Action),
+		Content:  mr.Content,
+		EditedAt: mr.EditedAt,
+	}
+}
+
+func MessageRevisionEntityToPO(mr *entity.MessageRevision) *po.MessageRevision {
+	return &po.MessageRevision{
+		BaseModel: po.BaseModel{
+			ID:        mr.ID,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			DeletedAt: mr.DeletedAt,
+		},
+		Kind:     uint(mr.Kind),
+		MsgID:    mr.MsgID,
+		DialogID: mr.DialogID,
+		GroupID:  mr.GroupID,
+		SenderID: mr.SenderID,
+		EditorID: mr.EditorID,
+		Action:   uint(mr.Action),
+		Content:  mr.Content,
+		EditedAt: mr.EditedAt,
+	}
+}
+
+func MessageRevisionPOToEntityList(list []*po.MessageRevision) []*entity.MessageRevision {
+	result := make([]*entity.MessageRevision, 0, len(list))
+	for _, v := range list {
+		result = append(result, MessageRevisionPOToEntity(v))
+	}
+	return result
+}
+
+func GroupRevisionPolicyPOToEntity(gp *po.GroupRevisionPolicy) *entity.GroupRevisionPolicy {
+	return &entity.GroupRevisionPolicy{
+		BaseModel: entity.BaseModel{
+			ID:        gp.ID,
+			CreatedAt: gp.CreatedAt,
+			UpdatedAt: gp.UpdatedAt,
+			DeletedAt: gp.DeletedAt,
+		},
+		GroupID:    gp.GroupID,
+		Visibility: entity.GroupRevisionVisibility(gp.Visibility),
+		UpdatedBy:  gp.UpdatedBy,
+	}
+}
+
+func GroupRevisionPolicyEntityToPO(gp *entity.GroupRevisionPolicy) *po.GroupRevisionPolicy {
+	return &po.GroupRevisionPolicy{
+		BaseModel: po.BaseModel{
+			ID:        gp.ID,
+			CreatedAt: gp.CreatedAt,
+			UpdatedAt: gp.UpdatedAt,
+			DeletedAt: gp.DeletedAt,
+		},
+		GroupID:    gp.GroupID,
+		Visibility: uint(gp.Visibility),
+		UpdatedBy:  gp.UpdatedBy,
+	}
+}
@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func MessageChangePOToEntity(mc *po.MessageChange) *entity.MessageChange {
+	return &entity.MessageChange{
+		BaseModel: entity.BaseModel{
+			ID:        mc.ID,
+			CreatedAt: mc.CreatedAt,
+			UpdatedAt: mc.UpdatedAt,
+			DeletedAt: mc.DeletedAt,
+		},
+		DialogID: mc.DialogID,
+		SyncSeq:  mc.SyncSeq,
+		Kind:     entity.MessageKind(mc.Kind),
+		MsgID:    mc.MsgID,
+		Action:   entity.MessageChangeAction(mc.Action),
+	}
+}
+
+func MessageChangeEntityToPO(mc *entity.MessageChange) *po.MessageChange {
+	return &po.MessageChange{
+		BaseModel: po.BaseModel{
+			ID:        mc.ID,
+			CreatedAt: mc.CreatedAt,
+			UpdatedAt: mc.UpdatedAt,
+			DeletedAt: mc.DeletedAt,
+		},
+		DialogID: mc.DialogID,
+		SyncSeq:  mc.SyncSeq,
+		Kind:     uint(mc.Kind),
+		MsgID:    mc.MsgID,
+		Action:   uint(mc.Action),
+	}
+}
+
+func MessageChangePOToEntityList(list []*po.MessageChange) []*entity.MessageChange {
+	result := make([]*entity.MessageChange, 0, len(list))
+	for _, v := range list {
+		result = append(result, MessageChangePOToEntity(v))
+	}
+	return result
+}
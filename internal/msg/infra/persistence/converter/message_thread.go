@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func MessageThreadReadPOToEntity(mr *po.MessageThreadRead) *entity.MessageThreadRead {
+	return &entity.MessageThreadRead{
+		BaseModel: entity.BaseModel{
+			ID:        mr.ID,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			DeletedAt: mr.DeletedAt,
+		},
+		Kind:          entity.MessageKind(mr.Kind),
+		ThreadID:      mr.ThreadID,
+		DialogID:      mr.DialogID,
+		UserID:        mr.UserID,
+		LastReadMsgID: mr.LastReadMsgID,
+	}
+}
+
+func MessageThreadReadEntityToPO(mr *entity.MessageThreadRead) *po.MessageThreadRead {
+	return &po.MessageThreadRead{
+		BaseModel: po.BaseModel{
+			ID:        mr.ID,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			DeletedAt: mr.DeletedAt,
+		},
+		Kind:          uint(mr.Kind),
+		ThreadID:      mr.ThreadID,
+		DialogID:      mr.DialogID,
+		UserID:        mr.UserID,
+		LastReadMsgID: mr.LastReadMsgID,
+	}
+}
+
+func MessageThreadReadPOToEntityList(list []*po.MessageThreadRead) []*entity.MessageThreadRead {
+	result := make([]*entity.MessageThreadRead, 0, len(list))
+	for _, v := range list {
+		result = append(result, MessageThreadReadPOToEntity(v))
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package converter
+
+import (
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+)
+
+func ScheduledMessagePOToEntity(sm *po.ScheduledMessage) *entity.ScheduledMessage {
+	return &entity.ScheduledMessage{
+		BaseModel: entity.BaseModel{
+			ID:        sm.ID,
+			CreatedAt: sm.CreatedAt,
+			UpdatedAt: sm.UpdatedAt,
+			DeletedAt: sm.DeletedAt,
+		},
+		UserID:             sm.UserID,
+		DriverID:           sm.DriverID,
+		Kind:               entity.MessageKind(sm.Kind),
+		DialogID:           sm.DialogID,
+		ReceiverID:         sm.ReceiverID,
+		GroupID:            sm.GroupID,
+		Type:               entity.UserMessageType(sm.Type),
+		Content:            sm.Content,
+		ReplyId:            sm.ReplyId,
+		AtUsers:            sm.AtUsers,
+		AtAllUser:          sm.AtAllUser,
+		IsBurnAfterReading: sm.IsBurnAfterReading,
+		SendAt:             sm.SendAt,
+		Status:             entity.ScheduledMessageStatus(sm.Status),
+		MsgID:              sm.MsgID,
+		FailReason:         sm.FailReason,
+		LockedBy:           sm.LockedBy,
+		LockedUntil:        sm.LockedUntil,
+	}
+}
+
+func ScheduledMessageEntityToPO(sm *entity.ScheduledMessage) *po.ScheduledMessage {
+	return &po.ScheduledMessage{
+		BaseModel: po.BaseModel{
+			ID:        sm.ID,
+			CreatedAt: sm.CreatedAt,
+			UpdatedAt: sm.UpdatedAt,
+			DeletedAt: sm.DeletedAt,
+		},
+		UserID:             sm.UserID,
+		DriverID:           sm.DriverID,
+		Kind:               uint(sm.Kind),
+		DialogID:           sm.DialogID,
+		ReceiverID:         sm.ReceiverID,
+		GroupID:            sm.GroupID,
+		Type:               uint(sm.Type),
+		Content:            sm.Content,
+		ReplyId:            sm.ReplyId,
+		AtUsers:            sm.AtUsers,
+		AtAllUser:          sm.AtAllUser,
+		IsBurnAfterReading: sm.IsBurnAfterReading,
+		SendAt:             sm.SendAt,
+		Status:             uint(sm.Status),
+		MsgID:              sm.MsgID,
+		FailReason:         sm.FailReason,
+		LockedBy:           sm.LockedBy,
+		LockedUntil:        sm.LockedUntil,
+	}
+}
+
+func ScheduledMessagePOToEntityList(list []*po.ScheduledMessage) []*entity.ScheduledMessage {
+	result := make([]*entity.ScheduledMessage, 0, len(list))
+	for _, v := range list {
+		result = append(result, ScheduledMessagePOToEntity(v))
+	}
+	return result
+}
@@ -9,8 +9,10 @@ func UserMessagePOToEntity(um *po.UserMessage) *entity.UserMessage {
 	return &entity.UserMessage{
 		Type:               entity.UserMessageType(um.Type),
 		DialogId:           um.DialogId,
+		Seq:                um.Seq,
 		IsRead:             entity.ReadType(um.IsRead),
 		ReplyId:            um.ReplyId,
+		ThreadId:           um.ThreadId,
 		ReadAt:             um.ReadAt,
 		ReceiveID:          um.ReceiveID,
 		SendID:             um.SendID,
@@ -18,6 +20,9 @@ func UserMessagePOToEntity(um *po.UserMessage) *entity.UserMessage {
 		IsLabel:            um.IsLabel,
 		IsBurnAfterReading: um.IsBurnAfterReading,
 		ReplyEmoji:         um.ReplyEmoji,
+		ExpireAt:           um.ExpireAt,
+		DeliveredAt:        um.DeliveredAt,
+		EditedAt:           um.EditedAt,
 		BaseModel: entity.BaseModel{
 			ID:        um.ID,
 			CreatedAt: um.CreatedAt,
@@ -31,8 +36,10 @@ func UserMessageEntityToPO(um *entity.UserMessage) *po.UserMessage {
 	return &po.UserMessage{
 		Type:               uint(um.Type),
 		DialogId:           um.DialogId,
+		Seq:                um.Seq,
 		IsRead:             uint(um.IsRead),
 		ReplyId:            um.ReplyId,
+		ThreadId:           um.ThreadId,
 		ReadAt:             um.ReadAt,
 		ReceiveID:          um.ReceiveID,
 		SendID:             um.SendID,
@@ -40,6 +47,9 @@ func UserMessageEntityToPO(um *entity.UserMessage) *po.UserMessage {
 		IsLabel:            um.IsLabel,
 		IsBurnAfterReading: um.IsBurnAfterReading,
 		ReplyEmoji:         um.ReplyEmoji,
+		ExpireAt:           um.ExpireAt,
+		DeliveredAt:        um.DeliveredAt,
+		EditedAt:           um.EditedAt,
 		BaseModel: po.BaseModel{
 			ID:        um.ID,
 			CreatedAt: um.CreatedAt,
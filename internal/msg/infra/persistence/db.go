@@ -10,6 +10,17 @@ type Repositories struct {
 	Umr  repository.UserMessageRepository
 	Gmr  repository.GroupMessageRepository
 	Gmrr repository.GroupMsgReadRepository
+	Mrr  repository.MessageReactionRepository
+	Mtrr repository.MessageThreadReadRepository
+	Msr  repository.MessageSearchRepository
+	Smr  repository.ScheduledMessageRepository
+	Mer  repository.MessageExpiryRepository
+	Mdr  repository.MessageDeliveryRepository
+	Mdrr repository.MessageDraftRepository
+	Mcr  repository.MessageSyncRepository
+	Cmr  repository.ClientMessageRepository
+	Mrvr repository.MessageRevisionRepository
+	Grpr repository.GroupRevisionPolicyRepository
 	db   *gorm.DB
 }
 
@@ -18,10 +29,34 @@ func NewRepositories(db *gorm.DB) *Repositories {
 		Umr:  NewUserMsgRepo(db),
 		Gmr:  NewGroupMsgRepo(db),
 		Gmrr: NewGroupMsgReadRepo(db),
+		Mrr:  NewMessageReactionRepo(db),
+		Mtrr: NewMessageThreadReadRepo(db),
+		Smr:  NewScheduledMessageRepo(db),
+		Mer:  NewMessageExpiryRepo(db),
+		Mdr:  NewMessageDeliveryRepo(db),
+		Mdrr: NewMessageDraftRepo(db),
+		Mcr:  NewMessageSyncRepo(db),
+		Cmr:  NewClientMessageRepo(db),
+		Mrvr: NewMessageRevisionRepo(db),
+		Grpr: NewGroupRevisionPolicyRepo(db),
 		db:   db,
 	}
 }
 
 func (s *Repositories) Automigrate() error {
-	return s.db.AutoMigrate(&po.GroupMessage{}, &po.UserMessage{}, &po.GroupMessageRead{})
+	// 创建序号唯一索引之前先为已有的消息分配序号
+	if err := migrateMsgSeq(s.db); err != nil {
+		return err
+	}
+	return s.db.AutoMigrate(&po.GroupMessage{}, &po.UserMessage{}, &po.GroupMessageRead{}, &po.MessageReaction{}, &po.MessageThreadRead{}, &po.ScheduledMessage{}, &po.GroupMessageDelivery{}, &po.MessageDraft{}, &po.DialogSequence{}, &po.MessageChange{}, &po.ClientMessage{}, &po.MessageRevision{}, &po.GroupRevisionPolicy{})
+}
+
+// OpenSearchIndex 打开消息全文索引
+func (s *Repositories) OpenSearchIndex(path string) error {
+	msr, err := NewMessageSearchRepo(path)
+	if err != nil {
+		return err
+	}
+	s.Msr = msr
+	return nil
 }
@@ -0,0 +1,35 @@
+package persistence
+
+import (
+	"fmt"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"strings"
+	"testing"
+)
+
+// newTestRepositories 使用内存sqlite创建所有表，每个测试使用独立的数据库
+func newTestRepositories(t *testing.T) *Repositories {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql db: %v", err)
+	}
+	// sqlite 同一时间只允许一个写入，并发测试通过单个连接排队执行
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	repos := NewRepositories(db)
+	if err := repos.Automigrate(); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return repos
+}
@@ -34,7 +34,15 @@ func (g *GroupMsgRepo) GetGroupUnreadMsgList(dialogId uint, msgIds []uint) ([]*e
 
 func (g *GroupMsgRepo) InsertGroupMessage(msg *entity.GroupMessage) (*entity.GroupMessage, error) {
 	gm := converter.GroupMessageEntityToPO(msg)
-	if err := g.db.Create(gm).Error; err != nil {
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		seq, err := allocDialogSeq(tx, gm.DialogId, msgSeqColumn, 1)
+		if err != nil {
+			return err
+		}
+		gm.Seq = seq
+		return tx.Create(gm).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -187,6 +195,19 @@ func (g *GroupMsgRepo) GetGroupMsgsByIDs(msgIds []uint) ([]*entity.GroupMessage,
 	return resp, err
 }
 
+func (g *GroupMsgRepo) GetGroupMsgsAfterID(msgId uint, limit int) ([]*entity.GroupMessage, error) {
+	var groupMessages []*po.GroupMessage
+	err := g.db.Model(&po.GroupMessage{}).
+		Where("id > ? AND deleted_at = 0", msgId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&groupMessages).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.GroupMessagePOToEntityList(groupMessages), nil
+}
+
 func (g *GroupMsgRepo) GetGroupMsgIdsByDialogID(dialogID uint) ([]uint, error) {
 	var msgIds []uint
 	err := g.db.Model(&po.GroupMessage{}).
@@ -270,3 +291,72 @@ func (g *GroupMsgRepo) GetGroupMsgIdBeforeMsgList(dialogId uint, msgId uint, pag
 	resp := converter.GroupMessagePOToEntityList(groupMessages)
 	return resp, int32(total), err
 }
+
+func (g *GroupMsgRepo) GetGroupThreadMsgs(threadId uint, pageNumber, pageSize int) ([]*entity.GroupMessage, int64, error) {
+	var msgs []*po.GroupMessage
+	var total int64
+	err := g.db.Model(&po.GroupMessage{}).
+		Where("thread_id = ? AND deleted_at = 0", threadId).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = g.db.Model(&po.GroupMessage{}).
+		Where("thread_id = ? AND deleted_at = 0", threadId).
+		Order("id ASC").
+		Limit(pageSize).
+		Offset(pageSize * (pageNumber - 1)).
+		Find(&msgs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return converter.GroupMessagePOToEntityList(msgs), total, nil
+}
+
+func (g *GroupMsgRepo) GetGroupThreadStats(threadIds []uint) ([]*entity.ThreadStat, error) {
+	stats := make([]*entity.ThreadStat, 0)
+	if len(threadIds) == 0 {
+		return stats, nil
+	}
+	err := g.db.Model(&po.GroupMessage{}).
+		Select("thread_id, COUNT(*) AS reply_count, MAX(id) AS last_reply_id").
+		Where("thread_id IN (?) AND deleted_at = 0", threadIds).
+		Group("thread_id").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (g *GroupMsgRepo) CountGroupThreadUnread(userID string, lastRead map[uint]uint) (map[uint]int64, error) {
+	result := make(map[uint]int64)
+	if len(lastRead) == 0 {
+		return result, nil
+	}
+
+	var cond *gorm.DB
+	for threadId, msgId := range lastRead {
+		if cond == nil {
+			cond = g.db.Where("thread_id = ? AND id > ?", threadId, msgId)
+			continue
+		}
+		cond = cond.Or("thread_id = ? AND id > ?", threadId, msgId)
+	}
+
+	var rows []struct {
+		ThreadID uint
+		Count    int64
+	}
+	err := g.db.Model(&po.GroupMessage{}).
+		Select("thread_id, COUNT(*) AS count").
+		Where(cond).
+		Where("user_id <> ? AND deleted_at = 0", userID).
+		Group("thread_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.ThreadID] = row.Count
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.MessageDeliveryRepository = &MessageDeliveryRepo{}
+
+type MessageDeliveryRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageDeliveryRepo(db *gorm.DB) *MessageDeliveryRepo {
+	return &MessageDeliveryRepo{db: db}
+}
+
+func (m *MessageDeliveryRepo) SetUserMsgsDelivered(ctx context.Context, userID string, msgIDs []uint, deliveredAt int64) ([]*entity.UserMessage, error) {
+	if len(msgIDs) == 0 {
+		return nil, nil
+	}
+
+	var delivered []*po.UserMessage
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var msgs []*po.UserMessage
+		if err := tx.Model(&po.UserMessage{}).
+			Where("id IN (?) AND receive_id = ? AND delivered_at = 0 AND deleted_at = 0", msgIDs, userID).
+			Find(&msgs).Error; err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			// 多个设备同时送达时只有第一次更新成功的会通知发送者
+			result := tx.Model(&po.UserMessage{}).
+				Where("id = ? AND delivered_at = 0", msg.ID).
+				Update("delivered_at", deliveredAt)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 1 {
+				msg.DeliveredAt = deliveredAt
+				delivered = append(delivered, msg)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.UserMessagePOToEntityList(delivered), nil
+}
+
+func (m *MessageDeliveryRepo) SetGroupMsgsDelivered(ctx context.Context, userID string, msgIDs []uint, deliveredAt int64) ([]*entity.GroupMessage, error) {
+	if len(msgIDs) == 0 {
+		return nil, nil
+	}
+
+	var delivered []*po.GroupMessage
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var msgs []*po.GroupMessage
+		if err := tx.Model(&po.GroupMessage{}).
+			Where("id IN (?) AND user_id <> ? AND deleted_at = 0", msgIDs, userID).
+			Find(&msgs).Error; err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&po.GroupMessageDelivery{
+				MsgID:       msg.ID,
+				DialogID:    msg.DialogId,
+				GroupID:     msg.GroupID,
+				UserID:      userID,
+				DeliveredAt: deliveredAt,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			if err := tx.Model(&po.GroupMessage{}).
+				Where("id = ?", msg.ID).
+				Update("delivered_count", gorm.Expr("delivered_count + 1")).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&po.GroupMessage{}).
+				Select("delivered_count").
+				Where("id = ?", msg.ID).
+				Scan(&msg.DeliveredCount).Error; err != nil {
+				return err
+			}
+			delivered = append(delivered, msg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.GroupMessagePOToEntityList(delivered), nil
+}
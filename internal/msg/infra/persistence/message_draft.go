@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.MessageDraftRepository = &MessageDraftRepo{}
+
+type MessageDraftRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageDraftRepo(db *gorm.DB) *MessageDraftRepo {
+	return &MessageDraftRepo{db: db}
+}
+
+func (m *MessageDraftRepo) SaveDraft(ctx context.Context, draft *entity.MessageDraft) error {
+	model := converter.MessageDraftEntityToPO(draft)
+	err := m.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "dialog_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content", "reply_id", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return err
+	}
+
+	saved, err := m.GetDraft(ctx, draft.UserID, draft.DialogID)
+	if err != nil {
+		return err
+	}
+	if saved != nil {
+		*draft = *saved
+	}
+	return nil
+}
+
+func (m *MessageDraftRepo) GetDraft(ctx context.Context, userID string, dialogID uint) (*entity.MessageDraft, error) {
+	model := &po.MessageDraft{}
+	err := m.db.WithContext(ctx).
+		Where("user_id = ? AND dialog_id = ?", userID, dialogID).
+		First(model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return converter.MessageDraftPOToEntity(model), nil
+}
+
+func (m *MessageDraftRepo) GetDraftsByDialogIDs(ctx context.Context, userID string, dialogIDs []uint) ([]*entity.MessageDraft, error) {
+	var drafts []*po.MessageDraft
+	query := m.db.WithContext(ctx).Model(&po.MessageDraft{}).Where("user_id = ?", userID)
+	if len(dialogIDs) > 0 {
+		query = query.Where("dialog_id IN (?)", dialogIDs)
+	}
+	if err := query.Order("updated_at DESC").Find(&drafts).Error; err != nil {
+		return nil, err
+	}
+	return converter.MessageDraftPOToEntityList(drafts), nil
+}
+
+func (m *MessageDraftRepo) DeleteDraft(ctx context.Context, userID string, dialogID uint) (bool, error) {
+	result := m.db.WithContext(ctx).
+		Where("user_id = ? AND dialog_id = ?", userID, dialogID).
+		Delete(&po.MessageDraft{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
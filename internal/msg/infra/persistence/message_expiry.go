@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+)
+
+var _ repository.MessageExpiryRepository = &MessageExpiryRepo{}
+
+type MessageExpiryRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageExpiryRepo(db *gorm.DB) *MessageExpiryRepo {
+	return &MessageExpiryRepo{db: db}
+}
+
+func (m *MessageExpiryRepo) model(kind entity.MessageKind) interface{} {
+	if kind == entity.GroupMessageKind {
+		return &po.GroupMessage{}
+	}
+	return &po.UserMessage{}
+}
+
+func (m *MessageExpiryRepo) SetBurnExpireAt(ctx context.Context, kind entity.MessageKind, msgIDs []uint, readerID string, expireAt int64) error {
+	if len(msgIDs) == 0 {
+		return nil
+	}
+	query := m.db.WithContext(ctx).Model(m.model(kind)).
+		Where("id IN (?) AND is_burn_after_reading = ? AND expire_at = 0 AND deleted_at = 0", msgIDs, true)
+	// 私聊只有接收者阅读才开始计时，群聊由发送者以外的成员首次阅读开始计时
+	if kind == entity.GroupMessageKind {
+		query = query.Where("user_id <> ?", readerID)
+	} else {
+		query = query.Where("receive_id = ?", readerID)
+	}
+	return query.Update("expire_at", expireAt).Error
+}
+
+func (m *MessageExpiryRepo) GetExpiredMessages(ctx context.Context, kind entity.MessageKind, now int64, limit int) ([]*entity.ExpiredMessage, error) {
+	query := m.db.WithContext(ctx).Model(m.model(kind)).
+		Where("expire_at > 0 AND expire_at <= ? AND deleted_at = 0", now).
+		Order("expire_at ASC").
+		Limit(limit)
+
+	result := make([]*entity.ExpiredMessage, 0)
+	if kind == entity.GroupMessageKind {
+		var msgs []*po.GroupMessage
+		if err := query.Find(&msgs).Error; err != nil {
+			return nil, err
+		}
+		for _, v := range msgs {
+			result = append(result, &entity.ExpiredMessage{
+				Kind:     kind,
+				ID:       v.ID,
+				DialogID: v.DialogId,
+				GroupID:  v.GroupID,
+				SenderID: v.UserID,
+				ExpireAt: v.ExpireAt,
+			})
+		}
+		return result, nil
+	}
+
+	var msgs []*po.UserMessage
+	if err := query.Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+	for _, v := range msgs {
+		result = append(result, &entity.ExpiredMessage{
+			Kind:       kind,
+			ID:         v.ID,
+			DialogID:   v.DialogId,
+			SenderID:   v.SendID,
+			ReceiverID: v.ReceiveID,
+			ExpireAt:   v.ExpireAt,
+		})
+	}
+	return result, nil
+}
+
+func (m *MessageExpiryRepo) DeleteExpiredMessage(ctx context.Context, kind entity.MessageKind, msgID uint, now int64) (bool, error) {
+	deleted := false
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(m.model(kind)).
+			Where("id = ? AND deleted_at = 0", msgID).
+			Update("deleted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+
+		var dialogIDs []uint
+		if err := tx.Model(m.model(kind)).Where("id = ?", msgID).Pluck("dialog_id", &dialogIDs).Error; err != nil {
+			return err
+		}
+		if len(dialogIDs) == 0 {
+			return nil
+		}
+		deleted = true
+		// 修订记录中保存了消息原内容，随消息一起删除
+		if err := tx.Where("kind = ? AND msg_id = ?", kind, msgID).Delete(&po.MessageRevision{}).Error; err != nil {
+			return err
+		}
+		if err := NewMessageReactionRepo(tx).DeleteReactionsByMsgIDs(ctx, kind, []uint{msgID}); err != nil {
+			return err
+		}
+		return NewMessageSyncRepo(tx).AppendChanges(ctx, []*entity.MessageChange{{
+			DialogID: dialogIDs[0],
+			Kind:     kind,
+			MsgID:    msgID,
+			Action:   entity.MessageChangeDelete,
+		}})
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"testing"
+)
+
+func TestDeleteExpiredMessageRemovesRevisionsAndReactions(t *testing.T) {
+	repos := newTestRepositories(t)
+	ctx := context.Background()
+
+	msg, err := repos.Umr.InsertUserMessage(&entity.UserMessage{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "secret", IsBurnAfterReading: true})
+	if err != nil {
+		t.Fatalf("InsertUserMessage: %v", err)
+	}
+	if err := repos.Mrvr.AddRevision(ctx, &entity.MessageRevision{Kind: entity.UserMessageKind, MsgID: msg.ID, DialogID: 1, SenderID: "u1", Content: "secret"}); err != nil {
+		t.Fatalf("AddRevision: %v", err)
+	}
+	if err := repos.Mrr.AddReaction(ctx, &entity.MessageReaction{Kind: entity.UserMessageKind, MsgID: msg.ID, DialogID: 1, UserID: "u2", Emoji: "👍"}); err != nil {
+		t.Fatalf("AddReaction: %v", err)
+	}
+
+	deleted, err := repos.Mer.DeleteExpiredMessage(ctx, entity.UserMessageKind, msg.ID, 1000)
+	if err != nil || !deleted {
+		t.Fatalf("DeleteExpiredMessage: %v, %v", deleted, err)
+	}
+
+	// 阅后即焚消息的原内容不能保留在修订记录中
+	revisions, err := repos.Mrvr.GetRevisionsByMsgID(ctx, entity.UserMessageKind, msg.ID)
+	if err != nil {
+		t.Fatalf("GetRevisionsByMsgID: %v", err)
+	}
+	if len(revisions) != 0 {
+		t.Fatalf("expected revisions to be deleted, got %d", len(revisions))
+	}
+
+	reactions, err := repos.Mrr.GetReactionsByMsgIDs(ctx, entity.UserMessageKind, []uint{msg.ID})
+	if err != nil {
+		t.Fatalf("GetReactionsByMsgIDs: %v", err)
+	}
+	if len(reactions) != 0 {
+		t.Fatalf("expected reactions to be deleted, got %d", len(reactions))
+	}
+
+	changes, err := repos.Mcr.GetChanges(ctx, 1, 0, entity.MaxSyncLimit)
+	if err != nil {
+		t.Fatalf("GetChanges: %v", err)
+	}
+	if len(changes) != 1 || changes[0].MsgID != msg.ID || changes[0].Action != entity.MessageChangeDelete {
+		t.Fatalf("expected a delete change, got %+v", changes)
+	}
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+)
+
+var _ repository.MessageReactionRepository = &MessageReactionRepo{}
+
+type MessageReactionRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageReactionRepo(db *gorm.DB) *MessageReactionRepo {
+	return &MessageReactionRepo{db: db}
+}
+
+func (m *MessageReactionRepo) AddReaction(ctx context.Context, reaction *entity.MessageReaction) error {
+	model := converter.MessageReactionEntityToPO(reaction)
+	err := m.db.WithContext(ctx).
+		Where(&po.MessageReaction{Kind: model.Kind, MsgID: model.MsgID, UserID: model.UserID, Emoji: model.Emoji}).
+		FirstOrCreate(model).Error
+	if err != nil {
+		return err
+	}
+	*reaction = *converter.MessageReactionPOToEntity(model)
+	return nil
+}
+
+func (m *MessageReactionRepo) RemoveReaction(ctx context.Context, kind entity.MessageKind, msgID uint, userID string, emoji string) error {
+	return m.db.WithContext(ctx).
+		Where("kind = ? AND msg_id = ? AND user_id = ? AND emoji = ?", uint(kind), msgID, userID, emoji).
+		Delete(&po.MessageReaction{}).Error
+}
+
+func (m *MessageReactionRepo) GetReactionsByMsgIDs(ctx context.Context, kind entity.MessageKind, msgIDs []uint) ([]*entity.MessageReaction, error) {
+	if len(msgIDs) == 0 {
+		return nil, nil
+	}
+	var reactions []*po.MessageReaction
+	err := m.db.WithContext(ctx).Model(&po.MessageReaction{}).
+		Where("kind = ? AND msg_id IN (?)", uint(kind), msgIDs).
+		Order("id ASC").
+		Find(&reactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.MessageReactionPOToEntityList(reactions), nil
+}
+
+func (m *MessageReactionRepo) DeleteReactionsByMsgIDs(ctx context.Context, kind entity.MessageKind, msgIDs []uint) error {
+	if len(msgIDs) == 0 {
+		return nil
+	}
+	return m.db.WithContext(ctx).
+		Where("kind = ? AND msg_id IN (?)", uint(kind), msgIDs).
+		Delete(&po.MessageReaction{}).Error
+}
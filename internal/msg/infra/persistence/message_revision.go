@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.MessageRevisionRepository = &MessageRevisionRepo{}
+
+type MessageRevisionRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageRevisionRepo(db *gorm.DB) *MessageRevisionRepo {
+	return &MessageRevisionRepo{db: db}
+}
+
+func (m *MessageRevisionRepo) AddRevision(ctx context.Context, revision *entity.MessageRevision) error {
+	model := converter.MessageRevisionEntityToPO(revision)
+	if err := m.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	*revision = *converter.MessageRevisionPOToEntity(model)
+	return nil
+}
+
+func (m *MessageRevisionRepo) GetRevisionsByMsgID(ctx context.Context, kind entity.MessageKind, msgID uint) ([]*entity.MessageRevision, error) {
+	var revisions []*po.MessageRevision
+	err := m.db.WithContext(ctx).
+		Where("kind = ? AND msg_id = ?", uint(kind), msgID).
+		Order("edited_at ASC, id ASC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.MessageRevisionPOToEntityList(revisions), nil
+}
+
+var _ repository.GroupRevisionPolicyRepository = &GroupRevisionPolicyRepo{}
+
+type GroupRevisionPolicyRepo struct {
+	db *gorm.DB
+}
+
+func NewGroupRevisionPolicyRepo(db *gorm.DB) *GroupRevisionPolicyRepo {
+	return &GroupRevisionPolicyRepo{db: db}
+}
+
+func (g *GroupRevisionPolicyRepo) SavePolicy(ctx context.Context, policy *entity.GroupRevisionPolicy) error {
+	model := converter.GroupRevisionPolicyEntityToPO(policy)
+	err := g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "group_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"visibility", "updated_by", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return err
+	}
+
+	saved, err := g.GetPolicy(ctx, policy.GroupID)
+	if err != nil {
+		return err
+	}
+	if saved != nil {
+		*policy = *saved
+	}
+	return nil
+}
+
+func (g *GroupRevisionPolicyRepo) GetPolicy(ctx context.Context, groupID uint) (*entity.GroupRevisionPolicy, error) {
+	model := &po.GroupRevisionPolicy{}
+	err := g.db.WithContext(ctx).Where("group_id = ?", groupID).First(model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return converter.GroupRevisionPolicyPOToEntity(model), nil
+}
@@ -0,0 +1,329 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var _ repository.MessageSearchRepository = &MessageSearchRepo{}
+
+const (
+	searchFieldKind      = "kind"
+	searchFieldMsgID     = "msg_id"
+	searchFieldDialogID  = "dialog_id"
+	searchFieldGroupID   = "group_id"
+	searchFieldSendID    = "send_id"
+	searchFieldMsgType   = "msg_type"
+	searchFieldContent   = "content"
+	searchFieldCreatedAt = "created_at"
+
+	searchDeleteBatchSize = 1000
+)
+
+var (
+	// 同一进程内 grpc 与 http 共用同一份索引，bleve 不允许重复打开同一目录
+	searchIndexMu sync.Mutex
+	searchIndexes = map[string]*MessageSearchRepo{}
+)
+
+// messageIndexDoc 索引中实际存储的文档结构
+type messageIndexDoc struct {
+	Kind      string `json:"kind"`
+	MsgID     string `json:"msg_id"`
+	DialogID  string `json:"dialog_id"`
+	GroupID   string `json:"group_id"`
+	SendID    string `json:"send_id"`
+	MsgType   string `json:"msg_type"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type MessageSearchRepo struct {
+	index bleve.Index
+	path  string
+	refs  int
+}
+
+// NewMessageSearchRepo 打开或创建消息全文索引，path 为空时使用内存索引
+func NewMessageSearchRepo(path string) (*MessageSearchRepo, error) {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+
+	if r, ok := searchIndexes[path]; ok {
+		r.refs++
+		return r, nil
+	}
+
+	var index bleve.Index
+	var err error
+	if path == "" {
+		index, err = bleve.NewMemOnly(newMessageIndexMapping())
+	} else {
+		index, err = bleve.Open(path)
+		if err == bleve.ErrorIndexPathDoesNotExist {
+			index, err = bleve.New(path, newMessageIndexMapping())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &MessageSearchRepo{index: index, path: path, refs: 1}
+	searchIndexes[path] = r
+	return r, nil
+}
+
+func newMessageIndexMapping() mapping.IndexMapping {
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+	keywordField.IncludeInAll = false
+
+	// cjk 分析器对中日韩文字做二元切分，对英文按单词切分并转小写
+	contentField := bleve.NewTextFieldMapping()
+	contentField.Analyzer = cjk.AnalyzerName
+	contentField.Store = true
+	contentField.IncludeTermVectors = true
+
+	createdAtField := bleve.NewNumericFieldMapping()
+	createdAtField.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt(searchFieldKind, keywordField)
+	doc.AddFieldMappingsAt(searchFieldMsgID, keywordField)
+	doc.AddFieldMappingsAt(searchFieldDialogID, keywordField)
+	doc.AddFieldMappingsAt(searchFieldGroupID, keywordField)
+	doc.AddFieldMappingsAt(searchFieldSendID, keywordField)
+	doc.AddFieldMappingsAt(searchFieldMsgType, keywordField)
+	doc.AddFieldMappingsAt(searchFieldContent, contentField)
+	doc.AddFieldMappingsAt(searchFieldCreatedAt, createdAtField)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	m.DefaultAnalyzer = cjk.AnalyzerName
+	return m
+}
+
+func messageDocID(kind entity.SearchMessageKind, msgID uint) string {
+	return fmt.Sprintf("%s:%d", kind, msgID)
+}
+
+func (r *MessageSearchRepo) IndexMessages(ctx context.Context, docs ...*entity.MessageDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	batch := r.index.NewBatch()
+	for _, doc := range docs {
+		if doc == nil || doc.MsgID == 0 {
+			continue
+		}
+		id := messageDocID(doc.Kind, doc.MsgID)
+		// 没有可检索的文本时删除旧索引，避免编辑为空后仍能被搜到
+		if strings.TrimSpace(doc.Content) == "" {
+			batch.Delete(id)
+			continue
+		}
+		if err := batch.Index(id, &messageIndexDoc{
+			Kind:      string(doc.Kind),
+			MsgID:     strconv.FormatUint(uint64(doc.MsgID), 10),
+			DialogID:  strconv.FormatUint(uint64(doc.DialogID), 10),
+			GroupID:   strconv.FormatUint(uint64(doc.GroupID), 10),
+			SendID:    doc.SendID,
+			MsgType:   strconv.FormatUint(uint64(doc.MsgType), 10),
+			Content:   doc.Content,
+			CreatedAt: doc.CreatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+	return r.index.Batch(batch)
+}
+
+func (r *MessageSearchRepo) DeleteMessages(ctx context.Context, kind entity.SearchMessageKind, msgIds ...uint) error {
+	if len(msgIds) == 0 {
+		return nil
+	}
+	batch := r.index.NewBatch()
+	for _, id := range msgIds {
+		batch.Delete(messageDocID(kind, id))
+	}
+	return r.index.Batch(batch)
+}
+
+func (r *MessageSearchRepo) DeleteMessagesByDialogID(ctx context.Context, kind entity.SearchMessageKind, dialogId uint) error {
+	q := bleve.NewConjunctionQuery(
+		termQuery(searchFieldKind, string(kind)),
+		termQuery(searchFieldDialogID, strconv.FormatUint(uint64(dialogId), 10)),
+	)
+	for {
+		req := bleve.NewSearchRequestOptions(q, searchDeleteBatchSize, 0, false)
+		res, err := r.index.SearchInContext(ctx, req)
+		if err != nil {
+			return err
+		}
+		if len(res.Hits) == 0 {
+			return nil
+		}
+		batch := r.index.NewBatch()
+		for _, hit := range res.Hits {
+			batch.Delete(hit.ID)
+		}
+		if err := r.index.Batch(batch); err != nil {
+			return err
+		}
+	}
+}
+
+// 补建进度和变更同步进度与索引保存在一起，索引目录重建后会从头处理
+
+func indexCursorKey(kind entity.SearchMessageKind) []byte {
+	return []byte("backfill_cursor:" + string(kind))
+}
+
+var changeCursorKey = []byte("change_cursor")
+
+func (r *MessageSearchRepo) GetIndexCursor(ctx context.Context, kind entity.SearchMessageKind) (uint, error) {
+	return r.getCursor(indexCursorKey(kind))
+}
+
+func (r *MessageSearchRepo) SetIndexCursor(ctx context.Context, kind entity.SearchMessageKind, msgId uint) error {
+	return r.setCursor(indexCursorKey(kind), msgId)
+}
+
+func (r *MessageSearchRepo) GetChangeCursor(ctx context.Context) (uint, error) {
+	return r.getCursor(changeCursorKey)
+}
+
+func (r *MessageSearchRepo) SetChangeCursor(ctx context.Context, changeId uint) error {
+	return r.setCursor(changeCursorKey, changeId)
+}
+
+func (r *MessageSearchRepo) getCursor(key []byte) (uint, error) {
+	v, err := r.index.GetInternal(key)
+	if err != nil || len(v) == 0 {
+		return 0, err
+	}
+	id, err := strconv.ParseUint(string(v), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func (r *MessageSearchRepo) setCursor(key []byte, id uint) error {
+	return r.index.SetInternal(key, []byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+func (r *MessageSearchRepo) Search(ctx context.Context, q *entity.MessageSearchQuery) (*entity.MessageSearchResult, error) {
+	result := &entity.MessageSearchResult{Hits: make([]*entity.MessageSearchHit, 0)}
+	if strings.TrimSpace(q.Keyword) == "" || len(q.DialogIds) == 0 {
+		return result, nil
+	}
+
+	match := bleve.NewMatchQuery(q.Keyword)
+	match.SetField(searchFieldContent)
+	match.Analyzer = cjk.AnalyzerName
+	match.SetOperator(query.MatchQueryOperatorAnd)
+
+	// 完整短语命中的结果排在前面
+	phrase := bleve.NewMatchPhraseQuery(q.Keyword)
+	phrase.SetField(searchFieldContent)
+	phrase.Analyzer = cjk.AnalyzerName
+	phrase.SetBoost(2)
+
+	keywordQuery := bleve.NewBooleanQuery()
+	keywordQuery.AddMust(match)
+	keywordQuery.AddShould(phrase)
+
+	dialogs := make([]query.Query, 0, len(q.DialogIds))
+	for _, id := range q.DialogIds {
+		dialogs = append(dialogs, termQuery(searchFieldDialogID, strconv.FormatUint(uint64(id), 10)))
+	}
+
+	filters := []query.Query{keywordQuery, bleve.NewDisjunctionQuery(dialogs...)}
+	if q.SendID != "" {
+		filters = append(filters, termQuery(searchFieldSendID, q.SendID))
+	}
+	if q.StartAt > 0 || q.EndAt > 0 {
+		var min, max *float64
+		if q.StartAt > 0 {
+			v := float64(q.StartAt)
+			min = &v
+		}
+		if q.EndAt > 0 {
+			v := float64(q.EndAt)
+			max = &v
+		}
+		inclusive := true
+		rq := bleve.NewNumericRangeInclusiveQuery(min, max, &inclusive, &inclusive)
+		rq.SetField(searchFieldCreatedAt)
+		filters = append(filters, rq)
+	}
+
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	pageNum := q.PageNum
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(filters...), pageSize, (pageNum-1)*pageSize, false)
+	req.Fields = []string{searchFieldKind, searchFieldMsgID, searchFieldDialogID, searchFieldContent}
+	req.Highlight = bleve.NewHighlightWithStyle(html.Name)
+	req.Highlight.AddField(searchFieldContent)
+	req.SortBy([]string{"-_score", "-" + searchFieldCreatedAt})
+
+	res, err := r.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Total = int64(res.Total)
+	for _, hit := range res.Hits {
+		kind, _ := hit.Fields[searchFieldKind].(string)
+		msgID, _ := hit.Fields[searchFieldMsgID].(string)
+		dialogID, _ := hit.Fields[searchFieldDialogID].(string)
+		content, _ := hit.Fields[searchFieldContent].(string)
+		mid, _ := strconv.ParseUint(msgID, 10, 64)
+		did, _ := strconv.ParseUint(dialogID, 10, 64)
+		result.Hits = append(result.Hits, &entity.MessageSearchHit{
+			Kind:       entity.SearchMessageKind(kind),
+			MsgID:      uint(mid),
+			DialogID:   uint(did),
+			Content:    content,
+			Score:      hit.Score,
+			Highlights: hit.Fragments[searchFieldContent],
+		})
+	}
+	return result, nil
+}
+
+func (r *MessageSearchRepo) Close() error {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+
+	r.refs--
+	if r.refs > 0 {
+		return nil
+	}
+	delete(searchIndexes, r.path)
+	return r.index.Close()
+}
+
+func termQuery(field, term string) query.Query {
+	q := bleve.NewTermQuery(term)
+	q.SetField(field)
+	return q
+}
@@ -0,0 +1,312 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+)
+
+var _ repository.MessageSyncRepository = &MessageSyncRepo{}
+
+type MessageSyncRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageSyncRepo(db *gorm.DB) *MessageSyncRepo {
+	return &MessageSyncRepo{db: db}
+}
+
+func (m *MessageSyncRepo) AppendChanges(ctx context.Context, changes []*entity.MessageChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	byDialog := make(map[uint][]*entity.MessageChange)
+	dialogIDs := make([]uint, 0)
+	for _, c := range changes {
+		if _, ok := byDialog[c.DialogID]; !ok {
+			dialogIDs = append(dialogIDs, c.DialogID)
+		}
+		byDialog[c.DialogID] = append(byDialog[c.DialogID], c)
+	}
+	// 固定加锁顺序，避免多个对话同时分配时死锁
+	sort.Slice(dialogIDs, func(i, j int) bool { return dialogIDs[i] < dialogIDs[j] })
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		models := make([]*po.MessageChange, 0, len(changes))
+		for _, dialogID := range dialogIDs {
+			list := byDialog[dialogID]
+			n := uint64(len(list))
+			last, err := allocDialogSeq(tx, dialogID, syncSeqColumn, n)
+			if err != nil {
+				return err
+			}
+
+			first := last - n + 1
+			for i, c := range list {
+				c.SyncSeq = first + uint64(i)
+				models = append(models, converter.MessageChangeEntityToPO(c))
+			}
+		}
+		return tx.Create(&models).Error
+	})
+}
+
+func (m *MessageSyncRepo) GetDialogMsgIDs(ctx context.Context, kind entity.MessageKind, dialogID uint, deleted bool) ([]uint, error) {
+	var model interface{} = &po.UserMessage{}
+	if kind == entity.GroupMessageKind {
+		model = &po.GroupMessage{}
+	}
+	query := m.db.WithContext(ctx).Model(model).Where("dialog_id = ?", dialogID)
+	if deleted {
+		query = query.Where("deleted_at <> 0")
+	} else {
+		query = query.Where("deleted_at = 0")
+	}
+	var ids []uint
+	if err := query.Order("id ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (m *MessageSyncRepo) GetChanges(ctx context.Context, dialogID uint, afterSeq uint64, limit int) ([]*entity.MessageChange, error) {
+	var changes []*po.MessageChange
+	err := m.db.WithContext(ctx).Model(&po.MessageChange{}).
+		Where("dialog_id = ? AND sync_seq > ?", dialogID, afterSeq).
+		Order("sync_seq ASC").
+		Limit(limit).
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.MessageChangePOToEntityList(changes), nil
+}
+
+func (m *MessageSyncRepo) GetChangesAfterID(ctx context.Context, afterID uint, before int64, limit int) ([]*entity.MessageChange, error) {
+	var changes []*po.MessageChange
+	err := m.db.WithContext(ctx).Model(&po.MessageChange{}).
+		Where("id > ? AND created_at <= ?", afterID, before).
+		Order("id ASC").
+		Limit(limit).
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.MessageChangePOToEntityList(changes), nil
+}
+
+func (m *MessageSyncRepo) GetMaxSyncSeq(ctx context.Context, dialogID uint) (uint64, error) {
+	var seq uint64
+	err := m.db.WithContext(ctx).Model(&po.DialogSequence{}).
+		Select("sync_seq").
+		Where("dialog_id = ?", dialogID).
+		Scan(&seq).Error
+	return seq, err
+}
+
+func (m *MessageSyncRepo) GetUserMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) ([]*entity.UserMessage, error) {
+	db := m.db.WithContext(ctx).Model(&po.UserMessage{}).
+		Where("dialog_id = ? AND deleted_at = 0", query.DialogID)
+	if query.SendID != "" {
+		db = db.Where("send_id = ?", query.SendID)
+	}
+	if query.Content != "" {
+		db = db.Where("content LIKE ?", "%"+query.Content+"%")
+	}
+	if entity.IsValidMessageType(query.MsgType) {
+		db = db.Where("type = ?", query.MsgType)
+	}
+
+	var msgs []*po.UserMessage
+	if err := cursorScope(db, query).Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+	if !query.Before {
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+	}
+	return converter.UserMessagePOToEntityList(msgs), nil
+}
+
+func (m *MessageSyncRepo) GetGroupMsgsByCursor(ctx context.Context, query *entity.MessageCursorQuery) ([]*entity.GroupMessage, error) {
+	db := m.db.WithContext(ctx).Model(&po.GroupMessage{}).
+		Where("dialog_id = ? AND deleted_at = 0", query.DialogID)
+	if query.SendID != "" {
+		db = db.Where("user_id = ?", query.SendID)
+	}
+	if query.Content != "" {
+		db = db.Where("content LIKE ?", "%"+query.Content+"%")
+	}
+	if entity.IsValidMessageType(query.MsgType) {
+		db = db.Where("type = ?", query.MsgType)
+	}
+
+	var msgs []*po.GroupMessage
+	if err := cursorScope(db, query).Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+	if !query.Before {
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+	}
+	return converter.GroupMessagePOToEntityList(msgs), nil
+}
+
+func (m *MessageSyncRepo) GetMaxMsgSeq(ctx context.Context, dialogID uint) (uint64, error) {
+	var seq uint64
+	err := m.db.WithContext(ctx).Model(&po.DialogSequence{}).
+		Select(msgSeqColumn).
+		Where("dialog_id = ?", dialogID).
+		Scan(&seq).Error
+	return seq, err
+}
+
+func (m *MessageSyncRepo) GetUserMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.UserMessage, error) {
+	var msgs []*po.UserMessage
+	err := m.db.WithContext(ctx).Model(&po.UserMessage{}).
+		Where("dialog_id = ? AND seq BETWEEN ? AND ? AND deleted_at = 0", dialogID, startSeq, endSeq).
+		Order("seq ASC").
+		Find(&msgs).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.UserMessagePOToEntityList(msgs), nil
+}
+
+func (m *MessageSyncRepo) GetGroupMsgsBySeq(ctx context.Context, dialogID uint, startSeq, endSeq uint64) ([]*entity.GroupMessage, error) {
+	var msgs []*po.GroupMessage
+	err := m.db.WithContext(ctx).Model(&po.GroupMessage{}).
+		Where("dialog_id = ? AND seq BETWEEN ? AND ? AND deleted_at = 0", dialogID, startSeq, endSeq).
+		Order("seq ASC").
+		Find(&msgs).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.GroupMessagePOToEntityList(msgs), nil
+}
+
+const (
+	syncSeqColumn = "sync_seq"
+	msgSeqColumn  = "msg_seq"
+)
+
+// allocDialogSeq 在事务中为对话分配n个连续序号，返回分配到的最后一个序号
+// 更新时会锁住对话所在行，事务提交前其他实例的分配会等待，保证序号严格递增且不会重复
+func allocDialogSeq(tx *gorm.DB, dialogID uint, column string, n uint64) (uint64, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&po.DialogSequence{DialogID: dialogID}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&po.DialogSequence{}).
+		Where("dialog_id = ?", dialogID).
+		Update(column, gorm.Expr(column+" + ?", n)).Error; err != nil {
+		return 0, err
+	}
+
+	var last uint64
+	if err := tx.Model(&po.DialogSequence{}).
+		Select(column).
+		Where("dialog_id = ?", dialogID).
+		Scan(&last).Error; err != nil {
+		return 0, err
+	}
+	return last, nil
+}
+
+// migrateMsgSeq 为序号上线前写入的消息补充序号，并删除旧的非唯一索引
+func migrateMsgSeq(db *gorm.DB) error {
+	models := []struct {
+		model       interface{}
+		legacyIndex string
+	}{
+		{&po.UserMessage{}, "idx_user_msg_dialog_seq"},
+		{&po.GroupMessage{}, "idx_group_msg_dialog_seq"},
+	}
+	if err := db.AutoMigrate(&po.DialogSequence{}); err != nil {
+		return err
+	}
+	for _, m := range models {
+		migrator := db.Migrator()
+		// 新部署的数据库由AutoMigrate直接创建
+		if !migrator.HasTable(m.model) {
+			continue
+		}
+		if !migrator.HasColumn(m.model, "Seq") {
+			if err := migrator.AddColumn(m.model, "Seq"); err != nil {
+				return err
+			}
+		}
+		if migrator.HasIndex(m.model, m.legacyIndex) {
+			if err := migrator.DropIndex(m.model, m.legacyIndex); err != nil {
+				return err
+			}
+		}
+		if err := backfillMsgSeq(db, m.model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillMsgSeq 按id顺序为序号为0的消息分配序号，接在对话已分配的序号之后
+// 客户端已经拿到的序号不能改变，所以不对整个对话重新编号
+func backfillMsgSeq(db *gorm.DB, model interface{}) error {
+	var dialogIDs []uint
+	if err := db.Model(model).Where("seq = 0").Distinct("dialog_id").Pluck("dialog_id", &dialogIDs).Error; err != nil {
+		return err
+	}
+	for _, dialogID := range dialogIDs {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// 先锁住对话的序号，避免多个实例同时补充
+			if _, err := allocDialogSeq(tx, dialogID, msgSeqColumn, 0); err != nil {
+				return err
+			}
+			var ids []uint
+			if err := tx.Model(model).Where("dialog_id = ? AND seq = 0", dialogID).Order("id ASC").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+			last, err := allocDialogSeq(tx, dialogID, msgSeqColumn, uint64(len(ids)))
+			if err != nil {
+				return err
+			}
+			first := last - uint64(len(ids)) + 1
+			for i, id := range ids {
+				if err := tx.Model(model).Where("id = ?", id).UpdateColumn("seq", first+uint64(i)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cursorScope 向前翻页按id降序取游标之前的消息，向后翻页按id升序取游标之后的消息
+func cursorScope(db *gorm.DB, query *entity.MessageCursorQuery) *gorm.DB {
+	if query.Before {
+		if query.Cursor > 0 {
+			db = db.Where("id < ?", query.Cursor)
+		}
+		db = db.Order("id DESC")
+	} else {
+		if query.Cursor > 0 {
+			db = db.Where("id > ?", query.Cursor)
+		}
+		db = db.Order("id ASC")
+	}
+	return db.Limit(query.Limit)
+}
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestInsertMessageSeqConcurrent(t *testing.T) {
+	repos := newTestRepositories(t)
+	ctx := context.Background()
+
+	// 多个实例同时在同一个对话中发送消息，序号连续且不重复
+	const workers, perWorker = 4, 10
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				var err error
+				if j%2 == 0 {
+					_, err = repos.Umr.InsertUserMessage(&entity.UserMessage{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "hello"})
+				} else {
+					err = repos.Umr.InsertUserMessages([]*entity.UserMessage{
+						{DialogId: 1, SendID: "u1", ReceiveID: "u2", Content: "a"},
+						{DialogId: 2, SendID: "u1", ReceiveID: "u3", Content: "b"},
+					})
+				}
+				if err != nil {
+					t.Errorf("insert message: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assertDialogSeqs(t, repos, &po.UserMessage{}, 1, workers*perWorker)
+	assertDialogSeqs(t, repos, &po.UserMessage{}, 2, workers*perWorker/2)
+	if seq, err := repos.Mcr.GetMaxMsgSeq(ctx, 1); err != nil || seq != workers*perWorker {
+		t.Fatalf("expected max seq %d, got %d, %v", workers*perWorker, seq, err)
+	}
+}
+
+// assertDialogSeqs 对话内的消息序号从1开始连续
+func assertDialogSeqs(t *testing.T, repos *Repositories, model interface{}, dialogID uint, n int) {
+	t.Helper()
+	var seqs []uint64
+	if err := repos.db.Model(model).Where("dialog_id = ?", dialogID).Pluck("seq", &seqs).Error; err != nil {
+		t.Fatalf("pluck seq: %v", err)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	if len(seqs) != n {
+		t.Fatalf("expected %d messages in dialog %d, got %d", n, dialogID, len(seqs))
+	}
+	for i, seq := range seqs {
+		if seq != uint64(i+1) {
+			t.Fatalf("dialog %d: expected seq %d, got %v", dialogID, i+1, seqs)
+		}
+	}
+}
+
+func TestMigrateMsgSeq(t *testing.T) {
+	repos := newTestRepositories(t)
+	db := repos.db
+	ctx := context.Background()
+
+	// 模拟升级前的数据库：非唯一索引，部分消息没有序号
+	migrator := db.Migrator()
+	if err := migrator.DropIndex(&po.GroupMessage{}, "idx_group_msg_dialog_seq_unique"); err != nil {
+		t.Fatalf("drop index: %v", err)
+	}
+	if err := db.Exec("CREATE INDEX idx_group_msg_dialog_seq ON group_messages (dialog_id, seq)").Error; err != nil {
+		t.Fatalf("create legacy index: %v", err)
+	}
+	var legacy []uint
+	for i := 0; i < 2; i++ {
+		m := &po.GroupMessage{DialogId: 1, GroupID: 1, UserID: "u1", Content: "legacy"}
+		if err := db.Create(m).Error; err != nil {
+			t.Fatalf("create legacy message: %v", err)
+		}
+		legacy = append(legacy, m.ID)
+	}
+	// 序号上线后已经分配了序号的消息
+	msg, err := repos.Gmr.InsertGroupMessage(&entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: "u1", Content: "new"})
+	if err != nil {
+		t.Fatalf("InsertGroupMessage: %v", err)
+	}
+	if err := db.Create(&po.GroupMessage{DialogId: 2, GroupID: 2, UserID: "u1", Content: "legacy"}).Error; err != nil {
+		t.Fatalf("create legacy message: %v", err)
+	}
+
+	if err := repos.Automigrate(); err != nil {
+		t.Fatalf("Automigrate: %v", err)
+	}
+	if migrator.HasIndex(&po.GroupMessage{}, "idx_group_msg_dialog_seq") {
+		t.Fatal("legacy index should be dropped")
+	}
+	if !migrator.HasIndex(&po.GroupMessage{}, "idx_group_msg_dialog_seq_unique") {
+		t.Fatal("unique index should be created")
+	}
+
+	// 已分配的序号不变，没有序号的消息按id顺序排在后面
+	want := map[uint]uint64{msg.ID: 1, legacy[0]: 2, legacy[1]: 3}
+	for id, seq := range want {
+		var got []uint64
+		if err := db.Model(&po.GroupMessage{}).Where("id = ?", id).Pluck("seq", &got).Error; err != nil || len(got) != 1 || got[0] != seq {
+			t.Fatalf("message %d: expected seq %d, got %v, %v", id, seq, got, err)
+		}
+	}
+	assertDialogSeqs(t, repos, &po.GroupMessage{}, 2, 1)
+	if seq, err := repos.Mcr.GetMaxMsgSeq(ctx, 1); err != nil || seq != 3 {
+		t.Fatalf("expected max seq 3, got %d, %v", seq, err)
+	}
+
+	// 新消息接着补充后的序号分配
+	if msg, err = repos.Gmr.InsertGroupMessage(&entity.GroupMessage{DialogID: 1, GroupID: 1, UserID: "u1", Content: "next"}); err != nil || msg.Seq != 4 {
+		t.Fatalf("expected seq 4, got %+v, %v", msg, err)
+	}
+	// 唯一索引拒绝重复的序号
+	if err := db.Create(&po.GroupMessage{DialogId: 1, Seq: 4, GroupID: 1, UserID: "u1"}).Error; err == nil {
+		t.Fatal("expected duplicate seq to be rejected")
+	}
+}
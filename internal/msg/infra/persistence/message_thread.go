@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"github.com/cossim/coss-server/pkg/utils/time"
+	"gorm.io/gorm"
+)
+
+var _ repository.MessageThreadReadRepository = &MessageThreadReadRepo{}
+
+type MessageThreadReadRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageThreadReadRepo(db *gorm.DB) *MessageThreadReadRepo {
+	return &MessageThreadReadRepo{db: db}
+}
+
+func (m *MessageThreadReadRepo) GetThreadReads(ctx context.Context, kind entity.MessageKind, userID string, threadIDs []uint) ([]*entity.MessageThreadRead, error) {
+	if len(threadIDs) == 0 {
+		return nil, nil
+	}
+	var reads []*po.MessageThreadRead
+	err := m.db.WithContext(ctx).Model(&po.MessageThreadRead{}).
+		Where("kind = ? AND user_id = ? AND thread_id IN (?)", uint(kind), userID, threadIDs).
+		Find(&reads).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.MessageThreadReadPOToEntityList(reads), nil
+}
+
+func (m *MessageThreadReadRepo) SetThreadRead(ctx context.Context, read *entity.MessageThreadRead) error {
+	model := converter.MessageThreadReadEntityToPO(read)
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&po.MessageThreadRead{Kind: model.Kind, ThreadID: model.ThreadID, UserID: model.UserID}).
+			Attrs(&po.MessageThreadRead{DialogID: model.DialogID}).
+			FirstOrCreate(&po.MessageThreadRead{}).Error; err != nil {
+			return err
+		}
+		// 多端同时上报时只保留最新的阅读进度
+		return tx.Model(&po.MessageThreadRead{}).
+			Where("kind = ? AND thread_id = ? AND user_id = ? AND last_read_msg_id < ?", model.Kind, model.ThreadID, model.UserID, model.LastReadMsgID).
+			Updates(map[string]interface{}{
+				"last_read_msg_id": model.LastReadMsgID,
+				"updated_at":       time.Now(),
+			}).Error
+	})
+}
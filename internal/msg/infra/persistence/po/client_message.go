@@ -0,0 +1,16 @@
+package po
+
+type ClientMessage struct {
+	BaseModel
+	SenderID    string `gorm:"type:varchar(64);uniqueIndex:idx_sender_client_msg,priority:1;comment:发送者ID" json:"sender_id"`
+	ClientMsgID string `gorm:"type:varchar(64);uniqueIndex:idx_sender_client_msg,priority:2;comment:客户端消息ID" json:"client_msg_id"`
+	DialogID    uint   `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	MsgID       uint   `gorm:"default:0;comment:消息ID，0表示发送中" json:"msg_id"`
+	Seq         uint64 `gorm:"default:0;comment:对话内消息序号" json:"seq"`
+	IsGroup     bool   `gorm:"default:false;comment:是否群聊消息" json:"is_group"`
+	Token       string `gorm:"type:varchar(64);comment:当前发送者的标识" json:"token"`
+}
+
+func (bm *ClientMessage) TableName() string {
+	return "client_messages"
+}
@@ -7,11 +7,14 @@ import (
 
 type GroupMessage struct {
 	BaseModel
-	DialogId           uint     `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	DialogId           uint     `gorm:"default:0;uniqueIndex:idx_group_msg_dialog_seq_unique,priority:1;comment:对话ID" json:"dialog_id"`
+	Seq                uint64   `gorm:"default:0;uniqueIndex:idx_group_msg_dialog_seq_unique,priority:2;comment:对话内消息序号" json:"seq"`
 	GroupID            uint     `gorm:"comment:群聊id" json:"group_id"`
 	Type               uint     `gorm:"comment:消息类型" json:"type"`
 	ReplyId            uint     `gorm:"default:0;comment:回复ID" json:"reply_id"`
+	ThreadId           uint     `gorm:"default:0;index;comment:话题根消息ID" json:"thread_id"`
 	ReadCount          int      `gorm:"default:0;comment:已读数量" json:"read_count"`
+	DeliveredCount     int      `gorm:"default:0;comment:送达数量" json:"delivered_count"`
 	UserID             string   `gorm:"comment:用户ID" json:"user_id"`
 	Content            string   `gorm:"longtext;comment:详细消息" json:"content"`
 	IsLabel            uint     `gorm:"default:0;comment:是否标注" json:"is_label"`
@@ -19,6 +22,8 @@ type GroupMessage struct {
 	AtAllUser          uint     `gorm:"default:0;comment:是否at全体用户" json:"at_all_users"`
 	AtUsers            []string `gorm:"serializer:json;comment:at的用户" json:"at_users"`
 	IsBurnAfterReading bool     `gorm:"default:0;comment:是否阅后即焚消息" json:"is_burn_after_reading"`
+	ExpireAt           int64    `gorm:"default:0;index;comment:阅后即焚过期时间" json:"expire_at"`
+	EditedAt           int64    `gorm:"default:0;comment:最后编辑时间" json:"edited_at"`
 }
 
 type BaseModel struct {
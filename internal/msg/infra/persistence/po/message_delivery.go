@@ -0,0 +1,14 @@
+package po
+
+type GroupMessageDelivery struct {
+	BaseModel
+	MsgID       uint   `gorm:"uniqueIndex:idx_msg_delivery,priority:1;comment:消息ID" json:"msg_id"`
+	DialogID    uint   `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	GroupID     uint   `gorm:"default:0;comment:群聊ID" json:"group_id"`
+	UserID      string `gorm:"type:varchar(64);uniqueIndex:idx_msg_delivery,priority:2;comment:送达用户ID" json:"user_id"`
+	DeliveredAt int64  `gorm:"comment:送达时间" json:"delivered_at"`
+}
+
+func (bm *GroupMessageDelivery) TableName() string {
+	return "group_message_deliveries"
+}
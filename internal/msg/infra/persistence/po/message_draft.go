@@ -0,0 +1,13 @@
+package po
+
+type MessageDraft struct {
+	BaseModel
+	UserID   string `gorm:"type:varchar(64);uniqueIndex:idx_user_dialog_draft,priority:1;comment:用户ID" json:"user_id"`
+	DialogID uint   `gorm:"uniqueIndex:idx_user_dialog_draft,priority:2;comment:对话ID" json:"dialog_id"`
+	Content  string `gorm:"type:longtext;comment:草稿内容" json:"content"`
+	ReplyId  uint   `gorm:"default:0;comment:回复ID" json:"reply_id"`
+}
+
+func (bm *MessageDraft) TableName() string {
+	return "message_drafts"
+}
@@ -0,0 +1,14 @@
+package po
+
+type MessageReaction struct {
+	BaseModel
+	Kind     uint   `gorm:"default:0;uniqueIndex:idx_msg_reaction,priority:1;comment:消息类型 0私聊 1群聊" json:"kind"`
+	MsgID    uint   `gorm:"uniqueIndex:idx_msg_reaction,priority:2;comment:消息ID" json:"msg_id"`
+	DialogID uint   `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	UserID   string `gorm:"type:varchar(64);uniqueIndex:idx_msg_reaction,priority:3;comment:用户ID" json:"user_id"`
+	Emoji    string `gorm:"type:varchar(64);uniqueIndex:idx_msg_reaction,priority:4;comment:表情" json:"emoji"`
+}
+
+func (bm *MessageReaction) TableName() string {
+	return "message_reactions"
+}
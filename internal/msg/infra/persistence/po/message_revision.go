@@ -0,0 +1,29 @@
+package po
+
+type MessageRevision struct {
+	BaseModel
+	Kind     uint   `gorm:"index:idx_kind_msg_revision,priority:1;comment:消息类型 (0=私聊 1=群聊)" json:"kind"`
+	MsgID    uint   `gorm:"index:idx_kind_msg_revision,priority:2;comment:消息ID" json:"msg_id"`
+	DialogID uint   `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	GroupID  uint   `gorm:"default:0;comment:群聊ID" json:"group_id"`
+	SenderID string `gorm:"type:varchar(64);comment:消息发送者ID" json:"sender_id"`
+	EditorID string `gorm:"type:varchar(64);comment:修改者ID" json:"editor_id"`
+	Action   uint   `gorm:"default:0;comment:操作类型 (0=编辑 1=撤回)" json:"action"`
+	Content  string `gorm:"type:longtext;comment:修改前的内容" json:"content"`
+	EditedAt int64  `gorm:"comment:修改时间" json:"edited_at"`
+}
+
+func (bm *MessageRevision) TableName() string {
+	return "message_revisions"
+}
+
+type GroupRevisionPolicy struct {
+	BaseModel
+	GroupID    uint   `gorm:"uniqueIndex;comment:群聊ID" json:"group_id"`
+	Visibility uint   `gorm:"default:0;comment:修订记录可见范围 (0=群主和管理员 1=仅群主 2=仅发送者)" json:"visibility"`
+	UpdatedBy  string `gorm:"type:varchar(64);comment:设置者ID" json:"updated_by"`
+}
+
+func (bm *GroupRevisionPolicy) TableName() string {
+	return "group_revision_policies"
+}
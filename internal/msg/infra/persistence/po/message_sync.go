@@ -0,0 +1,25 @@
+package po
+
+// DialogSequence 对话序号分配表，每个对话一行，分配时锁住该行保证多实例下序号严格递增
+type DialogSequence struct {
+	DialogID uint   `gorm:"primaryKey;autoIncrement:false;comment:对话ID" json:"dialog_id"`
+	SyncSeq  uint64 `gorm:"default:0;comment:已分配的最大变更序号" json:"sync_seq"`
+	MsgSeq   uint64 `gorm:"default:0;comment:已分配的最大消息序号" json:"msg_seq"`
+}
+
+func (bm *DialogSequence) TableName() string {
+	return "dialog_sequences"
+}
+
+type MessageChange struct {
+	BaseModel
+	DialogID uint   `gorm:"uniqueIndex:idx_dialog_sync_seq,priority:1;comment:对话ID" json:"dialog_id"`
+	SyncSeq  uint64 `gorm:"uniqueIndex:idx_dialog_sync_seq,priority:2;comment:变更序号" json:"sync_seq"`
+	Kind     uint   `gorm:"default:0;comment:消息类型 0私聊 1群聊" json:"kind"`
+	MsgID    uint   `gorm:"comment:消息ID" json:"msg_id"`
+	Action   uint   `gorm:"default:0;comment:变更类型 0新消息 1修改 2删除" json:"action"`
+}
+
+func (bm *MessageChange) TableName() string {
+	return "message_changes"
+}
@@ -0,0 +1,14 @@
+package po
+
+type MessageThreadRead struct {
+	BaseModel
+	Kind          uint   `gorm:"default:0;uniqueIndex:idx_thread_read,priority:1;comment:消息类型 0私聊 1群聊" json:"kind"`
+	ThreadID      uint   `gorm:"uniqueIndex:idx_thread_read,priority:2;comment:话题根消息ID" json:"thread_id"`
+	DialogID      uint   `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	UserID        string `gorm:"type:varchar(64);uniqueIndex:idx_thread_read,priority:3;comment:用户ID" json:"user_id"`
+	LastReadMsgID uint   `gorm:"default:0;comment:最后已读的回复ID" json:"last_read_msg_id"`
+}
+
+func (bm *MessageThreadRead) TableName() string {
+	return "message_thread_reads"
+}
@@ -0,0 +1,27 @@
+package po
+
+type ScheduledMessage struct {
+	BaseModel
+	UserID             string   `gorm:"type:varchar(64);index:idx_scheduled_user;comment:创建用户ID" json:"user_id"`
+	DriverID           string   `gorm:"type:varchar(64);comment:创建时的设备ID" json:"driver_id"`
+	Kind               uint     `gorm:"default:0;comment:消息类型 0私聊 1群聊" json:"kind"`
+	DialogID           uint     `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	ReceiverID         string   `gorm:"type:varchar(64);comment:接收用户ID" json:"receiver_id"`
+	GroupID            uint     `gorm:"default:0;comment:群聊ID" json:"group_id"`
+	Type               uint     `gorm:"comment:消息类型" json:"type"`
+	Content            string   `gorm:"longtext;comment:详细消息" json:"content"`
+	ReplyId            uint     `gorm:"default:0;comment:回复ID" json:"reply_id"`
+	AtUsers            []string `gorm:"serializer:json;comment:at的用户" json:"at_users"`
+	AtAllUser          bool     `gorm:"default:0;comment:是否at全体用户" json:"at_all_user"`
+	IsBurnAfterReading bool     `gorm:"default:0;comment:是否阅后即焚消息" json:"is_burn_after_reading"`
+	SendAt             int64    `gorm:"index:idx_scheduled_dispatch,priority:2;comment:计划发送时间" json:"send_at"`
+	Status             uint     `gorm:"default:0;index:idx_scheduled_dispatch,priority:1;comment:状态 0等待 1发送中 2已发送 3已取消 4失败" json:"status"`
+	MsgID              uint     `gorm:"default:0;comment:发送后生成的消息ID" json:"msg_id"`
+	FailReason         string   `gorm:"type:varchar(255);comment:失败原因" json:"fail_reason"`
+	LockedBy           string   `gorm:"type:varchar(64);comment:领取任务的实例" json:"locked_by"`
+	LockedUntil        int64    `gorm:"default:0;comment:领取过期时间" json:"locked_until"`
+}
+
+func (bm *ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
@@ -3,15 +3,20 @@ package po
 type UserMessage struct {
 	BaseModel
 	Type               uint   `gorm:";comment:消息类型" json:"type"`
-	DialogId           uint   `gorm:"default:0;comment:对话ID" json:"dialog_id"`
+	DialogId           uint   `gorm:"default:0;uniqueIndex:idx_user_msg_dialog_seq_unique,priority:1;comment:对话ID" json:"dialog_id"`
+	Seq                uint64 `gorm:"default:0;uniqueIndex:idx_user_msg_dialog_seq_unique,priority:2;comment:对话内消息序号" json:"seq"`
 	IsRead             uint   `gorm:"default:0;comment:是否已读" json:"is_read"`
 	ReplyId            uint   `gorm:"default:0;comment:回复ID" json:"reply_id"`
+	ThreadId           uint   `gorm:"default:0;index;comment:话题根消息ID" json:"thread_id"`
 	ReadAt             int64  `gorm:"comment:阅读时间" json:"read_at"`
 	ReceiveID          string `gorm:"default:0;comment:接收用户id" json:"receive_id"`
 	SendID             string `gorm:"default:0;comment:发送用户id" json:"send_id"`
 	Content            string `gorm:"longtext;comment:详细消息" json:"content"`
 	IsLabel            bool   `gorm:"default:0;comment:是否标注" json:"is_label"`
 	IsBurnAfterReading bool   `gorm:"default:0;comment:是否阅后即焚消息" json:"is_burn_after_reading"`
+	ExpireAt           int64  `gorm:"default:0;index;comment:阅后即焚过期时间" json:"expire_at"`
+	DeliveredAt        int64  `gorm:"default:0;comment:送达时间" json:"delivered_at"`
+	EditedAt           int64  `gorm:"default:0;comment:最后编辑时间" json:"edited_at"`
 	ReplyEmoji         string `gorm:"comment:回复时使用的 Emoji" json:"reply_emoji"`
 }
 
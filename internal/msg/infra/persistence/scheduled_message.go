@@ -0,0 +1,168 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"github.com/cossim/coss-server/internal/msg/domain/repository"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/converter"
+	"github.com/cossim/coss-server/internal/msg/infra/persistence/po"
+	"github.com/cossim/coss-server/pkg/utils/time"
+	"gorm.io/gorm"
+)
+
+var _ repository.ScheduledMessageRepository = &ScheduledMessageRepo{}
+
+type ScheduledMessageRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduledMessageRepo(db *gorm.DB) *ScheduledMessageRepo {
+	return &ScheduledMessageRepo{db: db}
+}
+
+func (s *ScheduledMessageRepo) CreateScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) error {
+	model := converter.ScheduledMessageEntityToPO(msg)
+	if err := s.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	*msg = *converter.ScheduledMessagePOToEntity(model)
+	return nil
+}
+
+func (s *ScheduledMessageRepo) GetScheduledMessage(ctx context.Context, id uint) (*entity.ScheduledMessage, error) {
+	model := &po.ScheduledMessage{}
+	if err := s.db.WithContext(ctx).Where("id = ? AND deleted_at = 0", id).First(model).Error; err != nil {
+		return nil, err
+	}
+	return converter.ScheduledMessagePOToEntity(model), nil
+}
+
+func (s *ScheduledMessageRepo) ListScheduledMessages(ctx context.Context, query *entity.ScheduledMessageQuery) ([]*entity.ScheduledMessage, int64, error) {
+	var (
+		list  []*po.ScheduledMessage
+		total int64
+	)
+
+	where := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+		Where("user_id = ? AND deleted_at = 0", query.UserID)
+	if query.DialogID != 0 {
+		where = where.Where("dialog_id = ?", query.DialogID)
+	}
+	if query.Status != nil {
+		where = where.Where("status = ?", uint(*query.Status))
+	}
+
+	if err := where.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	find := where.Session(&gorm.Session{}).Order("send_at ASC")
+	if query.PageSize > 0 {
+		offset := 0
+		if query.PageNum > 0 {
+			offset = (query.PageNum - 1) * query.PageSize
+		}
+		find = find.Offset(offset).Limit(query.PageSize)
+	}
+	if err := find.Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return converter.ScheduledMessagePOToEntityList(list), total, nil
+}
+
+func (s *ScheduledMessageRepo) UpdatePendingScheduledMessage(ctx context.Context, msg *entity.ScheduledMessage) (bool, error) {
+	model := converter.ScheduledMessageEntityToPO(msg)
+	result := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+		Where("id = ? AND user_id = ? AND status = ? AND deleted_at = 0", model.ID, model.UserID, uint(entity.ScheduledMessagePending)).
+		Select("type", "content", "reply_id", "at_users", "at_all_user", "is_burn_after_reading", "send_at", "updated_at").
+		Updates(&po.ScheduledMessage{
+			BaseModel:          po.BaseModel{UpdatedAt: time.Now()},
+			Type:               model.Type,
+			Content:            model.Content,
+			ReplyId:            model.ReplyId,
+			AtUsers:            model.AtUsers,
+			AtAllUser:          model.AtAllUser,
+			IsBurnAfterReading: model.IsBurnAfterReading,
+			SendAt:             model.SendAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (s *ScheduledMessageRepo) CancelScheduledMessage(ctx context.Context, id uint, userID string) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+		Where("id = ? AND user_id = ? AND status = ? AND deleted_at = 0", id, userID, uint(entity.ScheduledMessagePending)).
+		Updates(map[string]interface{}{
+			"status":     uint(entity.ScheduledMessageCanceled),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (s *ScheduledMessageRepo) ClaimDueScheduledMessages(ctx context.Context, owner string, now, lockUntil int64, limit int) ([]*entity.ScheduledMessage, error) {
+	var candidates []*po.ScheduledMessage
+	if err := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+		Where("status = ? AND send_at <= ? AND deleted_at = 0", uint(entity.ScheduledMessagePending), now).
+		Order("send_at ASC").
+		Limit(limit).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*po.ScheduledMessage, 0, len(candidates))
+	for _, c := range candidates {
+		// 通过带状态条件的更新抢占，多个实例同时领取时只有一个会成功
+		result := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+			Where("id = ? AND status = ?", c.ID, uint(entity.ScheduledMessagePending)).
+			Updates(map[string]interface{}{
+				"status":       uint(entity.ScheduledMessageSending),
+				"locked_by":    owner,
+				"locked_until": lockUntil,
+				"updated_at":   time.Now(),
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			c.Status = uint(entity.ScheduledMessageSending)
+			c.LockedBy = owner
+			c.LockedUntil = lockUntil
+			claimed = append(claimed, c)
+		}
+	}
+
+	return converter.ScheduledMessagePOToEntityList(claimed), nil
+}
+
+func (s *ScheduledMessageRepo) FailExpiredScheduledMessages(ctx context.Context, now int64, reason string) (int64, error) {
+	result := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+		Where("status = ? AND locked_until < ?", uint(entity.ScheduledMessageSending), now).
+		Updates(map[string]interface{}{
+			"status":      uint(entity.ScheduledMessageFailed),
+			"fail_reason": reason,
+			"updated_at":  time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (s *ScheduledMessageRepo) FinishScheduledMessage(ctx context.Context, id uint, owner string, status entity.ScheduledMessageStatus, msgID uint, reason string) (bool, error) {
+	// 失败状态只会由领取超时产生，领取者仍是自己时以实际发送结果为准
+	result := s.db.WithContext(ctx).Model(&po.ScheduledMessage{}).
+		Where("id = ? AND locked_by = ? AND status IN (?)", id, owner, []uint{uint(entity.ScheduledMessageSending), uint(entity.ScheduledMessageFailed)}).
+		Updates(map[string]interface{}{
+			"status":      uint(status),
+			"msg_id":      msgID,
+			"fail_reason": reason,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
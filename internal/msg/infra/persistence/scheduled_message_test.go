@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/msg/domain/entity"
+	"sync"
+	"testing"
+)
+
+func createScheduledMessages(t *testing.T, repo *ScheduledMessageRepo, sendAt ...int64) {
+	t.Helper()
+	for _, at := range sendAt {
+		if err := repo.CreateScheduledMessage(context.Background(), &entity.ScheduledMessage{
+			UserID:   "u1",
+			DialogID: 1,
+			Content:  "hello",
+			SendAt:   at,
+		}); err != nil {
+			t.Fatalf("CreateScheduledMessage: %v", err)
+		}
+	}
+}
+
+func TestClaimDueScheduledMessages(t *testing.T) {
+	repo := newTestRepositories(t).Smr.(*ScheduledMessageRepo)
+	ctx := context.Background()
+	createScheduledMessages(t, repo, 100, 200, 300, 5000)
+
+	// 多个实例同时领取，每条消息只会被领取一次，未到期的消息不会被领取
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claimed := make(map[uint]string)
+	for _, owner := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(owner string) {
+			defer wg.Done()
+			msgs, err := repo.ClaimDueScheduledMessages(ctx, owner, 1000, 2000, 10)
+			if err != nil {
+				t.Errorf("ClaimDueScheduledMessages: %v", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, m := range msgs {
+				if prev, ok := claimed[m.ID]; ok {
+					t.Errorf("message %d claimed by %s and %s", m.ID, prev, owner)
+				}
+				claimed[m.ID] = owner
+			}
+		}(owner)
+	}
+	wg.Wait()
+	if len(claimed) != 3 {
+		t.Fatalf("expected 3 claimed messages, got %d", len(claimed))
+	}
+
+	// 按数量分批领取
+	createScheduledMessages(t, repo, 400, 500)
+	msgs, err := repo.ClaimDueScheduledMessages(ctx, "a", 1000, 2000, 1)
+	if err != nil || len(msgs) != 1 || msgs[0].SendAt != 400 {
+		t.Fatalf("expected earliest due message, got %v, %v", msgs, err)
+	}
+}
+
+func TestFailExpiredScheduledMessages(t *testing.T) {
+	repo := newTestRepositories(t).Smr.(*ScheduledMessageRepo)
+	ctx := context.Background()
+	createScheduledMessages(t, repo, 100, 200)
+
+	if _, err := repo.ClaimDueScheduledMessages(ctx, "a", 150, 1000, 10); err != nil {
+		t.Fatalf("ClaimDueScheduledMessages: %v", err)
+	}
+	if _, err := repo.ClaimDueScheduledMessages(ctx, "b", 250, 3000, 10); err != nil {
+		t.Fatalf("ClaimDueScheduledMessages: %v", err)
+	}
+
+	// 只有领取已过期的消息会被标记为失败
+	n, err := repo.FailExpiredScheduledMessages(ctx, 2000, "timeout")
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 expired message, got %d, %v", n, err)
+	}
+	first, _ := repo.GetScheduledMessage(ctx, 1)
+	second, _ := repo.GetScheduledMessage(ctx, 2)
+	if first.Status != entity.ScheduledMessageFailed || first.FailReason != "timeout" {
+		t.Fatalf("expired message should fail, got %+v", first)
+	}
+	if second.Status != entity.ScheduledMessageSending {
+		t.Fatalf("message within claim should keep sending, got %+v", second)
+	}
+}
+
+func TestFinishScheduledMessageAfterExpiry(t *testing.T) {
+	repo := newTestRepositories(t).Smr.(*ScheduledMessageRepo)
+	ctx := context.Background()
+	createScheduledMessages(t, repo, 100)
+
+	msgs, err := repo.ClaimDueScheduledMessages(ctx, "a", 150, 1000, 10)
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("ClaimDueScheduledMessages: %v, %v", msgs, err)
+	}
+	id := msgs[0].ID
+
+	// 发送期间领取过期，发送完成后仍然记录实际结果
+	if _, err := repo.FailExpiredScheduledMessages(ctx, 2000, "timeout"); err != nil {
+		t.Fatalf("FailExpiredScheduledMessages: %v", err)
+	}
+	ok, err := repo.FinishScheduledMessage(ctx, id, "a", entity.ScheduledMessageSent, 42, "")
+	if err != nil || !ok {
+		t.Fatalf("finish after expiry should update, got %v, %v", ok, err)
+	}
+	msg, _ := repo.GetScheduledMessage(ctx, id)
+	if msg.Status != entity.ScheduledMessageSent || msg.MsgID != 42 || msg.FailReason != "" {
+		t.Fatalf("unexpected message after finish: %+v", msg)
+	}
+
+	// 不是领取者不能更新结果，已完成的消息也不会再被更新
+	if ok, _ := repo.FinishScheduledMessage(ctx, id, "b", entity.ScheduledMessageFailed, 0, "x"); ok {
+		t.Fatal("other owner should not finish the message")
+	}
+	if ok, _ := repo.FinishScheduledMessage(ctx, id, "a", entity.ScheduledMessageFailed, 0, "x"); ok {
+		t.Fatal("sent message should not be finished again")
+	}
+}
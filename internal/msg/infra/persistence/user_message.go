@@ -10,6 +10,7 @@ import (
 	"github.com/cossim/coss-server/pkg/code"
 	"github.com/cossim/coss-server/pkg/utils/time"
 	"gorm.io/gorm"
+	"sort"
 )
 
 var _ repository.UserMessageRepository = &UserMsgRepo{}
@@ -123,7 +124,15 @@ func (g *UserMsgRepo) ReadAllUserMsg(uid string, dialogId uint) error {
 func (g *UserMsgRepo) InsertUserMessage(message *entity.UserMessage) (*entity.UserMessage, error) {
 	um := converter.UserMessageEntityToPO(message)
 
-	if err := g.db.Create(um).Error; err != nil {
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		seq, err := allocDialogSeq(tx, um.DialogId, msgSeqColumn, 1)
+		if err != nil {
+			return err
+		}
+		um.Seq = seq
+		return tx.Create(um).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	entityUser := converter.UserMessagePOToEntity(um)
@@ -305,13 +314,60 @@ func (g *UserMsgRepo) GetUserMsgByIDs(msgIds []uint) ([]*entity.UserMessage, err
 	return resp, err
 }
 
+func (g *UserMsgRepo) GetUserMsgsAfterID(msgId uint, limit int) ([]*entity.UserMessage, error) {
+	var userMessages []*po.UserMessage
+	err := g.db.Model(&po.UserMessage{}).
+		Where("id > ? AND deleted_at = 0", msgId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&userMessages).Error
+	if err != nil {
+		return nil, err
+	}
+	return converter.UserMessagePOToEntityList(userMessages), nil
+}
+
 func (g *UserMsgRepo) UpdateUserMsgColumnByDialogId(dialogId uint, column string, value interface{}) error {
 	return g.db.Model(&po.UserMessage{}).Where("dialog_id = ?", dialogId).Update(column, value).Error
 }
 
 func (g *UserMsgRepo) InsertUserMessages(message []*entity.UserMessage) error {
 	msg := converter.UserMessageEntityToPOList(message)
-	return g.db.Create(&msg).Error
+
+	byDialog := make(map[uint][]*po.UserMessage)
+	dialogIDs := make([]uint, 0)
+	for _, m := range msg {
+		if _, ok := byDialog[m.DialogId]; !ok {
+			dialogIDs = append(dialogIDs, m.DialogId)
+		}
+		byDialog[m.DialogId] = append(byDialog[m.DialogId], m)
+	}
+	// 固定加锁顺序，避免同时给多个对话分配序号时死锁
+	sort.Slice(dialogIDs, func(i, j int) bool { return dialogIDs[i] < dialogIDs[j] })
+
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		for _, dialogID := range dialogIDs {
+			list := byDialog[dialogID]
+			last, err := allocDialogSeq(tx, dialogID, msgSeqColumn, uint64(len(list)))
+			if err != nil {
+				return err
+			}
+			first := last - uint64(len(list)) + 1
+			for i, m := range list {
+				m.Seq = first + uint64(i)
+			}
+		}
+		return tx.Create(&msg).Error
+	})
+	if err != nil {
+		return err
+	}
+	for i := range msg {
+		message[i].ID = msg[i].ID
+		message[i].Seq = msg[i].Seq
+		message[i].CreatedAt = msg[i].CreatedAt
+	}
+	return nil
 }
 
 func (g *UserMsgRepo) DeleteUserMessagesByDialogID(dialogId uint) error {
@@ -376,3 +432,72 @@ func (g *UserMsgRepo) GetUserMsgIdBeforeMsgList(dialogId uint, msgId uint, pageS
 	resp := converter.UserMessagePOToEntityList(userMessages)
 	return resp, int32(total), err
 }
+
+func (g *UserMsgRepo) GetUserThreadMsgs(threadId uint, pageNumber, pageSize int) ([]*entity.UserMessage, int64, error) {
+	var msgs []*po.UserMessage
+	var total int64
+	err := g.db.Model(&po.UserMessage{}).
+		Where("thread_id = ? AND deleted_at = 0", threadId).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = g.db.Model(&po.UserMessage{}).
+		Where("thread_id = ? AND deleted_at = 0", threadId).
+		Order("id ASC").
+		Limit(pageSize).
+		Offset(pageSize * (pageNumber - 1)).
+		Find(&msgs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return converter.UserMessagePOToEntityList(msgs), total, nil
+}
+
+func (g *UserMsgRepo) GetUserThreadStats(threadIds []uint) ([]*entity.ThreadStat, error) {
+	stats := make([]*entity.ThreadStat, 0)
+	if len(threadIds) == 0 {
+		return stats, nil
+	}
+	err := g.db.Model(&po.UserMessage{}).
+		Select("thread_id, COUNT(*) AS reply_count, MAX(id) AS last_reply_id").
+		Where("thread_id IN (?) AND deleted_at = 0", threadIds).
+		Group("thread_id").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (g *UserMsgRepo) CountUserThreadUnread(userID string, lastRead map[uint]uint) (map[uint]int64, error) {
+	result := make(map[uint]int64)
+	if len(lastRead) == 0 {
+		return result, nil
+	}
+
+	var cond *gorm.DB
+	for threadId, msgId := range lastRead {
+		if cond == nil {
+			cond = g.db.Where("thread_id = ? AND id > ?", threadId, msgId)
+			continue
+		}
+		cond = cond.Or("thread_id = ? AND id > ?", threadId, msgId)
+	}
+
+	var rows []struct {
+		ThreadID uint
+		Count    int64
+	}
+	err := g.db.Model(&po.UserMessage{}).
+		Select("thread_id, COUNT(*) AS count").
+		Where(cond).
+		Where("send_id <> ? AND deleted_at = 0", userID).
+		Group("thread_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.ThreadID] = row.Count
+	}
+	return result, nil
+}
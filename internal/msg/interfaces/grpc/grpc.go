@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	api "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	appservice "github.com/cossim/coss-server/internal/msg/app/service/msg"
 	"github.com/cossim/coss-server/internal/msg/domain/service"
 	"github.com/cossim/coss-server/internal/msg/infra/persistence"
 	pkgconfig "github.com/cossim/coss-server/pkg/config"
@@ -23,6 +24,15 @@ type Handler struct {
 	gmd  service.GroupMsgDomain
 	umd  service.UserMsgDomain
 	gmrd service.GroupMsgReadDomain
+	mdd  service.MessageDeliveryDomain
+	repo *persistence.Repositories
+	// 由http服务初始化后设置，长连接发送消息需要走完整的发送流程
+	svc appservice.Service
+}
+
+// SetAppService 设置消息应用服务
+func (s *Handler) SetAppService(svc appservice.Service) {
+	s.svc = svc
 }
 
 func (s *Handler) Init(cfg *pkgconfig.AppConfig) error {
@@ -37,13 +47,18 @@ func (s *Handler) Init(cfg *pkgconfig.AppConfig) error {
 	}
 
 	repo := persistence.NewRepositories(dbConn)
+	if err := repo.OpenSearchIndex(cfg.Search.IndexPath); err != nil {
+		return err
+	}
 
 	s.umd = service.NewUserMsgDomain(dbConn, cfg, repo)
 	s.gmd = service.NewGroupMsgDomain(dbConn, cfg, repo)
 	s.gmrd = service.NewGroupMsgReadDomain(dbConn, cfg, repo)
+	s.mdd = service.NewMessageDeliveryDomain(dbConn, cfg, repo)
 
 	s.db = dbConn
 	s.ac = cfg
+	s.repo = repo
 	//s.cache = msgCache
 	//s.cacheEnable = cfg.Cache.Enable
 	return nil
@@ -65,6 +80,9 @@ func (s *Handler) RegisterHealth(srv *grpc.Server) {
 }
 
 func (s *Handler) Stop(ctx context.Context) error {
+	if s.repo != nil && s.repo.Msr != nil {
+		return s.repo.Msr.Close()
+	}
 	return nil
 }
 
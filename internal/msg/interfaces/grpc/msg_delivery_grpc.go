@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	api "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+)
+
+func (s *Handler) ConfirmMessagesDelivered(ctx context.Context, request *api.ConfirmMessagesDeliveredRequest) (*api.ConfirmMessagesDeliveredResponse, error) {
+	resp := &api.ConfirmMessagesDeliveredResponse{}
+
+	userMsgIds := make([]uint, 0, len(request.UserMsgIds))
+	for _, id := range request.UserMsgIds {
+		userMsgIds = append(userMsgIds, uint(id))
+	}
+	groupMsgIds := make([]uint, 0, len(request.GroupMsgIds))
+	for _, id := range request.GroupMsgIds {
+		groupMsgIds = append(groupMsgIds, uint(id))
+	}
+
+	msgs, err := s.mdd.ConfirmDelivered(ctx, request.UserId, userMsgIds, groupMsgIds, pkgtime.Now())
+	if err != nil {
+		return resp, err
+	}
+
+	for _, msg := range msgs {
+		resp.List = append(resp.List, &api.DeliveredMessage{
+			MsgId:          uint32(msg.ID),
+			DialogId:       uint32(msg.DialogID),
+			GroupId:        uint32(msg.GroupID),
+			SenderId:       msg.SenderID,
+			ReceiverId:     msg.ReceiverID,
+			DeliveredAt:    msg.DeliveredAt,
+			DeliveredCount: uint32(msg.DeliveredCount),
+		})
+	}
+	return resp, nil
+}
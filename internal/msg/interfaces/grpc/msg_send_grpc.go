@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"context"
+	api "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Handler) SendMessage(ctx context.Context, request *api.SendMessageRequest) (*api.SendMessageResponse, error) {
+	if s.svc == nil {
+		return nil, status.Error(codes.Unavailable, "msg service is not ready")
+	}
+	resp, err := s.svc.SendMessage(ctx, request)
+	if err != nil {
+		// 应用服务返回的业务错误码需要转换为grpc状态，调用方才能解析
+		if _, ok := status.FromError(err); !ok {
+			return nil, code.WrapCodeToGRPC(code.Cause(err))
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -75,6 +75,9 @@ func (h *Handler) Init(cfg *pkgconfig.AppConfig) error {
 	if err != nil {
 		return err
 	}
+	if h.MsgClient != nil {
+		h.MsgClient.SetAppService(h.svc)
+	}
 
 	return nil
 }
@@ -70,6 +70,220 @@ func (h *Handler) SendGroupMsg(c *gin.Context) {
 	response.SetSuccess(c, "发送成功", resp)
 }
 
+// ForwardMsg
+// @Summary 转发消息
+// @Description 逐条转发或合并为一条聊天记录转发到其他私聊或群聊对话
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @param request body v1.ForwardMsgRequest true "request"
+// @Success		200 {object} v1.Response{data=v1.ForwardMsgResponse{}}
+// @Router /msg/forward [post]
+func (h *Handler) ForwardMsg(c *gin.Context) {
+	req := new(v1.ForwardMsgRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.ForwardMsg(c, userID, driverID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "转发成功", resp)
+}
+
+// CreateScheduledMsg
+// @Summary 创建定时消息
+// @Description 到达发送时间后按普通消息发送，发送时会重新校验好友关系和禁言状态
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @param request body v1.CreateScheduledMsgRequest true "request"
+// @Success 200 {object} v1.Response{data=v1.ScheduledMessage{}}
+// @Router /msg/scheduled [post]
+func (h *Handler) CreateScheduledMsg(c *gin.Context) {
+	req := new(v1.CreateScheduledMsgRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.CreateScheduledMsg(c, userID, driverID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "创建成功", resp)
+}
+
+// ListScheduledMsg
+// @Summary 获取定时消息列表
+// @Description 获取当前用户创建的定时消息
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param dialog_id query int false "对话id"
+// @Param status query int false "状态 0=等待发送 1=发送中 2=已发送 3=已取消 4=发送失败"
+// @Param page_num query int true "页码"
+// @Param page_size query int true "页大小"
+// @Success 200 {object} v1.Response{data=v1.ListScheduledMsgResponse{}}
+// @Router /msg/scheduled [get]
+func (h *Handler) ListScheduledMsg(c *gin.Context, params v1.ListScheduledMsgParams) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.ListScheduledMsg(c, userID, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// EditScheduledMsg
+// @Summary 编辑定时消息
+// @Description 只能编辑等待发送的定时消息
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "定时消息ID"
+// @param request body v1.EditScheduledMsgRequest true "request"
+// @Success 200 {object} v1.Response{data=v1.ScheduledMessage{}}
+// @Router /msg/scheduled/{id} [put]
+func (h *Handler) EditScheduledMsg(c *gin.Context, id int) {
+	req := new(v1.EditScheduledMsgRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.EditScheduledMsg(c, userID, uint32(id), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "编辑成功", resp)
+}
+
+// CancelScheduledMsg
+// @Summary 取消定时消息
+// @Description 只能取消等待发送的定时消息
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "定时消息ID"
+// @Success 200 {object} v1.Response{}
+// @Router /msg/scheduled/{id} [delete]
+func (h *Handler) CancelScheduledMsg(c *gin.Context, id int) {
+	userID := c.Value(constants.UserID).(string)
+	if err := h.svc.CancelScheduledMsg(c, userID, uint32(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "取消成功", nil)
+}
+
+// ListDraft
+// @Summary 获取草稿列表
+// @Description 获取当前用户所有对话的草稿
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} v1.Response{data=v1.ListDraftResponse{}}
+// @Router /msg/draft [get]
+func (h *Handler) ListDraft(c *gin.Context) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.ListDraft(c, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// GetDraft
+// @Summary 获取对话草稿
+// @Description 没有草稿时返回空内容
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param dialog_id path int true "对话ID"
+// @Success 200 {object} v1.Response{data=v1.Draft{}}
+// @Router /msg/draft/{dialog_id} [get]
+func (h *Handler) GetDraft(c *gin.Context, dialogId int) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetDraft(c, userID, uint32(dialogId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// SaveDraft
+// @Summary 保存对话草稿
+// @Description 内容为空时清除草稿，保存后同步到用户的其他在线设备
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param dialog_id path int true "对话ID"
+// @param request body v1.SaveDraftRequest true "request"
+// @Success 200 {object} v1.Response{data=v1.Draft{}}
+// @Router /msg/draft/{dialog_id} [put]
+func (h *Handler) SaveDraft(c *gin.Context, dialogId int) {
+	req := new(v1.SaveDraftRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.SaveDraft(c, userID, driverID, uint32(dialogId), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "保存成功", resp)
+}
+
+// ClearDraft
+// @Summary 清除对话草稿
+// @Description 清除后同步到用户的其他在线设备
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param dialog_id path int true "对话ID"
+// @Success 200 {object} v1.Response{}
+// @Router /msg/draft/{dialog_id} [delete]
+func (h *Handler) ClearDraft(c *gin.Context, dialogId int) {
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	if err := h.svc.ClearDraft(c, userID, driverID, uint32(dialogId)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "清除成功", nil)
+}
+
 // GetUserMsgList
 // @Summary 获取私聊消息
 // @Description 获取私聊消息
@@ -85,6 +299,8 @@ func (h *Handler) SendGroupMsg(c *gin.Context) {
 // @Param page_size query int true "页大小"
 // @Param start_at query int64 false "开始时间"
 // @Param end_at query int64 true "结束时间"
+// @Param cursor query string false "游标"
+// @Param direction query string false "翻页方向 before after"
 // @Success		200 {object} v1.Response{}
 // @Router /msg/user/list [get]
 func (h *Handler) GetUserMsgList(c *gin.Context, params v1.GetUserMsgListParams) {
@@ -136,6 +352,8 @@ func (h *Handler) GetUserMsgList(c *gin.Context, params v1.GetUserMsgListParams)
 // @Param content query string false "消息"
 // @Param page_num query int true "页码"
 // @Param page_size query int true "页大小"
+// @Param cursor query string false "游标"
+// @Param direction query string false "翻页方向 before after"
 // @Success		200 {object} v1.Response{}
 // @Router /msg/group/list [get]
 func (h *Handler) GetGroupMsgList(c *gin.Context, params v1.GetGroupMsgListParams) {
@@ -169,6 +387,50 @@ func (h *Handler) GetGroupMsgList(c *gin.Context, params v1.GetGroupMsgListParam
 	response.SetSuccess(c, "获取成功", resp)
 }
 
+// SyncDialogMsg
+// @Summary 增量同步对话消息
+// @Description 返回同步序号之后的新消息和修改过的消息，撤回或删除的消息以墓碑返回
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param dialog_id path int true "对话id"
+// @Param seq query int false "上次同步返回的next_seq"
+// @Param limit query int false "每次最多返回的变更数量"
+// @Success		200 {object} v1.Response{}
+// @Router /msg/dialog/{dialog_id}/sync [get]
+func (h *Handler) SyncDialogMsg(c *gin.Context, dialogId int, params v1.SyncDialogMsgParams) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.SyncDialogMsg(c, userID, uint32(dialogId), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "同步成功", resp)
+}
+
+// GetDialogMsgBySeq
+// @Summary 按序号区间获取对话消息
+// @Description 返回序号在[start_seq, end_seq]之间的消息，区间内缺失的序号表示消息已撤回或删除
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param dialog_id path int true "对话id"
+// @Param start_seq query int true "起始序号"
+// @Param end_seq query int true "结束序号"
+// @Success		200 {object} v1.Response{}
+// @Router /msg/dialog/{dialog_id}/seq [get]
+func (h *Handler) GetDialogMsgBySeq(c *gin.Context, dialogId int, params v1.GetDialogMsgBySeqParams) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetDialogMsgBySeq(c, userID, uint32(dialogId), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
 // GetUserDialogList
 // 获取用户对话列表
 // @Summary 获取用户对话列表
@@ -364,6 +626,204 @@ func (h *Handler) LabelGroupMsg(c *gin.Context, id int) {
 	response.SetSuccess(c, "标注成功", nil)
 }
 
+// AddUserMsgReaction
+// @Summary 添加私聊消息表情回应
+// @Description 添加私聊消息表情回应
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "消息ID"
+// @Param request body v1.MessageReactionRequest true "request"
+// @Success 200 {object} v1.Response{data=v1.MessageReactionResponse{}}
+// @Router /msg/user/{id}/reactions [post]
+func (h *Handler) AddUserMsgReaction(c *gin.Context, id int) {
+	req := new(v1.MessageReactionRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.AddUserMsgReaction(c, userID, driverID, uint32(id), req.Emoji)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", resp)
+}
+
+// RemoveUserMsgReaction
+// @Summary 取消私聊消息表情回应
+// @Description 取消私聊消息表情回应
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "消息ID"
+// @Param emoji query string true "表情"
+// @Success 200 {object} v1.Response{data=v1.MessageReactionResponse{}}
+// @Router /msg/user/{id}/reactions [delete]
+func (h *Handler) RemoveUserMsgReaction(c *gin.Context, id int, params v1.RemoveUserMsgReactionParams) {
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.RemoveUserMsgReaction(c, userID, driverID, uint32(id), params.Emoji)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", resp)
+}
+
+// AddGroupMsgReaction
+// @Summary 添加群聊消息表情回应
+// @Description 添加群聊消息表情回应
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "消息ID"
+// @Param request body v1.MessageReactionRequest true "request"
+// @Success 200 {object} v1.Response{data=v1.MessageReactionResponse{}}
+// @Router /msg/group/{id}/reactions [post]
+func (h *Handler) AddGroupMsgReaction(c *gin.Context, id int) {
+	req := new(v1.MessageReactionRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.AddGroupMsgReaction(c, userID, driverID, uint32(id), req.Emoji)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", resp)
+}
+
+// RemoveGroupMsgReaction
+// @Summary 取消群聊消息表情回应
+// @Description 取消群聊消息表情回应
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "消息ID"
+// @Param emoji query string true "表情"
+// @Success 200 {object} v1.Response{data=v1.MessageReactionResponse{}}
+// @Router /msg/group/{id}/reactions [delete]
+func (h *Handler) RemoveGroupMsgReaction(c *gin.Context, id int, params v1.RemoveGroupMsgReactionParams) {
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.RemoveGroupMsgReaction(c, userID, driverID, uint32(id), params.Emoji)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", resp)
+}
+
+// GetUserThreadMsgList
+// @Summary 获取私聊消息话题回复列表
+// @Description 获取私聊消息话题回复列表，传入话题内的回复id时返回其所属话题
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "话题根消息ID"
+// @Param page_num query int true "页码"
+// @Param page_size query int true "页大小"
+// @Success 200 {object} v1.Response{data=v1.GetUserThreadMsgListResponse{}}
+// @Router /msg/user/{id}/thread [get]
+func (h *Handler) GetUserThreadMsgList(c *gin.Context, id int, params v1.GetUserThreadMsgListParams) {
+	if params.PageNum <= 0 || params.PageSize <= 0 {
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetUserThreadMsgList(c, userID, uint32(id), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// ReadUserThread
+// @Summary 设置私聊消息话题已读
+// @Description 将话题内当前所有回复设置为已读
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "话题根消息ID"
+// @Success 200 {object} v1.Response{data=v1.ThreadInfo{}}
+// @Router /msg/user/{id}/thread/read [put]
+func (h *Handler) ReadUserThread(c *gin.Context, id int) {
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.ReadUserThread(c, userID, driverID, uint32(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", resp)
+}
+
+// GetGroupThreadMsgList
+// @Summary 获取群聊消息话题回复列表
+// @Description 获取群聊消息话题回复列表，传入话题内的回复id时返回其所属话题
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "话题根消息ID"
+// @Param page_num query int true "页码"
+// @Param page_size query int true "页大小"
+// @Success 200 {object} v1.Response{data=v1.GetGroupThreadMsgListResponse{}}
+// @Router /msg/group/{id}/thread [get]
+func (h *Handler) GetGroupThreadMsgList(c *gin.Context, id int, params v1.GetGroupThreadMsgListParams) {
+	if params.PageNum <= 0 || params.PageSize <= 0 {
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetGroupThreadMsgList(c, userID, uint32(id), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// ReadGroupThread
+// @Summary 设置群聊消息话题已读
+// @Description 将话题内当前所有回复设置为已读
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "话题根消息ID"
+// @Success 200 {object} v1.Response{data=v1.ThreadInfo{}}
+// @Router /msg/group/{id}/thread/read [put]
+func (h *Handler) ReadGroupThread(c *gin.Context, id int) {
+	userID := c.Value(constants.UserID).(string)
+	driverID := c.Value(constants.DriverID).(string)
+	resp, err := h.svc.ReadGroupThread(c, userID, driverID, uint32(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "操作成功", resp)
+}
+
 // GetUserLabelMsgList
 // 获取私聊标注信息
 // @Summary 获取私聊标注信息
@@ -477,8 +937,8 @@ func (h *Handler) GetAfterMsgs(c *gin.Context) {
 // @Summary 批量设置群聊消息为已读
 // @Description 批量设置群聊消息为已读
 // @Tags Msg
-// @Accept json
-// @Produce json
+// @Accept  json
+// @Produce  json
 // @Param request body v1.GroupMessageReadRequest true "请求参数"
 // @Success 200 {object} v1.Response{}
 // @Router /msg/group/read [put]
@@ -505,8 +965,8 @@ func (h *Handler) GroupMessageRead(c *gin.Context) {
 // @Summary 获取消息已读人员
 // @Description 获取消息已读人员
 // @Tags Msg
-// @Accept json
-// @Produce json
+// @Accept  json
+// @Produce  json
 // @Param id path uint32 true "消息ID"
 // @Param dialog_id query uint32 true "对话ID"
 // @Param group_id query uint32 true "群聊ID"
@@ -541,3 +1001,117 @@ func (h *Handler) GetGroupMessageReaders(c *gin.Context, id int, params v1.GetGr
 
 	response.SetSuccess(c, "获取成功", resp)
 }
+
+// SearchMsg
+// @Summary 搜索消息
+// @Description 在用户所有对话中全文搜索消息，结果按相关度排序
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param keyword query string true "关键词"
+// @Param dialog_id query int false "对话id"
+// @Param user_id query string false "发送者id"
+// @Param start_at query int64 false "开始时间"
+// @Param end_at query int64 false "结束时间"
+// @Param page_num query int true "页码"
+// @Param page_size query int true "页大小"
+// @Success 200 {object} v1.Response{data=v1.SearchMsgResponse{}}
+// @Router /msg/search [get]
+func (h *Handler) SearchMsg(c *gin.Context, params v1.SearchMsgParams) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.SearchMsg(c, userID, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// GetUserMsgRevisions
+// @Summary 获取私聊消息修订记录
+// @Description 只有消息发送者可以查看，撤回的消息也可以查看
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "消息ID"
+// @Success 200 {object} v1.Response{data=v1.MessageRevisionListResponse{}}
+// @Router /msg/user/{id}/revisions [get]
+func (h *Handler) GetUserMsgRevisions(c *gin.Context, id int) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetUserMsgRevisions(c, userID, uint32(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// GetGroupMsgRevisions
+// @Summary 获取群聊消息修订记录
+// @Description 消息发送者可以查看，群主和管理员按群聊的修订记录可见策略查看，撤回的消息也可以查看
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param id path int true "消息ID"
+// @Success 200 {object} v1.Response{data=v1.MessageRevisionListResponse{}}
+// @Router /msg/group/{id}/revisions [get]
+func (h *Handler) GetGroupMsgRevisions(c *gin.Context, id int) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetGroupMsgRevisions(c, userID, uint32(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// GetGroupRevisionPolicy
+// @Summary 获取群聊修订记录可见策略
+// @Description 获取群聊修订记录可见策略，未设置时群主和管理员可见
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param group_id path int true "群聊ID"
+// @Success 200 {object} v1.Response{data=v1.GroupRevisionPolicy{}}
+// @Router /msg/group/revision_policy/{group_id} [get]
+func (h *Handler) GetGroupRevisionPolicy(c *gin.Context, groupId int) {
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.GetGroupRevisionPolicy(c, userID, uint32(groupId))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取成功", resp)
+}
+
+// SetGroupRevisionPolicy
+// @Summary 设置群聊修订记录可见策略
+// @Description 只有群主可以设置
+// @Tags Msg
+// @Accept  json
+// @Produce  json
+// @Param group_id path int true "群聊ID"
+// @param request body v1.SetGroupRevisionPolicyRequest true "request"
+// @Success 200 {object} v1.Response{data=v1.GroupRevisionPolicy{}}
+// @Router /msg/group/revision_policy/{group_id} [put]
+func (h *Handler) SetGroupRevisionPolicy(c *gin.Context, groupId int) {
+	req := new(v1.SetGroupRevisionPolicyRequest)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	userID := c.Value(constants.UserID).(string)
+	resp, err := h.svc.SetGroupRevisionPolicy(c, userID, uint32(groupId), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "设置成功", resp)
+}
@@ -114,6 +114,16 @@ const (
 	WSEventType_CreateGroupAnnouncementEvent   WSEventType = 29
 	WSEventType_UpdateGroupAnnouncementEvent   WSEventType = 30
 	WSEventType_UserLeaveGroupCallEvent        WSEventType = 31
+	WSEventType_MessageReactionEvent           WSEventType = 32
+	WSEventType_ThreadUpdateEvent              WSEventType = 33
+	WSEventType_ScheduledMessageEvent          WSEventType = 34
+	WSEventType_BurnAfterReadingExpiredEvent   WSEventType = 35
+	WSEventType_MessageDeliveredEvent          WSEventType = 36
+	WSEventType_MessageDraftEvent              WSEventType = 37
+	WSEventType_TypingEvent                    WSEventType = 38
+	WSEventType_RecordingEvent                 WSEventType = 39
+	WSEventType_PresenceUpdateEvent            WSEventType = 40
+	WSEventType_CallRecordingEvent             WSEventType = 41
 )
 
 // Enum value maps for WSEventType.
@@ -151,6 +161,16 @@ var (
 		29: "CreateGroupAnnouncementEvent",
 		30: "UpdateGroupAnnouncementEvent",
 		31: "UserLeaveGroupCallEvent",
+		32: "MessageReactionEvent",
+		33: "ThreadUpdateEvent",
+		34: "ScheduledMessageEvent",
+		35: "BurnAfterReadingExpiredEvent",
+		36: "MessageDeliveredEvent",
+		37: "MessageDraftEvent",
+		38: "TypingEvent",
+		39: "RecordingEvent",
+		40: "PresenceUpdateEvent",
+		41: "CallRecordingEvent",
 	}
 	WSEventType_value = map[string]int32{
 		"UnknownEvent":                   0,
@@ -185,6 +205,16 @@ var (
 		"CreateGroupAnnouncementEvent":   29,
 		"UpdateGroupAnnouncementEvent":   30,
 		"UserLeaveGroupCallEvent":        31,
+		"MessageReactionEvent":           32,
+		"ThreadUpdateEvent":              33,
+		"ScheduledMessageEvent":          34,
+		"BurnAfterReadingExpiredEvent":   35,
+		"MessageDeliveredEvent":          36,
+		"MessageDraftEvent":              37,
+		"TypingEvent":                    38,
+		"RecordingEvent":                 39,
+		"PresenceUpdateEvent":            40,
+		"CallRecordingEvent":             41,
 	}
 )
 
@@ -215,6 +245,112 @@ func (WSEventType) EnumDescriptor() ([]byte, []int) {
 	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{1}
 }
 
+// 推送到用户的哪些设备
+type DeviceRoute int32
+
+const (
+	DeviceRoute_AllDevices    DeviceRoute = 0 //所有设备
+	DeviceRoute_ExcludeDevice DeviceRoute = 1 //除DriverId以外的设备，用于同步操作者在其他设备上的状态
+	DeviceRoute_OnlyDevices   DeviceRoute = 2 //只推送到DriverIds中的设备
+)
+
+// Enum value maps for DeviceRoute.
+var (
+	DeviceRoute_name = map[int32]string{
+		0: "AllDevices",
+		1: "ExcludeDevice",
+		2: "OnlyDevices",
+	}
+	DeviceRoute_value = map[string]int32{
+		"AllDevices":    0,
+		"ExcludeDevice": 1,
+		"OnlyDevices":   2,
+	}
+)
+
+func (x DeviceRoute) Enum() *DeviceRoute {
+	p := new(DeviceRoute)
+	*p = x
+	return p
+}
+
+func (x DeviceRoute) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceRoute) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_v1_push_proto_enumTypes[2].Descriptor()
+}
+
+func (DeviceRoute) Type() protoreflect.EnumType {
+	return &file_api_grpc_v1_push_proto_enumTypes[2]
+}
+
+func (x DeviceRoute) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceRoute.Descriptor instead.
+func (DeviceRoute) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{2}
+}
+
+// 断开连接的原因，推送给被断开的客户端
+type DisconnectReason int32
+
+const (
+	DisconnectReason_DisconnectUnknown         DisconnectReason = 0
+	DisconnectReason_DisconnectLogout          DisconnectReason = 1 //退出登录
+	DisconnectReason_DisconnectPasswordChanged DisconnectReason = 2 //修改密码
+	DisconnectReason_DisconnectBanned          DisconnectReason = 3 //账号被封禁
+	DisconnectReason_DisconnectKicked          DisconnectReason = 4 //被管理员踢下线
+)
+
+// Enum value maps for DisconnectReason.
+var (
+	DisconnectReason_name = map[int32]string{
+		0: "DisconnectUnknown",
+		1: "DisconnectLogout",
+		2: "DisconnectPasswordChanged",
+		3: "DisconnectBanned",
+		4: "DisconnectKicked",
+	}
+	DisconnectReason_value = map[string]int32{
+		"DisconnectUnknown":         0,
+		"DisconnectLogout":          1,
+		"DisconnectPasswordChanged": 2,
+		"DisconnectBanned":          3,
+		"DisconnectKicked":          4,
+	}
+)
+
+func (x DisconnectReason) Enum() *DisconnectReason {
+	p := new(DisconnectReason)
+	*p = x
+	return p
+}
+
+func (x DisconnectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisconnectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_v1_push_proto_enumTypes[3].Descriptor()
+}
+
+func (DisconnectReason) Type() protoreflect.EnumType {
+	return &file_api_grpc_v1_push_proto_enumTypes[3]
+}
+
+func (x DisconnectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisconnectReason.Descriptor instead.
+func (DisconnectReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{3}
+}
+
 type PushRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -339,6 +475,10 @@ type SendWsUserMsg struct {
 	SenderInfo *SenderInfo `protobuf:"bytes,11,opt,name=sender_info,json=senderInfo,proto3" json:"sender_info"`
 	// @inject_tag: json:"reply_msg"
 	ReplyMsg *MessageInfo `protobuf:"bytes,12,opt,name=reply_msg,json=replyMsg,proto3" json:"reply_msg"`
+	// @inject_tag: json:"thread_id"
+	ThreadId uint32 `protobuf:"varint,13,opt,name=thread_id,json=threadId,proto3" json:"thread_id"`
+	// @inject_tag: json:"seq"
+	Seq uint64 `protobuf:"varint,14,opt,name=seq,proto3" json:"seq"`
 }
 
 func (x *SendWsUserMsg) Reset() {
@@ -457,6 +597,20 @@ func (x *SendWsUserMsg) GetReplyMsg() *MessageInfo {
 	return nil
 }
 
+func (x *SendWsUserMsg) GetThreadId() uint32 {
+	if x != nil {
+		return x.ThreadId
+	}
+	return 0
+}
+
+func (x *SendWsUserMsg) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
 type SenderInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -746,6 +900,10 @@ type SendWsGroupMsg struct {
 	SenderInfo *SenderInfo `protobuf:"bytes,12,opt,name=SenderInfo,proto3" json:"sender_info"`
 	// @inject_tag: json:"reply_msg"
 	ReplyMsg *MessageInfo `protobuf:"bytes,13,opt,name=ReplyMsg,proto3" json:"reply_msg"`
+	// @inject_tag: json:"thread_id"
+	ThreadId uint32 `protobuf:"varint,14,opt,name=ThreadId,proto3" json:"thread_id"`
+	// @inject_tag: json:"seq"
+	Seq uint64 `protobuf:"varint,15,opt,name=Seq,proto3" json:"seq"`
 }
 
 func (x *SendWsGroupMsg) Reset() {
@@ -871,6 +1029,20 @@ func (x *SendWsGroupMsg) GetReplyMsg() *MessageInfo {
 	return nil
 }
 
+func (x *SendWsGroupMsg) GetThreadId() uint32 {
+	if x != nil {
+		return x.ThreadId
+	}
+	return 0
+}
+
+func (x *SendWsGroupMsg) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
 type MessageInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1064,6 +1236,10 @@ type WsMsg struct {
 	PushOffline bool `protobuf:"varint,7,opt,name=PushOffline,proto3" json:"push_offline"`
 	// @inject_tag: json:"data"
 	Data *anypb.Any `protobuf:"bytes,6,opt,name=data,proto3" json:"data"`
+	// @inject_tag: json:"route"
+	Route DeviceRoute `protobuf:"varint,8,opt,name=Route,proto3,enum=push_v1.DeviceRoute" json:"route"`
+	// @inject_tag: json:"driver_ids"
+	DriverIds []string `protobuf:"bytes,9,rep,name=DriverIds,proto3" json:"driver_ids"`
 }
 
 func (x *WsMsg) Reset() {
@@ -1147,6 +1323,20 @@ func (x *WsMsg) GetData() *anypb.Any {
 	return nil
 }
 
+func (x *WsMsg) GetRoute() DeviceRoute {
+	if x != nil {
+		return x.Route
+	}
+	return DeviceRoute_AllDevices
+}
+
+func (x *WsMsg) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
 // driverId string, event constants.WSEventType, data interface{}, pushOffline bool
 type PushWsBatchByUserIdsRequest struct {
 	state         protoimpl.MessageState
@@ -1163,6 +1353,10 @@ type PushWsBatchByUserIdsRequest struct {
 	PushOffline bool `protobuf:"varint,4,opt,name=PushOffline,proto3" json:"push_offline"`
 	// @inject_tag: json:"driver_id"
 	DriverId string `protobuf:"bytes,5,opt,name=DriverId,proto3" json:"driver_id"`
+	// @inject_tag: json:"route"
+	Route DeviceRoute `protobuf:"varint,6,opt,name=Route,proto3,enum=push_v1.DeviceRoute" json:"route"`
+	// @inject_tag: json:"driver_ids"
+	DriverIds []string `protobuf:"bytes,7,rep,name=DriverIds,proto3" json:"driver_ids"`
 }
 
 func (x *PushWsBatchByUserIdsRequest) Reset() {
@@ -1232,6 +1426,20 @@ func (x *PushWsBatchByUserIdsRequest) GetDriverId() string {
 	return ""
 }
 
+func (x *PushWsBatchByUserIdsRequest) GetRoute() DeviceRoute {
+	if x != nil {
+		return x.Route
+	}
+	return DeviceRoute_AllDevices
+}
+
+func (x *PushWsBatchByUserIdsRequest) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
 type PushWsBatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1280,6 +1488,385 @@ func (x *PushWsBatchRequest) GetMsgs() []*WsMsg {
 	return nil
 }
 
+// 推送到用户登录设备的系统通知栏
+type PushMobileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"user_ids"
+	UserIds []string `protobuf:"bytes,1,rep,name=UserIds,proto3" json:"user_ids"`
+	// @inject_tag: json:"title"
+	Title string `protobuf:"bytes,2,opt,name=Title,proto3" json:"title"`
+	// @inject_tag: json:"content"
+	Content string `protobuf:"bytes,3,opt,name=Content,proto3" json:"content"`
+	// @inject_tag: json:"badge"
+	Badge int32 `protobuf:"varint,4,opt,name=Badge,proto3" json:"badge"`
+	// @inject_tag: json:"data"
+	Data map[string]string `protobuf:"bytes,5,rep,name=Data,proto3" json:"data" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *PushMobileRequest) Reset() {
+	*x = PushMobileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_push_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMobileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMobileRequest) ProtoMessage() {}
+
+func (x *PushMobileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_push_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMobileRequest.ProtoReflect.Descriptor instead.
+func (*PushMobileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PushMobileRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *PushMobileRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PushMobileRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PushMobileRequest) GetBadge() int32 {
+	if x != nil {
+		return x.Badge
+	}
+	return 0
+}
+
+func (x *PushMobileRequest) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PushEmailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"to"
+	To []string `protobuf:"bytes,1,rep,name=To,proto3" json:"to"`
+	// @inject_tag: json:"subject"
+	Subject string `protobuf:"bytes,2,opt,name=Subject,proto3" json:"subject"`
+	// @inject_tag: json:"body"
+	Body string `protobuf:"bytes,3,opt,name=Body,proto3" json:"body"`
+}
+
+func (x *PushEmailRequest) Reset() {
+	*x = PushEmailRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_push_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushEmailRequest) ProtoMessage() {}
+
+func (x *PushEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_push_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushEmailRequest.ProtoReflect.Descriptor instead.
+func (*PushEmailRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PushEmailRequest) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PushEmailRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PushEmailRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type PushMessageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"phones"
+	Phones []string `protobuf:"bytes,1,rep,name=Phones,proto3" json:"phones"`
+	// @inject_tag: json:"content"
+	Content string `protobuf:"bytes,2,opt,name=Content,proto3" json:"content"`
+}
+
+func (x *PushMessageRequest) Reset() {
+	*x = PushMessageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_push_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMessageRequest) ProtoMessage() {}
+
+func (x *PushMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_push_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMessageRequest.ProtoReflect.Descriptor instead.
+func (*PushMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PushMessageRequest) GetPhones() []string {
+	if x != nil {
+		return x.Phones
+	}
+	return nil
+}
+
+func (x *PushMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type DisconnectUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"user_id"
+	UserId string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"user_id"`
+	// @inject_tag: json:"reason"
+	Reason DisconnectReason `protobuf:"varint,2,opt,name=Reason,proto3,enum=push_v1.DisconnectReason" json:"reason"`
+	// 不断开的设备，例如修改密码的设备
+	// @inject_tag: json:"exclude_driver_id"
+	ExcludeDriverId string `protobuf:"bytes,3,opt,name=ExcludeDriverId,proto3" json:"exclude_driver_id"`
+}
+
+func (x *DisconnectUserRequest) Reset() {
+	*x = DisconnectUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_push_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectUserRequest) ProtoMessage() {}
+
+func (x *DisconnectUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_push_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectUserRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DisconnectUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DisconnectUserRequest) GetReason() DisconnectReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DisconnectReason_DisconnectUnknown
+}
+
+func (x *DisconnectUserRequest) GetExcludeDriverId() string {
+	if x != nil {
+		return x.ExcludeDriverId
+	}
+	return ""
+}
+
+type DisconnectDeviceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"user_id"
+	UserId string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"user_id"`
+	// @inject_tag: json:"driver_id"
+	DriverId string `protobuf:"bytes,2,opt,name=DriverId,proto3" json:"driver_id"`
+	// @inject_tag: json:"reason"
+	Reason DisconnectReason `protobuf:"varint,3,opt,name=Reason,proto3,enum=push_v1.DisconnectReason" json:"reason"`
+}
+
+func (x *DisconnectDeviceRequest) Reset() {
+	*x = DisconnectDeviceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_push_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectDeviceRequest) ProtoMessage() {}
+
+func (x *DisconnectDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_push_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectDeviceRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DisconnectDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DisconnectDeviceRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DisconnectDeviceRequest) GetReason() DisconnectReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DisconnectReason_DisconnectUnknown
+}
+
+type DisconnectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DisconnectResponse) Reset() {
+	*x = DisconnectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_v1_push_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisconnectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectResponse) ProtoMessage() {}
+
+func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_push_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_push_proto_rawDescGZIP(), []int{15}
+}
+
 var File_api_grpc_v1_push_proto protoreflect.FileDescriptor
 
 var file_api_grpc_v1_push_proto_rawDesc = []byte{
@@ -1292,7 +1879,7 @@ var file_api_grpc_v1_push_proto_rawDesc = []byte{
 	0x5f, 0x76, 0x31, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12,
 	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
 	0x74, 0x61, 0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0xc4, 0x03, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x57, 0x73, 0x55, 0x73, 0x65,
+	0x73, 0x65, 0x22, 0xf3, 0x03, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x57, 0x73, 0x55, 0x73, 0x65,
 	0x72, 0x4d, 0x73, 0x67, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73,
 	0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
@@ -1320,202 +1907,298 @@ var file_api_grpc_v1_push_proto_rawDesc = []byte{
 	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f,
 	0x6d, 0x73, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68,
 	0x5f, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x08, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x22, 0x50, 0x0a, 0x0a, 0x53, 0x65, 0x6e,
-	0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xd4, 0x04, 0x0a, 0x11,
-	0x57, 0x73, 0x55, 0x73, 0x65, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4d, 0x73,
-	0x67, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x49,
-	0x73, 0x52, 0x65, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x49, 0x73, 0x52,
-	0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x61,
-	0x6c, 0x6f, 0x67, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x44, 0x69, 0x61,
-	0x6c, 0x6f, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
-	0x36, 0x0a, 0x16, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65,
-	0x61, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x16, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64,
-	0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x75,
-	0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x30, 0x0a, 0x08,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x18,
-	0x0a, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x49, 0x73, 0x42, 0x75,
-	0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x10,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65,
-	0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x17, 0x42, 0x75, 0x72, 0x6e,
-	0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65,
-	0x4f, 0x75, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x17, 0x42, 0x75, 0x72, 0x6e, 0x41,
+	0x08, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x50, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xd4, 0x04, 0x0a, 0x11, 0x57,
+	0x73, 0x55, 0x73, 0x65, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4d, 0x73, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x49, 0x73,
+	0x52, 0x65, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x49, 0x73, 0x52, 0x65,
+	0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x61, 0x6c,
+	0x6f, 0x67, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x44, 0x69, 0x61, 0x6c,
+	0x6f, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x36,
+	0x0a, 0x16, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16,
+	0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x75, 0x73,
+	0x68, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x30, 0x0a, 0x08, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x18, 0x0a,
+	0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72,
+	0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x17, 0x42, 0x75, 0x72, 0x6e, 0x41,
 	0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x4f,
-	0x75, 0x74, 0x22, 0xaf, 0x03, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x64, 0x57, 0x73, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x4d, 0x73, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4d,
-	0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x4d, 0x73,
-	0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f,
-	0x67, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f,
-	0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x09,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
-	0x0a, 0x41, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0a, 0x41, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a,
-	0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64,
-	0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72,
-	0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x33, 0x0a,
-	0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64,
-	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x30, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x4d, 0x73, 0x67, 0x22, 0xdf, 0x03, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x07, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
-	0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x0a,
-	0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x75, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x17, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x75,
+	0x74, 0x22, 0xdd, 0x03, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x64, 0x57, 0x73, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x4d, 0x73, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x73,
+	0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x4d, 0x73, 0x67,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x41, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x41, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x12,
+	0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x33, 0x0a, 0x0a,
+	0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65,
 	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x37, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66,
-	0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76,
-	0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x52, 0x65,
-	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x74,
-	0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x41,
-	0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x74, 0x55, 0x73,
-	0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65,
-	0x72, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65,
-	0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
-	0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69,
-	0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x49, 0x73, 0x52, 0x65, 0x61, 0x64,
-	0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x49, 0x73, 0x52, 0x65, 0x61, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
-	0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x22, 0xd7, 0x01, 0x0a, 0x05, 0x57, 0x73, 0x4d, 0x73, 0x67,
-	0x12, 0x10, 0x0a, 0x03, 0x55, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55,
-	0x69, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x57, 0x53, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x52, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x52, 0x69, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x53, 0x65, 0x6e, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x65,
-	0x6e, 0x64, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x6c,
-	0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f,
-	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x22, 0xcb, 0x01, 0x0a, 0x1b, 0x50, 0x75, 0x73, 0x68, 0x57, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x07, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68,
-	0x5f, 0x76, 0x31, 0x2e, 0x57, 0x53, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61,
-	0x12, 0x20, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x6c, 0x69,
-	0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x38,
-	0x0a, 0x12, 0x50, 0x75, 0x73, 0x68, 0x57, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x04, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x57, 0x73, 0x4d,
-	0x73, 0x67, 0x52, 0x04, 0x4d, 0x73, 0x67, 0x73, 0x2a, 0x53, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x06, 0x0a, 0x02, 0x57, 0x73, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x6f, 0x62, 0x69,
-	0x6c, 0x65, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x10, 0x02, 0x12,
-	0x0b, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
-	0x57, 0x73, 0x5f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x73,
-	0x5f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x55, 0x73, 0x65, 0x72, 0x10, 0x05, 0x2a, 0xb2, 0x06,
-	0x0a, 0x0b, 0x57, 0x53, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
-	0x0c, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x00, 0x12,
-	0x0f, 0x0a, 0x0b, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x01,
-	0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15,
-	0x53, 0x65, 0x6e, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x46, 0x72, 0x69, 0x65, 0x6e,
-	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x07, 0x12,
-	0x19, 0x0a, 0x15, 0x50, 0x75, 0x73, 0x68, 0x45, 0x32, 0x45, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x4b, 0x65, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x4a, 0x6f,
-	0x69, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x09, 0x12, 0x19,
-	0x0a, 0x15, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x0a, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x6e, 0x76,
-	0x69, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x10, 0x0b, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x69, 0x6c, 0x65, 0x6e,
-	0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x10, 0x0c, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x69, 0x6c, 0x65, 0x6e,
-	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x10, 0x0d, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c,
-	0x52, 0x65, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x0e, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10,
-	0x0f, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6a,
-	0x65, 0x63, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x10, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x10, 0x11, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c,
-	0x45, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x12, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10,
-	0x13, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x61,
-	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x14, 0x12, 0x21, 0x0a, 0x1d, 0x46, 0x72, 0x69, 0x65,
-	0x6e, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x15, 0x12, 0x22, 0x0a, 0x1e, 0x50,
-	0x75, 0x73, 0x68, 0x41, 0x6c, 0x6c, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x4f, 0x6e, 0x6c, 0x69,
-	0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x16, 0x12,
-	0x11, 0x0a, 0x0d, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4d, 0x73, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x10, 0x18, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73,
-	0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x19, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72,
-	0x4d, 0x73, 0x67, 0x52, 0x65, 0x61, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x1a, 0x12, 0x17,
-	0x0a, 0x13, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x1b, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x43, 0x61, 0x6c, 0x6c, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10,
-	0x1c, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x10, 0x1d, 0x12, 0x20, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x10, 0x1e, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x65, 0x61,
-	0x76, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x10, 0x1f, 0x32, 0x42, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x33, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68,
-	0x5f, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x15, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x69, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73,
-	0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x12, 0x30, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x73, 0x67, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x4d, 0x73, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x64, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x53, 0x65,
+	0x71, 0x22, 0xdf, 0x03, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x4d,
+	0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x4d, 0x73,
+	0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53,
+	0x65, 0x6e, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x65, 0x6e,
+	0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x0a, 0x53, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x37,
+	0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x74, 0x41, 0x6c, 0x6c,
+	0x55, 0x73, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x41, 0x74, 0x41, 0x6c,
+	0x6c, 0x55, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12,
+	0x2e, 0x0a, 0x12, 0x49, 0x73, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x49, 0x73, 0x42,
+	0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x18, 0x0a, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x49, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x49, 0x73, 0x52, 0x65, 0x61, 0x64, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x49, 0x73, 0x52, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x64, 0x41, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x64, 0x41, 0x74, 0x22, 0xa1, 0x02, 0x0a, 0x05, 0x57, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x55, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x69, 0x64, 0x12,
+	0x2a, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x57, 0x53, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x52,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x52, 0x69, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e,
+	0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x41,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a,
+	0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70,
+	0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x52, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x95, 0x02, 0x0a, 0x1b, 0x50, 0x75, 0x73, 0x68,
+	0x57, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x12, 0x2a, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x57, 0x53, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a,
+	0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4f,
+	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x50, 0x75,
+	0x73, 0x68, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x05, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22,
+	0x38, 0x0a, 0x12, 0x50, 0x75, 0x73, 0x68, 0x57, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x04, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x57, 0x73,
+	0x4d, 0x73, 0x67, 0x52, 0x04, 0x4d, 0x73, 0x67, 0x73, 0x22, 0xe6, 0x01, 0x0a, 0x11, 0x50, 0x75,
+	0x73, 0x68, 0x4d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x61, 0x64,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x42, 0x61, 0x64, 0x67, 0x65, 0x12,
+	0x38, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x6f, 0x62, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74,
+	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x50, 0x0a, 0x10, 0x50, 0x75, 0x73, 0x68, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x42, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x42, 0x6f, 0x64, 0x79, 0x22, 0x46, 0x0a, 0x12, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x68,
+	0x6f, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x50, 0x68, 0x6f, 0x6e,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x8c, 0x01, 0x0a,
+	0x15, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x31,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x45, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x17,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x14,
+	0x0a, 0x12, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x53, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02,
+	0x57, 0x73, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x57, 0x73, 0x5f, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x73, 0x5f, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x55, 0x73, 0x65, 0x72, 0x10, 0x05, 0x2a, 0xa8, 0x08, 0x0a, 0x0b, 0x57, 0x53,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c,
+	0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x18,
+	0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x65, 0x6e, 0x64,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x05,
+	0x12, 0x12, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x46, 0x72,
+	0x69, 0x65, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x07, 0x12, 0x19, 0x0a, 0x15, 0x50,
+	0x75, 0x73, 0x68, 0x45, 0x32, 0x45, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x09, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x10, 0x0a, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x4a,
+	0x6f, 0x69, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x0b, 0x12,
+	0x1e, 0x0a, 0x1a, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x69, 0x6c, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x0c, 0x12,
+	0x1f, 0x0a, 0x1b, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x69, 0x6c, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x0d,
+	0x12, 0x14, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x10, 0x0e, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x0f, 0x12, 0x17, 0x0a,
+	0x13, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x10, 0x10, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x11,
+	0x12, 0x14, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x6e, 0x64, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x10, 0x12, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43,
+	0x61, 0x6c, 0x6c, 0x45, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x13, 0x12, 0x15, 0x0a,
+	0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x61, 0x64, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x10, 0x14, 0x12, 0x21, 0x0a, 0x1d, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x15, 0x12, 0x22, 0x0a, 0x1e, 0x50, 0x75, 0x73, 0x68, 0x41,
+	0x6c, 0x6c, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x16, 0x12, 0x11, 0x0a, 0x0d, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x4d, 0x73, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x17, 0x12, 0x10,
+	0x0a, 0x0c, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x18,
+	0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x10, 0x19, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x52,
+	0x65, 0x61, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x1a, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x73,
+	0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x10, 0x1b, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x61, 0x6c, 0x6c,
+	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x1c, 0x12, 0x20, 0x0a,
+	0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x1d, 0x12,
+	0x20, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10,
+	0x1e, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x1f, 0x12, 0x18,
+	0x0a, 0x14, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x20, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x21, 0x12,
+	0x19, 0x0a, 0x15, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x22, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x75,
+	0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x23, 0x12, 0x19, 0x0a, 0x15,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x24, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x44, 0x72, 0x61, 0x66, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x25, 0x12, 0x0f,
+	0x0a, 0x0b, 0x54, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x26, 0x12,
+	0x12, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x10, 0x27, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x10, 0x28, 0x12, 0x16, 0x0a, 0x12,
+	0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x10, 0x29, 0x2a, 0x41, 0x0a, 0x0b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x6c, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x6e, 0x6c, 0x79, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x10, 0x02, 0x2a, 0x8a, 0x01, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x11,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x10, 0x03, 0x12, 0x14,
+	0x0a, 0x10, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4b, 0x69, 0x63, 0x6b,
+	0x65, 0x64, 0x10, 0x04, 0x32, 0xe4, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x14, 0x2e, 0x70,
+	0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x20, 0x2e, 0x70,
+	0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x39, 0x5a, 0x37, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x69, 0x6d,
+	0x2f, 0x63, 0x6f, 0x73, 0x73, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1530,45 +2213,63 @@ func file_api_grpc_v1_push_proto_rawDescGZIP() []byte {
 	return file_api_grpc_v1_push_proto_rawDescData
 }
 
-var file_api_grpc_v1_push_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_grpc_v1_push_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_grpc_v1_push_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_grpc_v1_push_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_api_grpc_v1_push_proto_goTypes = []interface{}{
 	(Type)(0),                           // 0: push_v1.Type
 	(WSEventType)(0),                    // 1: push_v1.WSEventType
-	(*PushRequest)(nil),                 // 2: push_v1.PushRequest
-	(*PushResponse)(nil),                // 3: push_v1.PushResponse
-	(*SendWsUserMsg)(nil),               // 4: push_v1.SendWsUserMsg
-	(*SenderInfo)(nil),                  // 5: push_v1.SenderInfo
-	(*WsUserOperatorMsg)(nil),           // 6: push_v1.WsUserOperatorMsg
-	(*SendWsGroupMsg)(nil),              // 7: push_v1.SendWsGroupMsg
-	(*MessageInfo)(nil),                 // 8: push_v1.MessageInfo
-	(*WsMsg)(nil),                       // 9: push_v1.WsMsg
-	(*PushWsBatchByUserIdsRequest)(nil), // 10: push_v1.PushWsBatchByUserIdsRequest
-	(*PushWsBatchRequest)(nil),          // 11: push_v1.PushWsBatchRequest
-	(*anypb.Any)(nil),                   // 12: google.protobuf.Any
+	(DeviceRoute)(0),                    // 2: push_v1.DeviceRoute
+	(DisconnectReason)(0),               // 3: push_v1.DisconnectReason
+	(*PushRequest)(nil),                 // 4: push_v1.PushRequest
+	(*PushResponse)(nil),                // 5: push_v1.PushResponse
+	(*SendWsUserMsg)(nil),               // 6: push_v1.SendWsUserMsg
+	(*SenderInfo)(nil),                  // 7: push_v1.SenderInfo
+	(*WsUserOperatorMsg)(nil),           // 8: push_v1.WsUserOperatorMsg
+	(*SendWsGroupMsg)(nil),              // 9: push_v1.SendWsGroupMsg
+	(*MessageInfo)(nil),                 // 10: push_v1.MessageInfo
+	(*WsMsg)(nil),                       // 11: push_v1.WsMsg
+	(*PushWsBatchByUserIdsRequest)(nil), // 12: push_v1.PushWsBatchByUserIdsRequest
+	(*PushWsBatchRequest)(nil),          // 13: push_v1.PushWsBatchRequest
+	(*PushMobileRequest)(nil),           // 14: push_v1.PushMobileRequest
+	(*PushEmailRequest)(nil),            // 15: push_v1.PushEmailRequest
+	(*PushMessageRequest)(nil),          // 16: push_v1.PushMessageRequest
+	(*DisconnectUserRequest)(nil),       // 17: push_v1.DisconnectUserRequest
+	(*DisconnectDeviceRequest)(nil),     // 18: push_v1.DisconnectDeviceRequest
+	(*DisconnectResponse)(nil),          // 19: push_v1.DisconnectResponse
+	nil,                                 // 20: push_v1.PushMobileRequest.DataEntry
+	(*anypb.Any)(nil),                   // 21: google.protobuf.Any
 }
 var file_api_grpc_v1_push_proto_depIdxs = []int32{
 	0,  // 0: push_v1.PushRequest.type:type_name -> push_v1.Type
-	5,  // 1: push_v1.SendWsUserMsg.sender_info:type_name -> push_v1.SenderInfo
-	8,  // 2: push_v1.SendWsUserMsg.reply_msg:type_name -> push_v1.MessageInfo
-	5,  // 3: push_v1.WsUserOperatorMsg.SenderInfo:type_name -> push_v1.SenderInfo
-	8,  // 4: push_v1.WsUserOperatorMsg.ReplyMsg:type_name -> push_v1.MessageInfo
-	5,  // 5: push_v1.SendWsGroupMsg.SenderInfo:type_name -> push_v1.SenderInfo
-	8,  // 6: push_v1.SendWsGroupMsg.ReplyMsg:type_name -> push_v1.MessageInfo
-	5,  // 7: push_v1.MessageInfo.SenderInfo:type_name -> push_v1.SenderInfo
-	5,  // 8: push_v1.MessageInfo.ReceiverInfo:type_name -> push_v1.SenderInfo
+	7,  // 1: push_v1.SendWsUserMsg.sender_info:type_name -> push_v1.SenderInfo
+	10, // 2: push_v1.SendWsUserMsg.reply_msg:type_name -> push_v1.MessageInfo
+	7,  // 3: push_v1.WsUserOperatorMsg.SenderInfo:type_name -> push_v1.SenderInfo
+	10, // 4: push_v1.WsUserOperatorMsg.ReplyMsg:type_name -> push_v1.MessageInfo
+	7,  // 5: push_v1.SendWsGroupMsg.SenderInfo:type_name -> push_v1.SenderInfo
+	10, // 6: push_v1.SendWsGroupMsg.ReplyMsg:type_name -> push_v1.MessageInfo
+	7,  // 7: push_v1.MessageInfo.SenderInfo:type_name -> push_v1.SenderInfo
+	7,  // 8: push_v1.MessageInfo.ReceiverInfo:type_name -> push_v1.SenderInfo
 	1,  // 9: push_v1.WsMsg.Event:type_name -> push_v1.WSEventType
-	12, // 10: push_v1.WsMsg.data:type_name -> google.protobuf.Any
-	1,  // 11: push_v1.PushWsBatchByUserIdsRequest.Event:type_name -> push_v1.WSEventType
-	12, // 12: push_v1.PushWsBatchByUserIdsRequest.Data:type_name -> google.protobuf.Any
-	9,  // 13: push_v1.PushWsBatchRequest.Msgs:type_name -> push_v1.WsMsg
-	2,  // 14: push_v1.PushService.Push:input_type -> push_v1.PushRequest
-	3,  // 15: push_v1.PushService.Push:output_type -> push_v1.PushResponse
-	15, // [15:16] is the sub-list for method output_type
-	14, // [14:15] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	21, // 10: push_v1.WsMsg.data:type_name -> google.protobuf.Any
+	2,  // 11: push_v1.WsMsg.Route:type_name -> push_v1.DeviceRoute
+	1,  // 12: push_v1.PushWsBatchByUserIdsRequest.Event:type_name -> push_v1.WSEventType
+	21, // 13: push_v1.PushWsBatchByUserIdsRequest.Data:type_name -> google.protobuf.Any
+	2,  // 14: push_v1.PushWsBatchByUserIdsRequest.Route:type_name -> push_v1.DeviceRoute
+	11, // 15: push_v1.PushWsBatchRequest.Msgs:type_name -> push_v1.WsMsg
+	20, // 16: push_v1.PushMobileRequest.Data:type_name -> push_v1.PushMobileRequest.DataEntry
+	3,  // 17: push_v1.DisconnectUserRequest.Reason:type_name -> push_v1.DisconnectReason
+	3,  // 18: push_v1.DisconnectDeviceRequest.Reason:type_name -> push_v1.DisconnectReason
+	4,  // 19: push_v1.PushService.Push:input_type -> push_v1.PushRequest
+	17, // 20: push_v1.PushService.DisconnectUser:input_type -> push_v1.DisconnectUserRequest
+	18, // 21: push_v1.PushService.DisconnectDevice:input_type -> push_v1.DisconnectDeviceRequest
+	5,  // 22: push_v1.PushService.Push:output_type -> push_v1.PushResponse
+	19, // 23: push_v1.PushService.DisconnectUser:output_type -> push_v1.DisconnectResponse
+	19, // 24: push_v1.PushService.DisconnectDevice:output_type -> push_v1.DisconnectResponse
+	22, // [22:25] is the sub-list for method output_type
+	19, // [19:22] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_v1_push_proto_init() }
@@ -1697,14 +2398,86 @@ func file_api_grpc_v1_push_proto_init() {
 				return nil
 			}
 		}
+		file_api_grpc_v1_push_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMobileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_push_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushEmailRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_push_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMessageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_push_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_push_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectDeviceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_v1_push_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_grpc_v1_push_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   10,
+			NumEnums:      4,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
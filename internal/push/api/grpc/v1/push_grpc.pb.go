@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	PushService_Push_FullMethodName = "/push_v1.PushService/Push"
+	PushService_Push_FullMethodName             = "/push_v1.PushService/Push"
+	PushService_DisconnectUser_FullMethodName   = "/push_v1.PushService/DisconnectUser"
+	PushService_DisconnectDevice_FullMethodName = "/push_v1.PushService/DisconnectDevice"
 )
 
 // PushServiceClient is the client API for PushService service.
@@ -27,6 +29,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PushServiceClient interface {
 	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*PushResponse, error)
+	// 断开用户所有设备的连接
+	DisconnectUser(ctx context.Context, in *DisconnectUserRequest, opts ...grpc.CallOption) (*DisconnectResponse, error)
+	// 断开用户单个设备的连接
+	DisconnectDevice(ctx context.Context, in *DisconnectDeviceRequest, opts ...grpc.CallOption) (*DisconnectResponse, error)
 }
 
 type pushServiceClient struct {
@@ -46,11 +52,33 @@ func (c *pushServiceClient) Push(ctx context.Context, in *PushRequest, opts ...g
 	return out, nil
 }
 
+func (c *pushServiceClient) DisconnectUser(ctx context.Context, in *DisconnectUserRequest, opts ...grpc.CallOption) (*DisconnectResponse, error) {
+	out := new(DisconnectResponse)
+	err := c.cc.Invoke(ctx, PushService_DisconnectUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) DisconnectDevice(ctx context.Context, in *DisconnectDeviceRequest, opts ...grpc.CallOption) (*DisconnectResponse, error) {
+	out := new(DisconnectResponse)
+	err := c.cc.Invoke(ctx, PushService_DisconnectDevice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PushServiceServer is the server API for PushService service.
 // All implementations should embed UnimplementedPushServiceServer
 // for forward compatibility
 type PushServiceServer interface {
 	Push(context.Context, *PushRequest) (*PushResponse, error)
+	// 断开用户所有设备的连接
+	DisconnectUser(context.Context, *DisconnectUserRequest) (*DisconnectResponse, error)
+	// 断开用户单个设备的连接
+	DisconnectDevice(context.Context, *DisconnectDeviceRequest) (*DisconnectResponse, error)
 }
 
 // UnimplementedPushServiceServer should be embedded to have forward compatible implementations.
@@ -60,6 +88,12 @@ type UnimplementedPushServiceServer struct {
 func (UnimplementedPushServiceServer) Push(context.Context, *PushRequest) (*PushResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
 }
+func (UnimplementedPushServiceServer) DisconnectUser(context.Context, *DisconnectUserRequest) (*DisconnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectUser not implemented")
+}
+func (UnimplementedPushServiceServer) DisconnectDevice(context.Context, *DisconnectDeviceRequest) (*DisconnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectDevice not implemented")
+}
 
 // UnsafePushServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PushServiceServer will
@@ -90,6 +124,42 @@ func _PushService_Push_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PushService_DisconnectUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).DisconnectUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_DisconnectUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).DisconnectUser(ctx, req.(*DisconnectUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_DisconnectDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).DisconnectDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_DisconnectDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).DisconnectDevice(ctx, req.(*DisconnectDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PushService_ServiceDesc is the grpc.ServiceDesc for PushService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -101,6 +171,14 @@ var PushService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Push",
 			Handler:    _PushService_Push_Handler,
 		},
+		{
+			MethodName: "DisconnectUser",
+			Handler:    _PushService_DisconnectUser_Handler,
+		},
+		{
+			MethodName: "DisconnectDevice",
+			Handler:    _PushService_DisconnectDevice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/v1/push.proto",
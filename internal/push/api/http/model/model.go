@@ -1,5 +1,7 @@
 package model
 
+import "encoding/json"
+
 type OnlineEventData struct {
 	//DriverType string `json:"driver_type"`
 	Rid string `json:"rid"`
@@ -8,4 +10,65 @@ type OnlineEventData struct {
 type FriendOnlineStatusMsg struct {
 	UserId string `json:"user_id"`
 	Status int32  `json:"status"`
+	// State 0离线 1在线 2离开 3忙碌，隐身的好友显示为离线
+	State                uint   `json:"state"`
+	CustomStatus         string `json:"custom_status"`
+	CustomStatusExpireAt int64  `json:"custom_status_expire_at"`
+	LastSeen             int64  `json:"last_seen"`
+}
+
+// AckRequest 客户端确认收到的离线消息，id为离线消息中的ack_id
+type AckRequest struct {
+	Ids []string `json:"ids"`
+}
+
+// TypingRequest 客户端发送的正在输入、正在录音事件，active为空时表示开始
+type TypingRequest struct {
+	DialogId uint32 `json:"dialog_id"`
+	Active   *bool  `json:"active"`
+}
+
+// SendRequest 客户端通过长连接发送的消息，group_id不为0时发送群聊消息
+type SendRequest struct {
+	ClientMsgId        string   `json:"client_msg_id"`
+	DialogId           uint32   `json:"dialog_id"`
+	ReceiverId         string   `json:"receiver_id"`
+	GroupId            uint32   `json:"group_id"`
+	Content            string   `json:"content"`
+	Type               int32    `json:"type"`
+	ReplyId            uint32   `json:"reply_id"`
+	IsBurnAfterReading bool     `json:"is_burn_after_reading"`
+	AtUsers            []string `json:"at_users"`
+	AtAllUser          bool     `json:"at_all_user"`
+}
+
+// SendAck 发送结果，code为200时表示发送成功，duplicate表示是重复发送的消息
+type SendAck struct {
+	ClientMsgId string `json:"client_msg_id"`
+	Code        int    `json:"code"`
+	Msg         string `json:"msg"`
+	MsgId       uint32 `json:"msg_id"`
+	DialogId    uint32 `json:"dialog_id"`
+	Seq         uint64 `json:"seq"`
+	Duplicate   bool   `json:"duplicate"`
+}
+
+// Frame 原生websocket和sse连接上的数据帧
+// 服务端推送的event与socket.io相同，主要为reply和send_ack，data为socket.io事件的参数，json字符串会直接内嵌为对象
+// 客户端发送的event为ack、typing、recording、send和ping，data与socket.io事件的参数相同，服务端收到ping后回复pong
+type Frame struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// SseEventRequest sse连接只能接收推送，客户端事件通过单独的请求发送，sid为连接建立时open事件中返回的连接id
+type SseEventRequest struct {
+	Sid   string          `json:"sid"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// SseOpenData sse连接建立后推送的第一个事件
+type SseOpenData struct {
+	Sid string `json:"sid"`
 }
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+	v1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) DisconnectUser(ctx context.Context, request *v1.DisconnectUserRequest) (*v1.DisconnectResponse, error) {
+	if request.UserId == "" {
+		return nil, code.WrapCodeToGRPC(code.InvalidParameter)
+	}
+	if err := h.PushService.DisconnectUser(ctx, request.UserId, request.ExcludeDriverId, request.Reason); err != nil {
+		h.logger.Error("断开用户连接失败", zap.String("uid", request.UserId), zap.Error(err))
+		return nil, code.WrapCodeToGRPC(code.PushErrDisconnectFailed)
+	}
+	return &v1.DisconnectResponse{}, nil
+}
+
+func (h *Handler) DisconnectDevice(ctx context.Context, request *v1.DisconnectDeviceRequest) (*v1.DisconnectResponse, error) {
+	if request.UserId == "" || request.DriverId == "" {
+		return nil, code.WrapCodeToGRPC(code.InvalidParameter)
+	}
+	if err := h.PushService.DisconnectDevice(ctx, request.UserId, request.DriverId, request.Reason); err != nil {
+		h.logger.Error("断开设备连接失败", zap.String("uid", request.UserId), zap.String("driver_id", request.DriverId), zap.Error(err))
+		return nil, code.WrapCodeToGRPC(code.PushErrDisconnectFailed)
+	}
+	return &v1.DisconnectResponse{}, nil
+}
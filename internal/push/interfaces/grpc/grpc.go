@@ -58,6 +58,10 @@ func (h *Handler) RegisterHealth(s *grpc.Server) {
 }
 
 func (h *Handler) Stop(ctx context.Context) error {
+	// 集群模式下其他实例仍在使用这些缓存
+	if h.PushService.ClusterEnabled() {
+		return nil
+	}
 	return h.cache.DeleteAllCache(ctx)
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	v1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/utils"
+	"go.uber.org/zap"
 )
 
 func (h *Handler) Push(ctx context.Context, request *v1.PushRequest) (*v1.PushResponse, error) {
@@ -48,10 +49,43 @@ func (h *Handler) Push(ctx context.Context, request *v1.PushRequest) (*v1.PushRe
 		}
 	case v1.Type_Mobile:
 		//移动推送
+		bytes := request.GetData()
+		req := &v1.PushMobileRequest{}
+		if err := utils.BytesToStruct(bytes, req); err != nil {
+			h.logger.Error("解析移动推送请求失败", zap.Error(err))
+			return resp, err
+		}
+
+		_, err := h.PushService.PushMobile(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	case v1.Type_Email:
 		//发送邮件
+		bytes := request.GetData()
+		req := &v1.PushEmailRequest{}
+		if err := utils.BytesToStruct(bytes, req); err != nil {
+			h.logger.Error("解析邮件推送请求失败", zap.Error(err))
+			return resp, err
+		}
+
+		_, err := h.PushService.PushEmail(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	case v1.Type_Message:
 		//发送短信
+		bytes := request.GetData()
+		req := &v1.PushMessageRequest{}
+		if err := utils.BytesToStruct(bytes, req); err != nil {
+			h.logger.Error("解析短信推送请求失败", zap.Error(err))
+			return resp, err
+		}
+
+		_, err := h.PushService.PushMessage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return resp, nil
@@ -2,6 +2,8 @@ package http
 
 import (
 	"context"
+	v1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
 	"github.com/cossim/coss-server/internal/push/service"
 	authv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	"github.com/cossim/coss-server/internal/user/rpc/client"
@@ -16,6 +18,8 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"log"
+	"sync"
+	"time"
 )
 
 type Handler struct {
@@ -24,6 +28,25 @@ type Handler struct {
 	PushService  *service.Service
 	socketServer *socketio.Server
 	authService  authv1.UserAuthServiceClient
+	// conns 客户端连接使用的推送服务，默认为PushService
+	conns connService
+	// sse连接，客户端事件通过单独的请求发送时按连接id查找
+	sseConns sync.Map
+}
+
+// connService 客户端连接上线、下线和发送事件时使用的推送服务
+type connService interface {
+	JoinRoom(uid, driverId string, conn service.Conn)
+	LeaveRoom(uid, driverId string, conn service.Conn)
+	Ws(ctx context.Context, conn service.Conn, uid string, driverId string, rid, token string) error
+	WsOfflineClients(ctx context.Context, uid, driverId, rid string) error
+	AckOfflineMessages(uid, sid string, ids []string)
+	PushTyping(ctx context.Context, uid string, driverId string, event v1.WSEventType, dialogId uint32, active bool) error
+	SendMessage(ctx context.Context, conn service.Conn, uid, driverId string, req *model.SendRequest)
+	SetConnEventHandler(handler service.ConnEventHandler)
+	DispatchConnEvent(ctx context.Context, ev *service.ConnEvent) bool
+	PingInterval() time.Duration
+	IdleTimeout() time.Duration
 }
 
 func (h *Handler) Init(cfg *pkgconfig.AppConfig) error {
@@ -34,6 +57,8 @@ func (h *Handler) Init(cfg *pkgconfig.AppConfig) error {
 		}
 	}
 	h.socketServer = h.PushService.SocketServer
+	h.conns = h.PushService
+	h.conns.SetConnEventHandler(h.handleSseEvent)
 	h.setupSocketEvent()
 	h.enc = encryption.NewEncryptor([]byte(cfg.Encryption.Passphrase), cfg.Encryption.Name, cfg.Encryption.Email, cfg.Encryption.RsaBits, cfg.Encryption.Enable)
 	var userAddr string
@@ -64,6 +89,9 @@ func (h *Handler) RegisterRoute(r gin.IRouter) {
 	u.Use(middleware.AuthMiddleware(h.authService))
 	u.GET("/ws/*any", gin.WrapH(h.socketServer))
 	u.POST("/ws/*any", gin.WrapH(h.socketServer))
+	u.GET("/raw", h.rawWs)
+	u.GET("/sse", h.sse)
+	u.POST("/sse/event", h.sseEvent)
 }
 
 func (h *Handler) Health(r gin.IRouter) string {
@@ -76,7 +104,7 @@ func (h *Handler) Stop(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return h.PushService.LeaveCluster(ctx)
 }
 
 func (h *Handler) DiscoverServices(services map[string]*grpc.ClientConn) error {
@@ -97,6 +125,10 @@ func (h *Handler) setupSocketEvent() {
 	h.socketServer.OnDisconnect("/", h.disconnect)
 	h.socketServer.OnEvent("/", "reply", h.reply)
 	h.socketServer.OnEvent("/", "bye", h.bye)
+	h.socketServer.OnEvent("/", "ack", h.ack)
+	h.socketServer.OnEvent("/", "typing", h.typing)
+	h.socketServer.OnEvent("/", "recording", h.recording)
+	h.socketServer.OnEvent("/", "send", h.send)
 
 	go func() {
 		if err := h.socketServer.Serve(); err != nil {
@@ -2,6 +2,11 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	v1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	"github.com/cossim/coss-server/internal/push/service"
 	authv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
 	socketio "github.com/googollee/go-socket.io"
 	"go.uber.org/zap"
@@ -25,26 +30,26 @@ func (h *Handler) ws(s socketio.Conn) error {
 	userId := parseToken.UserID
 
 	s.Join(userId)
-	s.SetContext(nil)
-	err = h.PushService.Ws(context.Background(), s, userId, parseToken.DriverID, s.ID(), token)
+	// 同一用户的其他设备通过设备房间单独推送
+	if parseToken.DriverID != "" {
+		s.Join(service.DeviceRoom(userId, parseToken.DriverID))
+	}
+	s.SetContext(&connInfo{userID: userId, driverID: parseToken.DriverID})
+	err = h.conns.Ws(context.Background(), s, userId, parseToken.DriverID, s.ID(), token)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// disconnect 连接断开，包括心跳超时和服务端踢下线，使用连接建立时的用户信息，token失效后同样需要下线
 func (h *Handler) disconnect(s socketio.Conn, msg string) {
-	url := s.URL()
-	token := url.Query().Get("token")
-	parseToken, err := h.authService.ParseToken(context.Background(), &authv1.ParseTokenRequest{Token: token})
-	if err != nil {
-		h.logger.Error("解析token失败", zap.Error(err))
+	info, ok := s.Context().(*connInfo)
+	if !ok {
 		return
 	}
 
-	userId := parseToken.UserID
-
-	err = h.PushService.WsOfflineClients(context.Background(), userId, s.ID())
+	err := h.conns.WsOfflineClients(context.Background(), info.userID, info.driverID, s.ID())
 	if err != nil {
 		h.logger.Error("推送离线消息失败", zap.Error(err))
 	}
@@ -55,6 +60,91 @@ func (h *Handler) reply(s socketio.Conn, msg string) {
 	s.Emit("reply", "服务端触发客户端事件： "+msg)
 }
 
+// connInfo 连接建立时解析出的用户信息，连接上的事件不需要再次解析token
+type connInfo struct {
+	userID   string
+	driverID string
+}
+
+// typing 正在输入
+func (h *Handler) typing(s socketio.Conn, msg string) {
+	if info, ok := s.Context().(*connInfo); ok {
+		h.pushTyping(info, v1.WSEventType_TypingEvent, msg)
+	}
+}
+
+// recording 正在录音
+func (h *Handler) recording(s socketio.Conn, msg string) {
+	if info, ok := s.Context().(*connInfo); ok {
+		h.pushTyping(info, v1.WSEventType_RecordingEvent, msg)
+	}
+}
+
+// send 客户端发送消息，结果通过send_ack事件返回
+func (h *Handler) send(s socketio.Conn, msg string) {
+	if info, ok := s.Context().(*connInfo); ok {
+		h.sendMessage(s, info, msg)
+	}
+}
+
+// ack 客户端确认收到离线消息
+func (h *Handler) ack(s socketio.Conn, msg string) {
+	if info, ok := s.Context().(*connInfo); ok {
+		h.ackOffline(s.ID(), info, msg)
+	}
+}
+
+// handleClientEvent 处理原生websocket和sse连接上客户端发送的事件，与socket.io事件的处理相同
+func (h *Handler) handleClientEvent(conn service.Conn, info *connInfo, event string, msg string) {
+	switch event {
+	case "ack":
+		h.ackOffline(conn.ID(), info, msg)
+	case "typing":
+		h.pushTyping(info, v1.WSEventType_TypingEvent, msg)
+	case "recording":
+		h.pushTyping(info, v1.WSEventType_RecordingEvent, msg)
+	case "send":
+		h.sendMessage(conn, info, msg)
+	case "ping":
+		conn.Emit("pong")
+	default:
+		h.logger.Debug("未知的客户端事件", zap.String("uid", info.userID), zap.String("event", event))
+	}
+}
+
+func (h *Handler) pushTyping(info *connInfo, event v1.WSEventType, msg string) {
+	req := &model.TypingRequest{}
+	if err := json.Unmarshal([]byte(msg), req); err != nil {
+		h.logger.Error("解析输入状态失败", zap.Error(err))
+		return
+	}
+	active := req.Active == nil || *req.Active
+
+	err := h.conns.PushTyping(context.Background(), info.userID, info.driverID, event, req.DialogId, active)
+	if err != nil && !errors.Is(err, service.ErrTypingRateLimited) {
+		h.logger.Debug("推送输入状态失败", zap.String("uid", info.userID), zap.Error(err))
+	}
+}
+
+func (h *Handler) sendMessage(conn service.Conn, info *connInfo, msg string) {
+	req := &model.SendRequest{}
+	if err := json.Unmarshal([]byte(msg), req); err != nil {
+		h.logger.Error("解析发送消息失败", zap.Error(err))
+		return
+	}
+	// 发送需要等待msg服务返回，不阻塞连接上的其他事件
+	go h.conns.SendMessage(context.Background(), conn, info.userID, info.driverID, req)
+}
+
+func (h *Handler) ackOffline(sid string, info *connInfo, msg string) {
+	req := &model.AckRequest{}
+	if err := json.Unmarshal([]byte(msg), req); err != nil {
+		h.logger.Error("解析离线消息确认失败", zap.Error(err))
+		return
+	}
+	h.conns.AckOfflineMessages(info.userID, sid, req.Ids)
+}
+
 func (h *Handler) bye(s socketio.Conn) string {
 	last := s.Context().(string)
 	s.Emit("bye", last)
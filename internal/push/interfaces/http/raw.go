@@ -0,0 +1,232 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	"github.com/cossim/coss-server/internal/push/service"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	rawWriteWait      = 10 * time.Second
+	rawMaxMessageSize = 64 * 1024
+	// 每个连接等待发送的帧数，超过后认为客户端处理不过来，断开连接，未确认的离线消息下次连接时重新推送
+	connSendBuffer = 256
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	// 机器人和命令行工具没有Origin，跨域由CORS中间件处理
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// rawConn 原生websocket连接，所有写操作都在writeLoop中进行
+type rawConn struct {
+	id   string
+	ws   *websocket.Conn
+	send chan []byte
+	done chan struct{}
+	once sync.Once
+	// 发送ping的间隔和等待客户端消息的超时时间
+	pingPeriod  time.Duration
+	idleTimeout time.Duration
+}
+
+func newRawConn(ws *websocket.Conn, pingPeriod, idleTimeout time.Duration) *rawConn {
+	return &rawConn{
+		id:          "ws-" + xid.New().String(),
+		ws:          ws,
+		send:        make(chan []byte, connSendBuffer),
+		done:        make(chan struct{}),
+		pingPeriod:  pingPeriod,
+		idleTimeout: idleTimeout,
+	}
+}
+
+func (c *rawConn) ID() string {
+	return c.id
+}
+
+func (c *rawConn) Emit(event string, v ...interface{}) {
+	b, err := encodeFrame(event, v...)
+	if err != nil {
+		return
+	}
+	select {
+	case <-c.done:
+	case c.send <- b:
+	default:
+		_ = c.Close()
+	}
+}
+
+func (c *rawConn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+	})
+	return nil
+}
+
+func (c *rawConn) writeLoop() {
+	ticker := time.NewTicker(c.pingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.ws.Close()
+	}()
+	for {
+		select {
+		case <-c.done:
+			// 关闭前把已经排队的帧发送出去，例如上线失败的原因
+			c.flush()
+			_ = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(rawWriteWait))
+			return
+		case b := <-c.send:
+			_ = c.ws.SetWriteDeadline(time.Now().Add(rawWriteWait))
+			if err := c.ws.WriteMessage(websocket.TextMessage, b); err != nil {
+				_ = c.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(rawWriteWait)); err != nil {
+				_ = c.Close()
+				return
+			}
+		}
+	}
+}
+
+func (c *rawConn) flush() {
+	for {
+		select {
+		case b := <-c.send:
+			_ = c.ws.SetWriteDeadline(time.Now().Add(rawWriteWait))
+			if err := c.ws.WriteMessage(websocket.TextMessage, b); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// readLoop 读取客户端发送的帧，超过idleTimeout没有收到pong或者消息时认为连接已经失效，连接断开后返回
+func (c *rawConn) readLoop(handle func(event string, data string)) {
+	c.ws.SetReadLimit(rawMaxMessageSize)
+	_ = c.ws.SetReadDeadline(time.Now().Add(c.idleTimeout))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(c.idleTimeout))
+	})
+	for {
+		_, b, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = c.ws.SetReadDeadline(time.Now().Add(c.idleTimeout))
+		frame := &model.Frame{}
+		if err := json.Unmarshal(b, frame); err != nil || frame.Event == "" {
+			c.Emit("error", "invalid frame")
+			continue
+		}
+		handle(frame.Event, frameText(frame.Data))
+	}
+}
+
+// encodeFrame 把socket.io事件的参数转换为数据帧
+func encodeFrame(event string, v ...interface{}) ([]byte, error) {
+	frame := &model.Frame{Event: event}
+	if len(v) > 0 {
+		data, err := frameData(v[0])
+		if err != nil {
+			return nil, err
+		}
+		frame.Data = data
+	}
+	return json.Marshal(frame)
+}
+
+// frameData 推送的消息是json字符串时直接内嵌，加密后的消息按字符串返回
+func frameData(v interface{}) (json.RawMessage, error) {
+	if str, ok := v.(string); ok && json.Valid([]byte(str)) {
+		return json.RawMessage(str), nil
+	}
+	return json.Marshal(v)
+}
+
+// frameText 客户端可以直接发送对象，也可以和socket.io一样发送json字符串
+func frameText(data json.RawMessage) string {
+	var str string
+	if len(data) > 0 && data[0] == '"' && json.Unmarshal(data, &str) == nil {
+		return str
+	}
+	return string(data)
+}
+
+func requestToken(c *gin.Context) string {
+	if token := c.Query("token"); token != "" {
+		return token
+	}
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+}
+
+// rawWs
+// @Summary 原生websocket推送
+// @Tags Push
+// @Description 不使用socket.io的客户端通过该接口接收推送，推送内容、加密和离线消息与socket.io相同
+// @Description 每个websocket文本消息是一个json数据帧 {"event": "事件名", "data": 事件参数}
+// @Description 服务端推送reply（推送消息，离线消息带有ack_id）、send_ack（发送结果）、pong和error事件
+// @Description 客户端可以发送ack {"ids": []}、typing、recording、send和ping事件，参数与socket.io事件相同
+// @Param token query string true "token"
+// @Router /api/v1/push/raw [get]
+func (h *Handler) rawWs(c *gin.Context) {
+	info := &connInfo{
+		userID:   c.Value(constants.UserID).(string),
+		driverID: c.Value(constants.DriverID).(string),
+	}
+	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("升级websocket连接失败", zap.Error(err))
+		return
+	}
+	conn := newRawConn(ws, h.conns.PingInterval(), h.conns.IdleTimeout())
+	go conn.writeLoop()
+
+	if err := h.openConn(conn, info, requestToken(c)); err != nil {
+		conn.Emit("error", code.Cause(err).Message())
+		_ = conn.Close()
+		return
+	}
+	conn.readLoop(func(event string, data string) {
+		h.handleClientEvent(conn, info, event, data)
+	})
+	_ = conn.Close()
+	h.closeConn(conn, info)
+}
+
+// openConn 非socket.io连接加入用户和设备的房间并上线
+func (h *Handler) openConn(conn service.Conn, info *connInfo, token string) error {
+	h.conns.JoinRoom(info.userID, info.driverID, conn)
+	if err := h.conns.Ws(context.Background(), conn, info.userID, info.driverID, conn.ID(), token); err != nil {
+		h.conns.LeaveRoom(info.userID, info.driverID, conn)
+		return err
+	}
+	return nil
+}
+
+// closeConn 非socket.io连接断开后离开房间并下线
+func (h *Handler) closeConn(conn service.Conn, info *connInfo) {
+	h.conns.LeaveRoom(info.userID, info.driverID, conn)
+	if err := h.conns.WsOfflineClients(context.Background(), info.userID, info.driverID, conn.ID()); err != nil {
+		h.logger.Error("推送离线消息失败", zap.Error(err))
+	}
+}
@@ -0,0 +1,236 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	v1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	"github.com/cossim/coss-server/internal/push/service"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/encryption"
+	"github.com/cossim/coss-server/pkg/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeConnService 单节点的推送服务，记录连接的上线、下线和确认的离线消息
+type fakeConnService struct {
+	mu      sync.Mutex
+	handler service.ConnEventHandler
+	rooms   map[string]string
+	acks    map[string][]string
+	wsErr   error
+
+	opened chan string
+	closed chan string
+}
+
+func newFakeConnService() *fakeConnService {
+	return &fakeConnService{
+		rooms:  make(map[string]string),
+		acks:   make(map[string][]string),
+		opened: make(chan string, 1),
+		closed: make(chan string, 1),
+	}
+}
+
+func (f *fakeConnService) JoinRoom(uid, driverId string, conn service.Conn) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rooms[conn.ID()] = uid
+}
+
+func (f *fakeConnService) LeaveRoom(uid, driverId string, conn service.Conn) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.rooms, conn.ID())
+}
+
+func (f *fakeConnService) joined(sid string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.rooms[sid]
+	return ok
+}
+
+func (f *fakeConnService) roomsLen() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.rooms)
+}
+
+func (f *fakeConnService) Ws(ctx context.Context, conn service.Conn, uid string, driverId string, rid, token string) error {
+	if f.wsErr != nil {
+		return f.wsErr
+	}
+	f.opened <- rid
+	return nil
+}
+
+func (f *fakeConnService) WsOfflineClients(ctx context.Context, uid, driverId, rid string) error {
+	f.closed <- rid
+	return nil
+}
+
+func (f *fakeConnService) AckOfflineMessages(uid, sid string, ids []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.acks[sid] = append(f.acks[sid], ids...)
+}
+
+func (f *fakeConnService) acked(sid string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.acks[sid]
+}
+
+func (f *fakeConnService) PushTyping(ctx context.Context, uid string, driverId string, event v1.WSEventType, dialogId uint32, active bool) error {
+	return nil
+}
+
+func (f *fakeConnService) SendMessage(ctx context.Context, conn service.Conn, uid, driverId string, req *model.SendRequest) {
+}
+
+func (f *fakeConnService) SetConnEventHandler(handler service.ConnEventHandler) {
+	f.handler = handler
+}
+
+func (f *fakeConnService) DispatchConnEvent(ctx context.Context, ev *service.ConnEvent) bool {
+	return f.handler != nil && f.handler(ev)
+}
+
+func (f *fakeConnService) PingInterval() time.Duration { return time.Second }
+
+func (f *fakeConnService) IdleTimeout() time.Duration { return 5 * time.Second }
+
+// newTestServer 启动只注册了原生websocket和sse接口的服务，用户id通过uid参数指定
+func newTestServer(t *testing.T) (*httptest.Server, *Handler, *fakeConnService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	conns := newFakeConnService()
+	h := &Handler{logger: zap.NewNop(), conns: conns}
+	conns.SetConnEventHandler(h.handleSseEvent)
+
+	r := gin.New()
+	r.Use(middleware.EncryptionMiddleware(encryption.NewEncryptor(nil, "", "", 0, false)))
+	r.Use(func(c *gin.Context) {
+		c.Set(constants.UserID, c.Query("uid"))
+		c.Set(constants.DriverID, "d1")
+	})
+	r.GET("/api/v1/push/raw", h.rawWs)
+	r.GET("/api/v1/push/sse", h.sse)
+	r.POST("/api/v1/push/sse/event", h.sseEvent)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, h, conns
+}
+
+func dialRaw(t *testing.T, srv *httptest.Server, uid string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/push/raw?uid=" + uid
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = ws.Close() })
+	return ws
+}
+
+func readFrame(t *testing.T, ws *websocket.Conn) *model.Frame {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(3 * time.Second))
+	frame := &model.Frame{}
+	if err := ws.ReadJSON(frame); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	return frame
+}
+
+func waitSid(t *testing.T, ch chan string) string {
+	t.Helper()
+	select {
+	case sid := <-ch:
+		return sid
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out")
+		return ""
+	}
+}
+
+func TestRawWsEvents(t *testing.T) {
+	srv, _, conns := newTestServer(t)
+	ws := dialRaw(t, srv, "u1")
+
+	// 上线后加入房间
+	sid := waitSid(t, conns.opened)
+	if !strings.HasPrefix(sid, "ws-") || !conns.joined(sid) {
+		t.Fatalf("connection %q did not join the room", sid)
+	}
+
+	if err := ws.WriteJSON(&model.Frame{Event: "ping"}); err != nil {
+		t.Fatalf("write ping: %v", err)
+	}
+	if frame := readFrame(t, ws); frame.Event != "pong" {
+		t.Fatalf("event = %q, want pong", frame.Event)
+	}
+
+	// 无效的帧返回错误，连接不断开
+	if err := ws.WriteMessage(websocket.TextMessage, []byte("not json")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if frame := readFrame(t, ws); frame.Event != "error" {
+		t.Fatalf("event = %q, want error", frame.Event)
+	}
+
+	// data可以是对象，也可以和socket.io一样是json字符串
+	if err := ws.WriteJSON(&model.Frame{Event: "ack", Data: json.RawMessage(`{"ids":["1","2"]}`)}); err != nil {
+		t.Fatalf("write ack: %v", err)
+	}
+	if err := ws.WriteJSON(&model.Frame{Event: "ack", Data: json.RawMessage(`"{\"ids\":[\"3\"]}"`)}); err != nil {
+		t.Fatalf("write ack: %v", err)
+	}
+	if err := ws.WriteJSON(&model.Frame{Event: "ping"}); err != nil {
+		t.Fatalf("write ping: %v", err)
+	}
+	readFrame(t, ws)
+	if got := strings.Join(conns.acked(sid), ","); got != "1,2,3" {
+		t.Fatalf("acked = %s, want 1,2,3", got)
+	}
+
+	// 客户端断开后离开房间并下线
+	_ = ws.Close()
+	if closed := waitSid(t, conns.closed); closed != sid {
+		t.Fatalf("closed %q, want %q", closed, sid)
+	}
+	if conns.joined(sid) {
+		t.Fatal("connection still in the room after close")
+	}
+}
+
+func TestRawWsOpenFailed(t *testing.T) {
+	srv, _, conns := newTestServer(t)
+	conns.wsErr = code.MyCustomErrorCode.CustomMessage("登录设备超出限制")
+	ws := dialRaw(t, srv, "u1")
+
+	frame := readFrame(t, ws)
+	if frame.Event != "error" || frameText(frame.Data) != "登录设备超出限制" {
+		t.Fatalf("frame = %s %s, want the open error", frame.Event, frame.Data)
+	}
+	_ = ws.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, _, err := ws.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseNormalClosure {
+		t.Fatalf("read err = %v, want normal closure", err)
+	}
+	if conns.roomsLen() != 0 {
+		t.Fatal("connection still in the room after open failed")
+	}
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"fmt"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	"github.com/cossim/coss-server/internal/push/service"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/http/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// sseConn sse连接，只能推送，客户端事件通过单独的请求发送
+type sseConn struct {
+	id     string
+	userID string
+	send   chan []byte
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newSseConn(userID string) *sseConn {
+	return &sseConn{
+		id:     "sse-" + xid.New().String(),
+		userID: userID,
+		send:   make(chan []byte, connSendBuffer),
+		done:   make(chan struct{}),
+	}
+}
+
+func (c *sseConn) ID() string {
+	return c.id
+}
+
+func (c *sseConn) Emit(event string, v ...interface{}) {
+	data := []byte("null")
+	if len(v) > 0 {
+		b, err := frameData(v[0])
+		if err != nil {
+			return
+		}
+		data = b
+	}
+	// 数据是单行json，不需要按行拆分
+	b := []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event, data))
+	select {
+	case <-c.done:
+	case c.send <- b:
+	default:
+		_ = c.Close()
+	}
+}
+
+func (c *sseConn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+	})
+	return nil
+}
+
+// sse
+// @Summary sse推送
+// @Tags Push
+// @Description 只需要接收推送的客户端通过该接口接收推送，推送内容、加密和离线消息与socket.io相同
+// @Description 连接建立后先推送open事件 {"sid": "连接id"}，之后每个事件的data为socket.io事件的参数，json字符串会直接内嵌为对象
+// @Description 离线消息需要通过 POST /api/v1/push/sse/event 发送ack事件确认
+// @Param token query string true "token"
+// @Router /api/v1/push/sse [get]
+func (h *Handler) sse(c *gin.Context) {
+	info := &connInfo{
+		userID:   c.Value(constants.UserID).(string),
+		driverID: c.Value(constants.DriverID).(string),
+	}
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		response.SetFail(c, "不支持sse", nil)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭nginx的缓冲
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	conn := newSseConn(info.userID)
+	conn.Emit("open", &model.SseOpenData{Sid: conn.ID()})
+	h.sseConns.Store(conn.ID(), conn)
+	defer h.sseConns.Delete(conn.ID())
+
+	if err := h.openConn(conn, info, requestToken(c)); err != nil {
+		conn.Emit("error", code.Cause(err).Message())
+		h.writeSse(c, flusher, conn)
+		return
+	}
+
+	// 按心跳间隔发送注释行保活，避免代理因为连接空闲而断开，写入失败时按下线处理
+	ticker := time.NewTicker(h.conns.PingInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			_ = conn.Close()
+		case <-conn.done:
+			h.writeSse(c, flusher, conn)
+			h.closeConn(conn, info)
+			return
+		case b := <-conn.send:
+			if _, err := c.Writer.Write(b); err != nil {
+				_ = conn.Close()
+				continue
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := c.Writer.Write([]byte(": ping\n\n")); err != nil {
+				_ = conn.Close()
+				continue
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeSse 发送已经排队的事件
+func (h *Handler) writeSse(c *gin.Context, flusher http.Flusher, conn *sseConn) {
+	for {
+		select {
+		case b := <-conn.send:
+			if _, err := c.Writer.Write(b); err != nil {
+				return
+			}
+		default:
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+// sseEvent
+// @Summary sse客户端事件
+// @Tags Push
+// @Description sse连接发送ack、typing、recording和send事件，data与socket.io事件的参数相同，send事件的结果通过sse连接的send_ack事件返回
+// @Description 集群模式下请求不需要发送到sse连接所在的节点，由连接所在的节点处理
+// @Accept json
+// @Param request body model.SseEventRequest true "request"
+// @Router /api/v1/push/sse/event [post]
+func (h *Handler) sseEvent(c *gin.Context) {
+	req := &model.SseEventRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	ev := &service.ConnEvent{
+		Sid:      req.Sid,
+		UserID:   c.Value(constants.UserID).(string),
+		DriverID: c.Value(constants.DriverID).(string),
+		Event:    req.Event,
+		Data:     frameText(req.Data),
+	}
+	if !h.conns.DispatchConnEvent(c.Request.Context(), ev) {
+		response.SetFail(c, "连接不存在", nil)
+		return
+	}
+	response.SetSuccess(c, "发送成功", nil)
+}
+
+// handleSseEvent 处理本节点上sse连接的客户端事件，连接不在本节点或者不属于该用户时返回false
+func (h *Handler) handleSseEvent(ev *service.ConnEvent) bool {
+	v, ok := h.sseConns.Load(ev.Sid)
+	if !ok || v.(*sseConn).userID != ev.UserID {
+		return false
+	}
+	h.handleClientEvent(v.(*sseConn), &connInfo{userID: ev.UserID, driverID: ev.DriverID}, ev.Event, ev.Data)
+	return true
+}
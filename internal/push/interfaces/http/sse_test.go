@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	"github.com/cossim/coss-server/pkg/http/response"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseTestEvent struct {
+	event string
+	data  string
+}
+
+// openSse 建立sse连接，返回open事件中的连接id和之后收到的事件
+func openSse(t *testing.T, srv *httptest.Server, uid string) (string, chan sseTestEvent, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/push/sse?uid="+uid, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("open sse: %v", err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+
+	events := make(chan sseTestEvent, 16)
+	go func() {
+		defer resp.Body.Close()
+		defer close(events)
+		ev := sseTestEvent{}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				ev.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				ev.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && ev.event != "":
+				events <- ev
+				ev = sseTestEvent{}
+			}
+		}
+	}()
+
+	open := nextSseEvent(t, events)
+	if open.event != "open" {
+		t.Fatalf("first event = %q, want open", open.event)
+	}
+	data := &model.SseOpenData{}
+	if err := json.Unmarshal([]byte(open.data), data); err != nil || !strings.HasPrefix(data.Sid, "sse-") {
+		t.Fatalf("open data = %s", open.data)
+	}
+	return data.Sid, events, cancel
+}
+
+func nextSseEvent(t *testing.T, events chan sseTestEvent) sseTestEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("sse connection closed")
+		}
+		return ev
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for sse event")
+		return sseTestEvent{}
+	}
+}
+
+func postSseEvent(t *testing.T, srv *httptest.Server, uid string, req *model.SseEventRequest) *response.BaseResponse {
+	t.Helper()
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	resp, err := http.Post(srv.URL+"/api/v1/push/sse/event?uid="+uid, "application/json", bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("post sse event: %v", err)
+	}
+	defer resp.Body.Close()
+	result := &response.BaseResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return result
+}
+
+func TestSseEvents(t *testing.T) {
+	srv, h, conns := newTestServer(t)
+	sid, events, cancel := openSse(t, srv, "u1")
+
+	// 上线后加入房间
+	if opened := waitSid(t, conns.opened); opened != sid || !conns.joined(sid) {
+		t.Fatalf("connection %q did not join the room", sid)
+	}
+
+	if resp := postSseEvent(t, srv, "u1", &model.SseEventRequest{Sid: sid, Event: "ping"}); resp.Code != 200 {
+		t.Fatalf("ping response = %+v", resp)
+	}
+	if ev := nextSseEvent(t, events); ev.event != "pong" {
+		t.Fatalf("event = %q, want pong", ev.event)
+	}
+
+	if resp := postSseEvent(t, srv, "u1", &model.SseEventRequest{Sid: sid, Event: "ack", Data: json.RawMessage(`{"ids":["1","2"]}`)}); resp.Code != 200 {
+		t.Fatalf("ack response = %+v", resp)
+	}
+	if got := strings.Join(conns.acked(sid), ","); got != "1,2" {
+		t.Fatalf("acked = %s, want 1,2", got)
+	}
+
+	// 其他用户不能通过连接id发送事件
+	if resp := postSseEvent(t, srv, "u2", &model.SseEventRequest{Sid: sid, Event: "ack", Data: json.RawMessage(`{"ids":["3"]}`)}); resp.Code != 400 {
+		t.Fatalf("ack of another user = %+v, want fail", resp)
+	}
+	if got := strings.Join(conns.acked(sid), ","); got != "1,2" {
+		t.Fatalf("acked = %s, want 1,2", got)
+	}
+
+	// 客户端断开后离开房间并下线，之后的事件找不到连接
+	cancel()
+	if closed := waitSid(t, conns.closed); closed != sid {
+		t.Fatalf("closed %q, want %q", closed, sid)
+	}
+	if conns.joined(sid) {
+		t.Fatal("connection still in the room after close")
+	}
+	if _, ok := h.sseConns.Load(sid); ok {
+		t.Fatal("connection not removed after close")
+	}
+	if resp := postSseEvent(t, srv, "u1", &model.SseEventRequest{Sid: sid, Event: "ping"}); resp.Code != 400 {
+		t.Fatalf("event after close = %+v, want fail", resp)
+	}
+}
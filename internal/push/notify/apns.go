@@ -0,0 +1,160 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultAPNsEndpoint = "https://api.push.apple.com"
+
+	// apple要求token在20到60分钟之间刷新
+	apnsTokenTTL = 50 * time.Minute
+)
+
+// APNsProvider 使用token方式鉴权的苹果推送
+type APNsProvider struct {
+	endpoint string
+	keyID    string
+	teamID   string
+	topic    string
+	key      *ecdsa.PrivateKey
+	client   *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNsProvider(endpoint, keyID, teamID, topic string, key *ecdsa.PrivateKey, client *http.Client) *APNsProvider {
+	if endpoint == "" {
+		endpoint = DefaultAPNsEndpoint
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &APNsProvider{
+		endpoint: endpoint,
+		keyID:    keyID,
+		teamID:   teamID,
+		topic:    topic,
+		key:      key,
+		client:   client,
+	}
+}
+
+// LoadAPNsKey 读取apple开发者后台下载的.p8私钥
+func LoadAPNsKey(file string) (*ecdsa.PrivateKey, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseECPrivateKeyFromPEM(b)
+}
+
+type apnsAlert struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type apnsAps struct {
+	Alert apnsAlert `json:"alert"`
+	Badge *int      `json:"badge,omitempty"`
+	Sound string    `json:"sound,omitempty"`
+}
+
+type apnsError struct {
+	Reason string `json:"reason"`
+}
+
+func (p *APNsProvider) Push(ctx context.Context, token string, n *Notification) error {
+	payload := map[string]interface{}{}
+	for k, v := range n.Data {
+		payload[k] = v
+	}
+	aps := apnsAps{
+		Alert: apnsAlert{Title: n.Title, Body: n.Body},
+		Sound: "default",
+	}
+	if n.Badge > 0 {
+		badge := n.Badge
+		aps.Badge = &badge
+	}
+	payload["aps"] = aps
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	bearer, err := p.bearer()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authorization", "bearer "+bearer)
+	req.Header.Set("apns-topic", p.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var e apnsError
+	b, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(b, &e)
+	switch {
+	case resp.StatusCode == http.StatusGone, e.Reason == "BadDeviceToken", e.Reason == "Unregistered", e.Reason == "DeviceTokenNotForTopic":
+		return ErrInvalidToken
+	case e.Reason == "ExpiredProviderToken":
+		p.resetToken()
+	}
+	return fmt.Errorf("apns push failed: status %d reason %s", resp.StatusCode, e.Reason)
+}
+
+// bearer 返回缓存的鉴权token，apple限制了token的刷新频率，不能每次请求都重新签名
+func (p *APNsProvider) bearer() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && time.Since(p.issuedAt) < apnsTokenTTL {
+		return p.token, nil
+	}
+
+	now := time.Now()
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.teamID,
+		"iat": now.Unix(),
+	})
+	t.Header["kid"] = p.keyID
+	s, err := t.SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+	p.token = s
+	p.issuedAt = now
+	return s, nil
+}
+
+func (p *APNsProvider) resetToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+}
@@ -0,0 +1,221 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFCMEndpoint = "https://fcm.googleapis.com"
+
+	fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+	// 提前刷新access token，避免请求过程中过期
+	fcmTokenRefreshAhead = time.Minute
+)
+
+// FCMCredentials firebase服务账号密钥文件中用到的字段
+type FCMCredentials struct {
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// LoadFCMCredentials 读取firebase后台下载的服务账号json文件
+func LoadFCMCredentials(file string) (*FCMCredentials, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &FCMCredentials{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// FCMProvider 通过HTTP v1接口推送到安卓设备
+type FCMProvider struct {
+	endpoint    string
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	key         *rsa.PrivateKey
+	client      *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expireAt    time.Time
+}
+
+func NewFCMProvider(endpoint string, cred *FCMCredentials, client *http.Client) (*FCMProvider, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(cred.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == "" {
+		endpoint = DefaultFCMEndpoint
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &FCMProvider{
+		endpoint:    endpoint,
+		projectID:   cred.ProjectID,
+		clientEmail: cred.ClientEmail,
+		tokenURI:    cred.TokenURI,
+		key:         key,
+		client:      client,
+	}, nil
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmAndroidNotification struct {
+	NotificationCount int `json:"notification_count,omitempty"`
+}
+
+type fcmAndroid struct {
+	Priority     string                  `json:"priority"`
+	Notification *fcmAndroidNotification `json:"notification,omitempty"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      fcmAndroid        `json:"android"`
+}
+
+type fcmError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (p *FCMProvider) Push(ctx context.Context, token string, n *Notification) error {
+	msg := fcmMessage{
+		Token:        token,
+		Notification: fcmNotification{Title: n.Title, Body: n.Body},
+		Data:         n.Data,
+		Android:      fcmAndroid{Priority: "high"},
+	}
+	if n.Badge > 0 {
+		msg.Android.Notification = &fcmAndroidNotification{NotificationCount: n.Badge}
+	}
+	body, err := json.Marshal(map[string]interface{}{"message": msg})
+	if err != nil {
+		return err
+	}
+
+	accessToken, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/projects/%s/messages:send", p.endpoint, p.projectID), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var e fcmError
+	b, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(b, &e)
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrInvalidToken
+	}
+	for _, d := range e.Error.Details {
+		if d.ErrorCode == "UNREGISTERED" {
+			return ErrInvalidToken
+		}
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		p.resetToken()
+	}
+	return fmt.Errorf("fcm push failed: status %d %s", resp.StatusCode, e.Error.Message)
+}
+
+// token 用服务账号签名的jwt换取access token，过期前一直复用
+func (p *FCMProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.accessToken != "" && time.Now().Before(p.expireAt) {
+		return p.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   p.clientEmail,
+		"scope": fcmScope,
+		"aud":   p.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fcm get access token failed: status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	p.accessToken = result.AccessToken
+	p.expireAt = now.Add(time.Duration(result.ExpiresIn)*time.Second - fcmTokenRefreshAhead)
+	return p.accessToken, nil
+}
+
+func (p *FCMProvider) resetToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.accessToken = ""
+}
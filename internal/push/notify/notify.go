@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	"github.com/cossim/coss-server/pkg/email"
+	"github.com/cossim/coss-server/pkg/email/smtp"
+	"strings"
+	"sync"
+)
+
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+)
+
+var (
+	// ErrInvalidToken 设备token已经失效，调用方应该停止向该设备推送
+	ErrInvalidToken = errors.New("invalid device token")
+	// ErrNoProvider 没有配置对应的推送渠道
+	ErrNoProvider = errors.New("notify provider not configured")
+)
+
+// Notification 系统通知栏展示的内容
+type Notification struct {
+	Title string
+	Body  string
+	Badge int
+	// 客户端点击通知后使用的自定义数据
+	Data map[string]string
+}
+
+// MobileProvider 手机系统推送渠道，例如APNs、FCM
+type MobileProvider interface {
+	Push(ctx context.Context, token string, n *Notification) error
+}
+
+// SMSProvider 短信渠道
+type SMSProvider interface {
+	SendSMS(ctx context.Context, phone string, content string) error
+}
+
+// Notifier 管理各个推送渠道，渠道都可以替换，测试时可以指向本地的假服务
+type Notifier struct {
+	mu     sync.RWMutex
+	mobile map[string]MobileProvider
+	email  email.EmailProvider
+	sms    SMSProvider
+}
+
+func NewNotifier() *Notifier {
+	return &Notifier{
+		mobile: make(map[string]MobileProvider),
+	}
+}
+
+// New 根据配置创建启用的推送渠道
+func New(ac *pkgconfig.AppConfig) (*Notifier, error) {
+	n := NewNotifier()
+	cfg := ac.Push.Notify
+
+	if cfg.APNs.Enable {
+		key, err := LoadAPNsKey(cfg.APNs.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		topic := cfg.APNs.Topic
+		if topic == "" {
+			topic = ac.Push.PlatformAppID[PlatformIOS]
+		}
+		n.RegisterMobile(PlatformIOS, NewAPNsProvider(cfg.APNs.Endpoint, cfg.APNs.KeyID, cfg.APNs.TeamID, topic, key, nil))
+	}
+
+	if cfg.FCM.Enable {
+		cred, err := LoadFCMCredentials(cfg.FCM.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		p, err := NewFCMProvider(cfg.FCM.Endpoint, cred, nil)
+		if err != nil {
+			return nil, err
+		}
+		n.RegisterMobile(PlatformAndroid, p)
+	}
+
+	if cfg.SMS.Enable {
+		n.SetSMS(NewHTTPSMSProvider(cfg.SMS.Endpoint, cfg.SMS.APIKey, cfg.SMS.Sign, nil))
+	}
+
+	if ac.Email.Enable {
+		p, err := smtp.NewSmtpStorage(ac.Email.SmtpServer, ac.Email.Port, ac.Email.Username, ac.Email.Password)
+		if err != nil {
+			return nil, err
+		}
+		n.SetEmail(p)
+	}
+
+	return n, nil
+}
+
+// RegisterMobile 注册平台对应的推送渠道，platform与用户登录时上报的platform一致
+func (n *Notifier) RegisterMobile(platform string, p MobileProvider) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.mobile[strings.ToLower(platform)] = p
+}
+
+func (n *Notifier) SetEmail(p email.EmailProvider) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.email = p
+}
+
+func (n *Notifier) SetSMS(p SMSProvider) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sms = p
+}
+
+// HasMobile 是否配置了平台对应的推送渠道
+func (n *Notifier) HasMobile(platform string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	_, ok := n.mobile[strings.ToLower(platform)]
+	return ok
+}
+
+// PushMobile 根据设备平台选择渠道推送
+func (n *Notifier) PushMobile(ctx context.Context, platform string, token string, notification *Notification) error {
+	n.mu.RLock()
+	p, ok := n.mobile[strings.ToLower(platform)]
+	n.mu.RUnlock()
+	if !ok {
+		return ErrNoProvider
+	}
+	if token == "" {
+		return ErrInvalidToken
+	}
+	return p.Push(ctx, token, notification)
+}
+
+func (n *Notifier) SendEmail(to, subject, body string) error {
+	n.mu.RLock()
+	p := n.email
+	n.mu.RUnlock()
+	if p == nil {
+		return ErrNoProvider
+	}
+	return p.SendEmail(to, subject, body)
+}
+
+func (n *Notifier) SendSMS(ctx context.Context, phone string, content string) error {
+	n.mu.RLock()
+	p := n.sms
+	n.mu.RUnlock()
+	if p == nil {
+		return ErrNoProvider
+	}
+	return p.SendSMS(ctx, phone, content)
+}
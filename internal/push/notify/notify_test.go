@@ -0,0 +1,254 @@
+package notify_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cossim/coss-server/internal/push/notify"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeAPNs 模拟apple推送服务，invalid中的token返回410
+type fakeAPNs struct {
+	mu       sync.Mutex
+	key      *ecdsa.PrivateKey
+	invalid  map[string]bool
+	payloads map[string]map[string]interface{}
+	bearers  map[string]bool
+}
+
+func (f *fakeAPNs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bearer := strings.TrimPrefix(r.Header.Get("authorization"), "bearer ")
+	if _, err := jwt.Parse(bearer, func(t *jwt.Token) (interface{}, error) {
+		return &f.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"})); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"reason":"InvalidProviderToken"}`))
+		return
+	}
+	f.bearers[bearer] = true
+	if r.Header.Get("apns-topic") != "com.example.app" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"reason":"TopicDisallowed"}`))
+		return
+	}
+
+	token := strings.TrimPrefix(r.URL.Path, "/3/device/")
+	if f.invalid[token] {
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte(`{"reason":"Unregistered"}`))
+		return
+	}
+	var payload map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+	f.payloads[token] = payload
+}
+
+// fakeFCM 同时模拟google的oauth token接口和fcm的发送接口
+type fakeFCM struct {
+	mu         sync.Mutex
+	tokenCalls int
+	invalid    map[string]bool
+	messages   map[string]map[string]interface{}
+}
+
+func (f *fakeFCM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		_ = r.ParseForm()
+		if r.PostForm.Get("assertion") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.tokenCalls++
+		_, _ = w.Write([]byte(`{"access_token":"fake-access-token","expires_in":3600}`))
+		return
+	}
+
+	if r.URL.Path != "/v1/projects/demo/messages:send" || r.Header.Get("Authorization") != "Bearer fake-access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var body struct {
+		Message map[string]interface{} `json:"message"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	token, _ := body.Message["token"].(string)
+	if f.invalid[token] {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+		return
+	}
+	f.messages[token] = body.Message
+}
+
+type fakeEmail struct {
+	sent []string
+}
+
+func (f *fakeEmail) SendEmail(to, subject, body string) error {
+	f.sent = append(f.sent, to+"|"+subject+"|"+body)
+	return nil
+}
+
+func (f *fakeEmail) GenerateEmailVerificationContent(addr string, userId string, key string) string {
+	return ""
+}
+
+func TestAPNsProvider(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeAPNs{
+		key:      key,
+		invalid:  map[string]bool{"expired": true},
+		payloads: map[string]map[string]interface{}{},
+		bearers:  map[string]bool{},
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	n := notify.NewNotifier()
+	n.RegisterMobile(notify.PlatformIOS, notify.NewAPNsProvider(srv.URL, "KEY", "TEAM", "com.example.app", key, srv.Client()))
+
+	ctx := context.Background()
+	msg := &notify.Notification{Title: "alice", Body: "hello", Badge: 2, Data: map[string]string{"dialog_id": "7"}}
+	if err := n.PushMobile(ctx, "iOS", "device-1", msg); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if err := n.PushMobile(ctx, "ios", "device-2", msg); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+
+	payload := fake.payloads["device-1"]
+	aps, _ := payload["aps"].(map[string]interface{})
+	alert, _ := aps["alert"].(map[string]interface{})
+	if alert["title"] != "alice" || alert["body"] != "hello" || aps["badge"] != float64(2) || payload["dialog_id"] != "7" {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+	if len(fake.bearers) != 1 {
+		t.Fatalf("provider token should be reused, got %d tokens", len(fake.bearers))
+	}
+
+	if err := n.PushMobile(ctx, "ios", "expired", msg); !errors.Is(err, notify.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+	if err := n.PushMobile(ctx, notify.PlatformAndroid, "device-1", msg); !errors.Is(err, notify.ErrNoProvider) {
+		t.Fatalf("expected ErrNoProvider, got %v", err)
+	}
+}
+
+func TestFCMProvider(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeFCM{
+		invalid:  map[string]bool{"expired": true},
+		messages: map[string]map[string]interface{}{},
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	p, err := notify.NewFCMProvider(srv.URL, &notify.FCMCredentials{
+		ProjectID:   "demo",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		ClientEmail: "push@demo.iam.gserviceaccount.com",
+		TokenURI:    srv.URL + "/token",
+	}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := notify.NewNotifier()
+	n.RegisterMobile(notify.PlatformAndroid, p)
+
+	ctx := context.Background()
+	msg := &notify.Notification{Title: "bob", Body: "[图片]", Data: map[string]string{"msg_id": "3"}}
+	for _, token := range []string{"device-1", "device-2"} {
+		if err := n.PushMobile(ctx, notify.PlatformAndroid, token, msg); err != nil {
+			t.Fatalf("push failed: %v", err)
+		}
+	}
+
+	m := fake.messages["device-1"]
+	notification, _ := m["notification"].(map[string]interface{})
+	data, _ := m["data"].(map[string]interface{})
+	if notification["title"] != "bob" || notification["body"] != "[图片]" || data["msg_id"] != "3" {
+		t.Fatalf("unexpected message: %v", m)
+	}
+	if fake.tokenCalls != 1 {
+		t.Fatalf("access token should be reused, got %d token calls", fake.tokenCalls)
+	}
+
+	if err := n.PushMobile(ctx, notify.PlatformAndroid, "expired", msg); !errors.Is(err, notify.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestSMSAndEmail(t *testing.T) {
+	var got []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, body)
+	}))
+	defer srv.Close()
+
+	n := notify.NewNotifier()
+	ctx := context.Background()
+	if err := n.SendSMS(ctx, "13800000000", "hi"); !errors.Is(err, notify.ErrNoProvider) {
+		t.Fatalf("expected ErrNoProvider, got %v", err)
+	}
+	if err := n.SendEmail("a@example.com", "s", "b"); !errors.Is(err, notify.ErrNoProvider) {
+		t.Fatalf("expected ErrNoProvider, got %v", err)
+	}
+
+	n.SetSMS(notify.NewHTTPSMSProvider(srv.URL, "secret", "coss", srv.Client()))
+	if err := n.SendSMS(ctx, "13800000000", "hi"); err != nil {
+		t.Fatalf("send sms failed: %v", err)
+	}
+	if len(got) != 1 || got[0]["phone"] != "13800000000" || got[0]["content"] != "hi" || got[0]["sign"] != "coss" {
+		t.Fatalf("unexpected sms request: %v", got)
+	}
+
+	n.SetSMS(notify.NewHTTPSMSProvider(srv.URL, "wrong", "", srv.Client()))
+	if err := n.SendSMS(ctx, "13800000000", "hi"); err == nil {
+		t.Fatal("expected error when gateway rejects the request")
+	}
+
+	email := &fakeEmail{}
+	n.SetEmail(email)
+	if err := n.SendEmail("a@example.com", "subject", "body"); err != nil {
+		t.Fatalf("send email failed: %v", err)
+	}
+	if len(email.sent) != 1 || email.sent[0] != "a@example.com|subject|body" {
+		t.Fatalf("unexpected email: %v", email.sent)
+	}
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSMSProvider 通过http短信网关发送短信，网关负责对接具体的短信服务商
+type HTTPSMSProvider struct {
+	endpoint string
+	apiKey   string
+	sign     string
+	client   *http.Client
+}
+
+func NewHTTPSMSProvider(endpoint, apiKey, sign string, client *http.Client) *HTTPSMSProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPSMSProvider{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		sign:     sign,
+		client:   client,
+	}
+}
+
+type smsRequest struct {
+	Phone   string `json:"phone"`
+	Content string `json:"content"`
+	Sign    string `json:"sign,omitempty"`
+}
+
+func (p *HTTPSMSProvider) SendSMS(ctx context.Context, phone string, content string) error {
+	body, err := json.Marshal(&smsRequest{
+		Phone:   phone,
+		Content: content,
+		Sign:    p.sign,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("send sms failed: status %d", resp.StatusCode)
+	}
+	return nil
+}
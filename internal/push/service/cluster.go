@@ -0,0 +1,596 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	clusterNamespace = "/"
+	clusterEvent     = "reply"
+
+	// 节点存活标记，节点定时续期，过期后其他节点不再把推送转发给它
+	clusterNodeKeyPrefix = "push:cluster:node:"
+	// 用户在各个节点上的连接数，field为节点id
+	clusterRoomKeyPrefix = "push:cluster:room:"
+	// 节点上有连接的用户，用于节点退出时清理
+	clusterNodeRoomsKeyPrefix = "push:cluster:rooms:"
+	// 节点订阅的频道，其他节点把需要该节点推送的消息发布到这里
+	clusterNodeChannelPrefix = "push:cluster:channel:"
+	// 所有节点都订阅的频道
+	clusterBroadcastChannel = "push:cluster:broadcast"
+
+	clusterNodeTTL       = 30 * time.Second
+	clusterHeartbeatTick = 10 * time.Second
+	clusterOpTimeout     = 3 * time.Second
+	// 等待其他节点确认推送结果的时间，超时按未推送处理
+	clusterAckTimeout = time.Second
+)
+
+type clusterMessageType string
+
+const (
+	clusterMessageRoom   clusterMessageType = "room"   // 推送到房间
+	clusterMessageRemove clusterMessageType = "remove" // 断开连接
+	clusterMessageClose  clusterMessageType = "close"  // 推送后断开房间的所有连接
+	clusterMessageAck    clusterMessageType = "ack"    // 房间推送的结果
+	clusterMessageEvent  clusterMessageType = "event"  // 客户端事件，由连接所在的节点处理
+)
+
+// clusterMessage 节点之间转发的消息
+type clusterMessage struct {
+	Type    clusterMessageType `json:"type"`
+	From    string             `json:"from"`
+	Room    string             `json:"room,omitempty"`
+	Sid     string             `json:"sid,omitempty"`
+	Except  string             `json:"except,omitempty"`
+	Message string             `json:"message,omitempty"`
+	// ID 不为空时接收的节点需要回复推送结果
+	ID     string `json:"id,omitempty"`
+	Pushed bool   `json:"pushed,omitempty"`
+	// 客户端事件的设备和事件名，Room为用户id，Message为事件参数
+	Driver string `json:"driver,omitempty"`
+	Event  string `json:"event,omitempty"`
+}
+
+// cluster 多个push实例之间共享在线状态并转发推送
+// 每个节点只记录本地的连接数，推送时按记录把消息转发到用户连接所在的节点
+type cluster struct {
+	nodeID string
+	client *redis.Client
+	local  *localRooms
+	logger *zap.Logger
+	// handleEvent 处理其他节点转发的客户端事件，返回连接是否在本节点
+	handleEvent func(ev *ConnEvent) bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// 等待其他节点回复推送结果的请求
+	acksMu sync.Mutex
+	acks   map[string]chan bool
+}
+
+func newCluster(nodeID string, client *redis.Client, local *localRooms, logger *zap.Logger) *cluster {
+	if nodeID == "" {
+		nodeID = xid.New().String()
+	}
+	return &cluster{
+		nodeID: nodeID,
+		client: client,
+		local:  local,
+		logger: logger,
+		acks:   make(map[string]chan bool),
+	}
+}
+
+func (c *cluster) start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := c.heartbeat(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	pubsub := c.client.Subscribe(ctx, clusterNodeChannelPrefix+c.nodeID, clusterBroadcastChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		_ = pubsub.Close()
+		return err
+	}
+	c.cancel = cancel
+
+	c.wg.Add(2)
+	go func() {
+		defer c.wg.Done()
+		defer pubsub.Close()
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-ch:
+				if !ok {
+					return
+				}
+				c.handleMessage(m.Payload)
+			}
+		}
+	}()
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(clusterHeartbeatTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.heartbeat(ctx); err != nil {
+					c.logger.Error("push节点心跳失败", zap.String("node", c.nodeID), zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	c.logger.Info("push集群节点已启动", zap.String("node", c.nodeID))
+	return nil
+}
+
+// stop 停止转发并清理本节点的在线记录
+func (c *cluster) stop(ctx context.Context) error {
+	c.mu.Lock()
+	cancel := c.cancel
+	c.cancel = nil
+	c.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	c.wg.Wait()
+
+	rooms, err := c.client.SMembers(ctx, clusterNodeRoomsKeyPrefix+c.nodeID).Result()
+	if err != nil {
+		return err
+	}
+	pipe := c.client.Pipeline()
+	for _, room := range rooms {
+		pipe.HDel(ctx, clusterRoomKeyPrefix+room, c.nodeID)
+	}
+	pipe.Del(ctx, clusterNodeRoomsKeyPrefix+c.nodeID, clusterNodeKeyPrefix+c.nodeID)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// heartbeat 续期节点存活标记，并用本地连接数修正在线记录
+func (c *cluster) heartbeat(ctx context.Context) error {
+	if err := c.client.Set(ctx, clusterNodeKeyPrefix+c.nodeID, 1, clusterNodeTTL).Err(); err != nil {
+		return err
+	}
+	rooms, err := c.client.SMembers(ctx, clusterNodeRoomsKeyPrefix+c.nodeID).Result()
+	if err != nil {
+		return err
+	}
+	for _, room := range rooms {
+		if err := c.sync(ctx, room); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sync 把本地房间的连接数写入在线记录，连接加入或离开房间后调用
+func (c *cluster) sync(ctx context.Context, room string) error {
+	n := c.local.len(room)
+	pipe := c.client.TxPipeline()
+	if n > 0 {
+		pipe.HSet(ctx, clusterRoomKeyPrefix+room, c.nodeID, n)
+		pipe.SAdd(ctx, clusterNodeRoomsKeyPrefix+c.nodeID, room)
+	} else {
+		pipe.HDel(ctx, clusterRoomKeyPrefix+room, c.nodeID)
+		pipe.SRem(ctx, clusterNodeRoomsKeyPrefix+c.nodeID, room)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// roomNodes 返回房间在其他存活节点上的连接数
+func (c *cluster) roomNodes(ctx context.Context, room string) (map[string]int, error) {
+	fields, err := c.client.HGetAll(ctx, clusterRoomKeyPrefix+room).Result()
+	if err != nil {
+		return nil, err
+	}
+	delete(fields, c.nodeID)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	nodes := make([]string, 0, len(fields))
+	pipe := c.client.Pipeline()
+	exists := make([]*redis.IntCmd, 0, len(fields))
+	for node := range fields {
+		nodes = append(nodes, node)
+		exists = append(exists, pipe.Exists(ctx, clusterNodeKeyPrefix+node))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		// 节点已经退出但没有清理干净的记录直接忽略
+		if exists[i].Val() == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(fields[node])
+		if err != nil || n <= 0 {
+			continue
+		}
+		result[node] = n
+	}
+	return result, nil
+}
+
+// roomLen 返回房间在整个集群中的连接数
+func (c *cluster) roomLen(ctx context.Context, room string) int {
+	n := c.local.len(room)
+	nodes, err := c.roomNodes(ctx, room)
+	if err != nil {
+		c.logger.Error("获取集群在线状态失败", zap.String("room", room), zap.Error(err))
+		return n
+	}
+	for _, v := range nodes {
+		n += v
+	}
+	return n
+}
+
+// broadcastToRoom 推送到房间在所有节点上的连接，返回是否有连接收到
+// 本地没有连接时等待其他节点确认，节点没有订阅或者没有及时确认都按未推送处理，由调用方保存离线消息
+func (c *cluster) broadcastToRoom(ctx context.Context, room string, message string) bool {
+	pushed := c.local.broadcast(room, clusterEvent, message)
+
+	nodes, err := c.roomNodes(ctx, room)
+	if err != nil {
+		c.logger.Error("获取集群在线状态失败", zap.String("room", room), zap.Error(err))
+		return pushed
+	}
+	if len(nodes) == 0 {
+		return pushed
+	}
+
+	m := &clusterMessage{
+		Type:    clusterMessageRoom,
+		From:    c.nodeID,
+		Room:    room,
+		Message: message,
+	}
+	var acks chan bool
+	if !pushed {
+		m.ID = xid.New().String()
+		acks = c.waitAcks(m.ID, len(nodes))
+		defer c.removeAcks(m.ID)
+	}
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return pushed
+	}
+	sent := 0
+	for node := range nodes {
+		n, err := c.client.Publish(ctx, clusterNodeChannelPrefix+node, payload).Result()
+		if err != nil {
+			c.logger.Error("转发推送失败", zap.String("node", node), zap.Error(err))
+			continue
+		}
+		// 节点没有订阅频道，消息不会被处理
+		if n > 0 {
+			sent++
+		}
+	}
+	if pushed {
+		return true
+	}
+	return c.collectAcks(ctx, acks, sent, room)
+}
+
+// collectAcks 等待n个节点回复，有一个节点确认时返回true
+func (c *cluster) collectAcks(ctx context.Context, acks chan bool, n int, room string) bool {
+	timer := time.NewTimer(clusterAckTimeout)
+	defer timer.Stop()
+	for ; n > 0; n-- {
+		select {
+		case ok := <-acks:
+			if ok {
+				return true
+			}
+		case <-timer.C:
+			c.logger.Warn("等待推送结果超时", zap.String("room", room))
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return false
+}
+
+// dispatchEvent 把客户端事件转发到用户有连接的其他节点，返回是否有节点找到连接并处理
+func (c *cluster) dispatchEvent(ctx context.Context, ev *ConnEvent) bool {
+	nodes, err := c.roomNodes(ctx, ev.UserID)
+	if err != nil {
+		c.logger.Error("获取集群在线状态失败", zap.String("room", ev.UserID), zap.Error(err))
+		return false
+	}
+	if len(nodes) == 0 {
+		return false
+	}
+
+	m := &clusterMessage{
+		Type:    clusterMessageEvent,
+		From:    c.nodeID,
+		Room:    ev.UserID,
+		Sid:     ev.Sid,
+		Driver:  ev.DriverID,
+		Event:   ev.Event,
+		Message: ev.Data,
+		ID:      xid.New().String(),
+	}
+	acks := c.waitAcks(m.ID, len(nodes))
+	defer c.removeAcks(m.ID)
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return false
+	}
+	sent := 0
+	for node := range nodes {
+		n, err := c.client.Publish(ctx, clusterNodeChannelPrefix+node, payload).Result()
+		if err != nil {
+			c.logger.Error("转发客户端事件失败", zap.String("node", node), zap.Error(err))
+			continue
+		}
+		if n > 0 {
+			sent++
+		}
+	}
+	return c.collectAcks(ctx, acks, sent, ev.UserID)
+}
+
+func (c *cluster) waitAcks(id string, n int) chan bool {
+	ch := make(chan bool, n)
+	c.acksMu.Lock()
+	c.acks[id] = ch
+	c.acksMu.Unlock()
+	return ch
+}
+
+func (c *cluster) removeAcks(id string) {
+	c.acksMu.Lock()
+	delete(c.acks, id)
+	c.acksMu.Unlock()
+}
+
+// resolveAck 收到其他节点回复的推送结果
+func (c *cluster) resolveAck(id string, pushed bool) {
+	c.acksMu.Lock()
+	defer c.acksMu.Unlock()
+	ch, ok := c.acks[id]
+	if !ok {
+		return
+	}
+	select {
+	case ch <- pushed:
+	default:
+	}
+}
+
+// replyAck 回复房间推送的结果给转发的节点
+func (c *cluster) replyAck(to string, id string, pushed bool) {
+	payload, err := json.Marshal(&clusterMessage{
+		Type:   clusterMessageAck,
+		From:   c.nodeID,
+		ID:     id,
+		Pushed: pushed,
+	})
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clusterOpTimeout)
+	defer cancel()
+	if err := c.client.Publish(ctx, clusterNodeChannelPrefix+to, payload).Err(); err != nil {
+		c.logger.Error("回复推送结果失败", zap.String("node", to), zap.Error(err))
+	}
+}
+
+// closeRoom 推送后断开房间在所有节点上的连接，保留except房间中的连接
+func (c *cluster) closeRoom(ctx context.Context, room string, except string, message string) {
+	c.local.closeRoom(room, except, clusterEvent, message)
+
+	nodes, err := c.roomNodes(ctx, room)
+	if err != nil {
+		c.logger.Error("获取集群在线状态失败", zap.String("room", room), zap.Error(err))
+		return
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	payload, err := json.Marshal(&clusterMessage{
+		Type:    clusterMessageClose,
+		From:    c.nodeID,
+		Room:    room,
+		Except:  except,
+		Message: message,
+	})
+	if err != nil {
+		return
+	}
+	for node := range nodes {
+		if err := c.client.Publish(ctx, clusterNodeChannelPrefix+node, payload).Err(); err != nil {
+			c.logger.Error("转发断开连接失败", zap.String("node", node), zap.Error(err))
+		}
+	}
+}
+
+// remove 断开连接，连接可能在任意节点上
+func (c *cluster) remove(ctx context.Context, sid string) {
+	go c.local.remove(sid)
+
+	payload, err := json.Marshal(&clusterMessage{
+		Type: clusterMessageRemove,
+		From: c.nodeID,
+		Sid:  sid,
+	})
+	if err != nil {
+		return
+	}
+	if err := c.client.Publish(ctx, clusterBroadcastChannel, payload).Err(); err != nil {
+		c.logger.Error("转发断开连接失败", zap.String("sid", sid), zap.Error(err))
+	}
+}
+
+func (c *cluster) handleMessage(payload string) {
+	var m clusterMessage
+	if err := json.Unmarshal([]byte(payload), &m); err != nil {
+		c.logger.Error("解析集群消息失败", zap.Error(err))
+		return
+	}
+	if m.From == c.nodeID {
+		return
+	}
+
+	switch m.Type {
+	case clusterMessageRoom:
+		pushed := c.local.broadcast(m.Room, clusterEvent, m.Message)
+		if m.ID != "" {
+			c.replyAck(m.From, m.ID, pushed)
+		}
+	case clusterMessageAck:
+		c.resolveAck(m.ID, m.Pushed)
+	case clusterMessageRemove:
+		c.local.remove(m.Sid)
+	case clusterMessageClose:
+		c.local.closeRoom(m.Room, m.Except, clusterEvent, m.Message)
+	case clusterMessageEvent:
+		ev := &ConnEvent{Sid: m.Sid, UserID: m.Room, DriverID: m.Driver, Event: m.Event, Data: m.Message}
+		// 事件处理可能需要访问其他服务，不阻塞订阅协程
+		go func() {
+			c.replyAck(m.From, m.ID, c.handleEvent != nil && c.handleEvent(ev))
+		}()
+	}
+}
+
+// roomLen 用户在线的连接数，开启集群时统计所有节点
+func (s *Service) roomLen(ctx context.Context, uid string) int {
+	if s.cluster == nil {
+		return s.local.len(uid)
+	}
+	ctx, cancel := context.WithTimeout(ctx, clusterOpTimeout)
+	defer cancel()
+	return s.cluster.roomLen(ctx, uid)
+}
+
+// broadcastToRoom 推送到用户的所有连接，返回是否确认有连接收到
+func (s *Service) broadcastToRoom(ctx context.Context, uid string, message string) bool {
+	if s.cluster == nil {
+		return s.local.broadcast(uid, clusterEvent, message)
+	}
+	ctx, cancel := context.WithTimeout(ctx, clusterOpTimeout)
+	defer cancel()
+	return s.cluster.broadcastToRoom(ctx, uid, message)
+}
+
+func (s *Service) removeConn(ctx context.Context, sid string) {
+	if s.cluster == nil {
+		go s.local.remove(sid)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, clusterOpTimeout)
+	defer cancel()
+	s.cluster.remove(ctx, sid)
+}
+
+// closeRoom 推送后断开房间的所有连接，except不为空时保留该房间中的连接，开启集群时包括其他节点上的连接
+func (s *Service) closeRoom(ctx context.Context, room string, except string, message string) {
+	if s.cluster == nil {
+		s.local.closeRoom(room, except, clusterEvent, message)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, clusterOpTimeout)
+	defer cancel()
+	s.cluster.closeRoom(ctx, room, except, message)
+}
+
+// syncRoom 连接加入或离开房间后更新集群中的在线记录
+func (s *Service) syncRoom(ctx context.Context, uid string) {
+	if s.cluster == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, clusterOpTimeout)
+	defer cancel()
+	if err := s.cluster.sync(ctx, uid); err != nil {
+		s.logger.Error("更新集群在线状态失败", zap.String("uid", uid), zap.Error(err))
+	}
+}
+
+// ConnEvent 客户端通过单独的请求发送的连接事件，例如sse连接的ack
+type ConnEvent struct {
+	Sid      string
+	UserID   string
+	DriverID string
+	Event    string
+	Data     string
+}
+
+// ConnEventHandler 处理本节点上连接的客户端事件，连接不在本节点时返回false
+type ConnEventHandler func(ev *ConnEvent) bool
+
+// SetConnEventHandler 设置处理客户端事件的方法，开启集群时也用于处理其他节点转发的事件
+func (s *Service) SetConnEventHandler(handler ConnEventHandler) {
+	s.connEventMu.Lock()
+	defer s.connEventMu.Unlock()
+	s.connEvent = handler
+}
+
+func (s *Service) handleConnEvent(ev *ConnEvent) bool {
+	s.connEventMu.RLock()
+	handler := s.connEvent
+	s.connEventMu.RUnlock()
+	return handler != nil && handler(ev)
+}
+
+// DispatchConnEvent 由连接所在的节点处理客户端事件，返回是否找到连接
+// 请求可能被负载均衡到其他节点，开启集群时本节点没有该连接则转发到用户有连接的节点
+func (s *Service) DispatchConnEvent(ctx context.Context, ev *ConnEvent) bool {
+	if s.handleConnEvent(ev) {
+		return true
+	}
+	if s.cluster == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, clusterOpTimeout)
+	defer cancel()
+	return s.cluster.dispatchEvent(ctx, ev)
+}
+
+// LeaveCluster 节点退出集群，清理本节点的在线记录
+func (s *Service) LeaveCluster(ctx context.Context) error {
+	if s.cluster == nil {
+		return nil
+	}
+	return s.cluster.stop(ctx)
+}
+
+// ClusterEnabled 是否以集群模式运行
+func (s *Service) ClusterEnabled() bool {
+	return s.cluster != nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	socketio "github.com/googollee/go-socket.io"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+)
+
+// syncConn 可以在其他节点的订阅协程中并发推送的连接
+type syncConn struct {
+	id string
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func (c *syncConn) ID() string { return c.id }
+
+func (c *syncConn) Emit(event string, v ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, v[0].(string))
+}
+
+func (c *syncConn) Close() error { return nil }
+
+func (c *syncConn) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.messages)
+}
+
+func newTestCluster(t *testing.T, mr *miniredis.Miniredis, nodeID string) *cluster {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	// 注册命名空间后socket.io房间的连接数才会从0开始计算
+	server := socketio.NewServer(nil)
+	server.OnConnect(clusterNamespace, func(socketio.Conn) error { return nil })
+	return newCluster(nodeID, client, newLocalRooms(server), zap.NewNop())
+}
+
+func startTestCluster(t *testing.T, c *cluster) {
+	t.Helper()
+	if err := c.start(); err != nil {
+		t.Fatalf("start cluster %s: %v", c.nodeID, err)
+	}
+	t.Cleanup(func() { _ = c.stop(context.Background()) })
+}
+
+func TestClusterBroadcastToRemoteRoom(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestCluster(t, mr, "a")
+	b := newTestCluster(t, mr, "b")
+	startTestCluster(t, a)
+	startTestCluster(t, b)
+	ctx := context.Background()
+
+	conn := &syncConn{id: "c1"}
+	b.local.join("u1", conn)
+	if err := b.sync(ctx, "u1"); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	// 其他节点确认推送到连接后才算推送成功
+	if !a.broadcastToRoom(ctx, "u1", "hello") {
+		t.Fatal("expected message to be pushed through node b")
+	}
+	if conn.len() != 1 {
+		t.Fatalf("expected 1 message on node b, got %d", conn.len())
+	}
+
+	// 在线记录还没有更新时连接已经断开，其他节点回复未推送
+	b.local.leave("u1", conn)
+	if a.broadcastToRoom(ctx, "u1", "hello") {
+		t.Fatal("expected message not to be pushed after the connection left")
+	}
+	if len(a.acks) != 0 {
+		t.Fatalf("expected pending acks to be removed, got %d", len(a.acks))
+	}
+}
+
+func TestClusterBroadcastToUnsubscribedNode(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestCluster(t, mr, "a")
+	startTestCluster(t, a)
+	ctx := context.Background()
+
+	// 节点的存活标记还没有过期，但已经不再订阅频道
+	c := newTestCluster(t, mr, "c")
+	c.local.join("u1", &syncConn{id: "c1"})
+	if err := c.heartbeat(ctx); err != nil {
+		t.Fatalf("heartbeat: %v", err)
+	}
+	if err := c.sync(ctx, "u1"); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if n := a.roomLen(ctx, "u1"); n != 1 {
+		t.Fatalf("expected 1 remote connection, got %d", n)
+	}
+	if a.broadcastToRoom(ctx, "u1", "hello") {
+		t.Fatal("expected message forwarded to an unsubscribed node not to be pushed")
+	}
+
+	// 本地有连接时直接返回
+	a.local.join("u1", &syncConn{id: "c2"})
+	if !a.broadcastToRoom(ctx, "u1", "hello") {
+		t.Fatal("expected local connection to receive message")
+	}
+}
+
+func TestClusterDispatchEventToRemoteConn(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestCluster(t, mr, "a")
+	b := newTestCluster(t, mr, "b")
+	handled := make(chan *ConnEvent, 1)
+	b.handleEvent = func(ev *ConnEvent) bool {
+		if ev.Sid != "sse-1" {
+			return false
+		}
+		handled <- ev
+		return true
+	}
+	startTestCluster(t, a)
+	startTestCluster(t, b)
+	ctx := context.Background()
+
+	b.local.join("u1", &syncConn{id: "sse-1"})
+	if err := b.sync(ctx, "u1"); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	ev := &ConnEvent{Sid: "sse-1", UserID: "u1", DriverID: "d1", Event: "ack", Data: `{"ids":["1"]}`}
+	if !a.dispatchEvent(ctx, ev) {
+		t.Fatal("expected event to be handled by node b")
+	}
+	got := <-handled
+	if *got != *ev {
+		t.Fatalf("handled event = %+v, want %+v", got, ev)
+	}
+
+	// 连接不在任何节点上
+	if a.dispatchEvent(ctx, &ConnEvent{Sid: "sse-2", UserID: "u1", Event: "ack"}) {
+		t.Fatal("expected unknown connection not to be handled")
+	}
+	if a.dispatchEvent(ctx, &ConnEvent{Sid: "sse-1", UserID: "u2", Event: "ack"}) {
+		t.Fatal("expected event of an offline user not to be forwarded")
+	}
+	if len(a.acks) != 0 {
+		t.Fatalf("expected pending acks to be removed, got %d", len(a.acks))
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	socketio "github.com/googollee/go-socket.io"
+	"sync"
+)
+
+// Conn 推送连接，socket.io、原生websocket和sse连接都通过该接口推送
+type Conn interface {
+	ID() string
+	Emit(event string, v ...interface{})
+	Close() error
+}
+
+var _ Conn = socketio.Conn(nil)
+
+// localRooms 本节点上所有用户的连接，socket.io连接由socket server管理，其他连接保存在这里
+type localRooms struct {
+	server *socketio.Server
+
+	mu    sync.RWMutex
+	rooms map[string]map[string]Conn
+	conns map[string]Conn
+}
+
+func newLocalRooms(server *socketio.Server) *localRooms {
+	return &localRooms{
+		server: server,
+		rooms:  make(map[string]map[string]Conn),
+		conns:  make(map[string]Conn),
+	}
+}
+
+func (r *localRooms) join(room string, conn Conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conns, ok := r.rooms[room]
+	if !ok {
+		conns = make(map[string]Conn)
+		r.rooms[room] = conns
+	}
+	conns[conn.ID()] = conn
+	r.conns[conn.ID()] = conn
+}
+
+func (r *localRooms) leave(room string, conn Conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.conns, conn.ID())
+	conns, ok := r.rooms[room]
+	if !ok {
+		return
+	}
+	delete(conns, conn.ID())
+	if len(conns) == 0 {
+		delete(r.rooms, room)
+	}
+}
+
+func (r *localRooms) len(room string) int {
+	r.mu.RLock()
+	n := len(r.rooms[room])
+	r.mu.RUnlock()
+	return n + r.server.RoomLen(clusterNamespace, room)
+}
+
+// broadcast 推送到房间在本节点上的所有连接，返回是否有连接
+func (r *localRooms) broadcast(room string, event string, message string) bool {
+	pushed := false
+	if r.server.RoomLen(clusterNamespace, room) > 0 {
+		go r.server.BroadcastToRoom(clusterNamespace, room, event, message)
+		pushed = true
+	}
+
+	r.mu.RLock()
+	conns := make([]Conn, 0, len(r.rooms[room]))
+	for _, c := range r.rooms[room] {
+		conns = append(conns, c)
+	}
+	r.mu.RUnlock()
+	for _, c := range conns {
+		c.Emit(event, message)
+		pushed = true
+	}
+	return pushed
+}
+
+// closeRoom 推送消息后断开房间在本节点上的所有连接，except不为空时保留同时在该房间中的连接
+func (r *localRooms) closeRoom(room string, except string, event string, message string) {
+	// 在ForEach中断开连接会在持有房间锁时离开房间，先取出连接再断开
+	sockets := make([]socketio.Conn, 0)
+	r.server.ForEach(clusterNamespace, room, func(c socketio.Conn) {
+		sockets = append(sockets, c)
+	})
+	conns := make([]Conn, 0, len(sockets))
+	for _, c := range sockets {
+		if except != "" && inRoom(c.Rooms(), except) {
+			continue
+		}
+		conns = append(conns, c)
+	}
+	r.mu.RLock()
+	for id, c := range r.rooms[room] {
+		if _, ok := r.rooms[except][id]; except != "" && ok {
+			continue
+		}
+		conns = append(conns, c)
+	}
+	r.mu.RUnlock()
+
+	for _, c := range conns {
+		c.Emit(event, message)
+		_ = c.Close()
+	}
+}
+
+func inRoom(rooms []string, room string) bool {
+	for _, r := range rooms {
+		if r == room {
+			return true
+		}
+	}
+	return false
+}
+
+// remove 断开本节点上的连接
+func (r *localRooms) remove(sid string) {
+	r.mu.RLock()
+	c, ok := r.conns[sid]
+	r.mu.RUnlock()
+	if ok {
+		_ = c.Close()
+		return
+	}
+	r.server.Remove(sid)
+}
+
+// JoinRoom 非socket.io连接建立后加入用户和设备的房间，socket.io连接通过Join加入
+func (s *Service) JoinRoom(uid, driverId string, conn Conn) {
+	s.local.join(uid, conn)
+	if driverId != "" {
+		s.local.join(DeviceRoom(uid, driverId), conn)
+	}
+}
+
+// LeaveRoom 非socket.io连接断开后离开用户和设备的房间
+func (s *Service) LeaveRoom(uid, driverId string, conn Conn) {
+	s.local.leave(uid, conn)
+	if driverId != "" {
+		s.local.leave(DeviceRoom(uid, driverId), conn)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	socketio "github.com/googollee/go-socket.io"
+	"testing"
+)
+
+type fakeConn struct {
+	id       string
+	events   []string
+	payloads []interface{}
+	closed   bool
+}
+
+func (c *fakeConn) ID() string { return c.id }
+
+func (c *fakeConn) Emit(event string, v ...interface{}) {
+	c.events = append(c.events, event)
+	c.payloads = append(c.payloads, v...)
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestLocalRoomsCloseRoomExcept(t *testing.T) {
+	r := newLocalRooms(socketio.NewServer(nil))
+	current := &fakeConn{id: "c1"}
+	other := &fakeConn{id: "c2"}
+	// 登录记录已经过期的连接没有设备房间，也需要断开
+	stale := &fakeConn{id: "c3"}
+	r.join("u1", current)
+	r.join(DeviceRoom("u1", "d1"), current)
+	r.join("u1", other)
+	r.join(DeviceRoom("u1", "d2"), other)
+	r.join("u1", stale)
+
+	r.closeRoom("u1", DeviceRoom("u1", "d1"), clusterEvent, "offline")
+
+	if current.closed || len(current.events) != 0 {
+		t.Fatalf("excluded device should keep connection")
+	}
+	for _, c := range []*fakeConn{other, stale} {
+		if !c.closed || len(c.events) != 1 {
+			t.Fatalf("conn %s should receive message and be closed", c.id)
+		}
+	}
+
+	r.closeRoom("u1", "", clusterEvent, "offline")
+	if !current.closed {
+		t.Fatalf("all connections should be closed without except room")
+	}
+}
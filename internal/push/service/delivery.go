@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/utils"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	any "github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+	"time"
+)
+
+const confirmDeliveredTimeout = 5 * time.Second
+
+// deliveryReceipt 已经推送到用户设备、需要回执的消息
+type deliveryReceipt struct {
+	userMsgIds  []uint32
+	groupMsgIds []uint32
+}
+
+func (r *deliveryReceipt) empty() bool {
+	return len(r.userMsgIds) == 0 && len(r.groupMsgIds) == 0
+}
+
+// add 从推送的事件中取出消息id，非消息事件直接忽略
+func (r *deliveryReceipt) add(event pushgrpcv1.WSEventType, data map[string]interface{}) {
+	id, ok := data["msg_id"].(float64)
+	if !ok || id <= 0 {
+		return
+	}
+	switch event {
+	case pushgrpcv1.WSEventType_SendUserMessageEvent, pushgrpcv1.WSEventType_SendSilentUserMessageEvent:
+		r.userMsgIds = append(r.userMsgIds, uint32(id))
+	case pushgrpcv1.WSEventType_SendGroupMessageEvent, pushgrpcv1.WSEventType_SendSilentGroupMessageEvent:
+		r.groupMsgIds = append(r.groupMsgIds, uint32(id))
+	}
+}
+
+// addMessage 解析离线队列中的消息，加密过的消息无法解析，不做回执
+func (r *deliveryReceipt) addMessage(msg map[string]interface{}) {
+	event, ok := msg["event"].(float64)
+	if !ok {
+		return
+	}
+	data, ok := msg["data"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	r.add(pushgrpcv1.WSEventType(event), data)
+}
+
+func isDeliverableEvent(event pushgrpcv1.WSEventType) bool {
+	switch event {
+	case pushgrpcv1.WSEventType_SendUserMessageEvent, pushgrpcv1.WSEventType_SendSilentUserMessageEvent,
+		pushgrpcv1.WSEventType_SendGroupMessageEvent, pushgrpcv1.WSEventType_SendSilentGroupMessageEvent:
+		return true
+	}
+	return false
+}
+
+// msgDelivered 消息已经推送到用户设备，记录送达并通知发送者
+func (s *Service) msgDelivered(uid string, msg *pushgrpcv1.WsMsg) {
+	if !isDeliverableEvent(msg.Event) || msg.Data == nil {
+		return
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(msg.Data.Value, &data); err != nil {
+		return
+	}
+	receipt := &deliveryReceipt{}
+	receipt.add(msg.Event, data)
+	go s.confirmDelivered(uid, receipt)
+}
+
+func (s *Service) confirmDelivered(uid string, receipt *deliveryReceipt) {
+	if s.msgService == nil || receipt.empty() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), confirmDeliveredTimeout)
+	defer cancel()
+
+	resp, err := s.msgService.ConfirmMessagesDelivered(ctx, &msggrpcv1.ConfirmMessagesDeliveredRequest{
+		UserId:      uid,
+		UserMsgIds:  receipt.userMsgIds,
+		GroupMsgIds: receipt.groupMsgIds,
+	})
+	if err != nil {
+		s.logger.Error("确认消息送达失败", zap.String("uid", uid), zap.Error(err))
+		return
+	}
+
+	for _, v := range resp.List {
+		//自己发给自己的消息不需要回执
+		if v.SenderId == "" || v.SenderId == uid {
+			continue
+		}
+		bytes, err := utils.StructToBytes(&constants.MessageDeliveredEventData{
+			MsgId:          v.MsgId,
+			DialogId:       v.DialogId,
+			GroupId:        v.GroupId,
+			UserId:         uid,
+			DeliveredAt:    v.DeliveredAt,
+			DeliveredCount: int(v.DeliveredCount),
+		})
+		if err != nil {
+			s.logger.Error("序列化失败", zap.Error(err))
+			continue
+		}
+		_, err = s.PushWs(ctx, &pushgrpcv1.WsMsg{
+			Uid:    v.SenderId,
+			Event:  pushgrpcv1.WSEventType_MessageDeliveredEvent,
+			SendAt: pkgtime.Now(),
+			Data:   &any.Any{Value: bytes},
+		})
+		if err != nil {
+			s.logger.Error("推送送达回执失败", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"reflect"
+	"testing"
+)
+
+func TestDeliveryReceiptAdd(t *testing.T) {
+	r := &deliveryReceipt{}
+	if !r.empty() {
+		t.Fatal("expected new receipt to be empty")
+	}
+
+	r.add(pushgrpcv1.WSEventType_SendUserMessageEvent, map[string]interface{}{"msg_id": float64(1)})
+	r.add(pushgrpcv1.WSEventType_SendSilentUserMessageEvent, map[string]interface{}{"msg_id": float64(2)})
+	r.add(pushgrpcv1.WSEventType_SendGroupMessageEvent, map[string]interface{}{"msg_id": float64(3)})
+	r.add(pushgrpcv1.WSEventType_SendSilentGroupMessageEvent, map[string]interface{}{"msg_id": float64(4)})
+	// 非消息事件和没有消息id的事件不做回执
+	r.add(pushgrpcv1.WSEventType_OnlineEvent, map[string]interface{}{"msg_id": float64(5)})
+	r.add(pushgrpcv1.WSEventType_SendUserMessageEvent, map[string]interface{}{"msg_id": "6"})
+	r.add(pushgrpcv1.WSEventType_SendUserMessageEvent, map[string]interface{}{})
+
+	if !reflect.DeepEqual(r.userMsgIds, []uint32{1, 2}) {
+		t.Fatalf("user msg ids = %v", r.userMsgIds)
+	}
+	if !reflect.DeepEqual(r.groupMsgIds, []uint32{3, 4}) {
+		t.Fatalf("group msg ids = %v", r.groupMsgIds)
+	}
+}
+
+func TestDeliveryReceiptAddMessage(t *testing.T) {
+	r := &deliveryReceipt{}
+	r.addMessage(map[string]interface{}{
+		"event": float64(pushgrpcv1.WSEventType_SendGroupMessageEvent),
+		"data":  map[string]interface{}{"msg_id": float64(7)},
+	})
+	// 加密后的离线消息data是字符串，无法解析
+	r.addMessage(map[string]interface{}{
+		"event": float64(pushgrpcv1.WSEventType_SendUserMessageEvent),
+		"data":  "-----BEGIN PGP MESSAGE-----",
+	})
+	r.addMessage(map[string]interface{}{"data": map[string]interface{}{"msg_id": float64(8)}})
+
+	if len(r.userMsgIds) != 0 || !reflect.DeepEqual(r.groupMsgIds, []uint32{7}) {
+		t.Fatalf("unexpected receipt: %+v", r)
+	}
+}
+
+func TestIsDeliverableEvent(t *testing.T) {
+	for event, want := range map[pushgrpcv1.WSEventType]bool{
+		pushgrpcv1.WSEventType_SendUserMessageEvent:        true,
+		pushgrpcv1.WSEventType_SendSilentGroupMessageEvent: true,
+		pushgrpcv1.WSEventType_MessageDeliveredEvent:       false,
+		pushgrpcv1.WSEventType_OnlineEvent:                 false,
+	} {
+		if got := isDeliverableEvent(event); got != want {
+			t.Errorf("isDeliverableEvent(%s) = %v, want %v", event, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/msg_queue"
+	"github.com/cossim/coss-server/pkg/utils"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	any "github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+)
+
+// DisconnectUser 断开用户所有设备的连接，excludeDriverId不为空时保留该设备
+// 按连接所在的房间断开，不依赖登录记录，登录记录已过期的连接也会断开
+// 连接断开后和客户端主动断开一样通过WsOfflineClients下线
+func (s *Service) DisconnectUser(ctx context.Context, uid string, excludeDriverId string, reason pushgrpcv1.DisconnectReason) error {
+	except := ""
+	if excludeDriverId != "" {
+		except = DeviceRoom(uid, excludeDriverId)
+	}
+	return s.disconnectRoom(ctx, uid, uid, except, reason)
+}
+
+// DisconnectDevice 断开用户单个设备的连接
+func (s *Service) DisconnectDevice(ctx context.Context, uid string, driverId string, reason pushgrpcv1.DisconnectReason) error {
+	return s.disconnectRoom(ctx, uid, DeviceRoom(uid, driverId), "", reason)
+}
+
+// disconnectRoom 先推送下线事件告诉客户端断开的原因，客户端收到后不应该自动重连
+func (s *Service) disconnectRoom(ctx context.Context, uid string, room string, except string, reason pushgrpcv1.DisconnectReason) error {
+	bytes, err := utils.StructToBytes(&constants.OfflineEventData{Reason: int32(reason)})
+	if err != nil {
+		return err
+	}
+	js, err := wsMsgToJSON(&pushgrpcv1.WsMsg{Uid: uid, Event: pushgrpcv1.WSEventType_OfflineEvent, SendAt: pkgtime.Now(), Data: &any.Any{Value: bytes}}, false)
+	if err != nil {
+		return err
+	}
+	message, err := s.enc.GetSecretMessage(ctx, string(js), uid)
+	if err != nil {
+		return err
+	}
+	s.closeRoom(ctx, room, except, message)
+	return nil
+}
+
+// consumeServiceMessages 其他服务通过消息队列发送的UserWebsocketClose事件，数据与DisconnectDeviceRequest相同，driver_id为空时断开所有设备
+func (s *Service) consumeServiceMessages() {
+	msgs, err := s.rabbitMQClient.ConsumeServiceMessages(msg_queue.PushService, msg_queue.Service_Exchange)
+	if err != nil {
+		s.logger.Error("订阅服务消息失败", zap.Error(err))
+		return
+	}
+	for d := range msgs {
+		msg := &msg_queue.ServiceQueueMsg{}
+		if err := json.Unmarshal(d.Body, msg); err != nil {
+			s.logger.Error("解析服务消息失败", zap.Error(err))
+			continue
+		}
+		if msg.Action != msg_queue.UserWebsocketClose {
+			continue
+		}
+
+		b, err := json.Marshal(msg.Data)
+		if err != nil {
+			continue
+		}
+		req := &pushgrpcv1.DisconnectDeviceRequest{}
+		if err := json.Unmarshal(b, req); err != nil || req.UserId == "" {
+			s.logger.Error("解析断开连接请求失败", zap.String("form", string(msg.Form)), zap.Error(err))
+			continue
+		}
+		if req.DriverId == "" {
+			err = s.DisconnectUser(context.Background(), req.UserId, "", req.Reason)
+		} else {
+			err = s.DisconnectDevice(context.Background(), req.UserId, req.DriverId, req.Reason)
+		}
+		if err != nil {
+			s.logger.Error("断开用户连接失败", zap.String("uid", req.UserId), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/googollee/go-socket.io/engineio"
+	"time"
+)
+
+const (
+	defaultPingInterval = 25 * time.Second
+	defaultIdleTimeout  = 60 * time.Second
+)
+
+// PingInterval 服务端发送心跳或者要求客户端发送心跳的间隔
+func (s *Service) PingInterval() time.Duration {
+	interval := time.Duration(s.ac.Push.Heartbeat.PingInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultPingInterval
+	}
+	return interval
+}
+
+// IdleTimeout 超过该时间没有收到客户端的心跳或消息时断开连接，断开后按下线处理
+func (s *Service) IdleTimeout() time.Duration {
+	timeout := time.Duration(s.ac.Push.Heartbeat.IdleTimeout) * time.Second
+	// 至少要能等到下一次心跳
+	if timeout <= s.PingInterval() {
+		timeout = defaultIdleTimeout
+		if timeout <= s.PingInterval() {
+			timeout = 2 * s.PingInterval()
+		}
+	}
+	return timeout
+}
+
+// engineOptions socket.io客户端按ping_interval发送心跳，超过idle_timeout没有收到心跳时engine.io关闭连接
+func (s *Service) engineOptions() *engineio.Options {
+	interval := s.PingInterval()
+	return &engineio.Options{
+		PingInterval: interval,
+		PingTimeout:  s.IdleTimeout() - interval,
+	}
+}
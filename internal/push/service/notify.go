@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/push/notify"
+	usercache "github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	notifyTimeout = 10 * time.Second
+	// 通知栏内容的最大长度，超过时截断
+	notifyMaxBodyLength = 100
+)
+
+// SetNotifier 替换推送渠道，测试时使用本地的假服务
+func (s *Service) SetNotifier(n *notify.Notifier) {
+	s.notifier = n
+}
+
+func (s *Service) PushMobile(ctx context.Context, request *pushgrpcv1.PushMobileRequest) (*pushgrpcv1.PushResponse, error) {
+	n := &notify.Notification{
+		Title: request.Title,
+		Body:  request.Content,
+		Badge: int(request.Badge),
+		Data:  request.Data,
+	}
+	for _, uid := range request.UserIds {
+		if err := s.pushUserMobile(ctx, uid, n); err != nil {
+			return nil, err
+		}
+	}
+	return &pushgrpcv1.PushResponse{}, nil
+}
+
+func (s *Service) PushEmail(ctx context.Context, request *pushgrpcv1.PushEmailRequest) (*pushgrpcv1.PushResponse, error) {
+	for _, to := range request.To {
+		if err := s.notifier.SendEmail(to, request.Subject, request.Body); err != nil {
+			s.logger.Error("发送邮件失败", zap.String("to", to), zap.Error(err))
+			if errors.Is(err, notify.ErrNoProvider) {
+				return nil, code.PushErrChannelNotConfigured
+			}
+			return nil, code.PushErrSendEmailFailed
+		}
+	}
+	return &pushgrpcv1.PushResponse{}, nil
+}
+
+func (s *Service) PushMessage(ctx context.Context, request *pushgrpcv1.PushMessageRequest) (*pushgrpcv1.PushResponse, error) {
+	for _, phone := range request.Phones {
+		if err := s.notifier.SendSMS(ctx, phone, request.Content); err != nil {
+			s.logger.Error("发送短信失败", zap.String("phone", phone), zap.Error(err))
+			if errors.Is(err, notify.ErrNoProvider) {
+				return nil, code.PushErrChannelNotConfigured
+			}
+			return nil, code.PushErrSendSMSFailed
+		}
+	}
+	return &pushgrpcv1.PushResponse{}, nil
+}
+
+// pushUserMobile 推送到用户所有登录设备的通知栏，没有上报token或者平台没有配置渠道的设备跳过
+func (s *Service) pushUserMobile(ctx context.Context, uid string, n *notify.Notification) error {
+	logins, err := usercache.NewUserCacheRedisWithClient(s.redisClient.Client).GetUserLoginInfos(ctx, uid)
+	if err != nil {
+		s.logger.Error("获取用户登录信息失败", zap.String("uid", uid), zap.Error(err))
+		return err
+	}
+
+	pushed := make(map[string]bool, len(logins))
+	for _, login := range logins {
+		if login.DriverToken == "" || pushed[login.DriverToken] || !s.notifier.HasMobile(login.Platform) {
+			continue
+		}
+		pushed[login.DriverToken] = true
+
+		err := s.notifier.PushMobile(ctx, login.Platform, login.DriverToken, n)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, notify.ErrInvalidToken) {
+			s.clearDriverToken(ctx, uid, login)
+			continue
+		}
+		s.logger.Error("移动端推送失败", zap.String("uid", uid), zap.String("platform", login.Platform), zap.Error(err))
+	}
+	return nil
+}
+
+// clearDriverToken 设备token失效后不再向该设备推送，保留登录信息原来的过期时间
+func (s *Service) clearDriverToken(ctx context.Context, uid string, login *entity.UserLogin) {
+	ttl, err := s.redisClient.Client.TTL(ctx, usercache.GetUserLoginDriveKey(uid, login.DriverID)).Result()
+	if err != nil || ttl <= 0 {
+		return
+	}
+	login.DriverToken = ""
+	if err := usercache.NewUserCacheRedisWithClient(s.redisClient.Client).SetUserLoginInfo(ctx, uid, login.DriverID, login, ttl); err != nil {
+		s.logger.Error("清除失效的设备token失败", zap.String("uid", uid), zap.Error(err))
+	}
+}
+
+// notifyOffline 用户不在线时把新消息推送到设备通知栏，免打扰的消息不推送
+func (s *Service) notifyOffline(uid string, msg *pushgrpcv1.WsMsg) {
+	if s.notifier == nil || msg.Data == nil {
+		return
+	}
+	if msg.Event != pushgrpcv1.WSEventType_SendUserMessageEvent && msg.Event != pushgrpcv1.WSEventType_SendGroupMessageEvent {
+		return
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(msg.Data.Value, &data); err != nil {
+		return
+	}
+	n := offlineNotification(data)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		_ = s.pushUserMobile(ctx, uid, n)
+	}()
+}
+
+func offlineNotification(data map[string]interface{}) *notify.Notification {
+	n := &notify.Notification{
+		Data: make(map[string]string),
+	}
+	if info, ok := data["sender_info"].(map[string]interface{}); ok {
+		n.Title, _ = info["name"].(string)
+	}
+	if n.Title == "" {
+		n.Title = "新消息"
+	}
+
+	for _, k := range []string{"msg_id", "dialog_id", "group_id"} {
+		if v, ok := data[k].(float64); ok && v > 0 {
+			n.Data[k] = strconv.FormatInt(int64(v), 10)
+		}
+	}
+
+	msgType, _ := data["msg_type"].(float64)
+	content, _ := data["content"].(string)
+	burn, _ := data["is_burn_after_reading"].(bool)
+	n.Body = messagePreview(constants.UserMessageType(msgType), content, burn)
+	return n
+}
+
+// messagePreview 通知栏展示的消息摘要，阅后即焚的消息不展示内容
+func messagePreview(msgType constants.UserMessageType, content string, burn bool) string {
+	if burn {
+		return "[阅后即焚消息]"
+	}
+	switch msgType {
+	case constants.MessageTypeVoice:
+		return "[语音]"
+	case constants.MessageTypeImage:
+		return "[图片]"
+	case constants.MessageTypeFile:
+		return "[文件]"
+	case constants.MessageTypeVideo:
+		return "[视频]"
+	case constants.MessageTypeVoiceCall:
+		return "[语音通话]"
+	case constants.MessageTypeVideoCall:
+		return "[视频通话]"
+	}
+	if utf8.RuneCountInString(content) > notifyMaxBodyLength {
+		return string([]rune(content)[:notifyMaxBodyLength]) + "..."
+	}
+	if content == "" {
+		return "你收到了一条新消息"
+	}
+	return content
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/cossim/coss-server/pkg/metrics"
+	"github.com/cossim/coss-server/pkg/msg_queue"
+	"github.com/prometheus/client_golang/prometheus"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	offlineQueuePrefix = "push:offline:"
+	// 推送给客户端的离线消息中携带的确认id
+	offlineAckIDField = "ack_id"
+
+	defaultOfflineMessageTTL  = 7 * 24 * time.Hour
+	defaultOfflineMaxLength   = 10000
+	defaultOfflineMaxInflight = 200
+)
+
+var (
+	offlineEnqueuedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "push_offline_messages_enqueued_total",
+		Help: "Total number of messages saved to offline queues.",
+	})
+	offlineDeliveredTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "push_offline_messages_delivered_total",
+		Help: "Total number of offline messages pushed to clients.",
+	}, []string{"redelivered"})
+	offlineAckedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "push_offline_messages_acked_total",
+		Help: "Total number of offline messages acknowledged by clients.",
+	})
+	offlineUnacked = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "push_offline_messages_unacked",
+		Help: "Number of offline messages pushed to clients and waiting for ack.",
+	})
+	offlineBacklog = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "push_offline_queue_backlog",
+		Help:    "Number of messages in the offline queue when a client connects.",
+		Buckets: []float64{0, 1, 10, 50, 100, 500, 1000, 5000, 10000},
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(offlineEnqueuedTotal, offlineDeliveredTotal, offlineAckedTotal, offlineUnacked, offlineBacklog)
+}
+
+func offlineQueueName(uid string) string {
+	return offlineQueuePrefix + uid
+}
+
+func (s *Service) offlineQueueOptions() *msg_queue.QueueOptions {
+	cfg := s.ac.Push.OfflineQueue
+	ttl := time.Duration(cfg.MessageTTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultOfflineMessageTTL
+	}
+	maxLength := cfg.MaxLength
+	if maxLength <= 0 {
+		maxLength = defaultOfflineMaxLength
+	}
+	return &msg_queue.QueueOptions{
+		MessageTTL: ttl,
+		MaxLength:  maxLength,
+		Expires:    ttl,
+	}
+}
+
+// publishOffline 用户不在线时把消息保存到离线队列
+func (s *Service) publishOffline(uid string, message string) {
+	if _, err := s.rabbitMQClient.PublishMessageWithOptions(offlineQueueName(uid), message, s.offlineQueueOptions()); err != nil {
+		s.logger.Error("发布消息失败", zap.String("uid", uid), zap.Error(err))
+		return
+	}
+	offlineEnqueuedTotal.Inc()
+}
+
+// offlineChannel 推送离线消息使用的channel
+type offlineChannel interface {
+	Get(queue string, autoAck bool) (amqp.Delivery, bool, error)
+	Ack(tag uint64, multiple bool) error
+	Close() error
+}
+
+type pendingMessage struct {
+	tag  uint64
+	data map[string]interface{}
+}
+
+// offlineSession 一个连接上推送离线消息的过程
+// 每个会话使用独立的channel取消息，连接断开时关闭channel，未确认的消息由rabbitmq放回队列，下次连接时重新推送
+type offlineSession struct {
+	uid         string
+	queue       string
+	client      Conn
+	ch          offlineChannel
+	maxInflight int
+
+	mu      sync.Mutex
+	pending map[string]*pendingMessage
+	closed  bool
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+func (o *offlineSession) pendingLen() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending)
+}
+
+func (o *offlineSession) add(id string, p *pendingMessage) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return false
+	}
+	o.pending[id] = p
+	return true
+}
+
+func (o *offlineSession) take(id string) *pendingMessage {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	p, ok := o.pending[id]
+	if !ok {
+		return nil
+	}
+	delete(o.pending, id)
+	return p
+}
+
+func (o *offlineSession) notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *offlineSession) close() {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return
+	}
+	o.closed = true
+	offlineUnacked.Sub(float64(len(o.pending)))
+	o.pending = nil
+	close(o.done)
+	o.mu.Unlock()
+
+	_ = o.ch.Close()
+}
+
+// startOfflineSession 用户上线后推送离线消息
+func (s *Service) startOfflineSession(uid string, client Conn) error {
+	q, err := s.rabbitMQClient.DeclareQueueWithOptions(offlineQueueName(uid), s.offlineQueueOptions())
+	if err != nil {
+		return err
+	}
+	offlineBacklog.Observe(float64(q.Messages))
+	ch, err := s.rabbitMQClient.NewChannel()
+	if err != nil {
+		return err
+	}
+
+	go s.runOfflineSession(s.newOfflineSession(uid, q.Name, client, ch))
+	return nil
+}
+
+// newOfflineSession 创建连接的离线消息会话，替换该连接上已有的会话
+func (s *Service) newOfflineSession(uid, queue string, client Conn, ch offlineChannel) *offlineSession {
+	maxInflight := s.ac.Push.OfflineQueue.MaxInflight
+	if maxInflight <= 0 {
+		maxInflight = defaultOfflineMaxInflight
+	}
+	sess := &offlineSession{
+		uid:         uid,
+		queue:       queue,
+		client:      client,
+		ch:          ch,
+		maxInflight: maxInflight,
+		pending:     make(map[string]*pendingMessage),
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+
+	s.offlineMu.Lock()
+	old := s.offlineSessions[client.ID()]
+	s.offlineSessions[client.ID()] = sess
+	s.offlineMu.Unlock()
+	if old != nil {
+		old.close()
+	}
+	return sess
+}
+
+func (s *Service) runOfflineSession(sess *offlineSession) {
+	defer s.closeOfflineSession(sess.client.ID(), sess)
+	s.drainLegacyQueue(sess.uid, sess.client)
+
+	for {
+		drained, err := s.fillOfflineSession(sess)
+		if err != nil {
+			if !errors.Is(err, amqp.ErrClosed) {
+				s.logger.Error("推送离线消息失败", zap.String("uid", sess.uid), zap.Error(err))
+			}
+			return
+		}
+		// 队列已经取完并且都已确认
+		if drained && sess.pendingLen() == 0 {
+			return
+		}
+		select {
+		case <-sess.wake:
+		case <-sess.done:
+			return
+		}
+	}
+}
+
+// fillOfflineSession 从队列中取消息推送给客户端，直到等待确认的消息达到上限或者队列为空
+func (s *Service) fillOfflineSession(sess *offlineSession) (bool, error) {
+	for sess.pendingLen() < sess.maxInflight {
+		d, ok, err := sess.ch.Get(sess.queue, false)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return true, nil
+		}
+
+		data, err := decodeOfflineMessage(d.Body)
+		if err != nil {
+			// 无法解析的消息重新推送也没有意义，直接丢弃
+			s.logger.Error("转换消息失败", zap.String("uid", sess.uid), zap.Error(err))
+			_ = d.Ack(false)
+			continue
+		}
+		id := d.MessageId
+		if id == "" {
+			id = strconv.FormatUint(d.DeliveryTag, 10)
+		}
+		data[offlineAckIDField] = id
+		b, err := json.Marshal(data)
+		if err != nil {
+			_ = d.Ack(false)
+			continue
+		}
+
+		if !sess.add(id, &pendingMessage{tag: d.DeliveryTag, data: data}) {
+			return false, amqp.ErrClosed
+		}
+		offlineUnacked.Inc()
+		offlineDeliveredTotal.WithLabelValues(strconv.FormatBool(d.Redelivered)).Inc()
+		sess.client.Emit("reply", string(b))
+	}
+	return false, nil
+}
+
+// decodeOfflineMessage 队列中保存的是推送给客户端的json字符串
+func decodeOfflineMessage(body []byte) (map[string]interface{}, error) {
+	var str string
+	if err := json.Unmarshal(body, &str); err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(str), &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// AckOfflineMessages 客户端确认收到离线消息，确认后从队列中删除并记录送达
+func (s *Service) AckOfflineMessages(uid, sid string, ids []string) {
+	s.offlineMu.Lock()
+	sess := s.offlineSessions[sid]
+	s.offlineMu.Unlock()
+	// sse连接通过单独的请求确认，需要校验连接属于当前用户
+	if sess == nil || sess.uid != uid {
+		return
+	}
+
+	receipt := &deliveryReceipt{}
+	for _, id := range ids {
+		p := sess.take(id)
+		if p == nil {
+			continue
+		}
+		if err := sess.ch.Ack(p.tag, false); err != nil {
+			s.logger.Error("确认离线消息失败", zap.String("uid", sess.uid), zap.Error(err))
+			continue
+		}
+		offlineAckedTotal.Inc()
+		offlineUnacked.Dec()
+		receipt.addMessage(p.data)
+	}
+	sess.notify()
+	go s.confirmDelivered(sess.uid, receipt)
+}
+
+// closeOfflineSession 连接断开时结束会话，sess不为空时只结束该会话
+func (s *Service) closeOfflineSession(sid string, sess *offlineSession) {
+	s.offlineMu.Lock()
+	cur := s.offlineSessions[sid]
+	if cur != nil && (sess == nil || cur == sess) {
+		delete(s.offlineSessions, sid)
+	}
+	s.offlineMu.Unlock()
+
+	if sess == nil {
+		sess = cur
+	}
+	if sess != nil {
+		sess.close()
+	}
+}
+
+func (s *Service) closeAllOfflineSessions() {
+	s.offlineMu.Lock()
+	sessions := s.offlineSessions
+	s.offlineSessions = make(map[string]*offlineSession)
+	s.offlineMu.Unlock()
+
+	for _, sess := range sessions {
+		sess.close()
+	}
+}
+
+// drainLegacyQueue 推送升级前以用户id命名的离线队列，这些队列没有确认机制，推送完后删除
+func (s *Service) drainLegacyQueue(uid string, client Conn) {
+	receipt := &deliveryReceipt{}
+	defer func() {
+		s.confirmDelivered(uid, receipt)
+	}()
+	for {
+		body, ok, err := msg_queue.ConsumeMessages(uid, s.rabbitMQClient.GetChannel())
+		if err != nil || !ok {
+			_ = s.rabbitMQClient.DeleteEmptyQueue(uid)
+			return
+		}
+		data, err := decodeOfflineMessage(body)
+		if err != nil {
+			s.logger.Error("转换消息失败", zap.Error(err))
+			continue
+		}
+		wsData, err := json.Marshal(data)
+		if err != nil {
+			continue
+		}
+		client.Emit("reply", string(wsData))
+		receipt.addMessage(data)
+	}
+}
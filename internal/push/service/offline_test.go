@@ -0,0 +1,201 @@
+package service
+
+import (
+	"encoding/json"
+	pkgconfig "github.com/cossim/coss-server/pkg/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeBroker 模拟rabbitmq的单个队列，channel关闭时未确认的消息回到队列头部
+type fakeBroker struct {
+	mu       sync.Mutex
+	messages []amqp.Delivery
+}
+
+func (b *fakeBroker) publish(t *testing.T, msgID uint32) {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"event": 1, "data": map[string]interface{}{"msg_id": msgID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 队列中保存的是json字符串
+	body, err := json.Marshal(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, amqp.Delivery{MessageId: strconv.Itoa(int(msgID)), Body: body})
+}
+
+func (b *fakeBroker) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.messages)
+}
+
+type fakeChannel struct {
+	b       *fakeBroker
+	tag     uint64
+	unacked map[uint64]amqp.Delivery
+	closed  bool
+}
+
+func (b *fakeBroker) channel() *fakeChannel {
+	return &fakeChannel{b: b, unacked: make(map[uint64]amqp.Delivery)}
+}
+
+func (c *fakeChannel) Get(queue string, autoAck bool) (amqp.Delivery, bool, error) {
+	c.b.mu.Lock()
+	defer c.b.mu.Unlock()
+	if c.closed {
+		return amqp.Delivery{}, false, amqp.ErrClosed
+	}
+	if len(c.b.messages) == 0 {
+		return amqp.Delivery{}, false, nil
+	}
+	d := c.b.messages[0]
+	c.b.messages = c.b.messages[1:]
+	c.tag++
+	d.DeliveryTag = c.tag
+	c.unacked[d.DeliveryTag] = d
+	return d, true, nil
+}
+
+func (c *fakeChannel) Ack(tag uint64, multiple bool) error {
+	c.b.mu.Lock()
+	defer c.b.mu.Unlock()
+	if c.closed {
+		return amqp.ErrClosed
+	}
+	delete(c.unacked, tag)
+	return nil
+}
+
+func (c *fakeChannel) Close() error {
+	c.b.mu.Lock()
+	defer c.b.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	requeue := make([]amqp.Delivery, 0, len(c.unacked)+len(c.b.messages))
+	for tag := uint64(1); tag <= c.tag; tag++ {
+		if d, ok := c.unacked[tag]; ok {
+			d.Redelivered = true
+			requeue = append(requeue, d)
+		}
+	}
+	c.b.messages = append(requeue, c.b.messages...)
+	return nil
+}
+
+func newOfflineTestService(maxInflight int) *Service {
+	ac := &pkgconfig.AppConfig{}
+	ac.Push.OfflineQueue.MaxInflight = maxInflight
+	return &Service{
+		logger:          zap.NewNop(),
+		ac:              ac,
+		offlineSessions: make(map[string]*offlineSession),
+	}
+}
+
+// ackIDs 客户端收到的离线消息的确认id
+func ackIDs(t *testing.T, c *fakeConn) []string {
+	t.Helper()
+	ids := make([]string, 0, len(c.payloads))
+	for _, p := range c.payloads {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(p.(string)), &data); err != nil {
+			t.Fatalf("unmarshal payload: %v", err)
+		}
+		ids = append(ids, data[offlineAckIDField].(string))
+	}
+	return ids
+}
+
+func TestOfflineSessionInflightLimit(t *testing.T) {
+	s := newOfflineTestService(2)
+	b := &fakeBroker{}
+	for i := uint32(1); i <= 5; i++ {
+		b.publish(t, i)
+	}
+	client := &fakeConn{id: "c1"}
+	sess := s.newOfflineSession("u1", "q", client, b.channel())
+
+	// 等待确认的消息达到上限后停止推送
+	if drained, err := s.fillOfflineSession(sess); err != nil || drained {
+		t.Fatalf("fillOfflineSession: %v, %v", drained, err)
+	}
+	if len(client.payloads) != 2 {
+		t.Fatalf("expected 2 messages in flight, got %d", len(client.payloads))
+	}
+	if _, err := s.fillOfflineSession(sess); err != nil || len(client.payloads) != 2 {
+		t.Fatalf("expected no more messages before ack, got %d, %v", len(client.payloads), err)
+	}
+
+	// 其他用户不能确认
+	ids := ackIDs(t, client)
+	s.AckOfflineMessages("u2", "c1", ids[:1])
+	if sess.pendingLen() != 2 {
+		t.Fatalf("ack from other user should be ignored")
+	}
+
+	s.AckOfflineMessages("u1", "c1", ids[:1])
+	if sess.pendingLen() != 1 {
+		t.Fatalf("expected 1 pending message, got %d", sess.pendingLen())
+	}
+	if _, err := s.fillOfflineSession(sess); err != nil || len(client.payloads) != 3 {
+		t.Fatalf("expected 3 messages after ack, got %d, %v", len(client.payloads), err)
+	}
+}
+
+func TestOfflineSessionRedelivery(t *testing.T) {
+	s := newOfflineTestService(10)
+	b := &fakeBroker{}
+	for i := uint32(1); i <= 3; i++ {
+		b.publish(t, i)
+	}
+	client := &fakeConn{id: "c1"}
+	sess := s.newOfflineSession("u1", "q", client, b.channel())
+	if drained, err := s.fillOfflineSession(sess); err != nil || !drained {
+		t.Fatalf("fillOfflineSession: %v, %v", drained, err)
+	}
+	ids := ackIDs(t, client)
+	if len(ids) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(ids))
+	}
+	s.AckOfflineMessages("u1", "c1", ids[:1])
+
+	// 连接断开时未确认的消息回到队列
+	s.closeOfflineSession("c1", nil)
+	if b.len() != 2 {
+		t.Fatalf("expected 2 unacked messages to be requeued, got %d", b.len())
+	}
+
+	// 重新连接后按原顺序重新推送
+	client = &fakeConn{id: "c2"}
+	sess = s.newOfflineSession("u1", "q", client, b.channel())
+	if drained, err := s.fillOfflineSession(sess); err != nil || !drained {
+		t.Fatalf("fillOfflineSession: %v, %v", drained, err)
+	}
+	redelivered := ackIDs(t, client)
+	if len(redelivered) != 2 || redelivered[0] != ids[1] || redelivered[1] != ids[2] {
+		t.Fatalf("expected %v to be redelivered, got %v", ids[1:], redelivered)
+	}
+	s.AckOfflineMessages("u1", "c2", redelivered)
+	if sess.pendingLen() != 0 || b.len() != 0 {
+		t.Fatalf("expected all messages to be acked, pending %d, queued %d", sess.pendingLen(), b.len())
+	}
+
+	// 关闭会话后不再推送
+	s.closeOfflineSession("c2", nil)
+	b.publish(t, 4)
+	if _, err := s.fillOfflineSession(sess); err == nil {
+		t.Fatal("expected closed session to fail")
+	}
+}
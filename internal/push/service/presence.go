@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	usercache "github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+)
+
+// 用户状态由user服务写入，推送服务只读取并在上下线时更新最后在线时间
+func (s *Service) presenceCache() *usercache.UserCacheRedis {
+	return usercache.NewUserCacheRedisWithClient(s.redisClient.Client)
+}
+
+// 好友看到的用户状态，online表示本次事件之后用户是否还有设备在线
+func (s *Service) friendPresence(ctx context.Context, uid string, online bool) (model.FriendOnlineStatusMsg, error) {
+	p, err := s.presenceCache().GetUserPresence(ctx, uid)
+	if err != nil {
+		return model.FriendOnlineStatusMsg{}, err
+	}
+	p.Online = online
+	return toFriendOnlineStatusMsg(p.ViewFor("", true, pkgtime.Now())), nil
+}
+
+// 记录最后在线时间，隐身时不更新
+func (s *Service) updateLastSeen(ctx context.Context, uid string) {
+	cache := s.presenceCache()
+	p, err := cache.GetUserPresence(ctx, uid)
+	if err != nil {
+		s.logger.Error("获取用户状态失败", zap.Error(err))
+		return
+	}
+	if p.State == entity.PresenceInvisible {
+		return
+	}
+	if err := cache.SetUserLastSeen(ctx, uid, pkgtime.Now()); err != nil {
+		s.logger.Error("更新最后在线时间失败", zap.Error(err))
+	}
+}
+
+func toFriendOnlineStatusMsg(p *entity.Presence) model.FriendOnlineStatusMsg {
+	v := offlineEvent
+	if p.Online {
+		v = onlineEvent
+	}
+	return model.FriendOnlineStatusMsg{
+		UserId:               p.UserID,
+		Status:               int32(v),
+		State:                uint(p.State),
+		CustomStatus:         p.CustomStatus,
+		CustomStatusExpireAt: p.CustomStatusExpireAt,
+		LastSeen:             p.LastSeen,
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"testing"
+)
+
+func TestUpdateLastSeen(t *testing.T) {
+	s, _ := newRedisTestService(t)
+	ctx := context.Background()
+	cache := s.presenceCache()
+
+	s.updateLastSeen(ctx, "u1")
+	p, err := cache.GetUserPresence(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUserPresence: %v", err)
+	}
+	if p.LastSeen == 0 {
+		t.Fatal("expected last seen to be updated")
+	}
+
+	// 隐身时不更新最后在线时间
+	if err := cache.SetUserPresenceState(ctx, "u2", entity.PresenceInvisible, "", 0); err != nil {
+		t.Fatalf("SetUserPresenceState: %v", err)
+	}
+	s.updateLastSeen(ctx, "u2")
+	if p, err := cache.GetUserPresence(ctx, "u2"); err != nil || p.LastSeen != 0 {
+		t.Fatalf("expected invisible user to keep last seen, got %+v, %v", p, err)
+	}
+}
+
+func TestFriendPresence(t *testing.T) {
+	s, _ := newRedisTestService(t)
+	ctx := context.Background()
+	cache := s.presenceCache()
+	if err := cache.SetUserPresenceState(ctx, "u1", entity.PresenceBusy, "meeting", 0); err != nil {
+		t.Fatalf("SetUserPresenceState: %v", err)
+	}
+
+	msg, err := s.friendPresence(ctx, "u1", true)
+	if err != nil {
+		t.Fatalf("friendPresence: %v", err)
+	}
+	if msg.UserId != "u1" || msg.Status != int32(onlineEvent) || msg.State != uint(entity.PresenceBusy) || msg.CustomStatus != "meeting" {
+		t.Fatalf("unexpected online presence: %+v", msg)
+	}
+
+	// 隐身的用户上线时好友收到的是离线
+	if err := cache.SetUserPresenceState(ctx, "u1", entity.PresenceInvisible, "", 0); err != nil {
+		t.Fatalf("SetUserPresenceState: %v", err)
+	}
+	msg, err = s.friendPresence(ctx, "u1", true)
+	if err != nil {
+		t.Fatalf("friendPresence: %v", err)
+	}
+	if msg.Status != int32(offlineEvent) || msg.State != uint(entity.PresenceOffline) {
+		t.Fatalf("expected invisible user to be offline, got %+v", msg)
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	usercache "github.com/cossim/coss-server/internal/user/cache"
+	"go.uber.org/zap"
+)
+
+const deviceRoomPrefix = "device:"
+
+// DeviceRoom 用户单个设备的房间，连接同时加入用户的房间和设备的房间
+func DeviceRoom(uid, driverId string) string {
+	return deviceRoomPrefix + uid + ":" + driverId
+}
+
+// syncRooms 连接加入或离开房间后更新用户和设备房间的在线记录
+func (s *Service) syncRooms(ctx context.Context, uid, driverId string) {
+	s.syncRoom(ctx, uid)
+	if driverId != "" {
+		s.syncRoom(ctx, DeviceRoom(uid, driverId))
+	}
+}
+
+// routeRooms 按推送路由返回消息需要推送到的房间
+func (s *Service) routeRooms(ctx context.Context, msg *pushgrpcv1.WsMsg) []string {
+	switch msg.Route {
+	case pushgrpcv1.DeviceRoute_OnlyDevices:
+		rooms := make([]string, 0, len(msg.DriverIds))
+		for _, id := range msg.DriverIds {
+			rooms = append(rooms, DeviceRoom(msg.Uid, id))
+		}
+		return rooms
+	case pushgrpcv1.DeviceRoute_ExcludeDevice:
+		// 不知道发起操作的设备时无法排除，推送到所有设备
+		if msg.DriverId == "" {
+			return []string{msg.Uid}
+		}
+		logins, err := usercache.NewUserCacheRedisWithClient(s.redisClient.Client).GetUserLoginInfos(ctx, msg.Uid)
+		if err != nil {
+			s.logger.Error("获取用户登录信息失败", zap.String("uid", msg.Uid), zap.Error(err))
+			return nil
+		}
+		rooms := make([]string, 0, len(logins))
+		for _, login := range logins {
+			if login.DriverID == "" || login.DriverID == msg.DriverId {
+				continue
+			}
+			rooms = append(rooms, DeviceRoom(msg.Uid, login.DriverID))
+		}
+		return rooms
+	default:
+		return []string{msg.Uid}
+	}
+}
+
+// pushRouted 按推送路由推送消息，返回是否有连接收到
+func (s *Service) pushRouted(ctx context.Context, msg *pushgrpcv1.WsMsg, message string) bool {
+	pushed := false
+	for _, room := range s.routeRooms(ctx, msg) {
+		if s.broadcastToRoom(ctx, room, message) {
+			pushed = true
+		}
+	}
+	return pushed
+}
+
+// shouldPushOffline 只有推送到所有设备的消息才保存离线消息和推送通知栏，同步到其他设备的状态由设备上线后重新拉取
+func shouldPushOffline(msg *pushgrpcv1.WsMsg) bool {
+	return msg.PushOffline && msg.Route == pushgrpcv1.DeviceRoute_AllDevices
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	usercache "github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	any "github.com/golang/protobuf/ptypes/any"
+	"testing"
+)
+
+func TestPushWsRoute(t *testing.T) {
+	s, _ := newRedisTestService(t)
+	ctx := context.Background()
+	cache := usercache.NewUserCacheRedisWithClient(s.redisClient.Client)
+	for _, driverId := range []string{"d1", "d2", "d3"} {
+		if err := cache.SetUserLoginInfo(ctx, "u1", driverId, &entity.UserLogin{UserID: "u1", DriverID: driverId}, 0); err != nil {
+			t.Fatalf("SetUserLoginInfo: %v", err)
+		}
+	}
+	conns := map[string]*fakeConn{}
+	for _, driverId := range []string{"d1", "d2", "d3"} {
+		conns[driverId] = &fakeConn{id: "c-" + driverId}
+		s.JoinRoom("u1", driverId, conns[driverId])
+	}
+	received := func() map[string]int {
+		n := make(map[string]int)
+		for driverId, c := range conns {
+			n[driverId] = len(c.payloads)
+			c.payloads = nil
+		}
+		return n
+	}
+
+	tests := []struct {
+		name string
+		msg  *pushgrpcv1.WsMsg
+		want map[string]int
+	}{
+		{
+			name: "推送到所有设备",
+			msg:  &pushgrpcv1.WsMsg{DriverId: "d1"},
+			want: map[string]int{"d1": 1, "d2": 1, "d3": 1},
+		},
+		{
+			name: "排除发起操作的设备",
+			msg:  &pushgrpcv1.WsMsg{DriverId: "d1", Route: pushgrpcv1.DeviceRoute_ExcludeDevice},
+			want: map[string]int{"d1": 0, "d2": 1, "d3": 1},
+		},
+		{
+			name: "不知道发起操作的设备时推送到所有设备",
+			msg:  &pushgrpcv1.WsMsg{Route: pushgrpcv1.DeviceRoute_ExcludeDevice},
+			want: map[string]int{"d1": 1, "d2": 1, "d3": 1},
+		},
+		{
+			name: "只推送到指定的设备",
+			msg:  &pushgrpcv1.WsMsg{Route: pushgrpcv1.DeviceRoute_OnlyDevices, DriverIds: []string{"d2", "d3"}},
+			want: map[string]int{"d1": 0, "d2": 1, "d3": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.msg.Uid = "u1"
+			tt.msg.Event = pushgrpcv1.WSEventType_MessageDraftEvent
+			tt.msg.Data = &any.Any{Value: []byte(`{"dialog_id":1}`)}
+			if _, err := s.PushWs(ctx, tt.msg); err != nil {
+				t.Fatalf("PushWs: %v", err)
+			}
+			got := received()
+			for driverId, n := range tt.want {
+				if got[driverId] != n {
+					t.Fatalf("device %s received %d messages, want %d", driverId, got[driverId], n)
+				}
+			}
+		})
+	}
+
+	// 设备断开后离开设备的房间
+	s.LeaveRoom("u1", "d2", conns["d2"])
+	if _, err := s.PushWs(ctx, &pushgrpcv1.WsMsg{Uid: "u1", Event: pushgrpcv1.WSEventType_MessageDraftEvent, Route: pushgrpcv1.DeviceRoute_OnlyDevices, DriverIds: []string{"d2"}, Data: &any.Any{Value: []byte(`{}`)}}); err != nil {
+		t.Fatalf("PushWs: %v", err)
+	}
+	if got := received(); got["d2"] != 0 {
+		t.Fatalf("device d2 received %d messages after leaving", got["d2"])
+	}
+}
+
+func TestShouldPushOffline(t *testing.T) {
+	tests := []struct {
+		msg  *pushgrpcv1.WsMsg
+		want bool
+	}{
+		{&pushgrpcv1.WsMsg{PushOffline: true}, true},
+		{&pushgrpcv1.WsMsg{}, false},
+		// 同步到其他设备的状态不保存离线消息
+		{&pushgrpcv1.WsMsg{PushOffline: true, Route: pushgrpcv1.DeviceRoute_ExcludeDevice}, false},
+		{&pushgrpcv1.WsMsg{PushOffline: true, Route: pushgrpcv1.DeviceRoute_OnlyDevices}, false},
+	}
+	for _, tt := range tests {
+		if got := shouldPushOffline(tt.msg); got != tt.want {
+			t.Fatalf("shouldPushOffline(%+v) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/push/api/http/model"
+	"github.com/cossim/coss-server/pkg/code"
+	"go.uber.org/zap"
+	"time"
+)
+
+// 长连接发送消息的超时时间
+const sendTimeout = 10 * time.Second
+
+// SendMessage 客户端通过长连接发送消息，发送结果通过send_ack事件返回给发送消息的连接
+func (s *Service) SendMessage(ctx context.Context, conn Conn, uid, driverId string, req *model.SendRequest) {
+	ack := &model.SendAck{ClientMsgId: req.ClientMsgId}
+	resp, err := s.sendMessage(ctx, uid, driverId, req)
+	if err != nil {
+		c := code.Cause(err)
+		ack.Code = c.Code()
+		ack.Msg = c.Message()
+		s.logger.Debug("长连接发送消息失败", zap.String("uid", uid), zap.String("client_msg_id", req.ClientMsgId), zap.Error(err))
+	} else {
+		ack.Code = code.OK.Code()
+		ack.Msg = code.OK.Message()
+		ack.MsgId = resp.MsgId
+		ack.DialogId = resp.DialogId
+		ack.Seq = resp.Seq
+		ack.Duplicate = resp.Duplicate
+	}
+
+	js, err := json.Marshal(ack)
+	if err != nil {
+		s.logger.Error("转换发送结果失败", zap.Error(err))
+		return
+	}
+	if s.enc == nil {
+		s.logger.Error("加密客户端错误", zap.Error(nil))
+		return
+	}
+	message, err := s.enc.GetSecretMessage(ctx, string(js), uid)
+	if err != nil {
+		s.logger.Error("加密发送结果失败", zap.Error(err))
+		return
+	}
+	conn.Emit("send_ack", message)
+}
+
+func (s *Service) sendMessage(ctx context.Context, uid, driverId string, req *model.SendRequest) (*msggrpcv1.SendMessageResponse, error) {
+	if req.ClientMsgId == "" {
+		return nil, code.MsgErrInvalidClientMsgId
+	}
+	if s.msgService == nil {
+		return nil, code.InternalServerError
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return s.msgService.SendMessage(ctx, &msggrpcv1.SendMessageRequest{
+		ClientMsgId:        req.ClientMsgId,
+		SenderId:           uid,
+		DriverId:           driverId,
+		DialogId:           req.DialogId,
+		ReceiverId:         req.ReceiverId,
+		GroupId:            req.GroupId,
+		Content:            req.Content,
+		Type:               req.Type,
+		ReplyId:            req.ReplyId,
+		IsBurnAfterReading: req.IsBurnAfterReading,
+		AtUsers:            req.AtUsers,
+		AtAllUser:          req.AtAllUser,
+	})
+}
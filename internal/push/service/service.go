@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	msggrpcv1 "github.com/cossim/coss-server/internal/msg/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/push/notify"
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/cache"
 	pkgconfig "github.com/cossim/coss-server/pkg/config"
@@ -15,18 +17,31 @@ import (
 	"google.golang.org/grpc"
 	"gorm.io/gorm"
 	"strconv"
+	"sync"
 )
 
 type Service struct {
 	logger          *zap.Logger
 	rabbitMQClient  *msg_queue.RabbitMQ
 	relationService relationgrpcv1.UserRelationServiceClient
-	redisClient     *cache.RedisClient
-	ac              *pkgconfig.AppConfig
-	enc             encryption.Encryptor
+	// 检查临时状态事件的对话成员
+	relationDialogService relationgrpcv1.DialogServiceClient
+	msgService            msggrpcv1.MsgServiceClient
+	redisClient           *cache.RedisClient
+	ac                    *pkgconfig.AppConfig
+	enc                   encryption.Encryptor
 	//Buckets         map[constants.DriverType]*connect.Bucket
 	db           *gorm.DB
 	SocketServer *socketio.Server
+	local        *localRooms
+	cluster      *cluster
+	notifier     *notify.Notifier
+
+	offlineMu       sync.Mutex
+	offlineSessions map[string]*offlineSession
+
+	connEventMu sync.RWMutex
+	connEvent   ConnEventHandler
 }
 
 //var wsRid int64 = 0 //全局客户端id
@@ -53,11 +68,25 @@ func New(ac *pkgconfig.AppConfig) *Service {
 		rabbitMQClient: mqClient,
 		redisClient:    setupRedis(ac),
 		//Buckets:        make(map[constants.DriverType]*connect.Bucket),
-		db:           dbConn,
-		SocketServer: socketio.NewServer(nil),
+		db:              dbConn,
+		offlineSessions: make(map[string]*offlineSession),
 	}
+	s.SocketServer = socketio.NewServer(s.engineOptions())
 
+	s.local = newLocalRooms(s.SocketServer)
 	s.setupEncryption(ac)
+	s.notifier, err = notify.New(ac)
+	if err != nil {
+		panic(err)
+	}
+	if ac.Push.Cluster.Enable {
+		s.cluster = newCluster(ac.Push.Cluster.NodeID, s.redisClient.Client, s.local, s.logger)
+		s.cluster.handleEvent = s.handleConnEvent
+		if err := s.cluster.start(); err != nil {
+			panic(err)
+		}
+	}
+	go s.consumeServiceMessages()
 	//for _, driverType := range constants.GetDriverTypeList() {
 	//	s.Buckets[driverType] = connect.NewBucket()
 	//}
@@ -84,8 +113,9 @@ func setupRedis(ac *pkgconfig.AppConfig) *cache.RedisClient {
 
 func (s *Service) Stop(ctx context.Context) error {
 	//s.Buckets = make(map[constants.DriverType]*connect.Bucket)
+	s.closeAllOfflineSessions()
 	s.rabbitMQClient.Close()
-	return nil
+	return s.LeaveCluster(ctx)
 }
 
 func (s *Service) HandlerGrpcClient(serviceName string, conn *grpc.ClientConn) error {
@@ -93,6 +123,9 @@ func (s *Service) HandlerGrpcClient(serviceName string, conn *grpc.ClientConn) e
 	switch serviceName {
 	case "relation_service":
 		s.relationService = relationgrpcv1.NewUserRelationServiceClient(conn)
+		s.relationDialogService = relationgrpcv1.NewDialogServiceClient(conn)
+	case "msg_service":
+		s.msgService = msggrpcv1.NewMsgServiceClient(conn)
 	default:
 		return nil
 	}
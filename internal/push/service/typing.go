@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/utils"
+	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
+	any "github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	typingKeyPrefix = "push:typing:"
+	// 同一个用户在同一个对话中同类事件的最小间隔
+	typingInterval = 2 * time.Second
+	// 接收方超过该时间没有收到新的事件时清除状态，客户端应该在该时间内重复发送
+	typingTimeout = 6 * time.Second
+)
+
+var ErrTypingRateLimited = errors.New("typing event rate limited")
+
+// PushTyping 把正在输入、正在录音等临时状态推送给对话中的其他在线成员，不保存也不进入离线队列
+func (s *Service) PushTyping(ctx context.Context, uid string, driverId string, event pushgrpcv1.WSEventType, dialogId uint32, active bool) error {
+	if event != pushgrpcv1.WSEventType_TypingEvent && event != pushgrpcv1.WSEventType_RecordingEvent {
+		return code.InvalidParameter
+	}
+	if dialogId == 0 {
+		return code.InvalidParameter
+	}
+
+	// 开始和结束分开限流，避免结束事件被刚发送的开始事件拦截
+	key := fmt.Sprintf("%s%s:%d:%d:%t", typingKeyPrefix, uid, dialogId, event, active)
+	ok, err := s.redisClient.Client.SetNX(ctx, key, 1, typingInterval).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTypingRateLimited
+	}
+
+	if s.relationDialogService == nil {
+		return code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+	users, err := s.relationDialogService.GetAllUsersInConversation(ctx, &relationgrpcv1.GetAllUsersInConversationRequest{
+		DialogId: dialogId,
+	})
+	if err != nil {
+		return err
+	}
+	member := false
+	for _, v := range users.UserIds {
+		if v == uid {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return code.DialogErrGetDialogUserByDialogIDAndUserIDFailed
+	}
+
+	bytes, err := utils.StructToBytes(&constants.TypingEventData{
+		DialogId: dialogId,
+		UserId:   uid,
+		Active:   active,
+		Timeout:  typingTimeout.Milliseconds(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range users.UserIds {
+		if v == uid {
+			continue
+		}
+		_, err := s.PushWs(ctx, &pushgrpcv1.WsMsg{
+			Uid:      v,
+			Event:    event,
+			DriverId: driverId,
+			SendAt:   pkgtime.Now(),
+			Data:     &any.Any{Value: bytes},
+		})
+		if err != nil {
+			s.logger.Error("推送输入状态失败", zap.String("uid", v), zap.Error(err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
+	"github.com/cossim/coss-server/pkg/cache"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/encryption"
+	socketio "github.com/googollee/go-socket.io"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+type fakeDialogService struct {
+	relationgrpcv1.DialogServiceClient
+	users map[uint32][]string
+}
+
+func (f *fakeDialogService) GetAllUsersInConversation(ctx context.Context, in *relationgrpcv1.GetAllUsersInConversationRequest, opts ...grpc.CallOption) (*relationgrpcv1.GetAllUsersInConversationResponse, error) {
+	return &relationgrpcv1.GetAllUsersInConversationResponse{UserIds: f.users[in.DialogId]}, nil
+}
+
+// newRedisTestService 单节点的推送服务，使用miniredis并关闭加密
+func newRedisTestService(t *testing.T) (*Service, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	redisClient := cache.NewRedisClient(mr.Addr(), "")
+	t.Cleanup(func() { _ = redisClient.Client.Close() })
+	return &Service{
+		logger:      zap.NewNop(),
+		redisClient: redisClient,
+		enc:         encryption.NewEncryptor(nil, "", "", 0, false),
+		local:       newLocalRooms(socketio.NewServer(nil)),
+	}, mr
+}
+
+// wsEvents 解析连接收到的推送消息
+func wsEvents(t *testing.T, c *fakeConn) []map[string]interface{} {
+	t.Helper()
+	result := make([]map[string]interface{}, 0, len(c.payloads))
+	for _, p := range c.payloads {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(p.(string)), &data); err != nil {
+			t.Fatalf("unmarshal payload: %v", err)
+		}
+		result = append(result, data)
+	}
+	return result
+}
+
+func TestPushTyping(t *testing.T) {
+	s, mr := newRedisTestService(t)
+	s.relationDialogService = &fakeDialogService{users: map[uint32][]string{1: {"u1", "u2", "u3"}}}
+	ctx := context.Background()
+
+	sender := &fakeConn{id: "c1"}
+	u2 := &fakeConn{id: "c2"}
+	s.JoinRoom("u1", "d1", sender)
+	s.JoinRoom("u2", "d1", u2)
+
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_TypingEvent, 1, true); err != nil {
+		t.Fatalf("PushTyping: %v", err)
+	}
+	// 只推送给对话中的其他在线成员
+	if len(sender.payloads) != 0 {
+		t.Fatalf("sender received %d events", len(sender.payloads))
+	}
+	events := wsEvents(t, u2)
+	if len(events) != 1 || events[0]["event"] != float64(pushgrpcv1.WSEventType_TypingEvent) {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	data := events[0]["data"].(map[string]interface{})
+	if data["dialog_id"] != float64(1) || data["user_id"] != "u1" || data["active"] != true || data["timeout"] != float64(typingTimeout.Milliseconds()) {
+		t.Fatalf("unexpected typing data: %+v", data)
+	}
+
+	// 间隔内重复的同类事件被限流，结束事件和录音事件分开计算
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_TypingEvent, 1, true); !errors.Is(err, ErrTypingRateLimited) {
+		t.Fatalf("expected rate limit, got %v", err)
+	}
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_TypingEvent, 1, false); err != nil {
+		t.Fatalf("PushTyping stop: %v", err)
+	}
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_RecordingEvent, 1, true); err != nil {
+		t.Fatalf("PushTyping recording: %v", err)
+	}
+	if n := len(u2.payloads); n != 3 {
+		t.Fatalf("expected 3 events, got %d", n)
+	}
+	if data := wsEvents(t, u2)[1]["data"].(map[string]interface{}); data["active"] != false {
+		t.Fatalf("expected stop event, got %+v", data)
+	}
+
+	mr.FastForward(typingInterval)
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_TypingEvent, 1, true); err != nil {
+		t.Fatalf("PushTyping after interval: %v", err)
+	}
+	if n := len(u2.payloads); n != 4 {
+		t.Fatalf("expected 4 events, got %d", n)
+	}
+}
+
+func TestPushTypingRejected(t *testing.T) {
+	s, _ := newRedisTestService(t)
+	s.relationDialogService = &fakeDialogService{users: map[uint32][]string{1: {"u1", "u2"}}}
+	ctx := context.Background()
+	u2 := &fakeConn{id: "c2"}
+	s.JoinRoom("u2", "d1", u2)
+
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_SendUserMessageEvent, 1, true); !errors.Is(err, code.InvalidParameter) {
+		t.Fatalf("expected other events to be rejected, got %v", err)
+	}
+	if err := s.PushTyping(ctx, "u1", "d1", pushgrpcv1.WSEventType_TypingEvent, 0, true); !errors.Is(err, code.InvalidParameter) {
+		t.Fatalf("expected empty dialog to be rejected, got %v", err)
+	}
+	if err := s.PushTyping(ctx, "u3", "d1", pushgrpcv1.WSEventType_TypingEvent, 1, true); !errors.Is(err, code.DialogErrGetDialogUserByDialogIDAndUserIDFailed) {
+		t.Fatalf("expected non member to be rejected, got %v", err)
+	}
+	if len(u2.payloads) != 0 {
+		t.Fatalf("expected no events, got %d", len(u2.payloads))
+	}
+}
+
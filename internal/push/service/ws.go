@@ -9,21 +9,20 @@ import (
 	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	"github.com/cossim/coss-server/pkg/cache"
 	"github.com/cossim/coss-server/pkg/code"
-	"github.com/cossim/coss-server/pkg/msg_queue"
 	"github.com/cossim/coss-server/pkg/utils"
 	myos "github.com/cossim/coss-server/pkg/utils/time"
 	pkgtime "github.com/cossim/coss-server/pkg/utils/time"
 	any "github.com/golang/protobuf/ptypes/any"
-	socketio "github.com/googollee/go-socket.io"
 	"go.uber.org/zap"
-	"strconv"
 	"time"
 )
 
-func (s *Service) Ws(ctx context.Context, conn socketio.Conn, uid string, driverId string, rid, token string) error {
+func (s *Service) Ws(ctx context.Context, conn Conn, uid string, driverId string, rid, token string) error {
+	s.syncRooms(ctx, uid, driverId)
+
 	//设备限制
 	if s.ac.MultipleDeviceLimit.Enable {
-		if s.SocketServer.RoomLen("/", uid) > s.ac.MultipleDeviceLimit.Max {
+		if s.roomLen(ctx, uid) > s.ac.MultipleDeviceLimit.Max {
 			s.logger.Error("用户登录设备达到上限")
 			return code.MyCustomErrorCode.CustomMessage("登录设备超出限制")
 		}
@@ -129,7 +128,7 @@ func (s *Service) Ws(ctx context.Context, conn socketio.Conn, uid string, driver
 
 func (s *Service) PushWs(ctx context.Context, msg *pushgrpcv1.WsMsg) (*pushgrpcv1.PushResponse, error) {
 	if msg.Event == pushgrpcv1.WSEventType_OfflineEvent {
-		go s.SocketServer.Remove(msg.Rid)
+		s.removeConn(ctx, msg.Rid)
 	}
 	resp := &pushgrpcv1.PushResponse{}
 	pushd := false
@@ -142,19 +141,15 @@ func (s *Service) PushWs(ctx context.Context, msg *pushgrpcv1.WsMsg) (*pushgrpcv
 		return nil, err
 	}
 
-	if s.SocketServer.RoomLen("/", msg.Uid) > 0 {
-		go s.SocketServer.BroadcastToRoom("/", msg.Uid, "reply", message)
+	if s.pushRouted(ctx, msg, message) {
 		pushd = true
+		s.msgDelivered(msg.Uid, msg)
 	}
 
-	if msg.PushOffline && !pushd {
-		go func() {
-			//不在线则推送到消息队列
-			err := s.rabbitMQClient.PublishMessage(msg.Uid, message)
-			if err != nil {
-				s.logger.Error("发布消息失败", zap.Error(err))
-			}
-		}()
+	if shouldPushOffline(msg) && !pushd {
+		s.notifyOffline(msg.Uid, msg)
+		//不在线则推送到消息队列
+		go s.publishOffline(msg.Uid, message)
 	}
 
 	return resp, nil
@@ -173,22 +168,15 @@ func (s *Service) PushWsBatch(ctx context.Context, request *pushgrpcv1.PushWsBat
 			return nil, err
 		}
 
-		ui := s.SocketServer.RoomLen("/", msg.Uid)
-		if !msg.PushOffline && ui == 0 {
+		if s.pushRouted(ctx, msg, message) {
+			s.msgDelivered(msg.Uid, msg)
 			continue
 		}
-		if msg.PushOffline && ui == 0 {
+		if shouldPushOffline(msg) {
+			s.notifyOffline(msg.Uid, msg)
 			//不在线则推送到消息队列
-			go func() {
-				err := s.rabbitMQClient.PublishMessage(msg.Uid, message)
-				if err != nil {
-					s.logger.Error("发布消息失败：", zap.Error(err))
-				}
-			}()
-			continue
+			go s.publishOffline(msg.Uid, message)
 		}
-
-		go s.SocketServer.BroadcastToRoom("/", msg.Uid, "reply", message)
 	}
 	return resp, nil
 }
@@ -204,6 +192,8 @@ func (s *Service) PushWsBatchByUserIds(ctx context.Context, request *pushgrpcv1.
 			SendAt:      myos.Now(),
 			Data:        request.Data,
 			PushOffline: request.PushOffline,
+			Route:       request.Route,
+			DriverIds:   request.DriverIds,
 		}
 
 		bytes, err := wsMsgToJSON(msg, false)
@@ -221,29 +211,21 @@ func (s *Service) PushWsBatchByUserIds(ctx context.Context, request *pushgrpcv1.
 			return nil, err
 		}
 
-		ui := s.SocketServer.RoomLen("/", msg.Uid)
-		if !msg.PushOffline && ui == 0 {
+		if s.pushRouted(ctx, msg, message) {
+			s.msgDelivered(msg.Uid, msg)
 			continue
 		}
-		if msg.PushOffline && ui == 0 {
-			go func() {
-				//不在线则推送到消息队列
-				err := s.rabbitMQClient.PublishMessage(msg.Uid, message)
-				if err != nil {
-					s.logger.Error("发布消息失败：", zap.Error(err))
-				}
-			}()
-			continue
+		if shouldPushOffline(msg) {
+			s.notifyOffline(msg.Uid, msg)
+			//不在线则推送到消息队列
+			go s.publishOffline(msg.Uid, message)
 		}
-
-		go s.SocketServer.BroadcastToRoom("/", msg.Uid, "reply", message)
-
 	}
 	return resp, nil
 }
 
 // 用户上线
-func (s *Service) WsOnlineClients(ctx context.Context, msg *pushgrpcv1.WsMsg, client socketio.Conn) error {
+func (s *Service) WsOnlineClients(ctx context.Context, msg *pushgrpcv1.WsMsg, client Conn) error {
 
 	js, err := wsMsgToJSON(msg, false)
 	if err != nil {
@@ -263,6 +245,7 @@ func (s *Service) WsOnlineClients(ctx context.Context, msg *pushgrpcv1.WsMsg, cl
 
 	//上线推送消息
 	go client.Emit("reply", message)
+	s.updateLastSeen(ctx, msg.Uid)
 	err = s.pushAllFriendOnlineStatus(ctx, client, msg.Uid, msg.Rid)
 	if err != nil {
 		s.logger.Error("上线失败：", zap.Error(err))
@@ -280,47 +263,18 @@ func (s *Service) WsOnlineClients(ctx context.Context, msg *pushgrpcv1.WsMsg, cl
 		return err
 	}
 
-	go func() {
-		for {
-			msg2, ok, err := msg_queue.ConsumeMessages(msg.Uid, s.rabbitMQClient.GetChannel())
-			if err != nil || !ok {
-				//c.queue.Stop()
-				//拉取完之后删除队列
-				_ = s.rabbitMQClient.DeleteEmptyQueue(msg.Uid)
-				return
-			}
-
-			var a interface{}
-			err = json.Unmarshal(msg2, &a)
-			if err != nil {
-				s.logger.Error("上线失败：", zap.Error(err))
-				return
-			}
-
-			mm := a.(string)
-			// 尝试解析消息
-			var data2 map[string]interface{}
-			err = json.Unmarshal([]byte(mm), &data2)
-			if err != nil {
-				s.logger.Error("转换消息失败1", zap.Error(err))
-				return
-			}
-
-			// 尝试将解析后的数据转换为字节
-			wsData, err := json.Marshal(data2)
-			if err != nil {
-				s.logger.Error("转换消息失败2", zap.Error(err))
-				return
-			}
-
-			// 尝试将数据写入 WebSocket 连接
-			go client.Emit("reply", string(wsData))
-		}
-	}()
+	//推送离线消息，客户端确认后才从队列中删除
+	if err := s.startOfflineSession(msg.Uid, client); err != nil {
+		s.logger.Error("推送离线消息失败", zap.Error(err))
+		return err
+	}
 	return nil
 }
 
-func (s *Service) WsOfflineClients(ctx context.Context, uid, rid string) error {
+func (s *Service) WsOfflineClients(ctx context.Context, uid, driverId, rid string) error {
+	s.closeOfflineSession(rid, nil)
+	s.syncRooms(ctx, uid, driverId)
+
 	err := s.pushFriendStatus(ctx, offlineEvent, uid, rid)
 	if err != nil {
 		return err
@@ -332,66 +286,37 @@ func (s *Service) WsOfflineClients(ctx context.Context, uid, rid string) error {
 	return nil
 }
 
+// 在线客户端数由所有push实例共享，使用原子操作避免多个实例同时修改
 func (s *Service) addUserWsCount(ctx context.Context, uid string, rid string) error {
+	prefix := "push:online:"
+	return s.redisClient.Client.Incr(ctx, fmt.Sprintf("%s%s", prefix, uid)).Err()
+}
+
+func (s *Service) reduceUserWsCount(ctx context.Context, uid string, rid string) error {
 	prefix := "push:online:"
 	exists, err := s.redisClient.ExistsKey(fmt.Sprintf("%s%s", prefix, uid))
 	if err != nil {
 		return err
 	}
-
 	if !exists {
-		return s.redisClient.SetKey(fmt.Sprintf("%s%s", prefix, uid), 1, 0)
-	} else {
-		value, err := s.redisClient.GetKey(fmt.Sprintf("%s%s", prefix, uid))
-		if err != nil {
-			return err
-		}
-		str := value.(string)
-		num, err := strconv.Atoi(str)
-		if err != nil {
-			return err
-		}
-		num++
-		return s.redisClient.SetKey(fmt.Sprintf("%s%s", prefix, uid), num, 0)
+		return nil
 	}
-}
 
-func (s *Service) reduceUserWsCount(ctx context.Context, uid string, rid string) error {
-	prefix := "push:online:"
-	exists, err := s.redisClient.ExistsKey(fmt.Sprintf("%s%s", prefix, uid))
+	num, err := s.redisClient.Client.Decr(ctx, fmt.Sprintf("%s%s", prefix, uid)).Result()
 	if err != nil {
 		return err
 	}
-	if !exists {
-		//给好友推送下线
-		//err := c.pushFriendStatus(offlineEvent)
-		//if err != nil {
-		//	return err
-		//}
+	//fmt.Printf("%s账号当前还有%d个客户端在线", c.Uid, num)
+	if num > 0 {
 		return nil
-	} else {
-		value, err := s.redisClient.GetKey(fmt.Sprintf("%s%s", prefix, uid))
-		if err != nil {
-			return err
-		}
-		str := value.(string)
-		num, err := strconv.Atoi(str)
-		if err != nil {
-			return err
-		}
-		//fmt.Printf("%s账号当前还有%d个客户端在线", c.Uid, num)
-		if num == 1 {
-			//给好友推送下线
-			err := s.pushFriendStatus(ctx, offlineEvent, uid, rid)
-			if err != nil {
-				return err
-			}
-			return s.redisClient.DelKey(fmt.Sprintf("%s%s", prefix, uid))
-		} else {
-			num--
-			return s.redisClient.SetKey(fmt.Sprintf("%s%s", prefix, uid), num, 0)
-		}
 	}
+	s.updateLastSeen(ctx, uid)
+	//给好友推送下线
+	err = s.pushFriendStatus(ctx, offlineEvent, uid, rid)
+	if err != nil {
+		return err
+	}
+	return s.redisClient.DelKey(fmt.Sprintf("%s%s", prefix, uid))
 }
 
 //func (s *Service) reduceUserWsCount(ctx context.Context, uid string) error {
@@ -447,7 +372,12 @@ func (s *Service) pushFriendStatus(ctx context.Context, v status, uid, rid strin
 		return err
 	}
 	if len(list.FriendList) > 0 {
-		bytes, err := utils.StructToBytes(model.FriendOnlineStatusMsg{Status: int32(v), UserId: uid})
+		//隐身的用户对好友显示为离线
+		presence, err := s.friendPresence(ctx, uid, v == onlineEvent)
+		if err != nil {
+			return err
+		}
+		bytes, err := utils.StructToBytes(presence)
 		if err != nil {
 			return err
 		}
@@ -476,16 +406,14 @@ func (s *Service) pushFriendStatus(ctx context.Context, v status, uid, rid strin
 			//		continue
 			//	}
 			//}
-			go s.SocketServer.BroadcastToRoom("/", friend.UserId, "reply", message)
+			s.broadcastToRoom(ctx, friend.UserId, message)
 		}
 	}
 	return nil
 }
 
 // 获取所有好友在线状态
-func (s *Service) pushAllFriendOnlineStatus(ctx context.Context, c socketio.Conn, uid string, rid string) error {
-	prefix := "push:online:"
-
+func (s *Service) pushAllFriendOnlineStatus(ctx context.Context, c Conn, uid string, rid string) error {
 	//查询所有好友
 	list, err := s.relationService.GetFriendList(context.Background(), &relationgrpcv1.GetFriendListRequest{UserId: uid})
 	if err != nil {
@@ -494,29 +422,17 @@ func (s *Service) pushAllFriendOnlineStatus(ctx context.Context, c socketio.Conn
 	var friendList []model.FriendOnlineStatusMsg
 
 	if len(list.FriendList) > 0 {
+		friendIDs := make([]string, 0, len(list.FriendList))
 		for _, friend := range list.FriendList {
-			exists, err := s.redisClient.ExistsKey(fmt.Sprintf("%s%s", prefix, friend.UserId))
-			if err != nil {
-				return err
-			}
-			if exists {
-				value, err := s.redisClient.GetKey(fmt.Sprintf("%s%s", prefix, friend.UserId))
-				if err != nil {
-					return err
-				}
-				str := value.(string)
-				num, err := strconv.Atoi(str)
-				if err != nil {
-					return err
-				}
-				if num > 0 {
-					friendList = append(friendList, model.FriendOnlineStatusMsg{Status: int32(onlineEvent), UserId: friend.UserId})
-				} else {
-					friendList = append(friendList, model.FriendOnlineStatusMsg{Status: int32(offlineEvent), UserId: friend.UserId})
-				}
-			} else {
-				friendList = append(friendList, model.FriendOnlineStatusMsg{Status: int32(offlineEvent), UserId: friend.UserId})
-			}
+			friendIDs = append(friendIDs, friend.UserId)
+		}
+		presences, err := s.presenceCache().GetUsersPresence(ctx, friendIDs)
+		if err != nil {
+			return err
+		}
+		now := pkgtime.Now()
+		for _, id := range friendIDs {
+			friendList = append(friendList, toFriendOnlineStatusMsg(presences[id].ViewFor(uid, true, now)))
 		}
 	}
 
@@ -96,13 +96,63 @@ func (FileType) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_storage_proto_rawDescGZIP(), []int{0}
 }
 
+// 文件访问权限
+type FileAccess int32
+
+const (
+	FileAccess_Public       FileAccess = 0 // 公开，所有人都可以访问
+	FileAccess_OwnerOnly    FileAccess = 1 // 只有所有者和授权用户可以访问
+	FileAccess_GroupMembers FileAccess = 2 // 所有者和群聊成员可以访问
+)
+
+// Enum value maps for FileAccess.
+var (
+	FileAccess_name = map[int32]string{
+		0: "Public",
+		1: "OwnerOnly",
+		2: "GroupMembers",
+	}
+	FileAccess_value = map[string]int32{
+		"Public":       0,
+		"OwnerOnly":    1,
+		"GroupMembers": 2,
+	}
+)
+
+func (x FileAccess) Enum() *FileAccess {
+	p := new(FileAccess)
+	*p = x
+	return p
+}
+
+func (x FileAccess) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileAccess) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_storage_proto_enumTypes[1].Descriptor()
+}
+
+func (FileAccess) Type() protoreflect.EnumType {
+	return &file_api_v1_storage_proto_enumTypes[1]
+}
+
+func (x FileAccess) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileAccess.Descriptor instead.
+func (FileAccess) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_storage_proto_rawDescGZIP(), []int{1}
+}
+
 type UploadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// @inject_tag: json:"user_id"
-	UserID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"user_id"`
+	UserID string `protobuf:"bytes,1,opt,name=UserID,proto3" json:"user_id"`
 	// @inject_tag: json:"file_name"
 	FileName string `protobuf:"bytes,2,opt,name=FileName,proto3" json:"file_name"`
 	// @inject_tag: json:"path"
@@ -115,6 +165,14 @@ type UploadRequest struct {
 	Type FileType `protobuf:"varint,6,opt,name=Type,proto3,enum=v1.FileType" json:"type"`
 	// @inject_tag: json:"size"
 	Size uint64 `protobuf:"varint,7,opt,name=Size,proto3" json:"size"`
+	// @inject_tag: json:"group_id"
+	// 文件所属群聊，访问权限为 GroupMembers 时使用
+	GroupID uint32 `protobuf:"varint,8,opt,name=GroupID,proto3" json:"group_id"`
+	// @inject_tag: json:"access"
+	Access FileAccess `protobuf:"varint,9,opt,name=Access,proto3,enum=v1.FileAccess" json:"access"`
+	// @inject_tag: json:"grantees"
+	// 除所有者外可以访问非公开文件的用户
+	Grantees []string `protobuf:"bytes,10,rep,name=Grantees,proto3" json:"grantees"`
 }
 
 func (x *UploadRequest) Reset() {
@@ -198,6 +256,27 @@ func (x *UploadRequest) GetSize() uint64 {
 	return 0
 }
 
+func (x *UploadRequest) GetGroupID() uint32 {
+	if x != nil {
+		return x.GroupID
+	}
+	return 0
+}
+
+func (x *UploadRequest) GetAccess() FileAccess {
+	if x != nil {
+		return x.Access
+	}
+	return FileAccess_Public
+}
+
+func (x *UploadRequest) GetGrantees() []string {
+	if x != nil {
+		return x.Grantees
+	}
+	return nil
+}
+
 type UploadResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -205,6 +284,8 @@ type UploadResponse struct {
 
 	// @inject_tag: json:"url"
 	Url string `protobuf:"bytes,1,opt,name=Url,proto3" json:"url"`
+	// @inject_tag: json:"file_id"
+	FileID string `protobuf:"bytes,2,opt,name=FileID,proto3" json:"file_id"`
 }
 
 func (x *UploadResponse) Reset() {
@@ -246,6 +327,13 @@ func (x *UploadResponse) GetUrl() string {
 	return ""
 }
 
+func (x *UploadResponse) GetFileID() string {
+	if x != nil {
+		return x.FileID
+	}
+	return ""
+}
+
 type GetFileInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -491,11 +579,127 @@ func (*DeleteResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_storage_proto_rawDescGZIP(), []int{5}
 }
 
+type ShareFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// @inject_tag: json:"file_ids"
+	FileIDs []string `protobuf:"bytes,1,rep,name=FileIDs,proto3" json:"file_ids"`
+	// @inject_tag: json:"user_id"
+	// 共享文件的用户，需要有权访问所有文件
+	UserID string `protobuf:"bytes,2,opt,name=UserID,proto3" json:"user_id"`
+	// @inject_tag: json:"group_id"
+	// 共享者所在的群聊，共享者可以访问该群聊的群聊文件
+	GroupID uint32 `protobuf:"varint,3,opt,name=GroupID,proto3" json:"group_id"`
+	// @inject_tag: json:"grantees"
+	// 被授权访问文件的用户
+	Grantees []string `protobuf:"bytes,4,rep,name=Grantees,proto3" json:"grantees"`
+}
+
+func (x *ShareFilesRequest) Reset() {
+	*x = ShareFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_storage_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareFilesRequest) ProtoMessage() {}
+
+func (x *ShareFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_storage_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareFilesRequest.ProtoReflect.Descriptor instead.
+func (*ShareFilesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_storage_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ShareFilesRequest) GetFileIDs() []string {
+	if x != nil {
+		return x.FileIDs
+	}
+	return nil
+}
+
+func (x *ShareFilesRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *ShareFilesRequest) GetGroupID() uint32 {
+	if x != nil {
+		return x.GroupID
+	}
+	return 0
+}
+
+func (x *ShareFilesRequest) GetGrantees() []string {
+	if x != nil {
+		return x.Grantees
+	}
+	return nil
+}
+
+type ShareFilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ShareFilesResponse) Reset() {
+	*x = ShareFilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_storage_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareFilesResponse) ProtoMessage() {}
+
+func (x *ShareFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_storage_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareFilesResponse.ProtoReflect.Descriptor instead.
+func (*ShareFilesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_storage_proto_rawDescGZIP(), []int{7}
+}
+
 var File_api_v1_storage_proto protoreflect.FileDescriptor
 
 var file_api_v1_storage_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x55,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0x99, 0x02, 0x0a, 0x0d, 0x55,
 	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
 	0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73,
 	0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
@@ -507,49 +711,74 @@ var file_api_v1_storage_proto_rawDesc = []byte{
 	0x65, 0x72, 0x12, 0x20, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
 	0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x22, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x72,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x72, 0x6c, 0x22, 0x2c, 0x0a, 0x12,
-	0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x22, 0xe1, 0x01, 0x0a, 0x13, 0x47,
-	0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x72, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x20, 0x0a, 0x04,
-	0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e,
-	0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61,
-	0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x22, 0x27,
-	0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x22, 0x10, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x40, 0x0a, 0x08, 0x46, 0x69, 0x6c,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x10, 0x00,
-	0x12, 0x09, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x46,
-	0x69, 0x6c, 0x65, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x10, 0x03,
-	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x10, 0x04, 0x32, 0xb2, 0x01, 0x0a, 0x0e,
-	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2f,
-	0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x76, 0x31,
-	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46,
-	0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2f, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
-	0x6f, 0x73, 0x73, 0x69, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x49, 0x44, 0x12, 0x26, 0x0a, 0x06, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x52, 0x06, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x72,
+	0x61, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x47, 0x72,
+	0x61, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x22, 0x3a, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69,
+	0x6c, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x65,
+	0x49, 0x44, 0x22, 0x2c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44,
+	0x22, 0xe1, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x55, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x50, 0x61, 0x74, 0x68, 0x22, 0x27, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x22, 0x10, 0x0a,
+	0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x7b, 0x0a, 0x11, 0x53, 0x68, 0x61, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
+	0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x22, 0x14, 0x0a, 0x12,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2a, 0x40, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09,
+	0x0a, 0x05, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x02, 0x12, 0x09,
+	0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x74, 0x68,
+	0x65, 0x72, 0x10, 0x04, 0x2a, 0x39, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x10, 0x02, 0x32,
+	0xef, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x11, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x11, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6f, 0x73, 0x73, 0x69, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x73, 0x2d, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -564,31 +793,37 @@ func file_api_v1_storage_proto_rawDescGZIP() []byte {
 	return file_api_v1_storage_proto_rawDescData
 }
 
-var file_api_v1_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_v1_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_api_v1_storage_proto_goTypes = []interface{}{
 	(FileType)(0),               // 0: v1.FileType
-	(*UploadRequest)(nil),       // 1: v1.UploadRequest
-	(*UploadResponse)(nil),      // 2: v1.UploadResponse
-	(*GetFileInfoRequest)(nil),  // 3: v1.GetFileInfoRequest
-	(*GetFileInfoResponse)(nil), // 4: v1.GetFileInfoResponse
-	(*DeleteRequest)(nil),       // 5: v1.DeleteRequest
-	(*DeleteResponse)(nil),      // 6: v1.DeleteResponse
+	(FileAccess)(0),             // 1: v1.FileAccess
+	(*UploadRequest)(nil),       // 2: v1.UploadRequest
+	(*UploadResponse)(nil),      // 3: v1.UploadResponse
+	(*GetFileInfoRequest)(nil),  // 4: v1.GetFileInfoRequest
+	(*GetFileInfoResponse)(nil), // 5: v1.GetFileInfoResponse
+	(*DeleteRequest)(nil),       // 6: v1.DeleteRequest
+	(*DeleteResponse)(nil),      // 7: v1.DeleteResponse
+	(*ShareFilesRequest)(nil),   // 8: v1.ShareFilesRequest
+	(*ShareFilesResponse)(nil),  // 9: v1.ShareFilesResponse
 }
 var file_api_v1_storage_proto_depIdxs = []int32{
 	0, // 0: v1.UploadRequest.Type:type_name -> v1.FileType
-	0, // 1: v1.GetFileInfoResponse.Type:type_name -> v1.FileType
-	1, // 2: v1.StorageService.Upload:input_type -> v1.UploadRequest
-	3, // 3: v1.StorageService.GetFileInfo:input_type -> v1.GetFileInfoRequest
-	5, // 4: v1.StorageService.Delete:input_type -> v1.DeleteRequest
-	2, // 5: v1.StorageService.Upload:output_type -> v1.UploadResponse
-	4, // 6: v1.StorageService.GetFileInfo:output_type -> v1.GetFileInfoResponse
-	6, // 7: v1.StorageService.Delete:output_type -> v1.DeleteResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1, // 1: v1.UploadRequest.Access:type_name -> v1.FileAccess
+	0, // 2: v1.GetFileInfoResponse.Type:type_name -> v1.FileType
+	2, // 3: v1.StorageService.Upload:input_type -> v1.UploadRequest
+	4, // 4: v1.StorageService.GetFileInfo:input_type -> v1.GetFileInfoRequest
+	6, // 5: v1.StorageService.Delete:input_type -> v1.DeleteRequest
+	8, // 6: v1.StorageService.ShareFiles:input_type -> v1.ShareFilesRequest
+	3, // 7: v1.StorageService.Upload:output_type -> v1.UploadResponse
+	5, // 8: v1.StorageService.GetFileInfo:output_type -> v1.GetFileInfoResponse
+	7, // 9: v1.StorageService.Delete:output_type -> v1.DeleteResponse
+	9, // 10: v1.StorageService.ShareFiles:output_type -> v1.ShareFilesResponse
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_storage_proto_init() }
@@ -669,14 +904,38 @@ func file_api_v1_storage_proto_init() {
 				return nil
 			}
 		}
+		file_api_v1_storage_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_storage_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareFilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_storage_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
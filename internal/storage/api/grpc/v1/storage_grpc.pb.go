@@ -22,6 +22,7 @@ const (
 	StorageService_Upload_FullMethodName      = "/v1.StorageService/Upload"
 	StorageService_GetFileInfo_FullMethodName = "/v1.StorageService/GetFileInfo"
 	StorageService_Delete_FullMethodName      = "/v1.StorageService/Delete"
+	StorageService_ShareFiles_FullMethodName  = "/v1.StorageService/ShareFiles"
 )
 
 // StorageServiceClient is the client API for StorageService service.
@@ -31,6 +32,7 @@ type StorageServiceClient interface {
 	Upload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*UploadResponse, error)
 	GetFileInfo(ctx context.Context, in *GetFileInfoRequest, opts ...grpc.CallOption) (*GetFileInfoResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ShareFiles(ctx context.Context, in *ShareFilesRequest, opts ...grpc.CallOption) (*ShareFilesResponse, error)
 }
 
 type storageServiceClient struct {
@@ -68,6 +70,15 @@ func (c *storageServiceClient) Delete(ctx context.Context, in *DeleteRequest, op
 	return out, nil
 }
 
+func (c *storageServiceClient) ShareFiles(ctx context.Context, in *ShareFilesRequest, opts ...grpc.CallOption) (*ShareFilesResponse, error) {
+	out := new(ShareFilesResponse)
+	err := c.cc.Invoke(ctx, StorageService_ShareFiles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageServiceServer is the server API for StorageService service.
 // All implementations must embed UnimplementedStorageServiceServer
 // for forward compatibility
@@ -75,6 +86,7 @@ type StorageServiceServer interface {
 	Upload(context.Context, *UploadRequest) (*UploadResponse, error)
 	GetFileInfo(context.Context, *GetFileInfoRequest) (*GetFileInfoResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	ShareFiles(context.Context, *ShareFilesRequest) (*ShareFilesResponse, error)
 	mustEmbedUnimplementedStorageServiceServer()
 }
 
@@ -91,6 +103,9 @@ func (UnimplementedStorageServiceServer) GetFileInfo(context.Context, *GetFileIn
 func (UnimplementedStorageServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedStorageServiceServer) ShareFiles(context.Context, *ShareFilesRequest) (*ShareFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareFiles not implemented")
+}
 func (UnimplementedStorageServiceServer) mustEmbedUnimplementedStorageServiceServer() {}
 
 // UnsafeStorageServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -158,6 +173,24 @@ func _StorageService_Delete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageService_ShareFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ShareFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_ShareFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ShareFiles(ctx, req.(*ShareFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageService_ServiceDesc is the grpc.ServiceDesc for StorageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,6 +210,10 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _StorageService_Delete_Handler,
 		},
+		{
+			MethodName: "ShareFiles",
+			Handler:    _StorageService_ShareFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/storage.proto",
@@ -197,6 +197,8 @@ func (siw *ServerInterfaceWrapper) DeleteFile(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -221,6 +223,8 @@ func (siw *ServerInterfaceWrapper) GetFileInfo(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -271,25 +275,26 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xXXWsbRxT9K+a2DwmsvHLytm9OS4obCiFp6UMwZqS9kifZ3ZnMzqYVYqF9iJ0PEydg",
-	"DKWlTkKhfUgaSqERidP+GX3YT/4LZWZWnzurxEJqSJ9s7c7eO/ecc++caUKVhZxFGMkYvCbE1U0Mif53",
-	"tcKE/IoHjPhX8GaCsVRPuWAchaSo19zAhvojGxzBg1gKGtXBgW9LdVZSD0vxDcpLjEvKIhKUOKORRAGe",
-	"FAmqZSykEkMuG+DVSBBj6kCiM25Qf76BU6cfjFWuY1VC6sAnLOQBSnxLkTUa4EZEQpx3qQtAz8Rqgo9x",
-	"VVD9JXjQ3d9uv/6r98frzs/3z5S944M/j58+OjncWfE6P/7du7t9crhzzjOLTg53zntHv24dP310FgaY",
-	"qcR1FB8OtZ+h/CIJJOWkr+FL2LiCMWdRjIU6tqHW2XvRbn2nFjj/MVMLhX88bbt1r334mPpzrdHGSzEF",
-	"VeaPNli/5NQBn0gy8mYYLMQ4JnVLW1pTGxlcpAEWb0K3ug0gw8ucAXIgEUFRLvVqsWykDsRYTQSVjatq",
-	"7BsILiARKFYTual+VfSvi0yERIIHn3/9JTjmkFCRzNvhNjel5JCqwDSqsXxlq5fXlmpMLCmYl2LJBKnj",
-	"ksKfqAWxCkRloCJdzV6uXl4DB26hiE2EleXyclmxyThGhFPw4PxyeXkFHOBEbuoKXMKpe2vFzRK4Kpt+",
-	"wZkZ7zbtG9BBBzbbWfPBy0QDDghzOlxgfsOoNZIY6Whhf864NSbCUl+t5iS1S6yIc3Cg1oe6QiMi8kNn",
-	"8TNe0aeqpQJ98K6ZDa+nqXluOkdXcq5cnoCCcB7QqkbPvR6rbY3i8LHAGnjwkTu0HG7mN1xLc+qEVqru",
-	"POzcOxjTL3jXxpV7bT1ddyBOwlCBmCNZknqsastkpspz7LpxffZNpPbmNhVOqdukfqqqqaNVS/eP3rwp",
-	"0NKnWSStVUFClChivXOqvlX6BQeM0TCkjBJhGnuIZm7iWcNQ/1RB1hdI8TRiDWIGPUPsCHNjkJ6CuWFj",
-	"EmVliydAt3X7+IdfsuP+zlbv7naOuxEzPPAVU6fC7DhZfPeCm28qM63bvYdboy03YCYP25ne3m/tVw/a",
-	"refd/eem5c7Oylk1M+fFtHV+31H70plNshxt4w5/0czZ7xPvkbz8vByQZwVvJqIy72ydh729g4kseiCN",
-	"kzTq1S9pn20bjzcT1MdhNtiGl7JZhuREsGzYDr9b2NG6yAE7/c5jkcfRg5ed3X2rPAqIm0kg5t7xNgNW",
-	"MHhP07nzcWI/Pes+ezLYzTv4sZkuj6lWudyIkrCifPu0bXRe7XZ2X1rkNNudznYVeG8jalRiViVOqOMU",
-	"Cuw7NR/758hk6seD8ytv1vRHypC+k137QHxWVrL1QBhHI4+zYx/x2RAxBu6fJ93vX9gGvAJyTd0J/0dg",
-	"ThmfVlAs0k3TfwMAAP//6SyxL4EVAAA=",
+	"H4sIAAAAAAAC/9xXXWsbRxf+K+a870UCK6+c3O2d05LihkJIW3phjBlrR/Ik2p3NzGxaYRZcGn8lInbA",
+	"OCTxRxJaGopjt7TEIlbqP6OVrCv/hTI7q8+ddWxhNaQQiLWze86c53nOmWfmIEcdj7rYFRysOeC5Weyg",
+	"6M/xGcrEt16RIvsWvutjLuRTj1EPM0Fw9M4dXJL/iZKHwQIuGHELYMAPmQLNyIcZfod4GeoJQl1UzHiU",
+	"uAIzsATzsXyNOkRgxxMlsPKoyHFggB9lnCb2xQYOjFYwOnMb5wQEBnxGHa+IBf5AkXlSxNMucvBFlzoE",
+	"9FSsObAxzzESfQkW1DeWaodvG38chtsPL2Wt5s6fzVePT6rlMSt8/ndjZemkWr5iqZdOquWr1vGvi81X",
+	"jy9DGzOZuIDZp0PtF1h85RcF8VBLwzdw6RbmHnU5TtWxDrVwfb9WmZcvGP8yU0OFvzdtrfKgVn1B7Aut",
+	"UcdLOgU5anc3WKvkwAAbCdS10gnmYM5RQdOW2tRKBtdJEadvImp1YqfxcsEAGeCzYlouuTRcNgIDOM75",
+	"jIjS13LsKwiuYcQwG/fFrPw1E/26TpmDBFjw5XffgKEOCRlJrXa2OSuEB4EMTNw8TVY2fnNiJE/ZiIR5",
+	"hAvKUAGPSPyRfIHLQEQUVcFqcfzmBBhwDzOuIoyNZkezkk3qYRd5BCy4OpodHQMDPCRmowpM5BHz3pgZ",
+	"xzBltmjBo1wkN6W0r0AHA9rbmbDBikUDBjB1Olyjdkmp1RXYjaI5rTlj5ilzMi21qpNUL7E0zsGAfAvq",
+	"GeIilhw6w5/xQaCqJQzbYE2qDU8FgXquOieq5Eo22wcF8rwiyUXombc5dXtx+D/DebDgf2bHcphqlZua",
+	"5owSaqlaXgsf7PToF6zJXuVOTgVTBnDfcSSICZIFKnBZWywRWZ6h141p0+9duTdzTuIUmHPEDmQ1BazV",
+	"0sPj9+9TtPR5HCnSKkMOFpjxaOdEfiv1CwYoo6FI6SZCNXYHzcTE04Yh9rmCTA2R4tOIVYgp9BSxXcz1",
+	"QHoO5jqNiaSVTZ8A9cpC8+nP8XG/vNhYWUpw12WG277i1KkwOE4a3z3k5juVmcpCY22xu+XazCRhu9RY",
+	"f11796hWeVPfeKNa7vKgnOVic55OW7hXlvuKMqtkCdp6Hf6wmdPfJz4iecl52SZPC95ARMXeWTsPG+s7",
+	"fVmInSCp26vfwKWU8XjXx6zUGWydS9kgQ7IvWDxsO98N7Wgd5oA9/c6jkcfxo4NwdUMrjxTiBhKIund8",
+	"yIClDN7zdO7FOLHN3fruy/ZuzuDHBro8BpHKxbTrOzOYnb6N8N1quHqgkdNgdzrdVeCjjahuiWmV2KeO",
+	"cyiw5dRs3DpH+lO/aJ9fSbMWfSQN6Zns2ifis+KSBzDQfWAlaTD0J0A8Y5S/O3pZ/3H/pFpubm2HC7th",
+	"db7x7H7cKKu/1TdX5L+tn5pP1xrP7jfWX9eXD8LV/drhLyrISbV8fLQePt+uVf6qP3l7vHfUfLIXbv4e",
+	"bs3rzhTJ3YS8hv6H+EtO7LPyl+RB30xB8M8AU9NrmBMWAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	"github.com/cossim/coss-server/internal/storage/domain/service"
 	"github.com/cossim/coss-server/internal/storage/infra/persistence"
 	usergrpcv1 "github.com/cossim/coss-server/internal/user/api/grpc/v1"
@@ -27,6 +28,9 @@ type ServiceImpl struct {
 	sp          storage.StorageProvider
 	ac          *pkgconfig.AppConfig
 
+	// relationGroupService 校验群聊文件的访问权限
+	relationGroupService relationgrpcv1.GroupRelationServiceClient
+
 	downloadURL    string
 	gatewayAddress string
 	gatewayPort    string
@@ -63,6 +67,8 @@ func (s *ServiceImpl) HandlerGrpcClient(serviceName string, conn *grpc.ClientCon
 	switch serviceName {
 	case "user_service":
 		s.userService = usergrpcv1.NewUserServiceClient(conn)
+	case "relation_service":
+		s.relationGroupService = relationgrpcv1.NewGroupRelationServiceClient(conn)
 	default:
 		return nil
 	}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	relationgrpcv1 "github.com/cossim/coss-server/internal/relation/api/grpc/v1"
 	v1 "github.com/cossim/coss-server/internal/storage/api/http/v1"
 	"github.com/cossim/coss-server/internal/storage/domain/entity"
 	"github.com/cossim/coss-server/pkg/code"
@@ -10,6 +11,7 @@ import (
 	httputil "github.com/cossim/coss-server/pkg/utils/http"
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
@@ -19,8 +21,8 @@ import (
 
 type StorageService interface {
 	Upload(ctx context.Context, userID string, file *multipart.FileHeader, _Type int) (*v1.UploadFileResponse, error)
-	GetFileInfo(ctx context.Context, id string) (*entity.File, error)
-	DeleteFile(ctx context.Context, id string) error
+	GetFileInfo(ctx context.Context, userID string, id string) (*entity.File, error)
+	DeleteFile(ctx context.Context, userID string, id string) error
 	GetMultipartUploadKey(ctx context.Context, fileName string, _Type int) (*v1.GetMultipartUploadKeyResponse, error)
 	UploadMultipart(ctx context.Context, key string, uploadId string, partNumber int, reader io.Reader, size int64) error
 	CompleteMultipartUpload(ctx context.Context, req *v1.CompleteUploadRequest) (string, error)
@@ -104,12 +106,25 @@ func (s *ServiceImpl) Upload(ctx context.Context, userID string, file *multipart
 	}, nil
 }
 
-func (s *ServiceImpl) GetFileInfo(ctx context.Context, id string) (*entity.File, error) {
+func (s *ServiceImpl) GetFileInfo(ctx context.Context, userID string, id string) (*entity.File, error) {
 	file, err := s.sd.GetFileInfo(ctx, id)
 	if err != nil {
 		return nil, status.Error(codes.Code(code.StorageErrGetFileInfoFailed.Code()), err.Error())
 	}
 
+	if file.Access.IsRestricted() {
+		if err := s.checkFileAccess(ctx, userID, file); err != nil {
+			return nil, err
+		}
+		// 私有桶的文件只能通过预签名地址访问
+		url, err := s.sp.GetUrl(ctx, file.Path)
+		if err != nil {
+			s.logger.Error("生成文件访问地址失败", zap.String("file_id", file.ID), zap.Error(err))
+			return nil, code.StorageErrGetFileInfoFailed
+		}
+		file.Content = url
+	}
+
 	//URL := file.Url
 	//if strings.Contains(URL, "http://minio:9000") {
 	//	URL = strings.Replace(URL, "http://minio:9000", "http://gateway:8080/api/v1/storage/files", 1)
@@ -119,12 +134,17 @@ func (s *ServiceImpl) GetFileInfo(ctx context.Context, id string) (*entity.File,
 	return file, nil
 }
 
-func (s *ServiceImpl) DeleteFile(ctx context.Context, fileId string) error {
+func (s *ServiceImpl) DeleteFile(ctx context.Context, userID string, fileId string) error {
 	resp, err := s.sd.GetFileInfo(ctx, fileId)
 	if err != nil {
 		return err
 	}
 
+	// 非公开的文件只有所有者可以删除
+	if resp.Access.IsRestricted() && resp.Owner != userID {
+		return code.StorageErrFileAccessDenied
+	}
+
 	if err = s.sp.Delete(ctx, resp.Path); err != nil {
 		return err
 	}
@@ -136,6 +156,36 @@ func (s *ServiceImpl) DeleteFile(ctx context.Context, fileId string) error {
 	return nil
 }
 
+// checkFileAccess 校验用户是否可以访问非公开的文件
+func (s *ServiceImpl) checkFileAccess(ctx context.Context, userID string, file *entity.File) error {
+	if file.Owner == userID {
+		return nil
+	}
+	granted, err := s.sd.IsGranted(ctx, file.ID, userID)
+	if err != nil {
+		s.logger.Error("查询文件授权失败", zap.String("file_id", file.ID), zap.Error(err))
+		return code.StorageErrFileAccessDenied
+	}
+	if granted {
+		return nil
+	}
+	if file.Access != entity.FileAccessGroupMembers || file.GroupID == 0 {
+		return code.StorageErrFileAccessDenied
+	}
+
+	if s.relationGroupService == nil {
+		return code.StorageErrFileAccessDenied
+	}
+	if _, err := s.relationGroupService.GetGroupRelation(ctx, &relationgrpcv1.GetGroupRelationRequest{
+		GroupId: file.GroupID,
+		UserId:  userID,
+	}); err != nil {
+		s.logger.Debug("用户不在文件所属群聊中", zap.String("uid", userID), zap.Uint32("group_id", file.GroupID), zap.Error(err))
+		return code.StorageErrFileAccessDenied
+	}
+	return nil
+}
+
 func (s *ServiceImpl) GetMultipartUploadKey(ctx context.Context, fileName string, _Type int) (*v1.GetMultipartUploadKeyResponse, error) {
 	// 获取桶名称
 	bucket, err := myminio.GetBucketName(_Type)
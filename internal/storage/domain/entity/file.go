@@ -17,9 +17,14 @@ type File struct {
 	Provider  Provider   `gorm:"default:MinIO;comment:文件供应商" json:"provider"`
 	Share     bool       `gorm:"comment:是否共享" json:"share"`
 	Size      uint64     `gorm:"comment:文件大小" json:"size"`
+	GroupID   uint32     `gorm:"default:0;comment:所属群聊id" json:"group_id"`
+	Access    FileAccess `gorm:"default:0;comment:访问权限" json:"access"`
 	CreatedAt int64      `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
 	UpdatedAt int64      `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
 	DeletedAt int64      `gorm:"default:0;comment:删除时间" json:"deleted_at"`
+
+	// Grantees 除所有者外可以访问非公开文件的用户，保存在file_grants表
+	Grantees []string `gorm:"-" json:"-"`
 }
 
 func (bm *File) BeforeCreate(tx *gorm.DB) error {
@@ -56,6 +61,20 @@ const (
 	Expired
 )
 
+// FileAccess 文件访问权限，非公开的文件保存在私有桶中，只能通过文件信息接口获取临时访问地址
+type FileAccess uint
+
+const (
+	FileAccessPublic       FileAccess = iota // 公开
+	FileAccessOwnerOnly                      // 只有所有者和授权用户可以访问
+	FileAccessGroupMembers                   // 所有者、授权用户和群聊成员可以访问
+)
+
+// IsRestricted 是否需要校验访问权限
+func (a FileAccess) IsRestricted() bool {
+	return a != FileAccessPublic
+}
+
 type Provider string
 
 const (
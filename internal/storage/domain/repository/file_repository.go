@@ -9,4 +9,7 @@ type FileRepository interface {
 	Update(file *entity.File) error
 	Delete(fileID string) error
 	GetByID(fileID string) (*entity.File, error)
+	// Grant 授权用户访问非公开文件，重复授权会被忽略
+	Grant(fileID string, userIDs []string) error
+	IsGranted(fileID string, userID string) (bool, error)
 }
@@ -16,6 +16,10 @@ type StorageDomain interface {
 	Upload(context.Context, *entity.File) error
 	GetFileInfo(context.Context, string) (*entity.File, error)
 	Delete(context.Context, string) error
+	// Share 将文件共享给其他用户，共享者需要有权访问所有文件，groupID 为共享者所在的群聊
+	Share(ctx context.Context, userID string, groupID uint32, fileIDs []string, grantees []string) error
+	// IsGranted 用户是否被授权访问非公开文件
+	IsGranted(ctx context.Context, fileID string, userID string) (bool, error)
 }
 
 type StorageDomainImpl struct {
@@ -49,15 +53,24 @@ func (s *StorageDomainImpl) Upload(ctx context.Context, file *entity.File) error
 		//Action:   entity.Pending,
 		Provider: file.Provider,
 		Size:     file.Size,
+		GroupID:  file.GroupID,
+		Access:   file.Access,
 	}
 
 	if err = s.repo.FR.Create(newfile); err != nil {
 		return status.Error(codes.Code(code.StorageErrCreateFileRecordFailed.Code()), err.Error())
 	}
+	if err = s.repo.FR.Grant(newfile.ID, file.Grantees); err != nil {
+		return status.Error(codes.Code(code.StorageErrCreateFileRecordFailed.Code()), err.Error())
+	}
 
 	return nil
 }
 
+func (s *StorageDomainImpl) IsGranted(ctx context.Context, fileID string, userID string) (bool, error) {
+	return s.repo.FR.IsGranted(fileID, userID)
+}
+
 func (s *StorageDomainImpl) GetFileInfo(ctx context.Context, u string) (*entity.File, error) {
 	file, err := s.repo.FR.GetByID(u)
 	if err != nil {
@@ -73,3 +86,51 @@ func (s *StorageDomainImpl) Delete(ctx context.Context, u string) error {
 	}
 	return nil
 }
+
+func (s *StorageDomainImpl) Share(ctx context.Context, userID string, groupID uint32, fileIDs []string, grantees []string) error {
+	if len(fileIDs) == 0 || len(grantees) == 0 {
+		return nil
+	}
+
+	// 先校验所有文件，避免只共享了部分文件
+	restricted := make([]string, 0, len(fileIDs))
+	for _, id := range fileIDs {
+		file, err := s.repo.FR.GetByID(id)
+		if err != nil {
+			return status.Error(codes.Code(code.StorageErrGetFileInfoFailed.Code()), err.Error())
+		}
+		// 公开的文件所有人都可以访问，不需要授权
+		if !file.Access.IsRestricted() {
+			continue
+		}
+		if err := s.checkShareAccess(file, userID, groupID); err != nil {
+			return err
+		}
+		restricted = append(restricted, file.ID)
+	}
+
+	for _, id := range restricted {
+		if err := s.repo.FR.Grant(id, grantees); err != nil {
+			return status.Error(codes.Code(code.StorageErrShareFileFailed.Code()), err.Error())
+		}
+	}
+	return nil
+}
+
+// checkShareAccess 所有者、被授权的用户和群聊文件所属群聊的成员可以共享文件
+func (s *StorageDomainImpl) checkShareAccess(file *entity.File, userID string, groupID uint32) error {
+	if file.Owner == userID {
+		return nil
+	}
+	if file.Access == entity.FileAccessGroupMembers && file.GroupID != 0 && file.GroupID == groupID {
+		return nil
+	}
+	granted, err := s.repo.FR.IsGranted(file.ID, userID)
+	if err != nil {
+		return status.Error(codes.Code(code.StorageErrShareFileFailed.Code()), err.Error())
+	}
+	if !granted {
+		return code.StorageErrFileAccessDenied
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/cossim/coss-server/internal/storage/domain/entity"
+	"github.com/cossim/coss-server/internal/storage/domain/service"
+	"github.com/cossim/coss-server/internal/storage/infra/persistence"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"strings"
+	"testing"
+)
+
+// newTestStorageDomain 使用内存sqlite创建存储领域服务，每个测试使用独立的数据库
+func newTestStorageDomain(t *testing.T) (service.StorageDomain, *persistence.Repositories) {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	repos := persistence.NewRepositories(db)
+	if err := repos.Automigrate(); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return service.NewStorageDomain(db, nil, repos), repos
+}
+
+func createFile(t *testing.T, repos *persistence.Repositories, file *entity.File) {
+	t.Helper()
+	if err := repos.FR.Create(file); err != nil {
+		t.Fatalf("create file: %v", err)
+	}
+}
+
+func assertGranted(t *testing.T, sd service.StorageDomain, fileID, userID string, want bool) {
+	t.Helper()
+	granted, err := sd.IsGranted(context.Background(), fileID, userID)
+	if err != nil {
+		t.Fatalf("IsGranted: %v", err)
+	}
+	if granted != want {
+		t.Fatalf("expected %s granted=%v on %s, got %v", userID, want, fileID, granted)
+	}
+}
+
+func TestShareGrantsRecipients(t *testing.T) {
+	sd, repos := newTestStorageDomain(t)
+	ctx := context.Background()
+
+	createFile(t, repos, &entity.File{ID: "private", Owner: "u1", Access: entity.FileAccessOwnerOnly})
+	createFile(t, repos, &entity.File{ID: "public", Owner: "u1", Access: entity.FileAccessPublic})
+
+	// 非所有者不能共享，且不能部分授权
+	if err := sd.Share(ctx, "u2", 0, []string{"public", "private"}, []string{"u3"}); !code.IsCode(err, code.StorageErrFileAccessDenied) {
+		t.Fatalf("expected StorageErrFileAccessDenied, got %v", err)
+	}
+	assertGranted(t, sd, "private", "u3", false)
+
+	if err := sd.Share(ctx, "u1", 0, []string{"public", "private"}, []string{"u2"}); err != nil {
+		t.Fatalf("Share: %v", err)
+	}
+	assertGranted(t, sd, "private", "u2", true)
+	// 公开文件不需要授权
+	assertGranted(t, sd, "public", "u2", false)
+
+	// 被授权的用户可以再次转发
+	if err := sd.Share(ctx, "u2", 0, []string{"private"}, []string{"u3", "u2"}); err != nil {
+		t.Fatalf("Share by grantee: %v", err)
+	}
+	assertGranted(t, sd, "private", "u3", true)
+
+	// 所有者可以删除已共享的文件，授权记录一并删除
+	if err := sd.Delete(ctx, "private"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	assertGranted(t, sd, "private", "u2", false)
+	assertGranted(t, sd, "private", "u3", false)
+}
+
+func TestShareGroupFile(t *testing.T) {
+	sd, repos := newTestStorageDomain(t)
+	ctx := context.Background()
+
+	createFile(t, repos, &entity.File{ID: "group", Owner: "u1", GroupID: 1, Access: entity.FileAccessGroupMembers})
+
+	// 只有从文件所属的群聊转发才可以共享
+	if err := sd.Share(ctx, "u2", 2, []string{"group"}, []string{"u3"}); !code.IsCode(err, code.StorageErrFileAccessDenied) {
+		t.Fatalf("expected StorageErrFileAccessDenied, got %v", err)
+	}
+	if err := sd.Share(ctx, "u2", 1, []string{"group"}, []string{"u3"}); err != nil {
+		t.Fatalf("Share: %v", err)
+	}
+	assertGranted(t, sd, "group", "u3", true)
+}
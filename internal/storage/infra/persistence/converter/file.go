@@ -17,6 +17,8 @@ func FileEntityToPO(e *entity.File) *po.File {
 		Content:   e.Content,
 		Path:      e.Path,
 		Share:     e.Share,
+		GroupID:   e.GroupID,
+		Access:    uint(e.Access),
 		CreatedAt: e.CreatedAt,
 	}
 }
@@ -33,6 +35,8 @@ func FilePOToEntity(po *po.File) *entity.File {
 		Content:   po.Content,
 		Path:      po.Path,
 		Share:     po.Share,
+		GroupID:   po.GroupID,
+		Access:    entity.FileAccess(po.Access),
 		CreatedAt: po.CreatedAt,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/cossim/coss-server/internal/storage/infra/persistence/converter"
 	"github.com/cossim/coss-server/internal/storage/infra/persistence/po"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FileRepo struct {
@@ -26,7 +27,12 @@ func (f *FileRepo) Update(file *entity.File) error {
 }
 
 func (f *FileRepo) Delete(fileID string) error {
-	return f.db.Where("id = ?", fileID).Delete(&po.File{}).Error
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("file_id = ?", fileID).Delete(&po.FileGrant{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", fileID).Delete(&po.File{}).Error
+	})
 }
 
 func (f *FileRepo) GetByID(fileID string) (*entity.File, error) {
@@ -39,3 +45,22 @@ func (f *FileRepo) GetByID(fileID string) (*entity.File, error) {
 
 	return file, nil
 }
+
+func (f *FileRepo) Grant(fileID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	grants := make([]*po.FileGrant, 0, len(userIDs))
+	for _, uid := range userIDs {
+		grants = append(grants, &po.FileGrant{FileID: fileID, UserID: uid})
+	}
+	return f.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&grants).Error
+}
+
+func (f *FileRepo) IsGranted(fileID string, userID string) (bool, error) {
+	var count int64
+	if err := f.db.Model(&po.FileGrant{}).Where("file_id = ? AND user_id = ?", fileID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -16,6 +16,8 @@ type File struct {
 	Provider  string `gorm:"default:MinIO;comment:文件供应商"`
 	Share     bool   `gorm:"comment:是否共享"`
 	Size      uint64 `gorm:"comment:文件大小"`
+	GroupID   uint32 `gorm:"default:0;comment:所属群聊id"`
+	Access    uint   `gorm:"default:0;comment:访问权限"`
 	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间"`
 	UpdatedAt int64  `gorm:"autoUpdateTime;comment:更新时间"`
 	DeletedAt int64  `gorm:"default:0;comment:删除时间"`
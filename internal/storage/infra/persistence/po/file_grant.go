@@ -0,0 +1,23 @@
+package po
+
+import (
+	ptime "github.com/cossim/coss-server/pkg/utils/time"
+	"gorm.io/gorm"
+)
+
+// FileGrant 非公开文件的授权用户
+type FileGrant struct {
+	ID        uint32 `gorm:"primaryKey;autoIncrement;"`
+	FileID    string `gorm:"type:char(64);uniqueIndex:idx_file_grant;comment:文件id"`
+	UserID    string `gorm:"type:char(64);uniqueIndex:idx_file_grant;comment:授权用户id"`
+	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间"`
+}
+
+func (bm *FileGrant) BeforeCreate(tx *gorm.DB) error {
+	bm.CreatedAt = ptime.Now()
+	return nil
+}
+
+func (bm *FileGrant) TableName() string {
+	return "file_grants"
+}
@@ -85,6 +85,9 @@ func (s *Handler) Upload(ctx context.Context, request *v1.UploadRequest) (*v1.Up
 		//Action:   entity.Pending,
 		Provider: entity.Provider(request.Provider),
 		Size:     request.Size,
+		GroupID:  request.GroupID,
+		Access:   entity.FileAccess(request.Access),
+		Grantees: request.Grantees,
 	}
 
 	if err = s.fd.Upload(ctx, file); err != nil {
@@ -92,6 +95,8 @@ func (s *Handler) Upload(ctx context.Context, request *v1.UploadRequest) (*v1.Up
 		return resp, status.Error(codes.Code(code.StorageErrCreateFileRecordFailed.Code()), err.Error())
 	}
 
+	resp.FileID = file.ID
+	resp.Url = file.Content
 	return resp, nil
 }
 
@@ -126,3 +131,11 @@ func (s *Handler) Delete(ctx context.Context, request *v1.DeleteRequest) (*v1.De
 	// 返回删除成功的响应
 	return &v1.DeleteResponse{}, nil
 }
+
+func (s *Handler) ShareFiles(ctx context.Context, request *v1.ShareFilesRequest) (*v1.ShareFilesResponse, error) {
+	if err := s.fd.Share(ctx, request.UserID, request.GroupID, request.FileIDs, request.Grantees); err != nil {
+		s.logger.Error("共享文件失败", zap.Error(err))
+		return nil, err
+	}
+	return &v1.ShareFilesResponse{}, nil
+}
@@ -132,7 +132,8 @@ func (h *Handler) GetFileInfo(c *gin.Context, id string) {
 		return
 	}
 
-	info, err := h.svc.GetFileInfo(context.Background(), fileID)
+	userID := c.Value(constants.UserID).(string)
+	info, err := h.svc.GetFileInfo(context.Background(), userID, fileID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -161,8 +162,10 @@ func (h *Handler) DeleteFile(c *gin.Context, id string) {
 		return
 	}
 
-	err := h.svc.DeleteFile(context.Background(), fileID)
+	userID := c.Value(constants.UserID).(string)
+	err := h.svc.DeleteFile(context.Background(), userID, fileID)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 
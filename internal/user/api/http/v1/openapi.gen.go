@@ -47,6 +47,18 @@ type ServerInterface interface {
 	// 修改密码
 	// (PUT /api/v1/user/password)
 	UpdateUserPassword(c *gin.Context)
+	// 获取用户状态
+	// (GET /api/v1/user/presence)
+	GetUsersPresence(c *gin.Context, params GetUsersPresenceParams)
+	// 设置用户状态
+	// (PUT /api/v1/user/presence)
+	UpdatePresence(c *gin.Context)
+	// 获取状态隐私设置
+	// (GET /api/v1/user/presence/privacy)
+	GetPresencePrivacy(c *gin.Context)
+	// 修改状态隐私设置
+	// (PUT /api/v1/user/presence/privacy)
+	UpdatePresencePrivacy(c *gin.Context)
 	// 设置用户pgp公钥
 	// (POST /api/v1/user/public_key)
 	SetUserPublicKey(c *gin.Context)
@@ -258,6 +270,86 @@ func (siw *ServerInterfaceWrapper) UpdateUserPassword(c *gin.Context) {
 	siw.Handler.UpdateUserPassword(c)
 }
 
+// GetUsersPresence operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersPresence(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersPresenceParams
+
+	// ------------- Required query parameter "user_ids" -------------
+
+	if paramValue := c.Query("user_ids"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_ids is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "user_ids", c.Request.URL.Query(), &params.UserIds)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_ids: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersPresence(c, params)
+}
+
+// UpdatePresence operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePresence(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdatePresence(c)
+}
+
+// GetPresencePrivacy operation middleware
+func (siw *ServerInterfaceWrapper) GetPresencePrivacy(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPresencePrivacy(c)
+}
+
+// UpdatePresencePrivacy operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePresencePrivacy(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdatePresencePrivacy(c)
+}
+
 // SetUserPublicKey operation middleware
 func (siw *ServerInterfaceWrapper) SetUserPublicKey(c *gin.Context) {
 
@@ -389,8 +481,6 @@ func (siw *ServerInterfaceWrapper) SsoLogin(c *gin.Context) {
 		return
 	}
 
-	c.Set(BearerAuthScopes, []string{})
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -554,6 +644,10 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.POST(options.BaseURL+"/api/v1/user/login", wrapper.UserLogin)
 	router.POST(options.BaseURL+"/api/v1/user/logout", wrapper.UserLogout)
 	router.PUT(options.BaseURL+"/api/v1/user/password", wrapper.UpdateUserPassword)
+	router.GET(options.BaseURL+"/api/v1/user/presence", wrapper.GetUsersPresence)
+	router.PUT(options.BaseURL+"/api/v1/user/presence", wrapper.UpdatePresence)
+	router.GET(options.BaseURL+"/api/v1/user/presence/privacy", wrapper.GetPresencePrivacy)
+	router.PUT(options.BaseURL+"/api/v1/user/presence/privacy", wrapper.UpdatePresencePrivacy)
 	router.POST(options.BaseURL+"/api/v1/user/public_key", wrapper.SetUserPublicKey)
 	router.PUT(options.BaseURL+"/api/v1/user/public_key", wrapper.ResetUserPublicKey)
 	router.POST(options.BaseURL+"/api/v1/user/register", wrapper.UserRegister)
@@ -571,53 +665,64 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+Rb/VPbyPn/Vzz7/f7QzhgMJL3p+KfmcneZtDdTGnrtDynjWezF6LBeWK1J3AwzdkIC",
-	"5Ai4LXnjuJLkyMuEYJPLCwk0yR8Dkuz/oqNdWZKllWQbkTDpb5YlPbv7fJ7n87zs6hLIyqIiS0giKkhf",
-	"Amp2AomQ/jyDJIQhQX86d1rOoXNIVWRJReYdBcsKwkRA9LkpnMnKOXojh9QsFhQiyBJIg4PdRWPvpXGv",
-	"ov343liYA0lASgoCaaASLEh5MJMERJ5EUsib7L7vxRn7H3nse5Qlpqhv5bwgBc8yYCRj5Yk+v2Pc3dPe",
-	"3dTmthr1im+0JLjYl5f7zD/71ElB6ZPpy7DQp8iCRBAGaYKLyHxMFgWCRIWUQHocFlQ0kwRFFeGMII3L",
-	"/ikVoEoyBXPaGSKIPAW+ua4/u88mp99+3bz9EiTBuIxFSEAaCBL54qQzW3MqeYQPOV0JXcjk0LSQRWxm",
-	"/knpd+pa9ZF+p67f2m7U3msbc2yCzkzGZLmAoBSL4nJBkAm5WIHyWxTPxoYxGkcYSVkGYDucsoKkzFgR",
-	"Sxk4ThDOYARz5rQCNKj9p6xV6807V7XqknbjhTG7+jcpbh0GTImaW0YuEuoYcZoPRiLEky6xsfiQKhSQ",
-	"RCIU+dPd5t6dZnnVWH8YtyJ5psBYcYRAUlSDWUel9/0zH0gn9LWn+sKmvrycGEwntJ1frIshemHcrzVq",
-	"G4kT9KLxYU5fW6eLQlJRBOnzA8nB5FDyxGiszs9bZfDKWpQfq/nkIIEuoc48RDUfr1HxFjsy8kcriEwV",
-	"kUr8a85hYTqAlLR3/9IWbjAmtFhxdVZbqR+8Kev35hr1a8azR7wAaIkMiE5Mqi2P/aDPJoyVJwe7S/rS",
-	"k2a5oi/8oK/tGpt1Y3XWeLFn7K0zP+ANqBQgMUNIeCg0J//2hba8zY29GE0VBYxyIH3epRPPYlxDjXKU",
-	"/Z2K8FluWITTkEAcN4dkZVW1kItTLBKhUPALnUnyontModsUTuVKRXEM4Xgl8xKA3qjUFCZkJyUoori1",
-	"rrTH4f/HaBykwf+lnFw2ZSWyKXfIpqZbgOZAGYeZPaqi8SYvQVLEsc87PBwY6w+N66/1csWMCPrWz9qb",
-	"N9b1UDrReLBpPKoYK0+soKDN32ve3UicTCeaKxWttsqe5ASJ5El/mDDZDxXiXp0rY4uVqN100xqj5Xm2",
-	"Tv3QBrEO5fjTBcFKKTxhjf6fERQOGdcemPy4WReUmGuEkLjCKD/mZNdhaiqSP6bxfE/79w8xj8t4C3Jy",
-	"uY9Y5cwEGMYIymJEvixKuQIvlaN3M2P2bV701OrXmv98qC1ePZIq8pMWQyrKFrFASiMmsTKVfIkgRvhU",
-	"kUyYV2P06psWcL//659N76RPm8GD3nWmOUGIAmZMwa3imLcwfe2Gdv1+4tTw2YS+XD14/yP7u1HbaNQr",
-	"++WK/uKJdm1xv1w5ePP0YHe38WpWv3XXqN03qteMrQXt+nrjyrv98mVzWIGYsPnkgiSYRlhlgw72D/QP",
-	"AKtwgooA0uBE/0D/CTOZgWSCLjoFFSE1PZgyIaFWUuRY88GHmr7ylo118OG+XqkDKhRThjqbA2nwnZKD",
-	"BJl2BxjFIZV8KedKLLmWiEVPUFEKQpa+lvpelSWnSROWOXFtc+OldmU5ZsM8orTKnThw7eLOK+Pxdsxr",
-	"aQv73k7MU7382Nh6r1VvxDyqFYg9lS3N6LXlHTcVKhOyhI7a0VnAZVUfNaqhgYGuTDIsGbOTfjpMlMvo",
-	"81Xt+nob+YD0+XbaOT86M5oEalEUIS4FOR6BebWVPIBRU57biVMwS4RpSCjseUQCbe5DWX+55/djFeFT",
-	"LQkmT2AoIoKwSufKE3T2K2CyHkiDqSLCJZAEzNRdyY2T8jDsHPX6qjGf6dBpskAUMM4kKnU1xughTaKd",
-	"pbqPZd42aocmxjHuJFdZbkOzLckDepQN2dQbGQ9sHg6KB6eYrLCoIBYLRFAgJimTHfpafZMglY8LhfYC",
-	"dEyQILWIyMZ6ewJu1XWHsoZIUPzKOiwTOKEvHEUnuYtG0ZXpBQFppZJxhfdDZqDeUqpd3OixQ761kEOD",
-	"79ZIOP6s+lMDA0FjaUdbvtWoP7Qku5p+xuqsvlDW1xbsMtFnGGcQ8dSfrGw9hEbNeK52Qonuotdxcogx",
-	"LPGA6GqhvQDUrSYjgKPNgNQ0wsK4pSzqPrLKAVFb/kezXGHE3rxcO9h7zY3pX5si/+KWGJcf2z1Dm45b",
-	"vYxwh2VPHQtH9euQG0O5qo6A0u468tFzd6lZGai9fW2srOvz1UbtQ/N27WBvw1hYZEWfH1XqB4dAEl2E",
-	"omKxr9O1AXAsOzh0wtsCT4OLpb+f/M0XdreKpXi/s4T0Z2WRZoyqekHGppjWTybLadUDQabdy89vO8L2",
-	"BS7Kq7PGyittq8psR1vb1n4qczc1bBUGBkXjXiWG7ZBfCbK6X65AKYdlIbdfrlxAY/vlykQRXkBCf3//",
-	"rzt0YteUkz1uocTv9WExpP10BYcRrP5dcCbtnBKI9H9rZ5pPAM1yWZvbtaVxXdwUEBdbt3lZp3thx4Kk",
-	"3ZrqJUZ7NB2Bm9sHQ7Jn2xWDkuZhxzHiATArS+MCFjMhJEF32+2ZifDit0jKmyoZHPptEoiC1Lr+gkMh",
-	"ciEXIlu//bhXwSFCb233JtRjsG1Tb+Mkn9JGj6BV1GE9wJbaexngsH+EBRfHCkI2M4lKwezTqL033tUY",
-	"oyl5Rbv6jLVZ2q15hGX6w1TgH+xuy+FtuX2K4eC6nu2ejqRiodBNOh+gFo/Gk3xmaM7dsN8O0Og5pB6V",
-	"Tj0nWTi5SVSenuwVlyQb/GNEizA74qZMNoT8VlxX/btOWnF+I+gpZvFMKcLvMcoLKrF2c0KKDrbZxM05",
-	"zrVEfLKg1V1m3bxcM2rP3ZsLgab9SbZiDpHQ92rZMR5S4OT4HcVTX3eaGlyjvqM/v6wtX9ZvboOjpYWA",
-	"us9fWH+s/nxkVWH7ZISPqwji7ERgY0+vrhkvHzCRnGhuvmvt1Hawv2M7F2/vpWUbH2/3pdcNObdOeqFi",
-	"j06jAFLlVMtJaAsodYla40wIK1MGZOdmnW8N3r5uPl1s1K18UasuNbavmJPY2tCXHmrLP7O3AurH02wG",
-	"rS5RKNi+jxQo3AokEw7arVvHA+3o+t1SaQ9oe9TqRtvENgDxvPWNSWYKh+y+ruvzVdZgstsxtvI5XXb3",
-	"ZyvgCNUZ8IEMz5WoQtlCnJl7+az9bsc67NBbuvUTrbZoqp3vJyOqHLuPRPhEb1lVGH7e897Hv6XWqTOq",
-	"qtytJ05h69yk25K4/sjMxkbW+ghj55fGg01mY/r8LesjobUF/ea8z3jopkrJ/QHF50e13M9DeB2O1srY",
-	"+V0PJ3hUbT3TMaZqFkqZKdxBJGVB3sMQPkrw00AWSjbP/g8FS+aTHn11mR15Xu4M1JJKkOhpVIXsVLNj",
-	"jsZmPbhVdQaR4TPD7V2VY9Bm6KQ4CFpj7/vRXH1FJK6XhNxMBAyh50CtUwHdHh1rd50uD419qpLCr47e",
-	"serujJ+Jkut4TyRYwcd7LLzssz2fD2pt598j0DvU6ZwgRXsxpDLxdEu1RVywDo6nUya4/e59dHME630v",
-	"CF9PI1wiE4KUT8AxuUgSlr+hiwRhCRa+krOcb3K+EaRcwnxalLGJc9vo6gWYzyPcL8i0ReYc3wQzozP/",
-	"DQAA//8YbCf7VD8AAA==",
+	"H4sIAAAAAAAC/+RcW1PbSPb/Kq7+/x92qxwMJDu15afNXCu7U7XZsJeHDOUSdmM0sSXRkgnelKvsDASc",
+	"QOydZUhgmCHJQMJCYpNJhotNwofBLdlP+Qpb6pZlWWrJxhZJJvuGben06XPO71y7uQGiYlISBSgoMgjf",
+	"AHJ0AiY58ucXUICIU+BfrnwixuAVKEuiIEP9FwmJEkQKD8lzkygSFWPkhxiUo4iXFF4UQBjUKgta9aX2",
+	"IIe/f63l50AQKGkJgjCQFcQLcZAJAkW8BgWPN+nvjhcz5jfi2NcwquikvhTjvODOpctK2tKWOr+vrVTx",
+	"q+/w3LN6OedYLQimz8XFc/qX5+RrvHROJC9ziXOSyAsKRCCsoBTUHxOTvAKTkpIG4XEuIcNMEKRkiCK8",
+	"MC46WUpwshJJ6GxHFD7JEuDBbfXpQ8qcem+vce8lCIJxESU5BYQBLygfXWhxq7MSh6hPdgV4PRKDU3wU",
+	"Us6cTKn3y7j4WL1fVpd366XXeGOOMtjiZEwUE5ATfBFczE1lfMxXRTktimVjlxEchwgKUSg71SlKUIiM",
+	"pZAQ4cYViCIIcjGdLRcJ4qMsLpYb92dx8S5efKHNrH4l+C1DF5aIuUXElEKA4af5IJjk0DULWV8wJPMJ",
+	"KCgdBPnDSqN6v5Fd1dY3/RakiynIuiFcRvwUF027oFuGLKdT383hQrlW3dTW7uD5XXUtq9vA2pZWOaYw",
+	"f3O0MKjms+pavlapBIZq1Vm8+QoX7gSGaweLePYpPsqCIIBCKgnCVweDQ8HhUV/9ANHkZIpHMAbCVy1b",
+	"GWVIgsaHEYVTUrK7/5XJ705ZDIYD6tq2mt9RC4XAUDiA9382PgyTD9rDUr20EThPPtSP59S19a8E2+aD",
+	"533fvmOX7jtrBj9fgRTjFM5CtMVHUo77Cy/WZkdG/myE08kUlBXnnmOIn3Jxz/jVv3F+kcYEIz6szuCl",
+	"cu0gqz6Yq5dvaU8fs1IBg6RLnKZUTXr0D/JsQFvaqlXuqne3Gtmcmr+jrlW0nbK2OqO9qGrVdeoRWAtK",
+	"CU7Rg6l3UqAzf/gCF3aZWYgVJS2Z2DZjWYqFn79JMU6BTX/iKvJoSlbEZMQNR/W5bVxarR3mtdt7ajb3",
+	"5mihdlDR/lNR7+2pB7ONlQ0QBElu+ksoxJUJEP7oQo/RMxNs5yQSSyGO8tCBJW11Rl3Lq9/NN33cvPbk",
+	"2zdHed3Z1R9uaRuV2sEiRTgrzUnyAp/UMT/YK9b1SKJwCiPRor73JJvTHlfxUfYkm8PHK9qjBXV+ubFa",
+	"rFd2Wg6HuJvghZ4djt1qKEdMu5AhusRMHLkpTuGQ31E2KsqygWg/ycIkxyecRDNBVv7rU3KrEyd0hVRy",
+	"DCJ/KbNS5N6SDZ0YH70mcEnot9Sl9kz1/xEcB2Hwf6FWtRcySr2QNaklxpkgeLZ4GpuoSEYWFzglhXzn",
+	"2ztN0NY3qS/RMwX12U/44MD4PBwO1B/taI9z2tKWkSzg+QeNlY3AhXCgsZTDpVX6JCN5YKFZj4ow4ffu",
+	"LDWNrwHc6lCaazSRZ8rUqVo3r0Ni/ycJ3ki6bXGIfB/hJYYbLT3S4+ZOmZd8rqI98g2aCvCxs1mRkmSv",
+	"qT2v4h/v+Lwu9Vsco9p5i32AjIthNJOUfrMTn2XWnpDAaYlHkClCZ0ZC841W1dVFIuJrnu9RJTLLQlw+",
+	"VJcPzSpQvbdXO6gMnjmbopDgBehagRMe/W5euORqRnLezHIHtcdVrXIcGKJMBIZpChc4TzO4wAWawL05",
+	"mseze7Xqcq1SoVW3no7eL9O33xzl/ZbYu25fUcCOwCiCyscpIZZg1eTk18iY+TOLU1y+1fh2Ey/Mnklj",
+	"9J3292QYTSFeSY/omRAVyceQQxBdTOk10g0wRj593oTWH//xVxCkLXJi5uTXFpsTiiKBjE642e9lbUxd",
+	"W8S3HwYuXr4UUAvF2uvv6df10ka9nDvJ5tQXW/jWwkk2VzvYrlUq9V9m1OUVrfRQK97SnuXx7fX6N69O",
+	"sjf1ZXklARl0QRBMQSTTRYcGBgcGgdEL5CQehMH5gcGB8yAIJE6ZIJsOcRIfmhoK6SrRP0sphu+sHZfU",
+	"pUO6Vu34oZorA0KUVn+XYiBsVLK63QGak0BZ+ViMpWmXRFCMfIKTpAQfJa+FvpZp5UiTUa9Sh2mbGy/x",
+	"NwUQ/DXUQdZMn2kX93/Rnuz6vJe2PN0+XNhWs0+0Z69xcdHnVY3M2RYqSGsGF/atwUqaEAV41kCnGTJt",
+	"3xGjGh4cPJVJelVPZpVOlukEGXW+iG+vtzkfEL7a7naujmZGg0BOJZMcSrsBT+HicjPbB6M6PSuIQ1xU",
+	"4aeM8BmHiqvNHWfVl1UnjmWILjYp6H4CcUmoQCQTXlmELn0KdK8HwmAyBVEaBAE1dUs10qpRqO5a4nW0",
+	"1RymQ9ikgchlnWswfao1Rvs0iXYvdfpYZp8MdmliDOMOMoVlNTTTkmxK72RDpuvtGA9MP+wWDy5SWl5R",
+	"IZlKKLzEISWke4dzzQa4m8jH+UR7x2iMFzhiER1nxZmM3VL6dRAdleIUVr+eoBX6vLXYSu46a9GS6bkp",
+	"0kgl/QrvfWagjmZqG7nR907zzY30rXyrRLz1T9s1smsgqN/dx4XlennToGyZ3ugVEhlCmn0dh2F8ARVb",
+	"w0gGfUpUj+dyNy7RsihogZxDiEuzFHGqjfaioNNKsoPiSPcuNAURP24Ii8BHlBlKxIV/6cMv4tgbN0u1",
+	"6h4zpn+mk/y7laJfODab/KY7pt90Aix96r0AqlOGzBjKFHUHVZpjArb2rONGWgbiwz1taV2dL9ZLx417",
+	"pVp1Q8sv0KLPqVWCgz40Cae5pGR431abFXBj0aHh8/ZZZhhMp/954Xcfme1lmuL9wSAyEBWTJGOU5esi",
+	"0sk0/6S0WjNXwItk3PDhzZVNLDC1vDqjLf2CnxWp7eC1XfxDlkWlJULXoKg9yPkw1/4NL8on2RwnxJDI",
+	"x06yuetw7CSbm0hx1yE/MDDw2y5BbGE52OMs3H/Ue8WQ9gODDI9gNNzdM+nWwbeO+DcOW7EdQCObxXMV",
+	"kxoT4joBv7x1G8q6PdTwXjhpq6R6idE2SXfQmxWDHtmzCUW3pPlyCxj+KDAqCuM8SkY8nAQ5NmVyZjn5",
+	"MTT8e3KYwjwJwnAhYiLmQVu996RXwh5El3d7I2oz2DbW23ySQ2ijZ9Aq6rIeoFvtvQxoef8OFmwZ2DHT",
+	"fzV/2Jgr0NTVjFB0lEJHLCfZnG1qhr9dYI6m6KCFBDjaz3xNJmmV5oiF8Za6kNdmn9CZlrY601gtak9y",
+	"9dJr7VWpfryEv//RreCQzUlkV+0pPmYsv7E6NDhYO9gGQQCnpQQ5uUf6hp7dK9mztWQWLA5rby9JgkBW",
+	"0qRhT8Jf3y2orgslU1ZdV0nWGVvvpZCVitNUg2yXamh/bhvvP9dtqWlyzvNt9ElcNFI0rbpCD8kaD5PX",
+	"6cyPZnUuDtpiSb06Z08FME/3veVUp90KGFonouxb6w4q3TuokNQ6R+3Rp6BkrX6C5SHsZ7PPULL2pdwh",
+	"5eC8D2CxpNAdvGj4sB1AN+Hi9NAdQGOVsP/YYQr37aHGqzYwunG+KNWNVhfoSY0l+GhEH364FhdWVEpx",
+	"Cc8+pVOUdrWO0Lh6mRD8E0z7lqq2s+idu1mePX21IaQSiR6dlVUs3cGoMbdovu0i0StQPiuZ2m4cMFoP",
+	"ndpwwV71EqSLv41i0MuOmB0RU4XsSdupxnPdTNqcRtBTScoypQ64RzDOywpE7qg3cnByloTZUrjSJPHO",
+	"atLTNc4aN0ta6bn17ICrab+TkxZ99Ot6tWwfDw0zWnhdlcuO4TMxuHp5X31+Exduqt/tgrN1Cy5tXWff",
+	"/G2N3zs2DU1MdsC4DDkUnXAv3Itr2stHlCQjmuvvGgexuqiPTXCxiuCmbby9wxW9nrexyqQXV2yTaScF",
+	"yWKoCRIy4QndINaY8fDKxAPS+42t2/GHe43thXrZqLlw8W599xudiWcb6t1NXPiJvuXSHv6EctAcAnkq",
+	"23GtnqhbPwbY0nbzp/dD253b84ZIe9C2TaxWbeu6ddF43PivCJFJ5HG4Sp/d0fmROW0xhc+oWK3/aOEs",
+	"q1WXf+nAghIRKN1Ii3O7P2v/tWsZdomW0+IElxZ0sbNxMiKLvmOkAyb8L4jt93J/RRMzWRZPC7VJZFzp",
+	"sJoKE3DULkzVGbcS9n+uP9qhRqTOL9Mv6Q1Uh3WQQxFp6032D8+XMu/ps1obzZ0ZPbx2PdpEzezzeehU",
+	"jnJCZBJ1ESppFLe5AAfmnTiPcoLpSP+HoiEFnU1ep0x/bC93p9S0rMCkrRPl0cGl1xS0nbJ7L0rv4H5x",
+	"ub1t8h70EbrJ/t322HuvlymvDpnpDT6W6dRI97rHYQzZTnv0ux06fOy9wY1XzeAUR78Dr27P6OtashzP",
+	"7ags9+O5hr7Ms7kfjtba7q910F5fp2vdBG3XIaGJppqiTaGEcfErHNKVO2A9B5cZNd+3K+GzKYjSygQv",
+	"xAPcmJhSAgbe4LQCkcAlPhWjjFu0n/NCLKA/nRSRrue21eXrXDwO0QAvgoypTsJ2ZjTz3wEAuaYJv+dN",
+	"AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -11,6 +11,13 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for PresencePrivacyLastSeen.
+const (
+	PresencePrivacyLastSeenN0 PresencePrivacyLastSeen = 0
+	PresencePrivacyLastSeenN1 PresencePrivacyLastSeen = 1
+	PresencePrivacyLastSeenN2 PresencePrivacyLastSeen = 2
+)
+
 // Defines values for QRCodeStatusResponseStatus.
 const (
 	QRCodeStatusResponseStatusN0 QRCodeStatusResponseStatus = 0
@@ -19,6 +26,14 @@ const (
 	QRCodeStatusResponseStatusN3 QRCodeStatusResponseStatus = 3
 )
 
+// Defines values for UpdatePresenceRequestState.
+const (
+	UpdatePresenceRequestStateN1 UpdatePresenceRequestState = 1
+	UpdatePresenceRequestStateN2 UpdatePresenceRequestState = 2
+	UpdatePresenceRequestStateN3 UpdatePresenceRequestState = 3
+	UpdatePresenceRequestStateN4 UpdatePresenceRequestState = 4
+)
+
 // Defines values for UserInfoStatus.
 const (
 	UserInfoStatusN0 UserInfoStatus = 0
@@ -64,6 +79,15 @@ type Preferences struct {
 	Silent bool `json:"silent"`
 }
 
+// PresencePrivacy defines model for PresencePrivacy.
+type PresencePrivacy struct {
+	// LastSeen 谁可以看到最后在线时间，0所有人 1仅好友 2不公开
+	LastSeen PresencePrivacyLastSeen `json:"last_seen"`
+}
+
+// PresencePrivacyLastSeen 谁可以看到最后在线时间，0所有人 1仅好友 2不公开
+type PresencePrivacyLastSeen int
+
 // QRCodeStatusResponse defines model for QRCodeStatusResponse.
 type QRCodeStatusResponse struct {
 	// Status 0: 未扫描 1: 已扫描 2: 已确认 3: 已过期
@@ -92,6 +116,21 @@ type SSOLoginRequest struct {
 	Platform string `json:"platform"`
 }
 
+// UpdatePresenceRequest defines model for UpdatePresenceRequest.
+type UpdatePresenceRequest struct {
+	// CustomStatus 自定义状态，为空时清除
+	CustomStatus string `json:"custom_status,omitempty"`
+
+	// CustomStatusDuration 自定义状态的有效时间（秒），0表示不过期
+	CustomStatusDuration int64 `json:"custom_status_duration,omitempty"`
+
+	// State 在线、离开、忙碌或隐身
+	State UpdatePresenceRequestState `json:"state"`
+}
+
+// UpdatePresenceRequestState 在线、离开、忙碌或隐身
+type UpdatePresenceRequestState int
+
 // UserInfo defines model for UserInfo.
 type UserInfo struct {
 	Avatar         string       `json:"avatar"`
@@ -129,6 +168,27 @@ type UserLoginClient struct {
 	LoginAt int64 `json:"login_at"`
 }
 
+// UserPresence defines model for UserPresence.
+type UserPresence struct {
+	// CustomStatus 自定义状态
+	CustomStatus string `json:"custom_status"`
+
+	// CustomStatusExpireAt 自定义状态的过期时间，0表示不过期
+	CustomStatusExpireAt int64 `json:"custom_status_expire_at"`
+
+	// LastSeen 最后在线时间，对方不公开时为0
+	LastSeen int64 `json:"last_seen"`
+
+	// Online 是否在线
+	Online bool `json:"online"`
+
+	// State 用户状态，0离线 1在线 2离开 3忙碌 4隐身（其他人看到的是离线）
+	State int `json:"state"`
+
+	// UserId 用户id
+	UserId string `json:"user_id"`
+}
+
 // UserSecretBundle defines model for UserSecretBundle.
 type UserSecretBundle struct {
 	// SecretBundle 用户密钥包
@@ -214,6 +274,12 @@ type UpdateUserPasswordJSONBody struct {
 	Password string `json:"password"`
 }
 
+// GetUsersPresenceParams defines parameters for GetUsersPresence.
+type GetUsersPresenceParams struct {
+	// UserIds 用户id，最多100个
+	UserIds []string `form:"user_ids" json:"user_ids"`
+}
+
 // SetUserPublicKeyJSONBody defines parameters for SetUserPublicKey.
 type SetUserPublicKeyJSONBody struct {
 	PublicKey string `json:"public_key"`
@@ -271,6 +337,12 @@ type UserLogoutJSONRequestBody UserLogoutJSONBody
 // UpdateUserPasswordJSONRequestBody defines body for UpdateUserPassword for application/json ContentType.
 type UpdateUserPasswordJSONRequestBody UpdateUserPasswordJSONBody
 
+// UpdatePresenceJSONRequestBody defines body for UpdatePresence for application/json ContentType.
+type UpdatePresenceJSONRequestBody = UpdatePresenceRequest
+
+// UpdatePresencePrivacyJSONRequestBody defines body for UpdatePresencePrivacy for application/json ContentType.
+type UpdatePresencePrivacyJSONRequestBody = PresencePrivacy
+
 // SetUserPublicKeyJSONRequestBody defines body for SetUserPublicKey for application/json ContentType.
 type SetUserPublicKeyJSONRequestBody SetUserPublicKeyJSONBody
 
@@ -25,6 +25,8 @@ type Commands struct {
 	GenerateQRCode            command.GenerateQRCodeHandler
 	UpdateQRCode              command.UpdateQRCodeHandler
 	SSOLogin                  command.SSOLoginHandler
+	UpdatePresence            command.UpdatePresenceHandler
+	UpdatePresencePrivacy     command.UpdatePresencePrivacyHandler
 	//CreateGroup command.CreateGroupHandler
 	//DeleteGroup command.DeleteGroupHandler
 	//UpdateGroup command.UpdateGroupHandler
@@ -35,6 +37,8 @@ type Queries struct {
 	GetUserBundle       query.GetUserBundleHandler
 	GetUserLoginClients query.GetUserClientsHandler
 	GetQRCode           query.GetQRCodeHandler
+	GetUsersPresence    query.GetUsersPresenceHandler
+	GetPresencePrivacy  query.GetPresencePrivacyHandler
 	//GetGroup    query.GetGroupHandler
 	//SearchGroup query.SearchGroupHandler
 }
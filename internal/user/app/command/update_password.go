@@ -3,8 +3,10 @@ package command
 import (
 	"context"
 	"errors"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
 	"github.com/cossim/coss-server/internal/user/domain/entity"
 	"github.com/cossim/coss-server/internal/user/domain/service"
+	"github.com/cossim/coss-server/internal/user/infra/rpc"
 	"github.com/cossim/coss-server/pkg/code"
 	"github.com/cossim/coss-server/pkg/decorator"
 	"github.com/cossim/coss-server/pkg/utils"
@@ -14,6 +16,7 @@ import (
 
 type UpdatePassword struct {
 	UserID          string
+	DriverID        string
 	OldPassword     string
 	ConfirmPassword string
 	NewPassword     string
@@ -21,16 +24,20 @@ type UpdatePassword struct {
 
 type UpdatePasswordHandler decorator.CommandHandler[*UpdatePassword, *interface{}]
 
-func NewUpdatePasswordHandler(logger *zap.Logger, ud service.UserDomain) UpdatePasswordHandler {
+func NewUpdatePasswordHandler(logger *zap.Logger, ud service.UserDomain, uld service.UserLoginDomain, pushService rpc.PushService) UpdatePasswordHandler {
 	return &updatePasswordHandler{
-		logger: logger,
-		ud:     ud,
+		logger:      logger,
+		ud:          ud,
+		uld:         uld,
+		pushService: pushService,
 	}
 }
 
 type updatePasswordHandler struct {
-	logger *zap.Logger
-	ud     service.UserDomain
+	logger      *zap.Logger
+	ud          service.UserDomain
+	uld         service.UserLoginDomain
+	pushService rpc.PushService
 }
 
 func (h *updatePasswordHandler) Handle(ctx context.Context, cmd *UpdatePassword) (*interface{}, error) {
@@ -66,5 +73,28 @@ func (h *updatePasswordHandler) Handle(ctx context.Context, cmd *UpdatePassword)
 		return nil, err
 	}
 
+	h.logoutOtherDevices(ctx, cmd.UserID, cmd.DriverID)
+
 	return nil, nil
 }
+
+// logoutOtherDevices 修改密码后其他设备需要重新登录，先断开推送连接再删除登录信息
+func (h *updatePasswordHandler) logoutOtherDevices(ctx context.Context, userID, driverID string) {
+	if err := h.pushService.DisconnectUser(ctx, userID, driverID, pushgrpcv1.DisconnectReason_DisconnectPasswordChanged); err != nil {
+		h.logger.Error("failed to disconnect user", zap.Error(err))
+	}
+
+	infos, err := h.uld.List(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get user login infos", zap.Error(err))
+		return
+	}
+	for _, info := range infos {
+		if info.DriverID == driverID {
+			continue
+		}
+		if err := h.uld.DeleteByUserIDAndDriverID(ctx, userID, info.DriverID); err != nil {
+			h.logger.Error("failed to delete user login info", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/cossim/coss-server/internal/user/domain/service"
+	"github.com/cossim/coss-server/internal/user/infra/rpc"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type fakeUserLoginDomain struct {
+	service.UserLoginDomain
+	logins map[string]*entity.UserLogin
+}
+
+func (f *fakeUserLoginDomain) List(ctx context.Context, userID string) ([]*entity.UserLogin, error) {
+	var infos []*entity.UserLogin
+	for _, info := range f.logins {
+		if info.UserID == userID {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+func (f *fakeUserLoginDomain) DeleteByUserIDAndDriverID(ctx context.Context, userID, driverID string) error {
+	delete(f.logins, driverID)
+	return nil
+}
+
+// fakePushService 断开连接时记录当时还存在的登录设备
+type fakePushService struct {
+	rpc.PushService
+	uld *fakeUserLoginDomain
+
+	excluded string
+	reason   pushgrpcv1.DisconnectReason
+	devices  []string
+}
+
+func (f *fakePushService) DisconnectUser(ctx context.Context, uid string, excludeDriverId string, reason pushgrpcv1.DisconnectReason) error {
+	f.excluded = excludeDriverId
+	f.reason = reason
+	for driverID := range f.uld.logins {
+		f.devices = append(f.devices, driverID)
+	}
+	return nil
+}
+
+func TestLogoutOtherDevices(t *testing.T) {
+	uld := &fakeUserLoginDomain{logins: map[string]*entity.UserLogin{
+		"d1": {UserID: "u1", DriverID: "d1"},
+		"d2": {UserID: "u1", DriverID: "d2"},
+		"d3": {UserID: "u1", DriverID: "d3"},
+	}}
+	push := &fakePushService{uld: uld}
+	h := &updatePasswordHandler{logger: zap.NewNop(), uld: uld, pushService: push}
+
+	h.logoutOtherDevices(context.Background(), "u1", "d1")
+
+	if push.excluded != "d1" || push.reason != pushgrpcv1.DisconnectReason_DisconnectPasswordChanged {
+		t.Fatalf("unexpected disconnect: excluded=%q reason=%v", push.excluded, push.reason)
+	}
+	// 断开连接时其他设备的登录信息还没有删除
+	if len(push.devices) != 3 {
+		t.Fatalf("disconnect after login infos deleted, devices = %v", push.devices)
+	}
+	if len(uld.logins) != 1 || uld.logins["d1"] == nil {
+		t.Fatalf("only current device should keep login info, got %v", uld.logins)
+	}
+}
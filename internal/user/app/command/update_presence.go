@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/cossim/coss-server/internal/user/infra/rpc"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/cossim/coss-server/pkg/decorator"
+	"github.com/cossim/coss-server/pkg/utils"
+	ptime "github.com/cossim/coss-server/pkg/utils/time"
+	"github.com/golang/protobuf/ptypes/any"
+	"go.uber.org/zap"
+	"unicode/utf8"
+)
+
+type UpdatePresence struct {
+	UserID       string
+	DriverID     string
+	State        entity.PresenceState
+	CustomStatus string
+	// 自定义状态的有效时间（秒），0表示不过期
+	CustomStatusDuration int64
+}
+
+type UpdatePresenceHandler decorator.CommandHandler[*UpdatePresence, *entity.Presence]
+
+func NewUpdatePresenceHandler(
+	logger *zap.Logger,
+	userCache cache.UserCache,
+	relationUserService rpc.RelationUserService,
+	pushService rpc.PushService,
+) UpdatePresenceHandler {
+	return &updatePresenceHandler{
+		logger:              logger,
+		userCache:           userCache,
+		relationUserService: relationUserService,
+		pushService:         pushService,
+	}
+}
+
+type updatePresenceHandler struct {
+	logger              *zap.Logger
+	userCache           cache.UserCache
+	relationUserService rpc.RelationUserService
+	pushService         rpc.PushService
+}
+
+func (h *updatePresenceHandler) Handle(ctx context.Context, cmd *UpdatePresence) (*entity.Presence, error) {
+	if cmd == nil || cmd.UserID == "" || !cmd.State.IsValid() || cmd.CustomStatusDuration < 0 {
+		return nil, code.InvalidParameter
+	}
+	if utf8.RuneCountInString(cmd.CustomStatus) > entity.MaxCustomStatusLength {
+		return nil, code.InvalidParameter
+	}
+
+	now := ptime.Now()
+	var expireAt int64
+	if cmd.CustomStatus != "" && cmd.CustomStatusDuration > 0 {
+		expireAt = now + cmd.CustomStatusDuration*1000
+	}
+	if err := h.userCache.SetUserPresenceState(ctx, cmd.UserID, cmd.State, cmd.CustomStatus, expireAt); err != nil {
+		h.logger.Error("set user presence error", zap.Error(err))
+		return nil, err
+	}
+
+	presence, err := h.userCache.GetUserPresence(ctx, cmd.UserID)
+	if err != nil {
+		h.logger.Error("get user presence error", zap.Error(err))
+		return nil, err
+	}
+
+	// 好友看到的是隐身后的状态，自己的其他设备看到真实的状态
+	friendIDs, err := h.relationUserService.GetFriendIDs(ctx, cmd.UserID)
+	if err != nil {
+		h.logger.Error("get friend list error", zap.Error(err))
+	} else if len(friendIDs) > 0 {
+		h.pushPresence(ctx, friendIDs, "", presence.ViewFor("", true, now))
+	}
+	self := presence.ViewFor(cmd.UserID, true, now)
+	h.pushPresence(ctx, []string{cmd.UserID}, cmd.DriverID, self)
+
+	return self, nil
+}
+
+func (h *updatePresenceHandler) pushPresence(ctx context.Context, userIDs []string, driverID string, p *entity.Presence) {
+	bytes, err := utils.StructToBytes(&constants.PresenceEventData{
+		UserId:               p.UserID,
+		State:                uint(p.State),
+		Online:               p.Online,
+		CustomStatus:         p.CustomStatus,
+		CustomStatusExpireAt: p.CustomStatusExpireAt,
+		LastSeen:             p.LastSeen,
+	})
+	if err != nil {
+		return
+	}
+
+	data, err := utils.StructToBytes(&pushgrpcv1.PushWsBatchByUserIdsRequest{
+		UserIds:  userIDs,
+		Event:    pushgrpcv1.WSEventType_PresenceUpdateEvent,
+		DriverId: driverID,
+		Data:     &any.Any{Value: bytes},
+	})
+	if err != nil {
+		return
+	}
+	if _, err := h.pushService.PushWSBatchByUserIds(ctx, data); err != nil {
+		h.logger.Error("push presence error", zap.Error(err))
+	}
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/decorator"
+	"go.uber.org/zap"
+)
+
+type UpdatePresencePrivacy struct {
+	UserID   string
+	LastSeen entity.LastSeenPrivacy
+}
+
+type UpdatePresencePrivacyHandler decorator.CommandHandlerNoneResponse[*UpdatePresencePrivacy]
+
+func NewUpdatePresencePrivacyHandler(
+	logger *zap.Logger,
+	userCache cache.UserCache,
+) UpdatePresencePrivacyHandler {
+	return &updatePresencePrivacyHandler{
+		logger:    logger,
+		userCache: userCache,
+	}
+}
+
+type updatePresencePrivacyHandler struct {
+	logger    *zap.Logger
+	userCache cache.UserCache
+}
+
+func (h *updatePresencePrivacyHandler) Handle(ctx context.Context, cmd *UpdatePresencePrivacy) error {
+	if cmd == nil || cmd.UserID == "" || !cmd.LastSeen.IsValid() {
+		return code.InvalidParameter
+	}
+	if err := h.userCache.SetUserLastSeenPrivacy(ctx, cmd.UserID, cmd.LastSeen); err != nil {
+		h.logger.Error("set presence privacy error", zap.Error(err))
+		return err
+	}
+	return nil
+}
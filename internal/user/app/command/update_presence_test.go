@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	pushgrpcv1 "github.com/cossim/coss-server/internal/push/api/grpc/v1"
+	"github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/cossim/coss-server/internal/user/infra/rpc"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/constants"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+type fakeRelationUserService struct {
+	rpc.RelationUserService
+	friends []string
+}
+
+func (f *fakeRelationUserService) GetFriendIDs(ctx context.Context, userID string) ([]string, error) {
+	return f.friends, nil
+}
+
+// fakePresencePushService 记录推送的状态变更
+type fakePresencePushService struct {
+	rpc.PushService
+	requests []*pushgrpcv1.PushWsBatchByUserIdsRequest
+}
+
+func (f *fakePresencePushService) PushWSBatchByUserIds(ctx context.Context, data []byte) (interface{}, error) {
+	req := &pushgrpcv1.PushWsBatchByUserIdsRequest{}
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, req)
+	return nil, nil
+}
+
+func presenceEvent(t *testing.T, req *pushgrpcv1.PushWsBatchByUserIdsRequest) *constants.PresenceEventData {
+	t.Helper()
+	if req.Event != pushgrpcv1.WSEventType_PresenceUpdateEvent {
+		t.Fatalf("event = %v", req.Event)
+	}
+	data := &constants.PresenceEventData{}
+	if err := json.Unmarshal(req.Data.Value, data); err != nil {
+		t.Fatalf("unmarshal presence event: %v", err)
+	}
+	return data
+}
+
+func newPresenceTestHandler(t *testing.T) (*updatePresenceHandler, *fakePresencePushService, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	push := &fakePresencePushService{}
+	return &updatePresenceHandler{
+		logger:              zap.NewNop(),
+		userCache:           cache.NewUserCacheRedisWithClient(client),
+		relationUserService: &fakeRelationUserService{friends: []string{"u2", "u3"}},
+		pushService:         push,
+	}, push, mr
+}
+
+func TestUpdatePresenceInvisible(t *testing.T) {
+	h, push, mr := newPresenceTestHandler(t)
+	mr.Set(cache.UserOnlineKey+"u1", "1")
+
+	self, err := h.Handle(context.Background(), &UpdatePresence{
+		UserID:               "u1",
+		DriverID:             "d1",
+		State:                entity.PresenceInvisible,
+		CustomStatus:         "busy",
+		CustomStatusDuration: 60,
+	})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if self.State != entity.PresenceInvisible || !self.Online || self.CustomStatus != "busy" || self.CustomStatusExpireAt == 0 {
+		t.Fatalf("unexpected self presence: %+v", self)
+	}
+
+	if len(push.requests) != 2 {
+		t.Fatalf("expected 2 pushes, got %d", len(push.requests))
+	}
+	// 好友看到的是离线
+	friends := push.requests[0]
+	if strings.Join(friends.UserIds, ",") != "u2,u3" || friends.DriverId != "" {
+		t.Fatalf("unexpected friend push: %+v", friends)
+	}
+	if ev := presenceEvent(t, friends); ev.UserId != "u1" || ev.Online || ev.State != uint(entity.PresenceOffline) {
+		t.Fatalf("friends should see u1 offline, got %+v", ev)
+	}
+	// 自己的其他设备看到真实的状态
+	own := push.requests[1]
+	if strings.Join(own.UserIds, ",") != "u1" || own.DriverId != "d1" {
+		t.Fatalf("unexpected self push: %+v", own)
+	}
+	if ev := presenceEvent(t, own); !ev.Online || ev.State != uint(entity.PresenceInvisible) || ev.CustomStatus != "busy" {
+		t.Fatalf("own devices should see the real state, got %+v", ev)
+	}
+}
+
+func TestUpdatePresenceInvalid(t *testing.T) {
+	h, push, _ := newPresenceTestHandler(t)
+	ctx := context.Background()
+
+	for _, cmd := range []*UpdatePresence{
+		{UserID: "u1", State: entity.PresenceOffline},
+		{UserID: "u1", State: entity.PresenceInvisible + 1},
+		{UserID: "u1", State: entity.PresenceAway, CustomStatusDuration: -1},
+		{UserID: "u1", State: entity.PresenceAway, CustomStatus: strings.Repeat("状", entity.MaxCustomStatusLength+1)},
+		{State: entity.PresenceAway},
+	} {
+		if _, err := h.Handle(ctx, cmd); !errors.Is(err, code.InvalidParameter) {
+			t.Fatalf("expected %+v to be rejected, got %v", cmd, err)
+		}
+	}
+	if len(push.requests) != 0 {
+		t.Fatalf("expected no pushes, got %d", len(push.requests))
+	}
+
+	// 长度按字符计算
+	if _, err := h.Handle(ctx, &UpdatePresence{UserID: "u1", State: entity.PresenceAway, CustomStatus: strings.Repeat("状", entity.MaxCustomStatusLength)}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+}
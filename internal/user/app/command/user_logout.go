@@ -8,11 +8,7 @@ import (
 	"github.com/cossim/coss-server/internal/user/domain/service"
 	"github.com/cossim/coss-server/internal/user/infra/rpc"
 	"github.com/cossim/coss-server/pkg/code"
-	"github.com/cossim/coss-server/pkg/constants"
 	"github.com/cossim/coss-server/pkg/decorator"
-	"github.com/cossim/coss-server/pkg/utils"
-	ptime "github.com/cossim/coss-server/pkg/utils/time"
-	"github.com/golang/protobuf/ptypes/any"
 	"go.uber.org/zap"
 )
 
@@ -46,9 +42,9 @@ func (h *userLogoutHandler) Handle(ctx context.Context, cmd *UserLogout) error {
 		return code.NotFound
 	}
 
-	// 通知消息服务关闭ws
-	if err := h.notifyMsgService(ctx, loginInfo); err != nil {
-		h.logger.Error("failed to notify msg service", zap.Error(err))
+	// 通知推送服务关闭该设备的ws
+	if err := h.disconnectDevice(ctx, loginInfo); err != nil {
+		h.logger.Error("failed to disconnect device", zap.Error(err))
 	}
 
 	// 删除客户端信息
@@ -60,36 +56,11 @@ func (h *userLogoutHandler) Handle(ctx context.Context, cmd *UserLogout) error {
 	return nil
 }
 
-func (h *userLogoutHandler) notifyMsgService(ctx context.Context, loginInfo *entity.UserLogin) error {
+// disconnectDevice 断开该设备的推送连接，连接断开后由推送服务下线
+func (h *userLogoutHandler) disconnectDevice(ctx context.Context, loginInfo *entity.UserLogin) error {
 	if loginInfo.Rid == "" {
 		return nil
 	}
 
-	data := &constants.OfflineEventData{
-		Rid: loginInfo.Rid,
-	}
-	toBytes, err := utils.StructToBytes(data)
-	if err != nil {
-		h.logger.Error("failed to struct to bytes", zap.Error(err))
-		return err
-	}
-
-	msg := &pushgrpcv1.WsMsg{
-		Uid:    loginInfo.UserID,
-		Event:  pushgrpcv1.WSEventType_OfflineEvent,
-		Data:   &any.Any{Value: toBytes},
-		SendAt: ptime.Now(),
-		Rid:    loginInfo.Rid,
-	}
-	toBytes2, err := utils.StructToBytes(msg)
-	if err != nil {
-		return err
-	}
-
-	if _, err := h.pushService.PushWS(ctx, toBytes2); err != nil {
-		h.logger.Error("发送消息失败", zap.Error(err))
-		return err
-	}
-
-	return nil
+	return h.pushService.DisconnectDevice(ctx, loginInfo.UserID, loginInfo.DriverID, pushgrpcv1.DisconnectReason_DisconnectLogout)
 }
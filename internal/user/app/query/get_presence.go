@@ -0,0 +1,102 @@
+package query
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/user/cache"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/cossim/coss-server/internal/user/infra/rpc"
+	"github.com/cossim/coss-server/pkg/code"
+	"github.com/cossim/coss-server/pkg/decorator"
+	ptime "github.com/cossim/coss-server/pkg/utils/time"
+	"go.uber.org/zap"
+)
+
+type GetUsersPresence struct {
+	CurrentUser string
+	UserIDs     []string
+}
+
+type GetUsersPresenceHandler decorator.CommandHandler[*GetUsersPresence, []*entity.Presence]
+
+func NewGetUsersPresenceHandler(logger *zap.Logger, userCache cache.UserCache, relationService rpc.RelationUserService) GetUsersPresenceHandler {
+	return &getUsersPresenceHandler{
+		logger:          logger,
+		userCache:       userCache,
+		relationService: relationService,
+	}
+}
+
+type getUsersPresenceHandler struct {
+	logger          *zap.Logger
+	userCache       cache.UserCache
+	relationService rpc.RelationUserService
+}
+
+func (h *getUsersPresenceHandler) Handle(ctx context.Context, q *GetUsersPresence) ([]*entity.Presence, error) {
+	if q == nil || len(q.UserIDs) == 0 || len(q.UserIDs) > entity.MaxPresenceQueryUsers {
+		return nil, code.InvalidParameter
+	}
+
+	userIDs := make([]string, 0, len(q.UserIDs))
+	seen := make(map[string]bool, len(q.UserIDs))
+	for _, id := range q.UserIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		userIDs = append(userIDs, id)
+	}
+
+	presences, err := h.userCache.GetUsersPresence(ctx, userIDs)
+	if err != nil {
+		h.logger.Error("get users presence error", zap.Error(err))
+		return nil, err
+	}
+
+	friendIDs, err := h.relationService.GetFriendIDs(ctx, q.CurrentUser)
+	if err != nil {
+		// 获取好友失败时按陌生人处理，只会少返回最后在线时间
+		h.logger.Warn("get friend list error", zap.Error(err))
+	}
+	friends := make(map[string]bool, len(friendIDs))
+	for _, id := range friendIDs {
+		friends[id] = true
+	}
+
+	now := ptime.Now()
+	result := make([]*entity.Presence, 0, len(userIDs))
+	for _, id := range userIDs {
+		result = append(result, presences[id].ViewFor(q.CurrentUser, friends[id], now))
+	}
+	return result, nil
+}
+
+type GetPresencePrivacy struct {
+	UserID string
+}
+
+type GetPresencePrivacyHandler decorator.CommandHandler[*GetPresencePrivacy, entity.LastSeenPrivacy]
+
+func NewGetPresencePrivacyHandler(logger *zap.Logger, userCache cache.UserCache) GetPresencePrivacyHandler {
+	return &getPresencePrivacyHandler{
+		logger:    logger,
+		userCache: userCache,
+	}
+}
+
+type getPresencePrivacyHandler struct {
+	logger    *zap.Logger
+	userCache cache.UserCache
+}
+
+func (h *getPresencePrivacyHandler) Handle(ctx context.Context, q *GetPresencePrivacy) (entity.LastSeenPrivacy, error) {
+	if q == nil || q.UserID == "" {
+		return 0, code.InvalidParameter
+	}
+	presence, err := h.userCache.GetUserPresence(ctx, q.UserID)
+	if err != nil {
+		h.logger.Error("get user presence error", zap.Error(err))
+		return 0, err
+	}
+	return presence.LastSeenPrivacy, nil
+}
@@ -69,6 +69,11 @@ type UserCache interface {
 	DeleteUserVerificationCode(ctx context.Context, userID, code string) error
 	SetQrCode(ctx context.Context, code *entity.QRCode) error
 	GetQrCode(ctx context.Context, token string) (*entity.QRCode, error)
+	GetUserPresence(ctx context.Context, userID string) (*entity.Presence, error)
+	GetUsersPresence(ctx context.Context, userIDs []string) (map[string]*entity.Presence, error)
+	SetUserPresenceState(ctx context.Context, userID string, state entity.PresenceState, customStatus string, customStatusExpireAt int64) error
+	SetUserLastSeen(ctx context.Context, userID string, lastSeen int64) error
+	SetUserLastSeenPrivacy(ctx context.Context, userID string, privacy entity.LastSeenPrivacy) error
 	Close() error
 }
 
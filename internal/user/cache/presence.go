@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+)
+
+const (
+	UserPresenceKey = UserKeyPrefix + "presence:"
+	// 推送服务维护的在线设备数
+	UserOnlineKey = "push:online:"
+
+	presenceFieldState                = "state"
+	presenceFieldCustomStatus         = "custom_status"
+	presenceFieldCustomStatusExpireAt = "custom_status_expire_at"
+	presenceFieldLastSeen             = "last_seen"
+	presenceFieldLastSeenPrivacy      = "last_seen_privacy"
+)
+
+func GetUserPresenceKey(userID string) string {
+	return UserPresenceKey + userID
+}
+
+func (u *UserCacheRedis) GetUserPresence(ctx context.Context, userID string) (*entity.Presence, error) {
+	if userID == "" {
+		return nil, ErrCacheKeyEmpty
+	}
+	presences, err := u.GetUsersPresence(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return presences[userID], nil
+}
+
+// GetUsersPresence 批量获取用户状态，没有记录的用户返回默认状态
+func (u *UserCacheRedis) GetUsersPresence(ctx context.Context, userIDs []string) (map[string]*entity.Presence, error) {
+	result := make(map[string]*entity.Presence, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	pipe := u.client.Pipeline()
+	fields := make([]*redis.MapStringStringCmd, len(userIDs))
+	online := make([]*redis.StringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		fields[i] = pipe.HGetAll(ctx, GetUserPresenceKey(userID))
+		online[i] = pipe.Get(ctx, UserOnlineKey+userID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	for i, userID := range userIDs {
+		m := fields[i].Val()
+		p := &entity.Presence{
+			UserID:       userID,
+			State:        entity.PresenceState(parseUint(m[presenceFieldState])),
+			CustomStatus: m[presenceFieldCustomStatus],
+			// 字段不存在时解析为0
+			CustomStatusExpireAt: parseInt(m[presenceFieldCustomStatusExpireAt]),
+			LastSeen:             parseInt(m[presenceFieldLastSeen]),
+			LastSeenPrivacy:      entity.LastSeenPrivacy(parseUint(m[presenceFieldLastSeenPrivacy])),
+		}
+		if n, err := online[i].Int(); err == nil && n > 0 {
+			p.Online = true
+		}
+		result[userID] = p
+	}
+	return result, nil
+}
+
+// SetUserPresenceState 设置用户手动选择的状态和自定义状态
+func (u *UserCacheRedis) SetUserPresenceState(ctx context.Context, userID string, state entity.PresenceState, customStatus string, customStatusExpireAt int64) error {
+	if userID == "" {
+		return ErrCacheKeyEmpty
+	}
+	return u.client.HSet(ctx, GetUserPresenceKey(userID),
+		presenceFieldState, uint(state),
+		presenceFieldCustomStatus, customStatus,
+		presenceFieldCustomStatusExpireAt, customStatusExpireAt,
+	).Err()
+}
+
+func (u *UserCacheRedis) SetUserLastSeen(ctx context.Context, userID string, lastSeen int64) error {
+	if userID == "" {
+		return ErrCacheKeyEmpty
+	}
+	return u.client.HSet(ctx, GetUserPresenceKey(userID), presenceFieldLastSeen, lastSeen).Err()
+}
+
+func (u *UserCacheRedis) SetUserLastSeenPrivacy(ctx context.Context, userID string, privacy entity.LastSeenPrivacy) error {
+	if userID == "" {
+		return ErrCacheKeyEmpty
+	}
+	return u.client.HSet(ctx, GetUserPresenceKey(userID), presenceFieldLastSeenPrivacy, uint(privacy)).Err()
+}
+
+func parseInt(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
+
+func parseUint(s string) uint64 {
+	v, _ := strconv.ParseUint(s, 10, 64)
+	return v
+}
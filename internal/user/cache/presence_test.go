@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cossim/coss-server/internal/user/domain/entity"
+	"github.com/redis/go-redis/v9"
+	"testing"
+)
+
+func TestUserPresence(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	u := NewUserCacheRedisWithClient(client)
+	ctx := context.Background()
+
+	// 没有记录时返回默认状态
+	p, err := u.GetUserPresence(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUserPresence: %v", err)
+	}
+	if *p != (entity.Presence{UserID: "u1"}) {
+		t.Fatalf("unexpected default presence: %+v", p)
+	}
+
+	if err := u.SetUserPresenceState(ctx, "u1", entity.PresenceBusy, "meeting", 2000); err != nil {
+		t.Fatalf("SetUserPresenceState: %v", err)
+	}
+	if err := u.SetUserLastSeen(ctx, "u1", 1000); err != nil {
+		t.Fatalf("SetUserLastSeen: %v", err)
+	}
+	if err := u.SetUserLastSeenPrivacy(ctx, "u1", entity.LastSeenFriends); err != nil {
+		t.Fatalf("SetUserLastSeenPrivacy: %v", err)
+	}
+	// 在线设备数由推送服务维护
+	mr.Set(UserOnlineKey+"u1", "2")
+	mr.Set(UserOnlineKey+"u2", "0")
+
+	presences, err := u.GetUsersPresence(ctx, []string{"u1", "u2"})
+	if err != nil {
+		t.Fatalf("GetUsersPresence: %v", err)
+	}
+	want := entity.Presence{
+		UserID:               "u1",
+		State:                entity.PresenceBusy,
+		CustomStatus:         "meeting",
+		CustomStatusExpireAt: 2000,
+		LastSeen:             1000,
+		LastSeenPrivacy:      entity.LastSeenFriends,
+		Online:               true,
+	}
+	if *presences["u1"] != want {
+		t.Fatalf("u1 = %+v, want %+v", presences["u1"], want)
+	}
+	if *presences["u2"] != (entity.Presence{UserID: "u2"}) {
+		t.Fatalf("u2 = %+v, want offline default", presences["u2"])
+	}
+
+	// 修改状态不影响最后在线时间和隐私设置
+	if err := u.SetUserPresenceState(ctx, "u1", entity.PresenceOnline, "", 0); err != nil {
+		t.Fatalf("SetUserPresenceState: %v", err)
+	}
+	p, err = u.GetUserPresence(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUserPresence: %v", err)
+	}
+	if p.State != entity.PresenceOnline || p.CustomStatus != "" || p.LastSeen != 1000 || p.LastSeenPrivacy != entity.LastSeenFriends {
+		t.Fatalf("unexpected presence after update: %+v", p)
+	}
+
+	if _, err := u.GetUserPresence(ctx, ""); err != ErrCacheKeyEmpty {
+		t.Fatalf("expected empty key error, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package entity
+
+// PresenceState 用户的在线状态
+type PresenceState uint
+
+const (
+	PresenceOffline   PresenceState = iota // 离线
+	PresenceOnline                         // 在线
+	PresenceAway                           // 离开
+	PresenceBusy                           // 忙碌
+	PresenceInvisible                      // 隐身，其他人看到的是离线
+)
+
+func (s PresenceState) IsValid() bool {
+	return s >= PresenceOnline && s <= PresenceInvisible
+}
+
+// LastSeenPrivacy 谁可以看到最后在线时间
+type LastSeenPrivacy uint
+
+const (
+	LastSeenEveryone LastSeenPrivacy = iota // 所有人
+	LastSeenFriends                         // 仅好友
+	LastSeenNobody                          // 不公开
+)
+
+func (p LastSeenPrivacy) IsValid() bool {
+	return p <= LastSeenNobody
+}
+
+const (
+	// MaxCustomStatusLength 自定义状态的最大长度
+	MaxCustomStatusLength = 64
+	// MaxPresenceQueryUsers 一次最多查询的用户数
+	MaxPresenceQueryUsers = 100
+)
+
+// Presence 保存在redis中的用户状态
+type Presence struct {
+	UserID string
+	// 用户手动设置的状态，没有设置时为在线
+	State        PresenceState
+	CustomStatus string
+	// 自定义状态的过期时间（毫秒），0表示不过期
+	CustomStatusExpireAt int64
+	LastSeen             int64
+	LastSeenPrivacy      LastSeenPrivacy
+	// 是否有设备连接到推送服务
+	Online bool
+}
+
+// ViewFor 返回viewer看到的状态，隐身时对其他人显示为离线，并按照隐私设置隐藏最后在线时间
+func (p *Presence) ViewFor(viewerID string, isFriend bool, now int64) *Presence {
+	v := *p
+	if v.State == PresenceOffline {
+		v.State = PresenceOnline
+	}
+	if v.CustomStatusExpireAt > 0 && v.CustomStatusExpireAt <= now {
+		v.CustomStatus = ""
+		v.CustomStatusExpireAt = 0
+	}
+	if viewerID == p.UserID {
+		if !v.Online {
+			v.State = PresenceOffline
+		}
+		return &v
+	}
+
+	if !v.Online || v.State == PresenceInvisible {
+		v.State = PresenceOffline
+		v.Online = false
+	}
+	switch v.LastSeenPrivacy {
+	case LastSeenFriends:
+		if !isFriend {
+			v.LastSeen = 0
+		}
+	case LastSeenNobody:
+		v.LastSeen = 0
+	}
+	return &v
+}
@@ -0,0 +1,89 @@
+package entity
+
+import "testing"
+
+func TestPresenceViewFor(t *testing.T) {
+	const now = 1000
+	tests := []struct {
+		name     string
+		p        Presence
+		viewer   string
+		isFriend bool
+		want     Presence
+	}{
+		{
+			name:   "没有设置状态时显示为在线",
+			p:      Presence{UserID: "u1", Online: true, LastSeen: 10},
+			viewer: "u2",
+			want:   Presence{UserID: "u1", State: PresenceOnline, Online: true, LastSeen: 10},
+		},
+		{
+			name:   "没有设备在线时显示为离线",
+			p:      Presence{UserID: "u1", State: PresenceBusy, LastSeen: 10},
+			viewer: "u2",
+			want:   Presence{UserID: "u1", State: PresenceOffline, LastSeen: 10},
+		},
+		{
+			name:   "隐身时对其他人显示为离线",
+			p:      Presence{UserID: "u1", State: PresenceInvisible, Online: true, LastSeen: 10},
+			viewer: "u2",
+			want:   Presence{UserID: "u1", State: PresenceOffline, LastSeen: 10},
+		},
+		{
+			name:   "隐身时自己看到真实的状态",
+			p:      Presence{UserID: "u1", State: PresenceInvisible, Online: true},
+			viewer: "u1",
+			want:   Presence{UserID: "u1", State: PresenceInvisible, Online: true},
+		},
+		{
+			name:   "过期的自定义状态被清除",
+			p:      Presence{UserID: "u1", Online: true, CustomStatus: "lunch", CustomStatusExpireAt: now},
+			viewer: "u2",
+			want:   Presence{UserID: "u1", State: PresenceOnline, Online: true},
+		},
+		{
+			name:   "未过期的自定义状态保留",
+			p:      Presence{UserID: "u1", Online: true, CustomStatus: "lunch", CustomStatusExpireAt: now + 1},
+			viewer: "u2",
+			want:   Presence{UserID: "u1", State: PresenceOnline, Online: true, CustomStatus: "lunch", CustomStatusExpireAt: now + 1},
+		},
+		{
+			name:     "仅好友可见时好友能看到最后在线时间",
+			p:        Presence{UserID: "u1", LastSeen: 10, LastSeenPrivacy: LastSeenFriends},
+			viewer:   "u2",
+			isFriend: true,
+			want:     Presence{UserID: "u1", State: PresenceOffline, LastSeen: 10, LastSeenPrivacy: LastSeenFriends},
+		},
+		{
+			name:   "仅好友可见时陌生人看不到最后在线时间",
+			p:      Presence{UserID: "u1", LastSeen: 10, LastSeenPrivacy: LastSeenFriends},
+			viewer: "u2",
+			want:   Presence{UserID: "u1", State: PresenceOffline, LastSeenPrivacy: LastSeenFriends},
+		},
+		{
+			name:     "不公开时好友也看不到最后在线时间",
+			p:        Presence{UserID: "u1", LastSeen: 10, LastSeenPrivacy: LastSeenNobody},
+			viewer:   "u2",
+			isFriend: true,
+			want:     Presence{UserID: "u1", State: PresenceOffline, LastSeenPrivacy: LastSeenNobody},
+		},
+		{
+			name:   "不公开时自己能看到最后在线时间",
+			p:      Presence{UserID: "u1", LastSeen: 10, LastSeenPrivacy: LastSeenNobody},
+			viewer: "u1",
+			want:   Presence{UserID: "u1", State: PresenceOffline, LastSeen: 10, LastSeenPrivacy: LastSeenNobody},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.p
+			got := p.ViewFor(tt.viewer, tt.isFriend, now)
+			if *got != tt.want {
+				t.Fatalf("ViewFor = %+v, want %+v", *got, tt.want)
+			}
+			if p != tt.p {
+				t.Fatalf("ViewFor modified the presence: %+v", p)
+			}
+		})
+	}
+}
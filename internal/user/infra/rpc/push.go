@@ -9,6 +9,11 @@ import (
 
 type PushService interface {
 	PushWS(ctx context.Context, data []byte) (interface{}, error)
+	PushWSBatchByUserIds(ctx context.Context, data []byte) (interface{}, error)
+	// DisconnectUser 断开用户所有设备的推送连接，excludeDriverID不为空时保留该设备
+	DisconnectUser(ctx context.Context, userID, excludeDriverID string, reason pushgrpcv1.DisconnectReason) error
+	// DisconnectDevice 断开用户单个设备的推送连接
+	DisconnectDevice(ctx context.Context, userID, driverID string, reason pushgrpcv1.DisconnectReason) error
 }
 
 func NewPushService(addr string) (PushService, error) {
@@ -50,3 +55,28 @@ func (s *PushServiceGrpc) PushWS(ctx context.Context, data []byte) (interface{},
 		Data: data,
 	})
 }
+
+func (s *PushServiceGrpc) PushWSBatchByUserIds(ctx context.Context, data []byte) (interface{}, error) {
+	return s.client.Push(ctx, &pushgrpcv1.PushRequest{
+		Type: pushgrpcv1.Type_Ws_Batch_User,
+		Data: data,
+	})
+}
+
+func (s *PushServiceGrpc) DisconnectUser(ctx context.Context, userID, excludeDriverID string, reason pushgrpcv1.DisconnectReason) error {
+	_, err := s.client.DisconnectUser(ctx, &pushgrpcv1.DisconnectUserRequest{
+		UserId:          userID,
+		Reason:          reason,
+		ExcludeDriverId: excludeDriverID,
+	})
+	return err
+}
+
+func (s *PushServiceGrpc) DisconnectDevice(ctx context.Context, userID, driverID string, reason pushgrpcv1.DisconnectReason) error {
+	_, err := s.client.DisconnectDevice(ctx, &pushgrpcv1.DisconnectDeviceRequest{
+		UserId:   userID,
+		DriverId: driverID,
+		Reason:   reason,
+	})
+	return err
+}
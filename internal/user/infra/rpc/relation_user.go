@@ -10,6 +10,7 @@ import (
 type RelationUserService interface {
 	GetUserRelation(ctx context.Context, userID string, friendID string) (*entity.Relation, error)
 	EstablishFriendship(ctx context.Context, userID string, friendID string) error
+	GetFriendIDs(ctx context.Context, userID string) ([]string, error)
 }
 
 var _ RelationUserService = &relationUserGrpc{}
@@ -73,3 +74,15 @@ func (s *relationUserGrpc) GetUserRelation(ctx context.Context, userID string, f
 
 	return resp, nil
 }
+
+func (s *relationUserGrpc) GetFriendIDs(ctx context.Context, userID string) ([]string, error) {
+	list, err := s.client.GetFriendList(ctx, &relationgrpcv1.GetFriendListRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(list.FriendList))
+	for _, v := range list.FriendList {
+		ids = append(ids, v.UserId)
+	}
+	return ids, nil
+}
@@ -183,6 +183,7 @@ func (h *HttpServer) UpdateUserPassword(c *gin.Context) {
 	}
 	_, err := h.app.Commands.UpdatePassword.Handle(c, &command.UpdatePassword{
 		UserID:          c.Value(constants.UserID).(string),
+		DriverID:        c.Value(constants.DriverID).(string),
 		OldPassword:     req.OldPassword,
 		ConfirmPassword: req.ConfirmPassword,
 		NewPassword:     req.Password,
@@ -636,3 +637,122 @@ func (h *HttpServer) ScanQRCode(c *gin.Context, token string) {
 
 	response.SetSuccess(c, "扫码成功", nil)
 }
+
+// GetUsersPresence
+// @Summary 批量获取用户状态
+// @Description 批量获取用户的在线状态、自定义状态和最后在线时间，最多100个
+// @Tags user
+// @Security BearerAuth
+// @Param user_ids query []string true "用户id"
+// @Success 200 {object} v1.Response{data=[]v1.UserPresence} "获取用户状态成功"
+// @Router /api/v1/user/presence [get]
+func (h *HttpServer) GetUsersPresence(c *gin.Context, params v1.GetUsersPresenceParams) {
+	presences, err := h.app.Queries.GetUsersPresence.Handle(c, &query.GetUsersPresence{
+		CurrentUser: c.Value(constants.UserID).(string),
+		UserIDs:     params.UserIds,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp := make([]*v1.UserPresence, 0, len(presences))
+	for _, p := range presences {
+		resp = append(resp, presenceToResponse(p))
+	}
+
+	response.SetSuccess(c, "获取用户状态成功", resp)
+}
+
+// UpdatePresence
+// @Summary 设置用户状态
+// @Description 设置在线、离开、忙碌或隐身状态以及自定义状态
+// @Tags user
+// @Security BearerAuth
+// @Accept application/json
+// @Param body v1.UpdatePresenceJSONRequestBody true "用户状态"
+// @Success 200 {object} v1.Response{data=v1.UserPresence} "设置用户状态成功"
+// @Router /api/v1/user/presence [put]
+func (h *HttpServer) UpdatePresence(c *gin.Context) {
+	req := &v1.UpdatePresenceJSONRequestBody{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	presence, err := h.app.Commands.UpdatePresence.Handle(c, &command.UpdatePresence{
+		UserID:               c.Value(constants.UserID).(string),
+		DriverID:             c.Value(constants.DriverID).(string),
+		State:                entity.PresenceState(req.State),
+		CustomStatus:         req.CustomStatus,
+		CustomStatusDuration: req.CustomStatusDuration,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "设置用户状态成功", presenceToResponse(presence))
+}
+
+// GetPresencePrivacy
+// @Summary 获取状态隐私设置
+// @Description 获取谁可以看到自己的最后在线时间
+// @Tags user
+// @Security BearerAuth
+// @Success 200 {object} v1.Response{data=v1.PresencePrivacy} "获取隐私设置成功"
+// @Router /api/v1/user/presence/privacy [get]
+func (h *HttpServer) GetPresencePrivacy(c *gin.Context) {
+	privacy, err := h.app.Queries.GetPresencePrivacy.Handle(c, &query.GetPresencePrivacy{
+		UserID: c.Value(constants.UserID).(string),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "获取隐私设置成功", &v1.PresencePrivacy{
+		LastSeen: v1.PresencePrivacyLastSeen(privacy),
+	})
+}
+
+// UpdatePresencePrivacy
+// @Summary 修改状态隐私设置
+// @Description 设置谁可以看到自己的最后在线时间
+// @Tags user
+// @Security BearerAuth
+// @Accept application/json
+// @Param body v1.UpdatePresencePrivacyJSONRequestBody true "隐私设置"
+// @Success 200 {object} v1.Response{} "修改隐私设置成功"
+// @Router /api/v1/user/presence/privacy [put]
+func (h *HttpServer) UpdatePresencePrivacy(c *gin.Context) {
+	req := &v1.UpdatePresencePrivacyJSONRequestBody{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Error("参数验证失败", zap.Error(err))
+		response.SetFail(c, "参数验证失败", nil)
+		return
+	}
+
+	err := h.app.Commands.UpdatePresencePrivacy.Handle(c, &command.UpdatePresencePrivacy{
+		UserID:   c.Value(constants.UserID).(string),
+		LastSeen: entity.LastSeenPrivacy(req.LastSeen),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.SetSuccess(c, "修改隐私设置成功", nil)
+}
+
+func presenceToResponse(p *entity.Presence) *v1.UserPresence {
+	return &v1.UserPresence{
+		UserId:               p.UserID,
+		State:                int(p.State),
+		Online:               p.Online,
+		CustomStatus:         p.CustomStatus,
+		CustomStatusExpireAt: p.CustomStatusExpireAt,
+		LastSeen:             p.LastSeen,
+	}
+}
@@ -143,7 +143,7 @@ func NewApplication(ctx context.Context, ac *config.AppConfig, logger *zap.Logge
 				userLoginDomain,
 				pushService,
 			),
-			UpdatePassword: command.NewUpdatePasswordHandler(logger, userDomain),
+			UpdatePassword: command.NewUpdatePasswordHandler(logger, userDomain, userLoginDomain, pushService),
 			UserActivate:   command.NewUserActivateHandler(logger, userDomain, userCache),
 			UserRegister: command.NewUserRegisterHandler(
 				logger,
@@ -195,6 +195,13 @@ func NewApplication(ctx context.Context, ac *config.AppConfig, logger *zap.Logge
 				msgService,
 				pushService,
 			),
+			UpdatePresence: command.NewUpdatePresenceHandler(
+				logger,
+				userCache,
+				relationUserService,
+				pushService,
+			),
+			UpdatePresencePrivacy: command.NewUpdatePresencePrivacyHandler(logger, userCache),
 		},
 		Queries: app.Queries{
 			GetUser: query.NewGetUserHandler(
@@ -211,7 +218,9 @@ func NewApplication(ctx context.Context, ac *config.AppConfig, logger *zap.Logge
 				userCache,
 				userDomain,
 			),
-			GetQRCode: query.NewGetQRCodeHandler(logger, userCache),
+			GetQRCode:          query.NewGetQRCodeHandler(logger, userCache),
+			GetUsersPresence:   query.NewGetUsersPresenceHandler(logger, userCache, relationUserService),
+			GetPresencePrivacy: query.NewGetPresencePrivacyHandler(logger, userCache),
 		},
 	}
 }
@@ -41,6 +41,8 @@ var (
 	StorageErrCreateFileRecordFailed = New(11001, "保存文件失败")
 	StorageErrGetFileInfoFailed      = New(11002, "获取文件信息失败")
 	StorageErrDeleteFileFailed       = New(11003, "删除文件失败")
+	StorageErrShareFileFailed        = New(11004, "共享文件失败")
+	StorageErrFileAccessDenied       = New(11006, "没有访问该文件的权限")
 
 	// 关系服务状态码定义
 	RelationErrUserNotFound                             = New(13000, "用户不存在")
@@ -141,6 +143,33 @@ var (
 	MsgErrSendMultipleFailed                        = New(14023, "批量发送消息失败")
 	DialogErrGetTargetIdFailed                      = New(14024, "获取对话目标成员id失败")
 	DialogErrTypeNotSupport                         = New(14025, "不支持的对话类型")
+	MsgErrSearchMessageFailed                       = New(14026, "搜索消息失败")
+	MsgErrAddReactionFailed                         = New(14027, "添加表情回应失败")
+	MsgErrRemoveReactionFailed                      = New(14028, "取消表情回应失败")
+	MsgErrGetReactionsFailed                        = New(14029, "获取表情回应失败")
+	MsgErrGetThreadFailed                           = New(14030, "获取话题消息失败")
+	MsgErrReadThreadFailed                          = New(14031, "设置话题已读失败")
+	MsgErrReplyMsgNotFound                          = New(14032, "回复的消息不存在")
+	MsgErrForwardMessageFailed                      = New(14033, "转发消息失败")
+	MsgErrMessageCannotForward                      = New(14034, "该消息不支持转发")
+	MsgErrCreateScheduledMsgFailed                  = New(14035, "创建定时消息失败")
+	MsgErrGetScheduledMsgFailed                     = New(14036, "获取定时消息失败")
+	MsgErrUpdateScheduledMsgFailed                  = New(14037, "修改定时消息失败")
+	MsgErrScheduledMsgNotPending                    = New(14038, "定时消息已发送或已取消")
+	MsgErrInvalidScheduledSendAt                    = New(14039, "定时发送时间无效")
+	MsgErrSetBurnExpireFailed                       = New(14040, "设置阅后即焚过期时间失败")
+	MsgErrSetMsgDeliveredFailed                     = New(14041, "设置消息送达失败")
+	MsgErrSaveDraftFailed                           = New(14042, "保存草稿失败")
+	MsgErrGetDraftFailed                            = New(14043, "获取草稿失败")
+	MsgErrClearDraftFailed                          = New(14044, "清除草稿失败")
+	MsgErrSyncMsgFailed                             = New(14045, "同步消息失败")
+	MsgErrInvalidMsgCursor                          = New(14046, "消息游标无效")
+	MsgErrInvalidClientMsgId                        = New(14047, "客户端消息id无效")
+	MsgErrClientMsgSending                          = New(14048, "消息正在发送中")
+	MsgErrSaveClientMsgFailed                       = New(14049, "保存客户端消息id失败")
+	MsgErrSaveRevisionFailed                        = New(14050, "保存消息修订记录失败")
+	MsgErrGetRevisionsFailed                        = New(14051, "获取消息修订记录失败")
+	MsgErrSetRevisionPolicyFailed                   = New(14052, "设置修订记录可见策略失败")
 
 	// 群组服务错误码定义
 	GroupErrGetGroupInfoByGidFailed               = New(15000, "获取群聊信息失败")
@@ -184,4 +213,17 @@ var (
 	LiveErrMediaDisconnected        = New(16022, "媒体断开连接")
 	LiveErrMediaError               = New(16023, "媒体错误")
 	LiveErrRejectCallFailed         = New(16024, "拒绝通话失败")
+	LiveErrGetCallHistoryFailed     = New(16025, "获取通话记录失败")
+	LiveErrStartRecordingFailed     = New(16026, "开始录制失败")
+	LiveErrStopRecordingFailed      = New(16027, "停止录制失败")
+	LiveErrAlreadyRecording         = New(16028, "通话正在录制")
+	LiveErrNotRecording             = New(16029, "通话未在录制")
+	LiveErrRecordingPermission      = New(16030, "没有录制通话的权限")
+
+	// 推送服务错误码定义
+	PushErrChannelNotConfigured = New(17000, "推送渠道未配置")
+	PushErrSendMobileFailed     = New(17001, "移动端推送失败")
+	PushErrSendEmailFailed      = New(17002, "发送邮件失败")
+	PushErrSendSMSFailed        = New(17003, "发送短信失败")
+	PushErrDisconnectFailed     = New(17004, "断开用户连接失败")
 )
@@ -130,6 +130,72 @@ type PushConfig struct {
 	Port    int    `mapstructure:"port" yaml:"port"`
 	// 手机厂商对应的appid 例如ios对应com.hitosea.xxx
 	PlatformAppID map[string]string `mapstructure:"platform_appid" yaml:"platform_appid"`
+	// 多个push实例部署时开启，通过redis共享在线状态并转发推送
+	Cluster PushClusterConfig `mapstructure:"cluster" yaml:"cluster"`
+	// 手机系统通知和短信渠道，邮件使用 email 配置
+	Notify PushNotifyConfig `mapstructure:"notify" yaml:"notify"`
+	// 离线消息队列
+	OfflineQueue PushOfflineQueueConfig `mapstructure:"offline_queue" yaml:"offline_queue"`
+	// 连接心跳
+	Heartbeat PushHeartbeatConfig `mapstructure:"heartbeat" yaml:"heartbeat"`
+}
+
+type PushHeartbeatConfig struct {
+	// 心跳间隔（秒），默认25秒
+	PingInterval int `mapstructure:"ping_interval" yaml:"ping_interval"`
+	// 超过该时间（秒）没有收到客户端的心跳或消息时断开连接，默认60秒
+	IdleTimeout int `mapstructure:"idle_timeout" yaml:"idle_timeout"`
+}
+
+type PushOfflineQueueConfig struct {
+	// 离线消息保存的时间（秒），默认7天，队列闲置同样时间后删除
+	MessageTTL int `mapstructure:"message_ttl" yaml:"message_ttl"`
+	// 每个用户最多保存的离线消息数，超过时丢弃最早的消息，默认10000
+	MaxLength int `mapstructure:"max_length" yaml:"max_length"`
+	// 每个连接最多等待确认的消息数，默认200
+	MaxInflight int `mapstructure:"max_inflight" yaml:"max_inflight"`
+}
+
+type PushClusterConfig struct {
+	Enable bool `mapstructure:"enable" yaml:"enable"`
+	// 节点id，为空时启动时随机生成
+	NodeID string `mapstructure:"node_id" yaml:"node_id"`
+}
+
+type PushNotifyConfig struct {
+	APNs APNsConfig `mapstructure:"apns" yaml:"apns"`
+	FCM  FCMConfig  `mapstructure:"fcm" yaml:"fcm"`
+	SMS  SMSConfig  `mapstructure:"sms" yaml:"sms"`
+}
+
+// APNsConfig 苹果推送，使用 token 方式鉴权
+type APNsConfig struct {
+	Enable bool `mapstructure:"enable" yaml:"enable"`
+	// 为空时使用正式环境地址，开发环境为 https://api.sandbox.push.apple.com
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint"`
+	KeyID    string `mapstructure:"key_id" yaml:"key_id"`
+	TeamID   string `mapstructure:"team_id" yaml:"team_id"`
+	// .p8 格式的私钥文件
+	KeyFile string `mapstructure:"key_file" yaml:"key_file"`
+	// 为空时使用 platform_appid 中 ios 对应的 appid
+	Topic string `mapstructure:"topic" yaml:"topic"`
+}
+
+// FCMConfig firebase 推送，使用服务账号鉴权
+type FCMConfig struct {
+	Enable   bool   `mapstructure:"enable" yaml:"enable"`
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint"`
+	// 服务账号的 json 密钥文件
+	CredentialsFile string `mapstructure:"credentials_file" yaml:"credentials_file"`
+}
+
+// SMSConfig 短信网关
+type SMSConfig struct {
+	Enable   bool   `mapstructure:"enable" yaml:"enable"`
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint"`
+	APIKey   string `mapstructure:"api_key" yaml:"api_key"`
+	// 短信签名
+	Sign string `mapstructure:"sign" yaml:"sign"`
 }
 
 func (c PushConfig) Addr() string {
@@ -155,6 +221,7 @@ type AppConfig struct {
 	AdminConfig         AdminConfig               `mapstructure:"admin" yaml:"admin"`
 	Push                PushConfig                `mapstructure:"push" yaml:"push"`
 	Cache               CacheConfig               `mapstructure:"cache" yaml:"cache"`
+	Search              SearchConfig              `mapstructure:"search" yaml:"search"`
 }
 
 func (c AppConfig) String() string {
@@ -213,6 +280,12 @@ type CacheConfig struct {
 	Enable bool `mapstructure:"enable" yaml:"enable"`
 }
 
+type SearchConfig struct {
+	// 全文索引存放目录，为空时使用内存索引
+	// 每个实例使用自己的索引，其他实例上的消息修改通过数据库中的消息变更记录同步
+	IndexPath string `mapstructure:"index_path" yaml:"index_path"`
+}
+
 func (c LivekitConfig) Addr() string {
 	return fmt.Sprintf("%s:%d", c.Address, c.Port)
 }
@@ -112,6 +112,8 @@ type SystemNotificationEventData struct {
 
 type OfflineEventData struct {
 	Rid string `json:"rid"`
+	// 服务端断开连接的原因，对应push服务的DisconnectReason
+	Reason int32 `json:"reason,omitempty"`
 	//DriverType DriverType `json:"driver_type"`
 }
 
@@ -163,3 +165,80 @@ type WsGroupMsg struct {
 	IsBurnAfterReading bool       `json:"is_burn_after_reading"`
 	SenderInfo         SenderInfo `json:"sender_info"`
 }
+
+type MessageReaction struct {
+	Emoji   string   `json:"emoji"`
+	Count   int      `json:"count"`
+	UserIds []string `json:"user_ids"`
+}
+
+type MessageReactionEventData struct {
+	MsgId     uint32            `json:"msg_id"`
+	DialogId  uint32            `json:"dialog_id"`
+	GroupId   uint32            `json:"group_id,omitempty"`
+	UserId    string            `json:"user_id"`
+	Emoji     string            `json:"emoji"`
+	Add       bool              `json:"add"`
+	Reactions []MessageReaction `json:"reactions"`
+}
+
+type ThreadUpdateEventData struct {
+	ThreadId    uint32      `json:"thread_id"`
+	DialogId    uint32      `json:"dialog_id"`
+	GroupId     uint32      `json:"group_id,omitempty"`
+	ReplyCount  int64       `json:"reply_count"`
+	LastReplyId uint32      `json:"last_reply_id"`
+	LastReply   interface{} `json:"last_reply,omitempty"`
+	ReadUserId  string      `json:"read_user_id,omitempty"` // 用户在其他设备上阅读了话题
+}
+
+type ScheduledMessageEventData struct {
+	Id         uint32 `json:"id"`
+	DialogId   uint32 `json:"dialog_id"`
+	Status     uint32 `json:"status"`
+	MsgId      uint32 `json:"msg_id,omitempty"`
+	FailReason string `json:"fail_reason,omitempty"`
+}
+
+type BurnAfterReadingExpiredEventData struct {
+	MsgId    uint32 `json:"msg_id"`
+	DialogId uint32 `json:"dialog_id"`
+	GroupId  uint32 `json:"group_id,omitempty"`
+}
+
+type MessageDeliveredEventData struct {
+	MsgId          uint32 `json:"msg_id"`
+	DialogId       uint32 `json:"dialog_id"`
+	GroupId        uint32 `json:"group_id,omitempty"`
+	UserId         string `json:"user_id"`
+	DeliveredAt    int64  `json:"delivered_at"`
+	DeliveredCount int    `json:"delivered_count,omitempty"`
+}
+
+type MessageDraftEventData struct {
+	DialogId  uint32 `json:"dialog_id"`
+	Content   string `json:"content"`
+	ReplyId   uint32 `json:"reply_id"`
+	UpdatedAt int64  `json:"updated_at"`
+	Cleared   bool   `json:"cleared"`
+}
+
+// TypingEventData 正在输入、正在录音等临时状态，不会保存也不会离线推送
+type TypingEventData struct {
+	DialogId uint32 `json:"dialog_id"`
+	UserId   string `json:"user_id"`
+	// 为false时表示状态结束
+	Active bool `json:"active"`
+	// 超过该时间（毫秒）没有收到新的事件时客户端自动清除状态
+	Timeout int64 `json:"timeout"`
+}
+
+// PresenceEventData 用户状态变更，隐身的用户对其他人显示为离线
+type PresenceEventData struct {
+	UserId               string `json:"user_id"`
+	State                uint   `json:"state"`
+	Online               bool   `json:"online"`
+	CustomStatus         string `json:"custom_status"`
+	CustomStatusExpireAt int64  `json:"custom_status_expire_at"`
+	LastSeen             int64  `json:"last_seen"`
+}
@@ -11,6 +11,7 @@ const (
 	RelationService ServiceType = "relation_service"
 	LiveUserService ServiceType = "live_user_service"
 	AdminService    ServiceType = "admin_service"
+	PushService     ServiceType = "push_service"
 )
 
 type ServiceActionType uint
@@ -0,0 +1,123 @@
+package msg_queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rs/xid"
+	"time"
+)
+
+// QueueOptions 队列参数，队列已经以不同的参数存在时沿用已有的队列
+type QueueOptions struct {
+	// 消息在队列中的最长保存时间，0表示不过期
+	MessageTTL time.Duration
+	// 队列最多保存的消息数，超过时丢弃最早的消息，0表示不限制
+	MaxLength int
+	// 队列闲置多久后自动删除，0表示不删除
+	Expires time.Duration
+}
+
+func (o *QueueOptions) args() amqp.Table {
+	if o == nil {
+		return nil
+	}
+	args := amqp.Table{}
+	if o.MessageTTL > 0 {
+		args["x-message-ttl"] = o.MessageTTL.Milliseconds()
+	}
+	if o.MaxLength > 0 {
+		args["x-max-length"] = int64(o.MaxLength)
+		args["x-overflow"] = "drop-head"
+	}
+	if o.Expires > 0 {
+		args["x-expires"] = o.Expires.Milliseconds()
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// DeclareQueue 声明持久化的队列，返回的队列中包含当前待消费的消息数
+// 队列已经以不同的参数存在时rabbitmq会关闭channel，不能使用共享的channel声明，见 DeclareQueueWithOptions
+func DeclareQueue(channel *amqp.Channel, queueName string, opts *QueueOptions) (amqp.Queue, error) {
+	return channel.QueueDeclare(
+		queueName,
+		true,
+		false,
+		false,
+		false,
+		opts.args(),
+	)
+}
+
+// DeclareQueueWithOptions 使用单独的channel声明队列，修改参数后已经存在的队列会声明失败，此时沿用已有的队列
+func (r *RabbitMQ) DeclareQueueWithOptions(queueName string, opts *QueueOptions) (amqp.Queue, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.declareQueue(queueName, opts)
+}
+
+func (r *RabbitMQ) declareQueue(queueName string, opts *QueueOptions) (amqp.Queue, error) {
+	ch, err := r.declareChannel()
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+	q, err := DeclareQueue(ch, queueName, opts)
+	if !isPreconditionFailed(err) {
+		return q, err
+	}
+	// 声明失败后channel已经被关闭，重新打开channel检查队列是否存在
+	if ch, err = r.declareChannel(); err != nil {
+		return amqp.Queue{}, err
+	}
+	return ch.QueueDeclarePassive(queueName, true, false, false, false, nil)
+}
+
+// declareChannel 声明队列专用的channel，被rabbitmq关闭后重新打开
+func (r *RabbitMQ) declareChannel() (*amqp.Channel, error) {
+	if r.declareCh != nil && !r.declareCh.IsClosed() {
+		return r.declareCh, nil
+	}
+	ch, err := r.connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+	r.declareCh = ch
+	return ch, nil
+}
+
+// isPreconditionFailed 队列已经以不同的参数存在
+func isPreconditionFailed(err error) bool {
+	var amqpErr *amqp.Error
+	return errors.As(err, &amqpErr) && amqpErr.Code == amqp.PreconditionFailed
+}
+
+// PublishMessageWithOptions 发布持久化的消息并返回消息id，消费者通过消息id确认消息
+func (r *RabbitMQ) PublishMessageWithOptions(queueName string, body interface{}, opts *QueueOptions) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	q, err := r.declareQueue(queueName, opts)
+	if err != nil {
+		return "", err
+	}
+	msg, err := json.Marshal(&body)
+	if err != nil {
+		return "", err
+	}
+	id := xid.New().String()
+	return id, r.channel.PublishWithContext(context.Background(),
+		"",
+		q.Name,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    id,
+			Timestamp:    time.Now(),
+			Body:         msg,
+		})
+}
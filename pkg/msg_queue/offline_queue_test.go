@@ -0,0 +1,30 @@
+package msg_queue
+
+import (
+	"fmt"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"testing"
+	"time"
+)
+
+func TestQueueOptionsArgs(t *testing.T) {
+	var opts *QueueOptions
+	if opts.args() != nil {
+		t.Fatal("nil options should have no args")
+	}
+	args := (&QueueOptions{MessageTTL: time.Minute, MaxLength: 10, Expires: time.Hour}).args()
+	if args["x-message-ttl"] != int64(60000) || args["x-max-length"] != int64(10) ||
+		args["x-overflow"] != "drop-head" || args["x-expires"] != int64(3600000) {
+		t.Fatalf("unexpected args %v", args)
+	}
+}
+
+func TestIsPreconditionFailed(t *testing.T) {
+	err := &amqp.Error{Code: amqp.PreconditionFailed, Reason: "PRECONDITION_FAILED - inequivalent arg 'x-max-length'"}
+	if !isPreconditionFailed(fmt.Errorf("declare: %w", err)) {
+		t.Fatal("expected precondition failed")
+	}
+	if isPreconditionFailed(&amqp.Error{Code: amqp.NotFound}) || isPreconditionFailed(nil) {
+		t.Fatal("unexpected precondition failed")
+	}
+}
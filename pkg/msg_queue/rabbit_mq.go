@@ -12,7 +12,9 @@ import (
 type RabbitMQ struct {
 	connection *amqp.Connection
 	channel    *amqp.Channel
-	lock       *sync.Mutex
+	// 声明队列专用的channel，声明失败时不影响发布消息的channel
+	declareCh *amqp.Channel
+	lock      *sync.Mutex
 }
 
 // NewRabbitMQ 用于创建 RabbitMQ 结构实例
@@ -52,6 +54,9 @@ func (r *RabbitMQ) GetConnection() *amqp.Connection {
 
 // Close 关闭 RabbitMQ 连接和通道
 func (r *RabbitMQ) Close() {
+	if r.declareCh != nil {
+		r.declareCh.Close()
+	}
 	r.channel.Close()
 	r.connection.Close()
 }
@@ -28,6 +28,9 @@ const PublicBucket = "public"
 // 临时桶
 const TemporaryBucket = "temp"
 
+// 通话录制桶，不公开读，只能通过预签名地址访问
+const RecordBucket = "record"
+
 var BucketList = map[storev1.FileType]string{
 	//storev1.FileType_Text:  FileBucket,
 	storev1.FileType_Voice: AudioBucket,
@@ -81,6 +84,10 @@ func NewMinIOStorage(endpoint, accessKey, secretKey string, useSSL bool, opts ..
 		panic(err)
 	}
 
+	if err = c.CreatePrivateBucket(context.Background(), RecordBucket); err != nil {
+		panic(err)
+	}
+
 	return c, nil
 }
 
@@ -210,6 +217,19 @@ func (m *MinIOStorage) CreateMinoBuket(bucketName string, fileType int) error {
 	return nil
 }
 
+// CreatePrivateBucket 创建不设置公开读策略的存储桶
+func (m *MinIOStorage) CreatePrivateBucket(ctx context.Context, bucketName string) error {
+	exists, err := m.client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return m.client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
+}
+
 func (m *MinIOStorage) CreateTemporaryBucket(ctx context.Context, bucketName string) error {
 	// 先检查存储桶是否已经存在
 	exists, err := m.client.BucketExists(ctx, bucketName)